@@ -0,0 +1,68 @@
+// Package alerting has what the governance notifications of notify and the
+// alerts of monitor share: the configs of the orgs and the gglogs adapters
+// the orgs are sent the messages through.
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	logs "gglogs"
+	"io/ioutil"
+)
+
+// ServiceNode is a peer or orderer of an org
+type ServiceNode struct {
+	ID       string
+	Endpoint string
+}
+
+// OrgConfig is an org and how it is sent the messages.
+// Adapters maps gglogs adapter names (smtp, slack, jianliao, conn) to their json config.
+type OrgConfig struct {
+	OrgName   string
+	OrgMSP    string
+	PeerNodes []*ServiceNode
+	Adapters  map[string]string
+}
+
+// LoadConfig reads the per org configs of a json file into configs, a
+// pointer to a slice of them
+func LoadConfig(file string, configs interface{}) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, configs)
+}
+
+// NewLogger returns a logger writing through the adapters only
+func NewLogger(adapters map[string]string) (*logs.BeeLogger, error) {
+	bl := logs.NewLogger()
+	if err := bl.DelLogger(logs.AdapterConsole); err != nil {
+		return nil, err
+	}
+	for name, config := range adapters {
+		config, err := withNoticeLevel(config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config of adapter %s: %s", name, err)
+		}
+		if err := bl.SetLogger(name, config); err != nil {
+			return nil, fmt.Errorf("Error setting adapter %s: %s", name, err)
+		}
+	}
+	return bl, nil
+}
+
+// withNoticeLevel lets the adapter pass notices, e.g. recoveries, when its
+// config has no level, the adapters would only pass emergencies otherwise.
+func withNoticeLevel(config string) (string, error) {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(config), &m); err != nil {
+		return "", err
+	}
+	if _, ok := m["level"]; !ok {
+		m["level"] = logs.LevelNotice
+	}
+	data, err := json.Marshal(m)
+	return string(data), err
+}
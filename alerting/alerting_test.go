@@ -0,0 +1,42 @@
+package alerting
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestWithNoticeLevel(t *testing.T) {
+	config, err := withNoticeLevel(`{"webhookurl":"http://127.0.0.1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := make(map[string]interface{})
+	json.Unmarshal([]byte(config), &m)
+	if m["level"] != float64(5) {
+		t.Fatalf("unexpected level in %s", config)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "alerting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString(`[{"OrgName": "org1", "OrgMSP": "Org1MSP", "PeerNodes": [{"ID": "peer0", "Endpoint": "127.0.0.1:7051"}], "MaxLag": 5}]`)
+	f.Close()
+
+	// the configs of notify and monitor embed OrgConfig
+	var configs []*struct {
+		OrgConfig
+		MaxLag uint64
+	}
+	if err := LoadConfig(f.Name(), &configs); err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].OrgName != "org1" || configs[0].MaxLag != 5 || configs[0].PeerNodes[0].ID != "peer0" {
+		t.Fatalf("unexpected configs %+v", configs)
+	}
+}
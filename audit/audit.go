@@ -0,0 +1,292 @@
+// Package audit records the administrative requests of the REST API in an
+// append-only store, each record being chained to the previous one by its
+// hash so that removing or modifying records is detected.
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"manageChain/ha"
+	"manageChain/logging"
+	"manageChain/protocols"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	beecontext "github.com/astaxie/beego/context"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var logger = logging.GetLogger("audit")
+
+const (
+	defaultFile   = "audit.log"
+	defaultPrefix = "audit"
+)
+
+var (
+	storeLock sync.RWMutex
+	store     *Store
+
+	// the records of the requests in progress by an ID of the server, the
+	// correlation IDs being the clients' and possibly shared. The sdk calls
+	// made for a request add their transactions to it.
+	pendingLock sync.Mutex
+	pending     = make(map[string]*Record)
+)
+
+// ErrUnavailable is returned when the store is not open
+var ErrUnavailable = errors.New("audit log is unavailable")
+
+// File is the file the records were kept in before the storage, AuditFile
+// of app.conf, audit.log by default. Its records are imported when the store
+// is empty.
+func File() string {
+	return beego.AppConfig.DefaultString("AuditFile", defaultFile)
+}
+
+// Prefix is the prefix of the keys of the store in the storage, AuditPrefix
+// of app.conf, audit by default
+func Prefix() string {
+	return beego.AppConfig.DefaultString("AuditPrefix", defaultPrefix)
+}
+
+// Start opens the store of Prefix in the storage, and records the
+// transactions of the sdk calls made for the administrative requests from
+// then on.
+func Start() error {
+	prefix := Prefix()
+	s, err := Open(sdk.Store(), prefix)
+	if err != nil {
+		logger.Error("Error opening audit log %s: %s", prefix, err)
+		return err
+	}
+	if seq, _ := s.LastHash(); seq == 0 {
+		file := File()
+		err := s.Import(file)
+		switch {
+		case err == nil:
+			logger.Info("audit log %s imported into %s", file, prefix)
+		case err == errNotEmpty:
+			logger.Warning("audit log %s not imported, another replica appended to %s", file, prefix)
+		case !os.IsNotExist(err):
+			logger.Error("Error importing audit log %s: %s", file, err)
+			return err
+		}
+	}
+	SetStore(s)
+	sdk.SetTxObserver(observeTx)
+	seq, hash := s.LastHash()
+	logger.Info("audit log %s opened at record %d hash %s", prefix, seq, hash)
+	return nil
+}
+
+// Stop closes the store, the administrative requests are refused from then on
+func Stop() error {
+	storeLock.Lock()
+	s := store
+	store = nil
+	storeLock.Unlock()
+	if s == nil {
+		return nil
+	}
+	return s.Close()
+}
+
+// SetStore sets the store the requests are recorded in
+func SetStore(s *Store) {
+	storeLock.Lock()
+	defer storeLock.Unlock()
+	store = s
+}
+
+// GetStore returns the store the requests are recorded in, if open
+func GetStore() (*Store, error) {
+	storeLock.RLock()
+	defer storeLock.RUnlock()
+	if store == nil {
+		return nil, ErrUnavailable
+	}
+	return store, nil
+}
+
+type recordIDKey struct{}
+
+// recordID returns the ID of the pending record ctx carries, if any
+func recordID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(recordIDKey{}).(string)
+	return id
+}
+
+// WithRecordOf returns a copy of ctx carrying the pending record of the
+// request of, for the calls made for it in another context.
+func WithRecordOf(ctx context.Context, of context.Context) context.Context {
+	if id := recordID(of); id != "" {
+		return context.WithValue(ctx, recordIDKey{}, id)
+	}
+	return ctx
+}
+
+func newRecordID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// configBlockOperation is the operation of the records of the config
+// blocks found after their request finished
+const configBlockOperation = "configblock"
+
+// observeTx adds tx to the pending record ctx carries. The config block of a
+// request already recorded is recorded on its own with the correlation ID of
+// the request.
+func observeTx(ctx context.Context, tx *sdk.Tx) {
+	id := logging.RequestID(ctx)
+	if id == "" && recordID(ctx) == "" {
+		return
+	}
+	var block *ConfigBlock
+	if tx.ConfigBlock != nil {
+		block = &ConfigBlock{Channel: tx.ChainID, TxID: tx.TxID, Number: *tx.ConfigBlock}
+	}
+	pendingLock.Lock()
+	r, ok := pending[recordID(ctx)]
+	switch {
+	case !ok:
+	case block != nil:
+		r.ConfigBlocks = append(r.ConfigBlocks, block)
+	default:
+		r.TxIDs = append(r.TxIDs, tx.TxID)
+		for _, signer := range tx.Signers {
+			if !contains(r.Signers, signer) {
+				r.Signers = append(r.Signers, signer)
+			}
+		}
+	}
+	pendingLock.Unlock()
+
+	if !ok && block != nil {
+		finish("", &Record{
+			Time:         time.Now().UTC().Format(time.RFC3339Nano),
+			RequestID:    id,
+			Operation:    configBlockOperation,
+			TxIDs:        []string{tx.TxID},
+			ConfigBlocks: []*ConfigBlock{block},
+		}, 0, "")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	recordKey = "auditRecord"
+	errorKey  = "auditError"
+)
+
+// StartRequest is a beego.BeforeRouter filter starting the record of an
+// administrative request, it refuses the request when it cannot be recorded.
+// It follows logging.StartRequest, the record is found by the ID the context
+// of the calls made for the request carries.
+func StartRequest(ctx *beecontext.Context) {
+	if _, err := GetStore(); err != nil {
+		ctx.Output.SetStatus(503)
+		ctx.Output.JSON(&protocols.ErrorMessage{Code: protocols.CodeUnavailable, Message: err.Error()}, false, false)
+		return
+	}
+	sum := sha256.Sum256(ctx.Input.RequestBody)
+	r := &Record{
+		Time:          time.Now().UTC().Format(time.RFC3339Nano),
+		RequestID:     logging.RequestID(ctx.Request.Context()),
+		Method:        ctx.Input.Method(),
+		Caller:        ha.Caller(ctx.Request),
+		RemoteAddr:    ctx.Input.IP(),
+		RequestDigest: hex.EncodeToString(sum[:]),
+	}
+	id := newRecordID()
+	ctx.Input.SetData(recordKey, r)
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), recordIDKey{}, id))
+	pendingLock.Lock()
+	pending[id] = r
+	pendingLock.Unlock()
+}
+
+// SetError tells the error an administrative request failed with
+func SetError(ctx *beecontext.Context, msg string) {
+	ctx.Input.SetData(errorKey, msg)
+}
+
+// FinishRequest is a beego.FinishRouter filter appending the record of an
+// administrative request with its outcome.
+func FinishRequest(ctx *beecontext.Context) {
+	r, ok := ctx.Input.GetData(recordKey).(*Record)
+	if !ok {
+		return
+	}
+	setOperation(ctx, r)
+	errMsg, _ := ctx.Input.GetData(errorKey).(string)
+	finish(recordID(ctx.Request.Context()), r, ctx.ResponseWriter.Status, errMsg)
+}
+
+// Detach takes the record of an administrative request out of the request,
+// for an operation going on after the response. It stays pending until the
+// returned func is called with the outcome, FinishRequest leaves it alone.
+func Detach(ctx *beecontext.Context) func(status int, errMsg string) {
+	r, ok := ctx.Input.GetData(recordKey).(*Record)
+	if !ok {
+		return func(int, string) {}
+	}
+	ctx.Input.SetData(recordKey, nil)
+	setOperation(ctx, r)
+	id := recordID(ctx.Request.Context())
+	return func(status int, errMsg string) {
+		finish(id, r, status, errMsg)
+	}
+}
+
+func setOperation(ctx *beecontext.Context, r *Record) {
+	r.Operation, _ = ctx.Input.GetData("RouterPattern").(string)
+	if r.Operation == "" {
+		r.Operation = ctx.Input.URL()
+	}
+}
+
+// finish appends r, pending as id, with its outcome
+func finish(id string, r *Record, status int, errMsg string) {
+	pendingLock.Lock()
+	delete(pending, id)
+	pendingLock.Unlock()
+
+	r.Status = status
+	if r.Status == 0 {
+		r.Status = 200
+	}
+	r.Outcome = Success
+	if r.Status >= 400 {
+		r.Outcome = Failure
+	}
+	r.Error = errMsg
+
+	s, err := GetStore()
+	if err == nil {
+		err = s.Append(r)
+	}
+	if err != nil {
+		logger.Error("Error recording %s %s of request %s: %s", r.Method, r.Operation, r.RequestID, err)
+		return
+	}
+	logger.Info("audit record %d %s %s %s hash %s", r.Seq, r.Operation, r.Outcome, r.RequestDigest, r.Hash)
+}
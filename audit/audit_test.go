@@ -0,0 +1,274 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"manageChain/fabrictest"
+	"manageChain/logging"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	beecontext "github.com/astaxie/beego/context"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+func tempStore(t *testing.T) (*Store, string) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(sdk.NewFileStorage(dir), defaultPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, dir
+}
+
+func appendRecords(t *testing.T, s *Store, operations ...string) {
+	for _, op := range operations {
+		if err := s.Append(&Record{Operation: op, Outcome: Success, TxIDs: []string{op + "tx"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	s, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+	appendRecords(t, s, "/a")
+
+	// the store another replica opens on the storage continues the chain
+	st := sdk.NewFileStorage(dir)
+	other, err := Open(st, defaultPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendRecords(t, other, "/b")
+	appendRecords(t, s, "/c")
+	v, err := s.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Problems) != 0 || v.LastSeq != 3 {
+		t.Fatalf("unexpected verification %+v", v)
+	}
+	if seq, hash := s.LastHash(); seq != 3 || hash != v.LastHash {
+		t.Fatalf("unexpected last hash %d %s", seq, hash)
+	}
+
+	records, err := s.Records(&Query{FromSeq: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Operation != "/b" || records[1].PrevHash != records[0].Hash {
+		t.Fatalf("unexpected records %+v", records)
+	}
+	records, err = s.Records(&Query{TxID: "/atx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Seq != 1 {
+		t.Fatalf("unexpected records %+v", records)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := s.Export(buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(buf.String(), "\n")
+	for name, tampered := range map[string]string{
+		"modified":  lines[0] + strings.Replace(lines[1], `"/b"`, `"/x"`, 1) + lines[2],
+		"removed":   lines[0] + lines[2],
+		"reordered": lines[1] + lines[0] + lines[2],
+	} {
+		v, err := Verify(strings.NewReader(tampered))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(v.Problems) == 0 {
+			t.Fatalf("the %s record should be detected", name)
+		}
+	}
+
+	// the log of a file is imported as it is
+	file := filepath.Join(dir, "audit.log")
+	if err := ioutil.WriteFile(file, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	imported, err := Open(st, "imported")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := imported.Import(file); err != nil {
+		t.Fatal(err)
+	}
+	if seq, hash := imported.LastHash(); seq != 3 || hash != v.LastHash {
+		t.Fatalf("unexpected last hash of the import %d %s", seq, hash)
+	}
+	if err := imported.Import(file); err != errNotEmpty {
+		t.Fatalf("importing into a store with records: %v", err)
+	}
+
+	if err := st.Remove(s.recordKey(2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(st, defaultPrefix); err == nil {
+		t.Fatal("a store that does not verify should not be opened")
+	}
+	if v, err := VerifyStorage(st, defaultPrefix); err != nil || len(v.Problems) == 0 {
+		t.Fatalf("unexpected verification %+v %v", v, err)
+	}
+}
+
+func TestObserveTx(t *testing.T) {
+	const orgDir = "../msp/testorg1"
+	ca, err := sdk.ConstructCAFromDir(orgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := sdk.NewClient(ca.AdminCommonName(), "testorg1", ca.AdminMSPDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := fabrictest.NewNetwork()
+	defer n.Stop()
+	ordererAddr, err := n.StartOrderer(orgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := &sdk.Organization{Name: "testorg1", ID: "testorg1", MSPDir: ca.MSPDir()}
+	if err := n.Bootstrap(sdk.CreateGenesisBlock(&sdk.GenesisConfig{
+		ChainID:                 sdk.DefaultSystemChainID,
+		OrdererType:             "kafka",
+		Addresses:               []string{ordererAddr},
+		KafkaBrokers:            []string{"127.0.0.1:9092"},
+		AdminsPolicy:            sdk.PolicyMajorityAdmins,
+		WritersPolicy:           sdk.PolicyAnyWriters,
+		ReadersPolicy:           sdk.PolicyAnyReaders,
+		OrdererOrganizations:    []*sdk.Organization{org},
+		ConsortiumOrganizations: []*sdk.Organization{org},
+		ConsortiumName:          sdk.DefaultConsortium,
+	})); err != nil {
+		t.Fatal(err)
+	}
+	orderer := &sdk.Endpoint{Address: ordererAddr, TLS: ca.TLSCACert(), Timeout: 3 * time.Second}
+
+	sdk.SetTxObserver(observeTx)
+	defer sdk.SetTxObserver(nil)
+	r := &Record{RequestID: "audittest"}
+	client = client.WithContext(context.WithValue(logging.WithRequestID(context.Background(), r.RequestID), recordIDKey{}, "record"))
+	pendingLock.Lock()
+	pending["record"] = r
+	pendingLock.Unlock()
+
+	if err := client.CreateChannel(&sdk.ChannelConfig{
+		ChainID:       "auditchannel",
+		Consortium:    sdk.DefaultConsortium,
+		AdminsPolicy:  sdk.PolicyMajorityAdmins,
+		WritersPolicy: sdk.PolicyAnyWriters,
+		ReadersPolicy: sdk.PolicyAnyReaders,
+		Organizations: []*sdk.Organization{org},
+	}, orderer); err != nil {
+		t.Fatal(err)
+	}
+	block, err := client.GetConfigBlockByChannel("auditchannel", orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca2, err := sdk.ConstructCAFromDir("../msp/testorg2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	org2 := &sdk.Organization{Name: "testorg2", ID: "testorg2", MSPDir: ca2.MSPDir()}
+	if err := client.UpdateChannel("auditchannel", block, nil, []*sdk.Organization{org2}, nil, nil, orderer); err != nil {
+		t.Fatal(err)
+	}
+
+	// the config blocks are looked for after the calls returned
+	for i := 0; ; i++ {
+		pendingLock.Lock()
+		found := len(r.ConfigBlocks)
+		pendingLock.Unlock()
+		if found == 2 {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("unexpected config blocks %+v", r.ConfigBlocks)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	pendingLock.Lock()
+	delete(pending, "record")
+	pendingLock.Unlock()
+	if len(r.TxIDs) != 2 || len(r.Signers) != 1 || r.Signers[0] != "testorg1" {
+		t.Fatalf("unexpected transactions %v signed by %v", r.TxIDs, r.Signers)
+	}
+	for i, num := range []uint64{0, 1} {
+		if b := r.ConfigBlocks[i]; b.Channel != "auditchannel" || b.TxID != r.TxIDs[i] || b.Number != num {
+			t.Fatalf("unexpected config block %+v", b)
+		}
+	}
+}
+
+func TestLateConfigBlock(t *testing.T) {
+	s, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+	SetStore(s)
+	defer Stop()
+
+	// the request was recorded before its config block was found
+	num := uint64(3)
+	observeTx(logging.WithRequestID(context.Background(), "late"), &sdk.Tx{ChainID: "mychannel", TxID: "tx", Config: true, ConfigBlock: &num})
+	records, err := s.Records(&Query{Operation: configBlockOperation})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].RequestID != "late" || len(records[0].ConfigBlocks) != 1 || records[0].ConfigBlocks[0].Number != 3 {
+		t.Fatalf("unexpected records %+v", records)
+	}
+}
+
+func TestSharedRequestID(t *testing.T) {
+	s, dir := tempStore(t)
+	defer os.RemoveAll(dir)
+	SetStore(s)
+	defer Stop()
+
+	// two requests sent with the same correlation ID keep their transactions apart
+	var ctxs []*beecontext.Context
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest("POST", "/channel/addorg", strings.NewReader("{}"))
+		r.Header.Set(logging.RequestIDHeader, "shared")
+		ctx := beecontext.NewContext()
+		ctx.Reset(httptest.NewRecorder(), r)
+		logging.StartRequest(ctx)
+		StartRequest(ctx)
+		ctxs = append(ctxs, ctx)
+	}
+	observeTx(ctxs[0].Request.Context(), &sdk.Tx{ChainID: "mychannel", TxID: "tx0"})
+	observeTx(ctxs[1].Request.Context(), &sdk.Tx{ChainID: "mychannel", TxID: "tx1"})
+	for _, ctx := range ctxs {
+		FinishRequest(ctx)
+	}
+	records, err := s.Records(&Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("unexpected records %+v", records)
+	}
+	for i, r := range records {
+		if r.RequestID != "shared" || len(r.TxIDs) != 1 || r.TxIDs[0] != fmt.Sprintf("tx%d", i) {
+			t.Fatalf("unexpected record %+v", r)
+		}
+	}
+	if len(pending) != 0 {
+		t.Fatalf("unexpected pending records %v", pending)
+	}
+}
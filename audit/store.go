@@ -0,0 +1,361 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"manageChain/protocols"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// outcomes of a Record
+const (
+	Success = "success"
+	Failure = "failure"
+)
+
+// the records and their queries and verification, see protocols
+type (
+	ConfigBlock  = protocols.AuditConfigBlock
+	Record       = protocols.AuditRecord
+	Query        = protocols.AuditQuery
+	Problem      = protocols.AuditProblem
+	Verification = protocols.AuditVerification
+)
+
+// recordHash is the hash of r, the sha256 of r with an empty Hash
+func recordHash(r *Record) string {
+	c := *r
+	c.Hash = ""
+	data, _ := json.Marshal(&c)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Store appends records to the keys under a prefix of a storage, one key
+// per record by sequence number, they are never rewritten. The records are
+// appended holding the lock of the prefix, the replicas sharing the storage
+// chain them to each other's.
+type Store struct {
+	mutex    sync.Mutex
+	storage  sdk.Storage
+	prefix   string
+	seq      uint64
+	lastHash string
+}
+
+// head is the last record appended, kept not to list the records for it
+type head struct {
+	Seq  uint64 `json:"seq"`
+	Hash string `json:"hash"`
+}
+
+func (s *Store) recordKey(seq uint64) string {
+	return fmt.Sprintf("%s/records/%020d", s.prefix, seq)
+}
+
+func (s *Store) headKey() string {
+	return s.prefix + "/head"
+}
+
+// Open opens the store of the keys under prefix of st. It fails when the
+// records in it do not verify, appending to them would hide where they were
+// tampered with.
+func Open(st sdk.Storage, prefix string) (*Store, error) {
+	s := &Store{storage: st, prefix: prefix}
+	v, err := verify(s.scan)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.Problems) != 0 {
+		return nil, fmt.Errorf("audit log %s does not verify: %s", prefix, v.Problems[0])
+	}
+	s.seq, s.lastHash = v.LastSeq, v.LastHash
+	return s, nil
+}
+
+// Close closes the store, the records are written as they are appended
+func (s *Store) Close() error {
+	return nil
+}
+
+// last returns the last record appended to the storage, by any replica. A
+// record written after the head it follows, the head not being written
+// before a crash, is the last one.
+func (s *Store) last() (*head, error) {
+	h := &head{}
+	data, err := s.storage.Read(s.headKey())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, h); err != nil {
+			return nil, fmt.Errorf("audit log %s head: %s", s.prefix, err)
+		}
+	}
+	for {
+		data, err := s.storage.Read(s.recordKey(h.Seq + 1))
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		r := &Record{}
+		if err := json.Unmarshal(data, r); err != nil {
+			return nil, fmt.Errorf("audit log %s record %d: %s", s.prefix, h.Seq+1, err)
+		}
+		h = &head{Seq: r.Seq, Hash: r.Hash}
+	}
+}
+
+// Append chains r to the last record and writes it, setting its Seq,
+// PrevHash and Hash.
+func (s *Store) Append(r *Record) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	unlock, err := s.storage.Lock(s.prefix)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	h, err := s.last()
+	if err != nil {
+		return err
+	}
+	r.Seq = h.Seq + 1
+	r.PrevHash = h.Hash
+	r.Hash = recordHash(r)
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := s.storage.Write(s.recordKey(r.Seq), data); err != nil {
+		return err
+	}
+	if err := s.writeHead(r.Seq, r.Hash); err != nil {
+		return err
+	}
+	s.seq, s.lastHash = r.Seq, r.Hash
+	return nil
+}
+
+func (s *Store) writeHead(seq uint64, hash string) error {
+	data, err := json.Marshal(&head{Seq: seq, Hash: hash})
+	if err != nil {
+		return err
+	}
+	return s.storage.Write(s.headKey(), data)
+}
+
+// errNotEmpty is returned by Import when the store has records
+var errNotEmpty = errors.New("the audit log has records")
+
+// Import appends the records of the file of path as they are, the store
+// being empty, e.g. the log kept in AuditFile before the storage.
+func (s *Store) Import(path string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	v, err := VerifyFile(path)
+	if err != nil {
+		return err
+	}
+	if len(v.Problems) != 0 {
+		return fmt.Errorf("audit log %s does not verify: %s", path, v.Problems[0])
+	}
+	unlock, err := s.storage.Lock(s.prefix)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if h, err := s.last(); err != nil || h.Seq != 0 {
+		if err == nil {
+			err = errNotEmpty
+		}
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	err = scanLines(file, func(line int, data []byte) error {
+		return s.storage.Write(s.recordKey(uint64(line)), data)
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.writeHead(v.LastSeq, v.LastHash); err != nil {
+		return err
+	}
+	s.seq, s.lastHash = v.LastSeq, v.LastHash
+	return nil
+}
+
+// LastHash returns the hash of the last record, keeping it elsewhere allows
+// to detect the truncation of the store.
+func (s *Store) LastHash() (uint64, string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// the other replicas may have appended since
+	if h, err := s.last(); err == nil {
+		s.seq, s.lastHash = h.Seq, h.Hash
+	}
+	return s.seq, s.lastHash
+}
+
+func match(q *Query, r *Record) bool {
+	if r.Seq < q.FromSeq || (q.ToSeq != 0 && r.Seq > q.ToSeq) {
+		return false
+	}
+	if (q.Operation != "" && r.Operation != q.Operation) ||
+		(q.Caller != "" && r.Caller != q.Caller) ||
+		(q.Outcome != "" && r.Outcome != q.Outcome) {
+		return false
+	}
+	if q.TxID == "" {
+		return true
+	}
+	for _, txID := range r.TxIDs {
+		if txID == q.TxID {
+			return true
+		}
+	}
+	return false
+}
+
+// Records returns the records matching q, in order
+func (s *Store) Records(q *Query) ([]*Record, error) {
+	records := []*Record{}
+	err := s.scan(func(line int, data []byte) error {
+		r := &Record{}
+		if err := json.Unmarshal(data, r); err != nil {
+			return fmt.Errorf("line %d: %s", line, err)
+		}
+		if match(q, r) {
+			records = append(records, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if q.Limit > 0 && len(records) > q.Limit {
+		records = records[len(records)-q.Limit:]
+	}
+	return records, nil
+}
+
+// Export writes the records as they are stored, to be verified with VerifyFile
+func (s *Store) Export(w io.Writer) error {
+	return s.scan(func(line int, data []byte) error {
+		_, err := w.Write(append(data, '\n'))
+		return err
+	})
+}
+
+// scan calls f with the records written so far, by their sequence number
+// as line
+func (s *Store) scan(f func(line int, data []byte) error) error {
+	keys, err := s.storage.List(s.prefix + "/records")
+	if err != nil {
+		return err
+	}
+	line := 0
+	for _, key := range keys {
+		// the values being written aside under other keys
+		if seq, err := strconv.ParseUint(path.Base(key), 10, 64); err != nil || key != s.recordKey(seq) {
+			continue
+		}
+		line++
+		data, err := s.storage.Read(key)
+		if err != nil {
+			return err
+		}
+		if err := f(line, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanLines(r io.Reader, f func(line int, data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if err := f(line, scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Verify verifies that the records of r follow each other without gaps and
+// are not modified.
+func Verify(r io.Reader) (*Verification, error) {
+	return verify(func(f func(line int, data []byte) error) error {
+		return scanLines(r, f)
+	})
+}
+
+// verify verifies the records scan calls its function with
+func verify(scan func(f func(line int, data []byte) error) error) (*Verification, error) {
+	v := &Verification{Problems: []*Problem{}}
+	err := scan(func(line int, data []byte) error {
+		rec := &Record{}
+		if err := json.Unmarshal(data, rec); err != nil {
+			v.Problems = append(v.Problems, &Problem{Line: line, Message: "unreadable record: " + err.Error()})
+			return nil
+		}
+		expected := v.LastSeq + 1
+		switch {
+		case rec.Seq != expected:
+			v.Problems = append(v.Problems, &Problem{Line: line, Seq: rec.Seq, Message: fmt.Sprintf("expected record %d, records are missing or reordered", expected)})
+		case rec.PrevHash != v.LastHash:
+			v.Problems = append(v.Problems, &Problem{Line: line, Seq: rec.Seq, Message: "previous hash does not match, the previous record was modified or removed"})
+		}
+		if canonical, _ := json.Marshal(rec); recordHash(rec) != rec.Hash || !bytes.Equal(canonical, data) {
+			v.Problems = append(v.Problems, &Problem{Line: line, Seq: rec.Seq, Message: "hash does not match, the record was modified"})
+		}
+		v.LastSeq, v.LastHash = rec.Seq, rec.Hash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Verify verifies the records of the store
+func (s *Store) Verify() (*Verification, error) {
+	return verify(s.scan)
+}
+
+// VerifyStorage verifies the records of the store of the keys under prefix
+// of st, without opening it
+func VerifyStorage(st sdk.Storage, prefix string) (*Verification, error) {
+	s := &Store{storage: st, prefix: prefix}
+	return s.Verify()
+}
+
+// VerifyFile verifies the records of path, exported by Export
+func VerifyFile(path string) (*Verification, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Verify(file)
+}
@@ -0,0 +1,258 @@
+// Package calls tells the sdk calls made for a request how long to wait for
+// the nodes, how many times to retry and in which order to try the nodes,
+// as configured in app.conf and overridden by the request.
+package calls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"manageChain/logging"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var logger = logging.GetLogger("calls")
+
+// operations, the keys of Options.Timeouts
+const (
+	// OpEndorse is a proposal of a query or an invoke
+	OpEndorse = "endorse"
+	// OpBroadcast is a transaction or a config update sent to an orderer
+	OpBroadcast = "broadcast"
+	// OpDeliver is reading blocks from an orderer
+	OpDeliver = "deliver"
+	// OpWaitTx is waiting for a transaction to be committed
+	OpWaitTx = "waittx"
+	// OpCreateChannel is a channel creation sent to an orderer
+	OpCreateChannel = "createchannel"
+	// OpJoinChannel is the join proposal sent to a peer
+	OpJoinChannel = "joinchannel"
+	// OpInstall is the install proposal of a chaincode package
+	OpInstall = "install"
+	// OpInstantiate is the instantiate proposal of a chaincode
+	OpInstantiate = "instantiate"
+)
+
+// failover orders of the nodes
+const (
+	// FailoverGiven tries the nodes in the order they are given
+	FailoverGiven = "given"
+	// FailoverRandom tries the nodes in a random order
+	FailoverRandom = "random"
+	// FailoverRoundRobin starts with the node after the one the previous
+	// call started with
+	FailoverRoundRobin = "roundrobin"
+)
+
+// defaultTimeouts are the timeouts of the operations not configured
+var defaultTimeouts = map[string]time.Duration{
+	OpEndorse:       5 * time.Second,
+	OpBroadcast:     5 * time.Second,
+	OpDeliver:       5 * time.Second,
+	OpWaitTx:        20 * time.Second,
+	OpCreateChannel: 5 * time.Second,
+	OpJoinChannel:   5 * time.Second,
+	OpInstall:       5 * time.Second,
+	OpInstantiate:   5 * time.Second,
+}
+
+// Options are the timeouts, retries and failover order of the calls
+type Options struct {
+	// Timeouts are of connecting to a node for an operation, and of waiting
+	// for a transaction for OpWaitTx
+	Timeouts map[string]time.Duration
+	// Retries is the number of times the nodes are tried again after all of
+	// them failed, only when the last error is a timeout or an unavailable
+	// node
+	Retries int
+	// Backoff is the delay before trying the nodes again
+	Backoff time.Duration
+	// Failover is one of the Failover constants
+	Failover string
+}
+
+var (
+	lock     sync.RWMutex
+	defaults = &Options{Timeouts: map[string]time.Duration{}, Backoff: time.Second, Failover: FailoverGiven}
+)
+
+// Setup sets the default options from Timeouts, Retries, RetryBackoff and
+// Failover of app.conf.
+func Setup() error {
+	opts, err := Default().Parse(beego.AppConfig.String("Timeouts"), beego.AppConfig.String("Retries"),
+		beego.AppConfig.String("RetryBackoff"), beego.AppConfig.String("Failover"))
+	if err != nil {
+		return err
+	}
+	SetDefault(opts)
+	return nil
+}
+
+// SetDefault sets the options of the calls made without options
+func SetDefault(opts *Options) {
+	lock.Lock()
+	defer lock.Unlock()
+	defaults = opts
+}
+
+// Default returns the options of the calls made without options
+func Default() *Options {
+	lock.RLock()
+	defer lock.RUnlock()
+	return defaults
+}
+
+// Parse returns a copy of o with the options given in the same syntax as
+// app.conf, the empty ones being left as they are. timeouts is a spec of
+// comma separated op=duration pairs, e.g. "install=2m,waittx=30s".
+func (o *Options) Parse(timeouts string, retries string, backoff string, failover string) (*Options, error) {
+	ret := *o
+	ret.Timeouts = map[string]time.Duration{}
+	for op, timeout := range o.Timeouts {
+		ret.Timeouts[op] = timeout
+	}
+	for _, field := range strings.Split(timeouts, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		i := strings.Index(field, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("timeout %s is not op=duration", field)
+		}
+		op := strings.ToLower(strings.TrimSpace(field[:i]))
+		if _, ok := defaultTimeouts[op]; !ok {
+			return nil, fmt.Errorf("unknown operation %s", op)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(field[i+1:]))
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout of %s: %s", op, field[i+1:])
+		}
+		ret.Timeouts[op] = timeout
+	}
+	if retries = strings.TrimSpace(retries); retries != "" {
+		n, err := strconv.Atoi(retries)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid retries: %s", retries)
+		}
+		ret.Retries = n
+	}
+	if backoff = strings.TrimSpace(backoff); backoff != "" {
+		d, err := time.ParseDuration(backoff)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid retry backoff: %s", backoff)
+		}
+		ret.Backoff = d
+	}
+	if failover = strings.ToLower(strings.TrimSpace(failover)); failover != "" {
+		switch failover {
+		case FailoverGiven, FailoverRandom, FailoverRoundRobin:
+		default:
+			return nil, fmt.Errorf("unknown failover order %s", failover)
+		}
+		ret.Failover = failover
+	}
+	return &ret, nil
+}
+
+// Timeout returns the timeout of op
+func (o *Options) Timeout(op string) time.Duration {
+	if timeout, ok := o.Timeouts[op]; ok {
+		return timeout
+	}
+	return defaultTimeouts[op]
+}
+
+// next is where the round robin order starts from
+var next uint32
+
+// Order returns endpoints in the order they are tried
+func (o *Options) Order(endpoints []*sdk.Endpoint) []*sdk.Endpoint {
+	ret := make([]*sdk.Endpoint, len(endpoints))
+	switch {
+	case len(endpoints) == 0:
+	case o.Failover == FailoverRandom:
+		for i, j := range rand.Perm(len(endpoints)) {
+			ret[i] = endpoints[j]
+		}
+	case o.Failover == FailoverRoundRobin:
+		start := int(atomic.AddUint32(&next, 1)-1) % len(endpoints)
+		copy(ret, endpoints[start:])
+		copy(ret[len(endpoints)-start:], endpoints[:start])
+	default:
+		copy(ret, endpoints)
+	}
+	return ret
+}
+
+type optionsKey struct{}
+
+// WithOptions returns a copy of ctx carrying opts
+func WithOptions(ctx context.Context, opts *Options) context.Context {
+	return context.WithValue(ctx, optionsKey{}, opts)
+}
+
+// FromContext returns the options ctx carries, the default ones when none
+func FromContext(ctx context.Context) *Options {
+	if opts, ok := ctx.Value(optionsKey{}).(*Options); ok {
+		return opts
+	}
+	return Default()
+}
+
+// Timeout returns the timeout of op of the calls made with ctx
+func Timeout(ctx context.Context, op string) time.Duration {
+	return FromContext(ctx).Timeout(op)
+}
+
+// Order returns endpoints in the order the calls made with ctx try them
+func Order(ctx context.Context, endpoints []*sdk.Endpoint) []*sdk.Endpoint {
+	return FromContext(ctx).Order(endpoints)
+}
+
+// Try calls f with the endpoints in their failover order until it succeeds.
+// When all of them failed with a retriable error, it tries them again as many
+// times as the options of ctx tell. It returns the last error of f, or the
+// error of ctx once it is done.
+func Try(ctx context.Context, endpoints []*sdk.Endpoint, f func(endpoint *sdk.Endpoint) error) error {
+	if len(endpoints) == 0 {
+		return errors.New("no nodes to call")
+	}
+	opts := FromContext(ctx)
+	var err error
+	for attempt := 0; ; attempt++ {
+		for _, endpoint := range opts.Order(endpoints) {
+			if err = f(endpoint); err == nil {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+		if attempt >= opts.Retries || !Retriable(err) {
+			return err
+		}
+		logger.Warning("Retrying %d/%d in %s after all nodes failed: %s", attempt+1, opts.Retries, opts.Backoff, err)
+		select {
+		case <-time.After(opts.Backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Retriable tells whether err may go away by calling again: a node that did
+// not answer in time, could not be reached or was not available.
+func Retriable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	return sdk.IsTimeout(err) || sdk.IsUnavailable(err)
+}
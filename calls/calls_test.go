@@ -0,0 +1,153 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParse(t *testing.T) {
+	base := &Options{Timeouts: map[string]time.Duration{OpEndorse: 3 * time.Second}, Backoff: time.Second, Failover: FailoverGiven}
+	opts, err := base.Parse("install=2m, WaitTx=30s", "2", "500ms", "RoundRobin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Timeout(OpInstall) != 2*time.Minute || opts.Timeout(OpWaitTx) != 30*time.Second ||
+		opts.Timeout(OpEndorse) != 3*time.Second || opts.Timeout(OpBroadcast) != 5*time.Second {
+		t.Fatalf("unexpected timeouts %v", opts.Timeouts)
+	}
+	if opts.Retries != 2 || opts.Backoff != 500*time.Millisecond || opts.Failover != FailoverRoundRobin {
+		t.Fatalf("unexpected options %+v", opts)
+	}
+	// the options parsed from are left as they are
+	if len(base.Timeouts) != 1 || base.Retries != 0 {
+		t.Fatalf("unexpected base options %+v", base)
+	}
+
+	same, err := base.Parse("", "", "", "")
+	if err != nil || same.Timeout(OpEndorse) != 3*time.Second || same.Failover != FailoverGiven {
+		t.Fatalf("unexpected options %+v: %v", same, err)
+	}
+
+	for _, bad := range [][4]string{
+		{"install", "", "", ""},
+		{"upgrade=1s", "", "", ""},
+		{"install=-1s", "", "", ""},
+		{"", "-1", "", ""},
+		{"", "", "soon", ""},
+		{"", "", "", "fastest"},
+	} {
+		if _, err := base.Parse(bad[0], bad[1], bad[2], bad[3]); err == nil {
+			t.Fatalf("%q parsed", bad)
+		}
+	}
+}
+
+func endpoints(addresses ...string) []*sdk.Endpoint {
+	var ret []*sdk.Endpoint
+	for _, address := range addresses {
+		ret = append(ret, &sdk.Endpoint{Address: address})
+	}
+	return ret
+}
+
+func addresses(endpoints []*sdk.Endpoint) (ret []string) {
+	for _, e := range endpoints {
+		ret = append(ret, e.Address)
+	}
+	return
+}
+
+func TestOrder(t *testing.T) {
+	nodes := endpoints("a", "b", "c")
+	given := &Options{Failover: FailoverGiven}
+	if got := addresses(given.Order(nodes)); len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Fatalf("unexpected order %v", got)
+	}
+
+	roundRobin := &Options{Failover: FailoverRoundRobin}
+	first := addresses(roundRobin.Order(nodes))
+	second := addresses(roundRobin.Order(nodes))
+	if len(second) != 3 || second[0] == first[0] {
+		t.Fatalf("round robin starts with %v then %v", first, second)
+	}
+
+	random := addresses((&Options{Failover: FailoverRandom}).Order(nodes))
+	seen := map[string]bool{}
+	for _, a := range random {
+		seen[a] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("unexpected order %v", random)
+	}
+}
+
+func TestTry(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	ctx := WithOptions(context.Background(), &Options{Retries: 1, Failover: FailoverGiven})
+
+	var tried []string
+	err := Try(ctx, endpoints("a", "b"), func(e *sdk.Endpoint) error {
+		tried = append(tried, e.Address)
+		if e.Address == "b" {
+			return nil
+		}
+		return unavailable
+	})
+	if err != nil || len(tried) != 2 {
+		t.Fatalf("tried %v: %v", tried, err)
+	}
+
+	// all the nodes are tried again after they all were unavailable
+	tried = nil
+	err = Try(ctx, endpoints("a", "b"), func(e *sdk.Endpoint) error {
+		tried = append(tried, e.Address)
+		return unavailable
+	})
+	if err != unavailable || len(tried) != 4 {
+		t.Fatalf("tried %v: %v", tried, err)
+	}
+
+	// but not after an error calling again does not fix
+	tried = nil
+	denied := errors.New("access denied")
+	err = Try(ctx, endpoints("a", "b"), func(e *sdk.Endpoint) error {
+		tried = append(tried, e.Address)
+		return denied
+	})
+	if err != denied || len(tried) != 2 {
+		t.Fatalf("tried %v: %v", tried, err)
+	}
+
+	// nor once the context is canceled
+	canceled, cancel := context.WithCancel(ctx)
+	tried = nil
+	err = Try(canceled, endpoints("a", "b"), func(e *sdk.Endpoint) error {
+		tried = append(tried, e.Address)
+		cancel()
+		return unavailable
+	})
+	if err != context.Canceled || len(tried) != 1 {
+		t.Fatalf("tried %v: %v", tried, err)
+	}
+
+	if err := Try(ctx, nil, func(*sdk.Endpoint) error { return nil }); err == nil {
+		t.Fatal("no nodes tried")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != Default() {
+		t.Fatal("no options are not the default ones")
+	}
+	opts := &Options{Timeouts: map[string]time.Duration{OpInstall: time.Minute}}
+	ctx := WithOptions(context.Background(), opts)
+	if FromContext(ctx) != opts || Timeout(ctx, OpInstall) != time.Minute || Timeout(ctx, OpWaitTx) != 20*time.Second {
+		t.Fatal("unexpected options of the context")
+	}
+}
@@ -1,19 +1,19 @@
 package chaincode
 
 import (
+	"context"
 	"errors"
-	logs "gglogs"
+	"fmt"
 	"io/ioutil"
+	"manageChain/calls"
+	"manageChain/logging"
+	"manageChain/protocols"
 
 	pp "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/sdk"
 )
 
-var logger *logs.BeeLogger
-
-func init() {
-	logger = logs.GetBeeLogger()
-}
+var logger = logging.GetLogger("chaincode")
 
 type Chaincode struct {
 	org       string
@@ -29,7 +29,7 @@ type Chaincode struct {
 func NewChaincode(orgMSP string, ccTarPath string, ccPath string, ccName string, ccVersion string, orgCA *sdk.CA, gm bool) (*Chaincode, error) {
 	client, err := sdk.NewClient(orgCA.AdminCommonName(), orgMSP, orgCA.AdminMSPDir(), gm)
 	if err != nil {
-		logger.Error("Error creating client for org", err)
+		logger.Error("Error creating client for org: %s", err)
 		return nil, err
 	}
 
@@ -44,28 +44,42 @@ func NewChaincode(orgMSP string, ccTarPath string, ccPath string, ccName string,
 	}, nil
 }
 
+// WithContext returns a copy of cc whose calls are canceled with ctx and made
+// with the options ctx carries, see calls.
+func (cc *Chaincode) WithContext(ctx context.Context) *Chaincode {
+	ret := *cc
+	ret.client = cc.client.WithContext(ctx)
+	return &ret
+}
+
+// log returns the logger of the calls of cc, its logs carry the correlation
+// ID of the context of cc
+func (cc *Chaincode) log() *logging.Logger {
+	return logger.WithContext(cc.client.Context())
+}
+
 func (cc *Chaincode) InstallChaincode(endorsers []*sdk.Endpoint) error {
 	ccTarPath := cc.ccTarPath
 	ccPath := cc.ccPath
 	ccName := cc.ccName
 	ccVersion := cc.ccVersion
 	if ccTarPath == "" {
-		return errors.New("chaincode package path should not be empty")
+		return protocols.Errorf(protocols.CodeInvalidRequest, "chaincode package path should not be empty")
 	}
 
 	err := installChaincode(cc.client, endorsers, ccTarPath, ccPath, ccName, ccVersion)
 	if err != nil {
-		logger.Error("Error installing  chaincode", err)
+		cc.log().Error("Error installing  chaincode: %s", err)
 		return err
 	}
-	logger.Info("Successfully installing  chaincode")
+	cc.log().Info("Successfully installing  chaincode")
 	return nil
 }
 
 func installChaincode(client *sdk.Client, endorsers []*sdk.Endpoint, tarPath string, ccPath string, name string, version string) error {
 	data, err := ioutil.ReadFile(tarPath)
 	if err != nil {
-		logger.Error("Error reading file", err)
+		logger.Error("Error reading file: %s", err)
 		return err
 	}
 	return client.InstallChaincode(name, version, ccPath, data, endorsers)
@@ -75,61 +89,71 @@ func (cc *Chaincode) GetOrgCA() *sdk.CA {
 	return cc.orgCA
 }
 
+func (cc *Chaincode) GetClient() *sdk.Client {
+	return cc.client
+}
+
 func (cc *Chaincode) InstantiateChaincode(endorsers []*sdk.Endpoint, casters []*sdk.Endpoint, channelName string, policy string, args [][]byte) error {
 	ccName := cc.ccName
 	ccVersion := cc.ccVersion
 
 	err := instantiateChaincode(cc.client, channelName, ccName, ccVersion, endorsers, casters, args, policy)
 	if err != nil {
-		logger.Error("Error Instantiate chaincode", err)
+		cc.log().Error("Error Instantiate chaincode: %s", err)
 		return err
 	}
-	logger.Info("Successfully Instantiate  chaincode")
+	cc.log().Info("Successfully Instantiate  chaincode")
 	return nil
 }
 
 func instantiateChaincode(client *sdk.Client, chainID string, ccName string, version string, endorsers []*sdk.Endpoint, casters []*sdk.Endpoint, args [][]byte, policy string) error {
 	logger.Info("policy:%s\n\n", policy)
-	for _, endorser := range endorsers {
-		if err := client.InstantiateChaincode(chainID, ccName, version, args, policy, nil, endorser, casters); err != nil {
-			logger.Error("Error Instantiate chaincode", err)
-			continue
+	casters = calls.Order(client.Context(), casters)
+	err := calls.Try(client.Context(), endorsers, func(endorser *sdk.Endpoint) error {
+		err := client.InstantiateChaincode(chainID, ccName, version, args, policy, nil, endorser, casters)
+		if err != nil {
+			logger.Error("Error Instantiate chaincode: %s", err)
 		}
-		return nil
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed Instantiate chaincode: %w", err)
 	}
-	return errors.New("failed Instantiate chaincode")
+	return nil
 }
 
-func (cc *Chaincode) Invoke(channelName string, peers []*sdk.Endpoint, orderers []*sdk.Endpoint, args [][]byte) error {
+// Invoke endorses the invocation with one of peers and one of the peers of
+// each of orgPeers, the peers of the other orgs the policy needs.
+func (cc *Chaincode) Invoke(channelName string, peers []*sdk.Endpoint, orgPeers [][]*sdk.Endpoint, orderers []*sdk.Endpoint, args [][]byte) error {
 	client := cc.client
 	ccName := cc.ccName
 
-	err := invoke(client, channelName, ccName, args, peers, orderers)
+	err := invoke(client, channelName, ccName, args, peers, orgPeers, orderers)
 	if err != nil {
-		logger.Error("Error invoke chaincode", err)
+		cc.log().Error("Error invoke chaincode: %s", err)
 		return err
 	}
-	logger.Info("Successfully invoke  chaincode")
+	cc.log().Info("Successfully invoke  chaincode")
 
 	return nil
 }
 
-func invoke(client *sdk.Client, chainID string, chaincode string, args [][]byte, peers []*sdk.Endpoint, orderers []*sdk.Endpoint) error {
-	txID, prop, resps, endorder, err := endorseOneOfList(client, chainID, chaincode, args, nil, peers)
+func invoke(client *sdk.Client, chainID string, chaincode string, args [][]byte, peers []*sdk.Endpoint, orgPeers [][]*sdk.Endpoint, orderers []*sdk.Endpoint) error {
+	txID, prop, resps, endorder, err := endorseOneOfList(client, chainID, chaincode, args, nil, peers, orgPeers)
 	if err != nil {
-		logger.Error("Error endorsing", err)
+		logger.Error("Error endorsing: %s", err)
 		return err
 	}
 
 	err = broadcastOneOfList(client, prop, resps, orderers)
 	if err != nil {
-		logger.Error("Error broadcasing", err)
+		logger.Error("Error broadcasing: %s", err)
 		return err
 	}
 
-	valid, err := client.WaitTx(chainID, txID, endorder, WaitTxTimeout)
+	valid, err := client.WaitTx(chainID, txID, endorder, calls.Timeout(client.Context(), calls.OpWaitTx))
 	if err != nil {
-		logger.Error("Error waiting transaction", err)
+		logger.Error("Error waiting transaction: %s", err)
 		return err
 	}
 
@@ -140,31 +164,41 @@ func invoke(client *sdk.Client, chainID string, chaincode string, args [][]byte,
 	return nil
 }
 
-func endorseOneOfList(client *sdk.Client, chainID string, chaincode string, args [][]byte, transient map[string][]byte, peerEndpoints []*sdk.Endpoint) (txID string, prop *pp.Proposal, resps []*pp.ProposalResponse, endorser *sdk.Endpoint, err error) {
-	for _, peer := range peerEndpoints {
-		txID, prop, resps, err = client.Endorse(chainID, chaincode, args, transient, []*sdk.Endpoint{peer})
-		if err == nil {
-			endorser = peer
-			break
+// endorseOneOfList endorses with one of peerEndpoints, and one of each of
+// orgPeers, the next one of every org being tried with the next of
+// peerEndpoints.
+func endorseOneOfList(client *sdk.Client, chainID string, chaincode string, args [][]byte, transient map[string][]byte, peerEndpoints []*sdk.Endpoint, orgPeers [][]*sdk.Endpoint) (txID string, prop *pp.Proposal, resps []*pp.ProposalResponse, endorser *sdk.Endpoint, err error) {
+	tries := 0
+	err = calls.Try(client.Context(), peerEndpoints, func(peer *sdk.Endpoint) (err error) {
+		endorsers := []*sdk.Endpoint{peer}
+		for _, others := range orgPeers {
+			endorsers = append(endorsers, others[tries%len(others)])
 		}
-		logger.Error("Error endorsing", err)
-	}
+		tries++
+		txID, prop, resps, err = client.Endorse(chainID, chaincode, args, transient, endorsers)
+		if err != nil {
+			logger.Error("Error endorsing: %s", err)
+			return err
+		}
+		endorser = peer
+		return nil
+	})
 	if err != nil {
-		return "", nil, nil, nil, errors.New("failed proposing through all peers")
+		return "", nil, nil, nil, fmt.Errorf("failed proposing through all peers: %w", err)
 	}
 	return
 }
 
 func broadcastOneOfList(client *sdk.Client, prop *pp.Proposal, resps []*pp.ProposalResponse, ordererEndpoints []*sdk.Endpoint) (err error) {
-	for _, orderer := range ordererEndpoints {
-		err = client.Broadcast(prop, resps, orderer)
-		if err == nil {
-			break
+	err = calls.Try(client.Context(), ordererEndpoints, func(orderer *sdk.Endpoint) error {
+		err := client.Broadcast(prop, resps, orderer)
+		if err != nil {
+			logger.Error("Error broadcasting: %s", err)
 		}
-		logger.Error("Error broadcasting", err)
-	}
+		return err
+	})
 	if err != nil {
-		return errors.New("failed broadcasting through all orderers")
+		return fmt.Errorf("failed broadcasting through all orderers: %w", err)
 	}
 	return
 }
@@ -0,0 +1,416 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"manageChain/chaincode"
+	"manageChain/channel"
+	"manageChain/fabrictest"
+	"manageChain/network"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// The flows of manageChain run here against a fake network, this chaincode
+// being package main, their own packages cannot run it.
+
+const flowSpec = `
+orgs:
+- name: testorg1
+  msp: testorg1
+  peers:
+  - {id: peer0, endpoint: "%s", public: true}
+  - {id: peer1, endpoint: "%s"}
+  orderers:
+  - {id: orderer0, endpoint: "%s", externalEndpoint: "%s"}
+- name: testorg2
+  msp: testorg2
+  peers:
+  - {id: peer0, endpoint: "%s", public: true}
+consensus:
+  type: kafka
+  kafkas: ["127.0.0.1:9092"]
+publicChain:
+  ccTarPath: "%s"
+channels:
+- name: mychannel
+  members: [testorg1, testorg2]
+chaincodes:
+- name: counter
+  path: counter
+  tarPath: "%s"
+  version: "1.0"
+  channels: [mychannel]
+`
+
+// TestTarball checks that the tarball Bootstrap deploys holds the sources of
+// the chaincode, this test aside as it needs manageChain
+func TestTarball(t *testing.T) {
+	f, err := os.Open("../../../public.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag != tar.TypeReg || filepath.Dir(filepath.Clean(hdr.Name)) != filepath.Join("src", "public") {
+			continue
+		}
+		if packed[filepath.Base(hdr.Name)], err = ioutil.ReadAll(tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := ioutil.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if file.Name() == "flow_test.go" {
+			continue
+		}
+		source, err := ioutil.ReadFile(file.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(packed[file.Name()], source) {
+			t.Errorf("%s differs in public.tar.gz, repack it", file.Name())
+		}
+		delete(packed, file.Name())
+	}
+	for name := range packed {
+		t.Errorf("%s of public.tar.gz has no source", name)
+	}
+}
+
+// counter counts its invocations
+type counter struct{}
+
+func (cc *counter) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (cc *counter) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	count, err := stub.GetState("count")
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	count = append(count, '.')
+	if err := stub.PutState("count", count); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(count)
+}
+
+// applyFlow applies flowSpec to a fake network, returning the orgs of the
+// spec with their clients, the addresses of the nodes, the network and the
+// func stopping it
+func applyFlow(t *testing.T) ([]*channel.OrgInfo, []string, *fabrictest.Network, func()) {
+	mspDir, err := filepath.Abs("../../../../msp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ccTarPath, err := filepath.Abs("../../../public.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the genesis block is written to the working directory
+	dir, err := ioutil.TempDir("", "flow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	fabric := fabrictest.NewNetwork()
+	stop := func() {
+		fabric.Stop()
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+	ok := false
+	defer func() {
+		if !ok {
+			stop()
+		}
+	}()
+	fabric.RegisterChaincode(channel.PublicCCName, new(PublicChaincode))
+	fabric.RegisterChaincode("counter", new(counter))
+	org1 := filepath.Join(mspDir, "testorg1")
+	org2 := filepath.Join(mspDir, "testorg2")
+	var addrs []string
+	for _, start := range []func() (string, error){
+		func() (string, error) { return fabric.StartPeer(org1, "testorg1") },
+		func() (string, error) { return fabric.StartPeer(org1, "testorg1") },
+		func() (string, error) { return fabric.StartOrderer(org1) },
+		func() (string, error) { return fabric.StartPeer(org2, "testorg2") },
+	} {
+		addr, err := start()
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs = append(addrs, addr)
+	}
+	spec, err := network.ParseSpec([]byte(fmt.Sprintf(flowSpec, addrs[0], addrs[1], addrs[2], addrs[2], addrs[3], ccTarPath, ccTarPath)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the orderers start from the genesis block of the orgs of the spec
+	var orgs []*channel.OrgInfo
+	for _, org := range spec.Orgs {
+		ca, err := channel.GetCA(filepath.Join(mspDir, org.Name), org.MSP)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := &channel.OrgInfo{OrgName: org.Name, OrgMSP: org.MSP, OrgCA: ca}
+		for _, node := range org.Peers {
+			info.PeerNodes = append(info.PeerNodes, &channel.ServiceNode{ID: node.ID, Endpoint: node.Endpoint, Public: node.Public})
+		}
+		for _, node := range org.Orderers {
+			info.OrdererNodes = append(info.OrdererNodes, &channel.ServiceNode{ID: node.ID, Endpoint: node.Endpoint, ExternalEndpoint: node.ExternalEndpoint})
+		}
+		orgs = append(orgs, info)
+	}
+	genesis, err := channel.GenGenesisBlock(orgs, spec.Consensus.Kafkas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fabric.Bootstrap(genesis); err != nil {
+		t.Fatal(err)
+	}
+
+	n := network.NewNetwork(context.Background(), spec, mspDir, false)
+	plan, err := n.Apply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range plan.Steps {
+		if !step.Done {
+			t.Fatalf("step %s is not applied, warnings: %v", step.Action, plan.Warnings)
+		}
+	}
+	if len(plan.Steps) == 0 || plan.Steps[0].Action != network.BootstrapPublicChain {
+		t.Fatalf("unexpected plan: %+v", plan.Steps)
+	}
+
+	// the network matches the spec once applied
+	plan, err = n.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Steps) != 0 || len(plan.Warnings) != 0 {
+		t.Fatalf("unexpected plan: %+v, warnings: %v", plan.Steps, plan.Warnings)
+	}
+
+	// the clients of the orgs
+	if _, err := channel.NewChannel(orgs, false); err != nil {
+		t.Fatal(err)
+	}
+	ok = true
+	return orgs, addrs, fabric, stop
+}
+
+func TestNetworkFlow(t *testing.T) {
+	orgs, addrs, _, stop := applyFlow(t)
+	defer stop()
+
+	// the directory of the public chain knows the orgs of the channel
+	peers := []*sdk.Endpoint{{Address: addrs[0], TLS: orgs[0].OrgCA.TLSCACert()}}
+	orgnames, err := channel.QueryChainOrgnames(orgs[0].Client, peers, "mychannel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(orgnames)
+	if !reflect.DeepEqual(orgnames, []string{"testorg1", "testorg2"}) {
+		t.Fatalf("unexpected orgs of mychannel: %v", orgnames)
+	}
+}
+
+// TestJoinPublishesOrg checks that the org is published with all its nodes
+// once its peers joined, a join step publishing only the peers it joins
+func TestJoinPublishesOrg(t *testing.T) {
+	orgs, addrs, _, stop := applyFlow(t)
+	defer stop()
+
+	peers := []*sdk.Endpoint{{Address: addrs[0], TLS: orgs[0].OrgCA.TLSCACert()}}
+	info, err := channel.QueryChainOrgInfo(orgs[0].Client, peers, "mychannel", "testorg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var published []string
+	for _, ep := range append(info.Peers, info.Orderers...) {
+		published = append(published, ep.Address)
+	}
+	if !reflect.DeepEqual(published, []string{addrs[0], addrs[1], addrs[2]}) {
+		t.Fatalf("unexpected nodes of testorg1 in mychannel: %v", published)
+	}
+}
+
+// TestEndorsingOrgs checks that an invocation is endorsed by the peers the
+// other orgs published too
+func TestEndorsingOrgs(t *testing.T) {
+	orgs, addrs, _, stop := applyFlow(t)
+	defer stop()
+
+	peers := []*sdk.Endpoint{{Address: addrs[0], TLS: orgs[0].OrgCA.TLSCACert()}}
+	orgPeers, err := channel.OrgPeers(orgs[0].Client, peers, "mychannel", []string{"testorg2"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgPeers) != 1 || len(orgPeers[0]) != 1 || orgPeers[0][0].Address != addrs[3] {
+		t.Fatalf("unexpected peers of testorg2: %+v", orgPeers)
+	}
+	if _, err := channel.OrgPeers(orgs[0].Client, peers, "mychannel", []string{"testorg3"}, 0); err == nil {
+		t.Fatal("an org that published nothing should have no peers")
+	}
+
+	var signers []string
+	sdk.SetTxObserver(func(ctx context.Context, tx *sdk.Tx) {
+		signers = tx.Signers
+	})
+	defer sdk.SetTxObserver(nil)
+	cc, err := chaincode.NewChaincode("testorg1", "", "", "counter", "", orgs[0].OrgCA, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderers := []*sdk.Endpoint{{Address: addrs[2], TLS: orgs[0].OrgCA.TLSCACert()}}
+	if err := cc.Invoke("mychannel", peers, orgPeers, orderers, [][]byte{[]byte("inc")}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(signers, []string{"testorg1", "testorg2"}) {
+		t.Fatalf("unexpected signers of the invocation: %v", signers)
+	}
+}
+
+// TestRemovalAfterConfigChange checks that a removal is executed from the
+// current configs when another config update landed since it was proposed
+func TestRemovalAfterConfigChange(t *testing.T) {
+	orgs, addrs, _, stop := applyFlow(t)
+	defer stop()
+
+	org1, err := channel.NewChannel(orgs[:1], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := org1.ProposeRemoval("mychannel", "testorg2", nil, "left"); err != nil {
+		t.Fatal(err)
+	}
+
+	// testorg3 joins mychannel before the vote
+	mspDir, err := filepath.Abs("../../../../msp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := channel.GetCA(filepath.Join(mspDir, "testorg3"), "testorg3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	org3, err := channel.NewChannel([]*channel.OrgInfo{{OrgName: "testorg3", OrgMSP: "testorg3", OrgCA: ca}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ic, err := org3.IdentityCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := json.Marshal(ic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := org1.AddOrg(identity, orgs[:1], "mychannel"); err != nil {
+		t.Fatal(err)
+	}
+
+	proposal, err := org1.VoteRemoval("mychannel", "testorg2", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &channel.RemovalResult{}
+	if err := json.Unmarshal([]byte(proposal.Result), result); err != nil {
+		t.Fatal(err)
+	}
+	if proposal.Status != "removed" || result.Error != "" {
+		t.Fatalf("unexpected removal: %s, %+v", proposal.Status, result)
+	}
+
+	peers := []*sdk.Endpoint{{Address: addrs[0], TLS: orgs[0].OrgCA.TLSCACert()}}
+	orgnames, err := channel.QueryChainOrgnames(orgs[0].Client, peers, "mychannel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(orgnames, []string{"testorg1"}) {
+		t.Fatalf("unexpected orgs of mychannel: %v", orgnames)
+	}
+}
+
+// TestRemovalResumes checks that a removal whose channel update failed after
+// the system channel update was applied resumes at the channel update
+func TestRemovalResumes(t *testing.T) {
+	orgs, _, fabric, stop := applyFlow(t)
+	defer stop()
+
+	org1, err := channel.NewChannel(orgs[:1], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := org1.ProposeRemoval("mychannel", "testorg2", nil, "left"); err != nil {
+		t.Fatal(err)
+	}
+	fabric.RejectConfigUpdate("mychannel")
+	if _, err := org1.VoteRemoval("mychannel", "testorg2", true); err == nil {
+		t.Fatal("the rejected channel update succeeded")
+	}
+	proposal, err := org1.GetRemoval("mychannel", "testorg2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := &channel.RemovalResult{}
+	if err := json.Unmarshal([]byte(proposal.Result), failed); err != nil {
+		t.Fatal(err)
+	}
+	if proposal.Status != "failed" || failed.SystemTxID == "" || failed.ChannelTxID != "" {
+		t.Fatalf("unexpected removal: %s, %+v", proposal.Status, failed)
+	}
+
+	result, err := org1.ExecuteRemoval("mychannel", "testorg2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SystemTxID != failed.SystemTxID || result.ChannelTxID == "" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if proposal, err = org1.GetRemoval("mychannel", "testorg2"); err != nil || proposal.Status != "removed" {
+		t.Fatalf("unexpected removal: %+v, %v", proposal, err)
+	}
+}
@@ -9,7 +9,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"time"
+	"strconv"
+	"unicode/utf8"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -21,6 +22,13 @@ const (
 	updateDataPrefix = "UpdateData"
 	signedPrefix     = "Signed"
 	channelOrgPrefix = "ChannelOrg"
+
+	removalPrefix       = "Removal"
+	removalSignedPrefix = "RemovalSigned"
+
+	// pagePrefix prefixes the simple keys indexing the orgs and the
+	// invitations, which the pages are range queries of
+	pagePrefix = "Page"
 )
 
 const (
@@ -41,6 +49,29 @@ const (
 	updateChainOrgInfo   = "UpdateChainOrgInfo"
 	getChainOrgInfo      = "GetChainOrgInfo"
 	getAllOrgnameOfChain = "GetAllOrgnameOfChain"
+
+	getOrgInfoByPage    = "GetOrgInfoByPage"
+	getOrgnameByPage    = "GetOrgnameByPage"
+	getInvitationByPage = "GetInvitationByPage"
+
+	startRemoval  = "StartRemoval"
+	signRemoval   = "SignRemoval"
+	getRemoval    = "GetRemoval"
+	recordRemoval = "RecordRemoval"
+)
+
+// governance events, a transaction carries at most one of them
+const (
+	invitationCreatedEvent = "InvitationCreated"
+	voteCastEvent          = "VoteCast"
+	proposalDecidedEvent   = "ProposalDecided"
+	orgInfoChangedEvent    = "OrgInfoChanged"
+	removalProposedEvent   = "RemovalProposed"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 200
 )
 
 const (
@@ -49,6 +80,11 @@ const (
 
 	acceptState = "Accept"
 	rejectState = "Reject"
+
+	approvedState = "approved"
+	rejectedState = "rejected"
+	removedState  = "removed"
+	failedState   = "failed"
 )
 
 type OrgInfo struct {
@@ -76,6 +112,38 @@ type InvitationSignStatus struct {
 	SignTime  int64  `json:"signTime"`
 }
 
+// GovernanceEvent is the payload of the chaincode events
+type GovernanceEvent struct {
+	Type     string `json:"type"`
+	ChainId  string `json:"chainId"`
+	Orgname  string `json:"orgname,omitempty"`
+	Inviter  string `json:"inviter,omitempty"`
+	Invitee  string `json:"invitee,omitempty"`
+	Signer   string `json:"signer,omitempty"`
+	Accepted string `json:"accepted,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Proposer string `json:"proposer,omitempty"`
+	Target   string `json:"target,omitempty"`
+	Time     int64  `json:"time"`
+}
+
+// InvitationFilter selects invitations, empty fields and zero times match everything
+type InvitationFilter struct {
+	Status    string `json:"status"`
+	Inviter   string `json:"inviter"`
+	Invitee   string `json:"invitee"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+}
+
+// Page is the result of a paginated query, pass Bookmark back to get the next page,
+// an empty Bookmark means there are no more records
+type Page struct {
+	Records             interface{} `json:"records"`
+	FetchedRecordsCount int32       `json:"fetchedRecordsCount"`
+	Bookmark            string      `json:"bookmark"`
+}
+
 type PublicChaincode struct {
 }
 
@@ -83,6 +151,12 @@ var logger = shim.NewLogger("PublicChaincode")
 
 func (t *PublicChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	logger.Info("====================Init===================")
+	// the orgs and the invitations of an upgraded chaincode are indexed
+	for _, objectType := range []string{orgPrefix, invitePrefix} {
+		if err := indexPages(stub, objectType); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
 	return shim.Success([]byte("Successfully init"))
 }
 
@@ -197,6 +271,59 @@ func (t *PublicChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		}
 		chainId := args[0]
 		return t.GetAllOrgnameOfChain(stub, chainId)
+	case getOrgInfoByPage:
+		if len(args) != 3 {
+			return shim.Error("GetOrgInfoByPage must include three arguments: [chainId, pageSize, bookmark]")
+		}
+		pageSize, err := parsePageSize(args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return t.GetOrgInfoByPage(stub, args[0], pageSize, args[2])
+	case getOrgnameByPage:
+		if len(args) != 3 {
+			return shim.Error("GetOrgnameByPage must include three arguments: [chainId, pageSize, bookmark]")
+		}
+		pageSize, err := parsePageSize(args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return t.GetOrgnameByPage(stub, args[0], pageSize, args[2])
+	case getInvitationByPage:
+		if len(args) != 4 {
+			return shim.Error("GetInvitationByPage must include four arguments: [chainId, pageSize, bookmark, filter]")
+		}
+		pageSize, err := parsePageSize(args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		filter := InvitationFilter{}
+		if args[3] != "" {
+			if err := json.Unmarshal([]byte(args[3]), &filter); err != nil {
+				return shim.Error(fmt.Sprintf("Error Unmarshal filter: %s, err: %s", args[3], err))
+			}
+		}
+		return t.GetInvitationByPage(stub, args[0], pageSize, args[2], filter)
+	case startRemoval:
+		if len(args) != 5 {
+			return shim.Error("StartRemoval must include five arguments: [chainId, proposer, target, reason, rawData]")
+		}
+		return t.StartRemoval(stub, args[0], args[1], args[2], args[3], args[4])
+	case signRemoval:
+		if len(args) != 5 {
+			return shim.Error("SignRemoval must include five arguments: [chainId, target, signer, signature, accepted(Accept or Reject)]")
+		}
+		return t.SignRemoval(stub, args[0], args[1], args[2], args[3], args[4])
+	case getRemoval:
+		if len(args) != 2 {
+			return shim.Error("GetRemoval must include two arguments: [chainId, target]")
+		}
+		return t.GetRemoval(stub, args[0], args[1])
+	case recordRemoval:
+		if len(args) != 4 {
+			return shim.Error("RecordRemoval must include four arguments: [chainId, target, status(removed or failed), result]")
+		}
+		return t.RecordRemoval(stub, args[0], args[1], args[2], args[3])
 	default:
 		return shim.Error("Unsupported operation")
 	}
@@ -221,6 +348,12 @@ func (t *PublicChaincode) AddOrgInfo(stub shim.ChaincodeStubInterface, chainId,
 	if err := stub.PutState(key, data); err != nil {
 		return shim.Error(fmt.Sprintf("Error adding org info: %s", err))
 	}
+	if err := indexPage(stub, key); err != nil {
+		return shim.Error(err.Error())
+	}
+	if err := setEvent(stub, GovernanceEvent{Type: orgInfoChangedEvent, ChainId: chainId, Orgname: orgname}); err != nil {
+		return shim.Error(err.Error())
+	}
 	logger.Infof("===============End AddOrgInfo============")
 	return shim.Success([]byte("Successfully adding org info"))
 }
@@ -245,6 +378,9 @@ func (t *PublicChaincode) UpdateOrgInfo(stub shim.ChaincodeStubInterface, chainI
 	if err := stub.PutState(key, data); err != nil {
 		return shim.Error(fmt.Sprintf("Error update org info: %s", err))
 	}
+	if err := setEvent(stub, GovernanceEvent{Type: orgInfoChangedEvent, ChainId: chainId, Orgname: orgname}); err != nil {
+		return shim.Error(err.Error())
+	}
 	logger.Infof("===============End UpdateOrgInfo============")
 	return shim.Success([]byte("Successfully updating org info"))
 }
@@ -270,6 +406,7 @@ func (t *PublicChaincode) GetAllOrgInfo(stub shim.ChaincodeStubInterface, chainI
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error getting state by partial composit key: %s", err))
 	}
+	defer iter.Close()
 
 	orgInfos := []OrgInfo{}
 	for iter.HasNext() {
@@ -304,6 +441,7 @@ func (t *PublicChaincode) GetAllOrgname(stub shim.ChaincodeStubInterface, chainI
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error getting state by partial composit key: %s", err))
 	}
+	defer iter.Close()
 
 	orgnames := []string{}
 	for iter.HasNext() {
@@ -335,6 +473,7 @@ func (t *PublicChaincode) GetAllOrgnameOfChain(stub shim.ChaincodeStubInterface,
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error getting state by partial composit key: %s", err))
 	}
+	defer iter.Close()
 
 	orgnames := []string{}
 	for iter.HasNext() {
@@ -363,7 +502,10 @@ func (t *PublicChaincode) GetAllOrgnameOfChain(stub shim.ChaincodeStubInterface,
 func (t *PublicChaincode) StartInvitation(stub shim.ChaincodeStubInterface, chainId, inviter, invitee, rawData string) pb.Response {
 	logger.Infof("===============Start StartInvitation============, chainId: %s, inviter: %s, invitee: %s, rawData: %s", chainId, inviter, invitee, rawData)
 
-	timestamp := time.Now().Unix()
+	timestamp, err := txTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
 
 	key, err := stub.CreateCompositeKey(invitePrefix, []string{chainId, inviter, invitee})
 	if err != nil {
@@ -406,6 +548,13 @@ func (t *PublicChaincode) StartInvitation(stub shim.ChaincodeStubInterface, chai
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error putting data: %s", err))
 	}
+	if err := indexPage(stub, key); err != nil {
+		return shim.Error(err.Error())
+	}
+	err = setEvent(stub, GovernanceEvent{Type: invitationCreatedEvent, ChainId: chainId, Inviter: inviter, Invitee: invitee, Status: initState, Time: timestamp})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
 	logger.Infof("===============End StartInvitation============")
 
 	return shim.Success([]byte("Successfully starting invitation"))
@@ -444,6 +593,10 @@ func (t *PublicChaincode) ConfirmInvitation(stub shim.ChaincodeStubInterface, ch
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error putting data: %s", err))
 	}
+	err = setEvent(stub, GovernanceEvent{Type: proposalDecidedEvent, ChainId: chainId, Inviter: inviter, Invitee: invitee, Status: confirmState})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
 	logger.Infof("===============End ConfirmInvitation============")
 	return shim.Success([]byte("Successfully confirming invitation"))
 }
@@ -499,7 +652,10 @@ func (t *PublicChaincode) GetAllInvitation(stub shim.ChaincodeStubInterface, cha
 
 func (t *PublicChaincode) SignInvitation(stub shim.ChaincodeStubInterface, chainId, inviter, invitee, signer, signature, accepted string) pb.Response {
 	logger.Infof("===============Start SignInvitation, chainId: %s, inviter: %s, invitee: %s, signer: %s, signature :%s, accepted: %s============", chainId, inviter, invitee, signer, signature, accepted)
-	timestamp := time.Now().Unix()
+	timestamp, err := txTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
 	key, err := stub.CreateCompositeKey(signedPrefix, []string{chainId, inviter, invitee, signer})
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error creating composit key: %s", err))
@@ -538,6 +694,10 @@ func (t *PublicChaincode) SignInvitation(stub shim.ChaincodeStubInterface, chain
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error putting data: %s", err))
 	}
+	err = setEvent(stub, GovernanceEvent{Type: voteCastEvent, ChainId: chainId, Inviter: inviter, Invitee: invitee, Signer: signer, Accepted: accepted, Time: timestamp})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
 
 	logger.Infof("===============End SignInvitation============")
 	return shim.Success([]byte("Successfully signing invitation"))
@@ -596,6 +756,10 @@ func (t *PublicChaincode) UpdateChainOrgInfo(stub shim.ChaincodeStubInterface, c
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Error updateChainOrgInfo: %s", err))
 	}
+	err = setEvent(stub, GovernanceEvent{Type: orgInfoChangedEvent, ChainId: chainId, Orgname: orgName})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
 	logger.Infof("end updateChainOrgInfo")
 	return shim.Success([]byte("Successfully UpdateChainOrgInfo"))
 }
@@ -618,6 +782,205 @@ func (t *PublicChaincode) GetChainOrgInfo(stub shim.ChaincodeStubInterface, chai
 	return shim.Success(data)
 }
 
+func (t *PublicChaincode) GetOrgInfoByPage(stub shim.ChaincodeStubInterface, chainId string, pageSize int32, bookmark string) pb.Response {
+	logger.Infof("===============Start GetOrgInfoByPage============, chainId: %s, pageSize: %d, bookmark: %s", chainId, pageSize, bookmark)
+	orgInfos := []OrgInfo{}
+	next, err := scanPage(stub, orgPrefix, []string{chainId}, pageSize, bookmark, func(key string, value []byte) (bool, error) {
+		_, partials, err := stub.SplitCompositeKey(key)
+		if err != nil {
+			return false, fmt.Errorf("Error splitting composit key: %s", err)
+		}
+		orgInfos = append(orgInfos, OrgInfo{ChainId: partials[0], Orgname: partials[1], Info: string(value)})
+		return true, nil
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	logger.Infof("===============End GetOrgInfoByPage============")
+	return pageResponse(orgInfos, len(orgInfos), next)
+}
+
+func (t *PublicChaincode) GetOrgnameByPage(stub shim.ChaincodeStubInterface, chainId string, pageSize int32, bookmark string) pb.Response {
+	logger.Infof("===============Start GetOrgnameByPage============, chainId: %s, pageSize: %d, bookmark: %s", chainId, pageSize, bookmark)
+	orgnames := []string{}
+	next, err := scanPage(stub, orgPrefix, []string{chainId}, pageSize, bookmark, func(key string, value []byte) (bool, error) {
+		_, partials, err := stub.SplitCompositeKey(key)
+		if err != nil {
+			return false, fmt.Errorf("Error splitting composit key: %s", err)
+		}
+		orgnames = append(orgnames, partials[1])
+		return true, nil
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	logger.Infof("===============End GetOrgnameByPage============")
+	return pageResponse(orgnames, len(orgnames), next)
+}
+
+func (t *PublicChaincode) GetInvitationByPage(stub shim.ChaincodeStubInterface, chainId string, pageSize int32, bookmark string, filter InvitationFilter) pb.Response {
+	logger.Infof("===============Start GetInvitationByPage============, chainId: %s, pageSize: %d, bookmark: %s, filter: %+v", chainId, pageSize, bookmark, filter)
+	attributes := []string{chainId}
+	if filter.Inviter != "" {
+		// invitations are keyed by [chainId, inviter, invitee]
+		attributes = append(attributes, filter.Inviter)
+	}
+	invitations := []Invitation{}
+	next, err := scanPage(stub, invitePrefix, attributes, pageSize, bookmark, func(key string, value []byte) (bool, error) {
+		invitation := Invitation{}
+		if err := json.Unmarshal(value, &invitation); err != nil {
+			return false, fmt.Errorf("Error Unmarshal value: %s, err: %s", value, err)
+		}
+		if !filter.match(invitation) {
+			return false, nil
+		}
+		invitations = append(invitations, invitation)
+		return true, nil
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	logger.Infof("===============End GetInvitationByPage============")
+	return pageResponse(invitations, len(invitations), next)
+}
+
+func (f InvitationFilter) match(invitation Invitation) bool {
+	if f.Status != "" && f.Status != invitation.Status {
+		return false
+	}
+	if f.Inviter != "" && f.Inviter != invitation.Inviter {
+		return false
+	}
+	if f.Invitee != "" && f.Invitee != invitation.Invitee {
+		return false
+	}
+	if f.StartTime != 0 && invitation.InviteTime < f.StartTime {
+		return false
+	}
+	if f.EndTime != 0 && invitation.InviteTime > f.EndTime {
+		return false
+	}
+	return true
+}
+
+// scanPage walks the keys under the partial composite key after bookmark, and hands
+// them to collect until pageSize of them are taken. It returns the bookmark of the
+// next page, which is empty when the keys are exhausted. The shim of fabric 1.2
+// lacking GetStateByPartialCompositeKeyWithPagination and range queries of
+// composite keys, it reads the page from the simple keys indexing them.
+func scanPage(stub shim.ChaincodeStubInterface, objectType string, attributes []string, pageSize int32, bookmark string, collect func(key string, value []byte) (bool, error)) (string, error) {
+	partial, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return "", fmt.Errorf("Error creating composit key: %s", err)
+	}
+	start := pagePrefix + partial
+	if bookmark > partial {
+		// the first key after the bookmark
+		start = pagePrefix + bookmark + "\x00"
+	}
+	iter, err := stub.GetStateByRange(start, pagePrefix+partial+string(utf8.MaxRune))
+	if err != nil {
+		return "", fmt.Errorf("Error getting state by range: %s", err)
+	}
+	defer iter.Close()
+
+	var taken int32
+	for iter.HasNext() {
+		k, err := iter.Next()
+		if err != nil {
+			return "", fmt.Errorf("Error getting next state: %s", err)
+		}
+		if taken == pageSize {
+			return bookmark, nil
+		}
+		key := string(k.Value)
+		value, err := stub.GetState(key)
+		if err != nil {
+			return "", fmt.Errorf("Error getting state: %s", err)
+		}
+		ok, err := collect(key, value)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			taken++
+		}
+		bookmark = key
+	}
+	return "", nil
+}
+
+// indexPage indexes the composite key for the pages, the index holding it
+func indexPage(stub shim.ChaincodeStubInterface, key string) error {
+	if err := stub.PutState(pagePrefix+key, []byte(key)); err != nil {
+		return fmt.Errorf("Error putting page index: %s", err)
+	}
+	return nil
+}
+
+// indexPages indexes the keys of objectType for the pages
+func indexPages(stub shim.ChaincodeStubInterface, objectType string) error {
+	iter, err := stub.GetStateByPartialCompositeKey(objectType, []string{})
+	if err != nil {
+		return fmt.Errorf("Error getting state by partial composit key: %s", err)
+	}
+	defer iter.Close()
+	for iter.HasNext() {
+		k, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("Error getting next state: %s", err)
+		}
+		if err := indexPage(stub, k.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setEvent(stub shim.ChaincodeStubInterface, event GovernanceEvent) error {
+	if event.Time == 0 {
+		timestamp, err := txTime(stub)
+		if err != nil {
+			return err
+		}
+		event.Time = timestamp
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("Error marshaling event: %s", err)
+	}
+	if err := stub.SetEvent(event.Type, payload); err != nil {
+		return fmt.Errorf("Error setting event: %s", err)
+	}
+	return nil
+}
+
+func pageResponse(records interface{}, count int, bookmark string) pb.Response {
+	data, err := json.Marshal(Page{
+		Records:             records,
+		FetchedRecordsCount: int32(count),
+		Bookmark:            bookmark,
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error marshaling: %s", err))
+	}
+	return shim.Success(data)
+}
+
+func parsePageSize(arg string) (int32, error) {
+	if arg == "" {
+		return defaultPageSize, nil
+	}
+	size, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Error parsing pageSize: %s", err)
+	}
+	if size <= 0 || size > maxPageSize {
+		return 0, fmt.Errorf("pageSize should be in (0, %d], got %d", maxPageSize, size)
+	}
+	return int32(size), nil
+}
+
 func InviterExist(stub shim.ChaincodeStubInterface, chainId, inviter string) bool {
 	logger.Infof("===============Start InviterExist, chainId: %s, inviter: %s============", chainId, inviter)
 
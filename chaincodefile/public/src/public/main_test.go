@@ -3,7 +3,9 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	// "strconv"
 	"testing"
@@ -77,6 +79,234 @@ func TestGetInvitationSignStatus(t *testing.T) {
 	fmt.Println(invitations)
 }
 
+func TestGetInvitationByPage(t *testing.T) {
+	publicCC := new(PublicChaincode)
+	stub := shim.NewMockStub("public", publicCC)
+
+	checkInvoke(t, stub, [][]byte{[]byte("AddOrgInfo"), []byte("publicchain"), []byte("orgA"), []byte("infoA")})
+	checkInvoke(t, stub, [][]byte{[]byte("AddOrgInfo"), []byte("publicchain"), []byte("orgD"), []byte("infoD")})
+	checkInvoke(t, stub, [][]byte{[]byte("StartInvitation"), []byte("publicchain"), []byte("orgA"), []byte("orgB"), []byte("RawData")})
+	checkInvoke(t, stub, [][]byte{[]byte("StartInvitation"), []byte("publicchain"), []byte("orgA"), []byte("orgC"), []byte("RawData")})
+	checkInvoke(t, stub, [][]byte{[]byte("StartInvitation"), []byte("publicchain"), []byte("orgD"), []byte("orgE"), []byte("RawData")})
+	checkInvoke(t, stub, [][]byte{[]byte("ConfirmInvitation"), []byte("publicchain"), []byte("orgA"), []byte("orgC")})
+
+	bookmark := ""
+	invitations := []Invitation{}
+	for {
+		res := checkInvoke(t, stub, [][]byte{[]byte("GetInvitationByPage"), []byte("publicchain"), []byte("2"), []byte(bookmark), []byte("")})
+		page := struct {
+			Records  []Invitation `json:"records"`
+			Bookmark string       `json:"bookmark"`
+		}{}
+		if err := json.Unmarshal(res.Payload, &page); err != nil {
+			t.Fatal(err)
+		}
+		invitations = append(invitations, page.Records...)
+		if page.Bookmark == "" {
+			break
+		}
+		bookmark = page.Bookmark
+	}
+	if len(invitations) != 3 {
+		t.Fatalf("expect 3 invitations, got %d", len(invitations))
+	}
+
+	filter, _ := json.Marshal(InvitationFilter{Inviter: "orgA", Status: "init"})
+	res := checkInvoke(t, stub, [][]byte{[]byte("GetInvitationByPage"), []byte("publicchain"), []byte("10"), []byte(""), filter})
+	page := struct {
+		Records []Invitation `json:"records"`
+	}{}
+	if err := json.Unmarshal(res.Payload, &page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Records) != 1 || page.Records[0].Invitee != "orgB" {
+		t.Fatalf("unexpected invitations: %+v", page.Records)
+	}
+
+	bookmark = ""
+	orgnames := []string{}
+	for {
+		res = checkInvoke(t, stub, [][]byte{[]byte("GetOrgnameByPage"), []byte("publicchain"), []byte("1"), []byte(bookmark)})
+		page := struct {
+			Records  []string `json:"records"`
+			Count    int32    `json:"fetchedRecordsCount"`
+			Bookmark string   `json:"bookmark"`
+		}{}
+		if err := json.Unmarshal(res.Payload, &page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Records) > 1 || int(page.Count) != len(page.Records) {
+			t.Fatalf("unexpected page: %+v", page)
+		}
+		orgnames = append(orgnames, page.Records...)
+		if page.Bookmark == "" {
+			break
+		}
+		bookmark = page.Bookmark
+	}
+	if len(orgnames) != 2 || orgnames[0] != "orgA" || orgnames[1] != "orgD" {
+		t.Fatalf("unexpected orgnames: %v", orgnames)
+	}
+}
+
+func TestPagesAfterUpgrade(t *testing.T) {
+	publicCC := new(PublicChaincode)
+	stub := shim.NewMockStub("public", publicCC)
+
+	// the orgs written before the pages were indexed
+	stub.MockTransactionStart("1")
+	for _, org := range []string{"orgA", "orgB", "orgC"} {
+		key, _ := stub.CreateCompositeKey(orgPrefix, []string{"publicchain", org})
+		stub.PutState(key, []byte("info"))
+	}
+	stub.MockTransactionEnd("1")
+	checkInit(t, stub, [][]byte{})
+	checkInvoke(t, stub, [][]byte{[]byte("AddOrgInfo"), []byte("publicchain"), []byte("orgD"), []byte("infoD")})
+
+	bookmark := ""
+	orgnames := []string{}
+	for {
+		res := checkInvoke(t, stub, [][]byte{[]byte("GetOrgnameByPage"), []byte("publicchain"), []byte("3"), []byte(bookmark)})
+		page := struct {
+			Records  []string `json:"records"`
+			Bookmark string   `json:"bookmark"`
+		}{}
+		if err := json.Unmarshal(res.Payload, &page); err != nil {
+			t.Fatal(err)
+		}
+		orgnames = append(orgnames, page.Records...)
+		if page.Bookmark == "" {
+			break
+		}
+		bookmark = page.Bookmark
+	}
+	if fmt.Sprint(orgnames) != "[orgA orgB orgC orgD]" {
+		t.Fatalf("unexpected orgnames: %v", orgnames)
+	}
+}
+
+func TestGovernanceEvents(t *testing.T) {
+	publicCC := new(PublicChaincode)
+	stub := shim.NewMockStub("public", publicCC)
+
+	// the events take the times of their transactions
+	var times []int64
+	for _, args := range [][][]byte{
+		{[]byte("AddOrgInfo"), []byte("publicchain"), []byte("orgA"), []byte("infoA")},
+		{[]byte("StartInvitation"), []byte("publicchain"), []byte("orgA"), []byte("orgB"), []byte("RawData")},
+		{[]byte("SignInvitation"), []byte("publicchain"), []byte("orgA"), []byte("orgB"), []byte("orgA"), []byte("signatureA"), []byte("Accept")},
+		{[]byte("ConfirmInvitation"), []byte("publicchain"), []byte("orgA"), []byte("orgB")},
+	} {
+		checkInvoke(t, stub, args)
+		times = append(times, stub.TxTimestamp.Seconds)
+	}
+
+	expected := []string{orgInfoChangedEvent, invitationCreatedEvent, voteCastEvent, proposalDecidedEvent}
+	for i, name := range expected {
+		ev := <-stub.ChaincodeEventsChannel
+		if ev.EventName != name {
+			t.Fatalf("expect event %s, got %s", name, ev.EventName)
+		}
+		event := GovernanceEvent{}
+		if err := json.Unmarshal(ev.Payload, &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.Type != name || event.ChainId != "publicchain" || event.Time != times[i] {
+			t.Fatalf("unexpected event payload: %+v", event)
+		}
+	}
+}
+
+func TestRemoval(t *testing.T) {
+	publicCC := &signedChaincode{PublicChaincode: new(PublicChaincode)}
+	stub := shim.NewMockStub("public", publicCC)
+
+	for _, org := range []string{"orgA", "orgB", "orgC"} {
+		checkInvoke(t, stub, [][]byte{[]byte("UpdateChainOrgInfo"), []byte("channel1"), []byte(org), []byte("{}")})
+	}
+	publicCC.mspID = "orgB"
+	res := stub.MockInvoke("1", [][]byte{[]byte("StartRemoval"), []byte("channel1"), []byte("orgA"), []byte("orgC"), []byte("reason"), []byte("RawData")})
+	if res.Status == shim.OK {
+		t.Fatal("orgB should not propose as orgA")
+	}
+	publicCC.mspID = "orgA"
+	checkInvoke(t, stub, [][]byte{[]byte("StartRemoval"), []byte("channel1"), []byte("orgA"), []byte("orgC"), []byte("reason"), []byte("RawData")})
+	res = checkInvoke(t, stub, [][]byte{[]byte("GetRemoval"), []byte("channel1"), []byte("orgC")})
+	proposal := RemovalProposal{}
+	if err := json.Unmarshal(res.Payload, &proposal); err != nil {
+		t.Fatal(err)
+	}
+	if proposal.ProposeTime != stub.TxTimestamp.Seconds {
+		t.Fatalf("the proposal should take the time of the transaction, got %d", proposal.ProposeTime)
+	}
+
+	res = checkInvoke(t, stub, [][]byte{[]byte("SignRemoval"), []byte("channel1"), []byte("orgC"), []byte("orgA"), []byte("signatureA"), []byte("Accept")})
+	if string(res.Payload) != "init" {
+		t.Fatalf("removal should wait for more votes, got %s", res.Payload)
+	}
+	res = stub.MockInvoke("1", [][]byte{[]byte("SignRemoval"), []byte("channel1"), []byte("orgC"), []byte("orgB"), []byte("signatureB"), []byte("Accept")})
+	if res.Status == shim.OK {
+		t.Fatal("orgA should not vote as orgB")
+	}
+	publicCC.mspID = "orgC"
+	res = stub.MockInvoke("1", [][]byte{[]byte("SignRemoval"), []byte("channel1"), []byte("orgC"), []byte("orgC"), []byte("signatureC"), []byte("Reject")})
+	if res.Status == shim.OK {
+		t.Fatal("the target should not vote")
+	}
+	publicCC.mspID = "orgB"
+	res = checkInvoke(t, stub, [][]byte{[]byte("SignRemoval"), []byte("channel1"), []byte("orgC"), []byte("orgB"), []byte("signatureB"), []byte("Accept")})
+	if string(res.Payload) != "approved" {
+		t.Fatalf("removal should be approved, got %s", res.Payload)
+	}
+
+	publicCC.mspID = "orgC"
+	res = stub.MockInvoke("1", [][]byte{[]byte("RecordRemoval"), []byte("channel1"), []byte("orgC"), []byte("failed"), []byte(`{"error":"BAD_REQUEST"}`)})
+	if res.Status == shim.OK {
+		t.Fatal("the target should not record the removal")
+	}
+	// a failed removal is retried
+	publicCC.mspID = "orgA"
+	checkInvoke(t, stub, [][]byte{[]byte("RecordRemoval"), []byte("channel1"), []byte("orgC"), []byte("failed"), []byte(`{"error":"BAD_REQUEST"}`)})
+	checkInvoke(t, stub, [][]byte{[]byte("RecordRemoval"), []byte("channel1"), []byte("orgC"), []byte("removed"), []byte(`{"systemTxId":"tx1","channelTxId":"tx2"}`)})
+	res = checkInvoke(t, stub, [][]byte{[]byte("GetRemoval"), []byte("channel1"), []byte("orgC")})
+	proposal = RemovalProposal{}
+	if err := json.Unmarshal(res.Payload, &proposal); err != nil {
+		t.Fatal(err)
+	}
+	if proposal.Status != "removed" || len(proposal.Votes) != 2 {
+		t.Fatalf("unexpected proposal: %+v", proposal)
+	}
+
+	res = checkInvoke(t, stub, [][]byte{[]byte("GetAllOrgnameOfChain"), []byte("channel1")})
+	orgnames := []string{}
+	if err := json.Unmarshal(res.Payload, &orgnames); err != nil {
+		t.Fatal(err)
+	}
+	if contains(orgnames, "orgC") {
+		t.Fatalf("orgC should leave channel1, got %v", orgnames)
+	}
+}
+
+// signedChaincode runs the chaincode as if a member of mspID signed the
+// transactions, the MockStub having no creator
+type signedChaincode struct {
+	*PublicChaincode
+	mspID string
+}
+
+func (c *signedChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return c.PublicChaincode.Invoke(&signedStub{ChaincodeStubInterface: stub, mspID: c.mspID})
+}
+
+type signedStub struct {
+	shim.ChaincodeStubInterface
+	mspID string
+}
+
+func (s *signedStub) GetCreator() ([]byte, error) {
+	return proto.Marshal(&msp.SerializedIdentity{Mspid: s.mspID})
+}
+
 func checkInit(t *testing.T, stub *shim.MockStub, args [][]byte) {
 	res := stub.MockInit("1", args)
 	if res.Status != shim.OK {
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// RemovalProposal proposes to remove Target from chain ChainId. Members of the chain
+// other than Target vote on it, it is approved once Threshold of them accepted.
+type RemovalProposal struct {
+	ChainId     string         `json:"chainId"`
+	Proposer    string         `json:"proposer"`
+	Target      string         `json:"target"`
+	Reason      string         `json:"reason"`
+	Status      string         `json:"status"`
+	Threshold   int            `json:"threshold"`
+	Voters      []string       `json:"voters"`
+	ProposeTime int64          `json:"proposeTime"`
+	DecideTime  int64          `json:"decideTime"`
+	RawData     string         `json:"rawdata"`
+	Result      string         `json:"result"`
+	Votes       []*RemovalVote `json:"votes,omitempty"`
+}
+
+type RemovalVote struct {
+	ChainId   string `json:"chainId"`
+	Target    string `json:"target"`
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
+	Accepted  string `json:"accepted"`
+	SignTime  int64  `json:"signTime"`
+}
+
+func (t *PublicChaincode) StartRemoval(stub shim.ChaincodeStubInterface, chainId, proposer, target, reason, rawData string) pb.Response {
+	logger.Infof("===============Start StartRemoval============, chainId: %s, proposer: %s, target: %s, reason: %s", chainId, proposer, target, reason)
+	if err := checkCreator(stub, proposer); err != nil {
+		return shim.Error(err.Error())
+	}
+	if proposer == target {
+		return shim.Error("an org cannot propose to remove itself")
+	}
+
+	members, err := chainMembers(stub, chainId)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if !contains(members, proposer) {
+		return shim.Error(fmt.Sprintf("Error proposer %s is not in chain: %s", proposer, chainId))
+	}
+	if !contains(members, target) {
+		return shim.Error(fmt.Sprintf("Error target %s is not in chain: %s", target, chainId))
+	}
+
+	key, err := stub.CreateCompositeKey(removalPrefix, []string{chainId, target})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error creating composit key: %s", err))
+	}
+	old, err := getRemovalProposal(stub, key)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if old != nil && (old.Status == initState || old.Status == approvedState) {
+		return shim.Error(fmt.Sprintf("Error removal of %s from chain %s is already %s", target, chainId, old.Status))
+	}
+	if err := deleteRemovalVotes(stub, chainId, target); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	var voters []string
+	for _, m := range members {
+		if m != target {
+			voters = append(voters, m)
+		}
+	}
+	timestamp, err := txTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	proposal := &RemovalProposal{
+		ChainId:     chainId,
+		Proposer:    proposer,
+		Target:      target,
+		Reason:      reason,
+		Status:      initState,
+		Threshold:   len(voters)/2 + 1,
+		Voters:      voters,
+		ProposeTime: timestamp,
+		RawData:     rawData,
+	}
+	if err := putJSON(stub, key, proposal); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	err = setEvent(stub, GovernanceEvent{Type: removalProposedEvent, ChainId: chainId, Proposer: proposer, Target: target, Status: initState, Time: timestamp})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	logger.Infof("===============End StartRemoval============")
+	return shim.Success([]byte("Successfully starting removal"))
+}
+
+func (t *PublicChaincode) SignRemoval(stub shim.ChaincodeStubInterface, chainId, target, signer, signature, accepted string) pb.Response {
+	logger.Infof("===============Start SignRemoval============, chainId: %s, target: %s, signer: %s, accepted: %s", chainId, target, signer, accepted)
+	if accepted != acceptState && accepted != rejectState {
+		return shim.Error(fmt.Sprintf("accepted should be %s or %s, got %s", acceptState, rejectState, accepted))
+	}
+	if err := checkCreator(stub, signer); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	key, err := stub.CreateCompositeKey(removalPrefix, []string{chainId, target})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error creating composit key: %s", err))
+	}
+	proposal, err := getRemovalProposal(stub, key)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if proposal == nil {
+		return shim.Error(fmt.Sprintf("Error no removal of %s from chain %s is proposed", target, chainId))
+	}
+	if proposal.Status != initState {
+		return shim.Error(fmt.Sprintf("Error removal of %s from chain %s is already %s", target, chainId, proposal.Status))
+	}
+	if !contains(proposal.Voters, signer) {
+		return shim.Error(fmt.Sprintf("Error %s cannot vote on removal of %s", signer, target))
+	}
+
+	voteKey, err := stub.CreateCompositeKey(removalSignedPrefix, []string{chainId, target, signer})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error creating composit key: %s", err))
+	}
+	value, err := stub.GetState(voteKey)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error getting state: %s", err))
+	}
+	if value != nil {
+		return shim.Error(fmt.Sprintf("Error signer: %s has already voted on removal of %s, cannot change anymore", signer, target))
+	}
+
+	timestamp, err := txTime(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	vote := &RemovalVote{
+		ChainId:   chainId,
+		Target:    target,
+		Signer:    signer,
+		Signature: signature,
+		Accepted:  accepted,
+		SignTime:  timestamp,
+	}
+	if err := putJSON(stub, voteKey, vote); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	cast, err := getRemovalVotes(stub, chainId, target)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	// a peer does not read the writes of the same transaction, count this vote by hand
+	votes := []*RemovalVote{vote}
+	for _, v := range cast {
+		if v.Signer != signer {
+			votes = append(votes, v)
+		}
+	}
+	accepts, rejects := 0, 0
+	for _, v := range votes {
+		if v.Accepted == acceptState {
+			accepts++
+		} else {
+			rejects++
+		}
+	}
+
+	event := GovernanceEvent{Type: voteCastEvent, ChainId: chainId, Target: target, Signer: signer, Accepted: accepted, Time: timestamp}
+	switch {
+	case accepts >= proposal.Threshold:
+		proposal.Status = approvedState
+	case len(proposal.Voters)-rejects < proposal.Threshold:
+		proposal.Status = rejectedState
+	}
+	if proposal.Status != initState {
+		proposal.DecideTime = timestamp
+		if err := putJSON(stub, key, proposal); err != nil {
+			return shim.Error(err.Error())
+		}
+		// only one event per transaction, the decision supersedes the vote
+		event = GovernanceEvent{Type: proposalDecidedEvent, ChainId: chainId, Proposer: proposal.Proposer, Target: target, Status: proposal.Status, Time: timestamp}
+	}
+	if err := setEvent(stub, event); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	logger.Infof("===============End SignRemoval============")
+	return shim.Success([]byte(proposal.Status))
+}
+
+func (t *PublicChaincode) GetRemoval(stub shim.ChaincodeStubInterface, chainId, target string) pb.Response {
+	logger.Infof("===============Start GetRemoval============, chainId: %s, target: %s", chainId, target)
+	key, err := stub.CreateCompositeKey(removalPrefix, []string{chainId, target})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error creating composit key: %s", err))
+	}
+	proposal, err := getRemovalProposal(stub, key)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if proposal == nil {
+		return shim.Success(nil)
+	}
+	proposal.Votes, err = getRemovalVotes(stub, chainId, target)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	data, err := json.Marshal(proposal)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error marshaling: %s", err))
+	}
+	logger.Infof("===============End GetRemoval============")
+	return shim.Success(data)
+}
+
+// RecordRemoval records the outcome of an approved removal, or of a failed one
+// retried, result carries the txIDs of the config updates. The target leaves
+// the directory of the chain once removed. Only the voters record it.
+func (t *PublicChaincode) RecordRemoval(stub shim.ChaincodeStubInterface, chainId, target, status, result string) pb.Response {
+	logger.Infof("===============Start RecordRemoval============, chainId: %s, target: %s, status: %s, result: %s", chainId, target, status, result)
+	if status != removedState && status != failedState {
+		return shim.Error(fmt.Sprintf("status should be %s or %s, got %s", removedState, failedState, status))
+	}
+	key, err := stub.CreateCompositeKey(removalPrefix, []string{chainId, target})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Error creating composit key: %s", err))
+	}
+	proposal, err := getRemovalProposal(stub, key)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if proposal == nil || (proposal.Status != approvedState && proposal.Status != failedState) {
+		return shim.Error(fmt.Sprintf("Error removal of %s from chain %s is not approved", target, chainId))
+	}
+	recorder, err := creatorMSP(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if !contains(proposal.Voters, recorder) {
+		return shim.Error(fmt.Sprintf("Error %s cannot record removal of %s", recorder, target))
+	}
+
+	proposal.Status = status
+	proposal.Result = result
+	if err := putJSON(stub, key, proposal); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	if status == removedState {
+		orgKey, err := stub.CreateCompositeKey(channelOrgPrefix, []string{chainId, target})
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Error creating composit key: %s", err))
+		}
+		if err := stub.DelState(orgKey); err != nil {
+			return shim.Error(fmt.Sprintf("Error deleting chain org info: %s", err))
+		}
+	}
+	err = setEvent(stub, GovernanceEvent{Type: orgInfoChangedEvent, ChainId: chainId, Orgname: target, Target: target, Status: status})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	logger.Infof("===============End RecordRemoval============")
+	return shim.Success([]byte("Successfully recording removal"))
+}
+
+// chainMembers returns the orgs that published their info of chainId
+func chainMembers(stub shim.ChaincodeStubInterface, chainId string) ([]string, error) {
+	iter, err := stub.GetStateByPartialCompositeKey(channelOrgPrefix, []string{chainId})
+	if err != nil {
+		return nil, fmt.Errorf("Error getting state by partial composit key: %s", err)
+	}
+	defer iter.Close()
+
+	var members []string
+	for iter.HasNext() {
+		k, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("Error getting next state: %s", err)
+		}
+		_, partials, err := stub.SplitCompositeKey(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("Error splitting composit key: %s", err)
+		}
+		members = append(members, partials[1])
+	}
+	return members, nil
+}
+
+func getRemovalProposal(stub shim.ChaincodeStubInterface, key string) (*RemovalProposal, error) {
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting state: %s", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	proposal := &RemovalProposal{}
+	if err := json.Unmarshal(data, proposal); err != nil {
+		return nil, fmt.Errorf("Error Unmarshal data: %s, err: %s", data, err)
+	}
+	return proposal, nil
+}
+
+func getRemovalVotes(stub shim.ChaincodeStubInterface, chainId, target string) ([]*RemovalVote, error) {
+	iter, err := stub.GetStateByPartialCompositeKey(removalSignedPrefix, []string{chainId, target})
+	if err != nil {
+		return nil, fmt.Errorf("Error getting state by partial composit key: %s", err)
+	}
+	defer iter.Close()
+
+	votes := []*RemovalVote{}
+	for iter.HasNext() {
+		k, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("Error getting next state: %s", err)
+		}
+		vote := &RemovalVote{}
+		if err := json.Unmarshal(k.Value, vote); err != nil {
+			return nil, fmt.Errorf("Error Unmarshal k.Value: %s, err: %s", k.Value, err)
+		}
+		votes = append(votes, vote)
+	}
+	return votes, nil
+}
+
+func deleteRemovalVotes(stub shim.ChaincodeStubInterface, chainId, target string) error {
+	votes, err := getRemovalVotes(stub, chainId, target)
+	if err != nil {
+		return err
+	}
+	for _, v := range votes {
+		key, err := stub.CreateCompositeKey(removalSignedPrefix, []string{chainId, target, v.Signer})
+		if err != nil {
+			return fmt.Errorf("Error creating composit key: %s", err)
+		}
+		if err := stub.DelState(key); err != nil {
+			return fmt.Errorf("Error deleting vote: %s", err)
+		}
+	}
+	return nil
+}
+
+// creatorMSP returns the MSP of the identity that signed the transaction, the
+// orgs of the chains being named after their MSPs
+func creatorMSP(stub shim.ChaincodeStubInterface) (string, error) {
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return "", fmt.Errorf("Error getting creator: %s", err)
+	}
+	id := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, id); err != nil {
+		return "", fmt.Errorf("Error unmarshaling creator: %s", err)
+	}
+	if id.Mspid == "" {
+		return "", fmt.Errorf("Error the creator has no MSP")
+	}
+	return id.Mspid, nil
+}
+
+// checkCreator fails unless org signed the transaction
+func checkCreator(stub shim.ChaincodeStubInterface, org string) error {
+	mspID, err := creatorMSP(stub)
+	if err != nil {
+		return err
+	}
+	if mspID != org {
+		return fmt.Errorf("Error %s cannot act as %s", mspID, org)
+	}
+	return nil
+}
+
+// txTime returns the time of the transaction in seconds, the same on every
+// endorsing peer
+func txTime(stub shim.ChaincodeStubInterface) (int64, error) {
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return 0, fmt.Errorf("Error getting tx timestamp: %s", err)
+	}
+	return ts.Seconds, nil
+}
+
+func putJSON(stub shim.ChaincodeStubInterface, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("Error marshaling %+v: %s", v, err)
+	}
+	if err := stub.PutState(key, data); err != nil {
+		return fmt.Errorf("Error putting data: %s", err)
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package channel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"manageChain/calls"
+	"manageChain/protocols"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// BootstrapPublicChain sets up the public chain of a new network with the
+// founding orgs: it creates publicchain, joins the peers of every org, installs
+// and instantiates publicchaincode, and seeds the profile and ChainOrgInfo of
+// every org. The first org must carry the orderers of the network.
+// Empty arguments fall back to the package in chaincodefile and an endorsement
+// policy any founding org satisfies on its own.
+func (c *Channel) BootstrapPublicChain(ccTarPath string, ccVersion string, policy string) error {
+	c.log().Info("start bootstrap public chain")
+	if ccTarPath == "" {
+		ccTarPath = PublicCCTarPath
+	}
+	if ccVersion == "" {
+		ccVersion = PublicCCVersion
+	}
+	if policy == "" {
+		policy = foundersPolicy(c.orgs)
+	}
+
+	casters := serviceNodesToEndpointList(c.orgs[0].OrdererNodes, callTimeout(c.orgs[0].Client, calls.OpBroadcast), c.orgs[0].OrgCA.TLSCACert())
+	if len(casters) == 0 {
+		return protocols.Errorf(protocols.CodeInvalidRequest, "the orderers of the network must be given with the first org")
+	}
+	code, err := ioutil.ReadFile(ccTarPath)
+	if err != nil {
+		c.log().Error("Error reading chaincode package: %s", err)
+		return err
+	}
+
+	if err := c.CreateChannel(PublicChainID); err != nil {
+		return err
+	}
+	for _, org := range c.orgs {
+		if err := joinPeers(org, PublicChainID, casters); err != nil {
+			c.log().Error("Error joining peers of %s: %s", org.OrgName, err)
+			return err
+		}
+		peers := serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpInstall), org.OrgCA.TLSCACert())
+		if err := org.Client.InstallChaincode(PublicCCName, ccVersion, PublicCCPath, code, peers); err != nil {
+			c.log().Error("Error installing public chaincode on peers of %s: %s", org.OrgName, err)
+			return err
+		}
+		c.log().Info("Successfully joined and installed, orgName:%s", org.OrgName)
+	}
+
+	if err := c.instantiatePublic(ccVersion, policy, casters); err != nil {
+		return err
+	}
+
+	for _, org := range c.orgs {
+		if err := addOrgProfile(org, true); err != nil {
+			c.log().Error("Error seeding profile of %s: %s", org.OrgName, err)
+			return err
+		}
+		if err := publishChainOrgInfo(org, PublicChainID); err != nil {
+			c.log().Error("Error seeding chain org info of %s: %s", org.OrgName, err)
+			return err
+		}
+	}
+	c.log().Info("end bootstrap public chain")
+	return nil
+}
+
+// instantiatePublic instantiates publicchaincode through the first org and
+// waits for it to be committed, so it can be invoked right away.
+func (c *Channel) instantiatePublic(ccVersion string, policy string, casters []*sdk.Endpoint) error {
+	org := c.orgs[0]
+	endorsers := serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpInstantiate), org.OrgCA.TLSCACert())
+	var txID string
+	var endorser *sdk.Endpoint
+	err := calls.Try(org.Client.Context(), endorsers, func(peer *sdk.Endpoint) (err error) {
+		if txID, err = org.Client.InstantiateChaincodeTx(PublicChainID, PublicCCName, ccVersion, [][]byte{[]byte("init")}, policy, nil, peer, casters); err != nil {
+			c.log().Error("Error instantiating public chaincode: %s", err)
+			return err
+		}
+		endorser = peer
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed instantiating public chaincode after try all peers: %w", err)
+	}
+	valid, err := org.Client.WaitTx(PublicChainID, txID, endorser, callTimeout(org.Client, calls.OpWaitTx))
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("instantiation of public chaincode is not valid")
+	}
+	c.log().Info("Successfully instantiated public chaincode, policy:%s", policy)
+	return nil
+}
+
+// foundersPolicy is satisfied by a member of any founding org, every org
+// invokes the public chaincode through its own peers.
+func foundersPolicy(orgs []*OrgInfo) string {
+	var principals []string
+	for _, org := range orgs {
+		principals = append(principals, fmt.Sprintf("'%s.member'", org.OrgMSP))
+	}
+	return fmt.Sprintf("OR(%s)", strings.Join(principals, ","))
+}
+
+func addOrgProfile(org *OrgInfo, founder bool) error {
+	data, err := json.Marshal(&OrgProfile{
+		OrgName:  org.OrgName,
+		OrgMSP:   org.OrgMSP,
+		Founder:  founder,
+		JoinTime: time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	args := [][]byte{
+		[]byte(addOrgInfo),
+		[]byte(PublicChainID),
+		[]byte(org.OrgName),
+		data,
+	}
+	return invokePublic(org, args)
+}
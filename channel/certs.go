@@ -0,0 +1,119 @@
+package channel
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// ListOrgs returns the certificates of the orgs whose crypto material is
+// under mspDir, generated by GenerateCrypto.
+func ListOrgs(mspDir string) ([]*OrgCerts, error) {
+	keys, err := sdk.Store().List(mspDir)
+	if err != nil {
+		logger.Error("Error listing %s: %s", mspDir, err)
+		return nil, err
+	}
+	var names []string
+	for _, key := range keys {
+		rel := strings.TrimPrefix(key, path.Join(mspDir)+"/")
+		if i := strings.Index(rel, "/"); i > 0 && (len(names) == 0 || names[len(names)-1] != rel[:i]) {
+			names = append(names, rel[:i])
+		}
+	}
+	orgs := []*OrgCerts{}
+	for _, name := range names {
+		dir, err := sdk.CheckoutCA(path.Join(mspDir, name))
+		if err != nil {
+			return nil, err
+		}
+		org, err := orgCerts(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if len(org.Certs) > 0 {
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs, nil
+}
+
+func orgCerts(dir string, orgName string) (*OrgCerts, error) {
+	org := &OrgCerts{OrgName: orgName, Certs: []*CertInfo{}}
+	add := func(kind string, name string, pattern string) error {
+		files, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return err
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			info, err := certInfo(file)
+			if err != nil {
+				logger.Error("Error reading certificate %s: %s", file, err)
+				return err
+			}
+			info.Kind = kind
+			info.Name = name
+			if info.Name == "" {
+				info.Name = strings.TrimSuffix(filepath.Base(file), "-cert.pem")
+			}
+			org.Certs = append(org.Certs, info)
+			if org.NotAfter.IsZero() || info.NotAfter.Before(org.NotAfter) {
+				org.NotAfter = info.NotAfter
+			}
+		}
+		return nil
+	}
+	if err := add("ca", "", "msp/cacerts/*.pem"); err != nil {
+		return nil, err
+	}
+	if err := add("tlsca", "", "msp/tlscacerts/*.pem"); err != nil {
+		return nil, err
+	}
+	if err := add("admin", "", "msp/admincerts/*.pem"); err != nil {
+		return nil, err
+	}
+	for _, nodeType := range []string{"peer", "orderer"} {
+		nodes, err := ioutil.ReadDir(filepath.Join(dir, nodeType+"s"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			base := filepath.Join(nodeType+"s", node.Name())
+			if err := add(nodeType, node.Name(), filepath.Join(base, "msp/signcerts/*.pem")); err != nil {
+				return nil, err
+			}
+			if err := add(nodeType+"-tls", node.Name(), filepath.Join(base, "tls/server.crt")); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return org, nil
+}
+
+func certInfo(file string) (*CertInfo, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data is found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &CertInfo{Subject: cert.Subject.String(), NotBefore: cert.NotBefore, NotAfter: cert.NotAfter}, nil
+}
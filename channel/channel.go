@@ -1,29 +1,27 @@
 package channel
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	logs "gglogs"
+	"manageChain/calls"
+	"manageChain/logging"
+	"manageChain/protocols"
 
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/sdk"
 )
 
-var logger *logs.BeeLogger
-
-func init() {
-	logger = logs.GetBeeLogger()
-}
+var logger = logging.GetLogger("channel")
 
 type Channel struct {
 	orgs []*OrgInfo
+	ctx  context.Context
 }
 
 func NewChannel(orgs []*OrgInfo, gm bool) (*Channel, error) {
 	if 0 == len(orgs) {
 		logger.Error("args err")
-		return nil, errors.New("args err")
+		return nil, protocols.Errorf(protocols.CodeInvalidRequest, "no orgs are given")
 	}
 	channel := &Channel{}
 	for _, org := range orgs {
@@ -32,7 +30,7 @@ func NewChannel(orgs []*OrgInfo, gm bool) (*Channel, error) {
 
 		client, err := sdk.NewClient(orgCA.AdminCommonName(), orgMSP, orgCA.AdminMSPDir(), gm)
 		if err != nil {
-			logger.Error("Error creating client for org", err)
+			logger.Error("Error creating client for org: %s", err)
 			return nil, err
 		}
 		org.Client = client
@@ -42,13 +40,36 @@ func NewChannel(orgs []*OrgInfo, gm bool) (*Channel, error) {
 	return channel, nil
 }
 
+// WithContext returns a copy of c whose calls are canceled with ctx and made
+// with the options ctx carries, see calls.
+func (c *Channel) WithContext(ctx context.Context) *Channel {
+	return &Channel{orgs: withContext(ctx, c.orgs), ctx: ctx}
+}
+
+// log returns the logger of the calls of c, its logs carry the correlation
+// ID of the context of c
+func (c *Channel) log() *logging.Logger {
+	return logger.WithContext(c.ctx)
+}
+
+// withContext returns copies of orgs whose clients make their calls with ctx
+func withContext(ctx context.Context, orgs []*OrgInfo) []*OrgInfo {
+	var ret []*OrgInfo
+	for _, org := range orgs {
+		o := *org
+		o.Client = org.Client.WithContext(ctx)
+		ret = append(ret, &o)
+	}
+	return ret
+}
+
 func (c *Channel) CreateChannel(ChainID string) error {
 
 	var organizations []*sdk.Organization
 	for _, org := range c.orgs {
 		anchors := AnchorPeers(org.PeerNodes)
 		if len(anchors) == 0 {
-			logger.Warning("no anchors can be found, cross-org gossip can't work")
+			c.log().Warning("no anchors can be found, cross-org gossip can't work")
 		}
 		organizations = append(organizations, &sdk.Organization{
 			Name:        org.OrgMSP,
@@ -67,21 +88,27 @@ func (c *Channel) CreateChannel(ChainID string) error {
 		Organizations: organizations,
 	}
 
-	//use org1
-	orgCA := c.GetOrgCA()
-	casters := serviceNodesToEndpointList(c.orgs[0].OrdererNodes, CreateChannelTimeout, orgCA.TLSCACert())
-
-	for _, caster := range casters {
-		if err := c.orgs[0].Client.CreateChannel(conf, caster); err != nil {
-			logger.Error("Error creating channel", err)
-			continue
-		} else {
-			logger.Info("Successfully creating channel")
-			return nil
-		}
+	//use org1, the new channel is unknown to the directory, any orderer of the public chain works
+	client := c.orgs[0].Client
+	casters, err := ordererEndpoints(c.orgs[0], PublicChainID, callTimeout(client, calls.OpCreateChannel))
+	if err != nil {
+		c.log().Error("Error resolving orderers: %s", err)
+		return err
 	}
 
-	return errors.New(fmt.Sprintf("failed creating %s chain after try all orderers", ChainID))
+	err = calls.Try(client.Context(), casters, func(caster *sdk.Endpoint) error {
+		err := client.CreateChannel(conf, caster)
+		if err != nil {
+			c.log().Error("Error creating channel: %s", err)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed creating %s chain after try all orderers: %w", ChainID, err)
+	}
+	c.log().Info("Successfully creating channel")
+	c.publishChainOrgInfoQuietly(ChainID)
+	return nil
 
 }
 
@@ -91,96 +118,30 @@ func (c *Channel) GetOrgCA() *sdk.CA {
 }
 
 func (c *Channel) JoinChannel(channelName string) error {
-	var err error
-	var block *cb.Block
-	orgCA := c.GetOrgCA()
-	endorsers := serviceNodesToEndpointList(c.orgs[0].PeerNodes, EndorseTimeout, orgCA.TLSCACert())
-	casters := serviceNodesToEndpointList(c.orgs[0].OrdererNodes, CreateChannelTimeout, orgCA.TLSCACert())
-	for _, caster := range casters {
-		if block, err = c.orgs[0].Client.GetBlockByChannel(channelName, 0, caster); err == nil {
-			break
-		}
-		logger.Error("Error getting block", err)
-	}
+	casters, err := ordererEndpoints(c.orgs[0], channelName, callTimeout(c.orgs[0].Client, calls.OpDeliver))
 	if err != nil {
-		return errors.New("failed getting block after try all orderers")
+		c.log().Error("Error resolving orderers: %s", err)
+		return err
 	}
-	return c.orgs[0].Client.JoinChannel(channelName, block, endorsers)
-}
-
-func (c *Channel) peers(channelName string) (peers []*sdk.Endpoint, err error) {
-	args := [][]byte{
-		[]byte(getChainOrgInfo),
-		[]byte(channelName),
-		[]byte(c.orgs[0].OrgName),
+	if err := joinPeers(c.orgs[0], channelName, casters); err != nil {
+		return err
 	}
-
-	endorsers := serviceNodesToEndpointList(c.orgs[0].PeerNodes, CreateChannelTimeout, c.orgs[0].OrgCA.TLSCACert())
-	data, err := query(c.orgs[0].Client, PublicChainID, PublicCCName, args, endorsers)
-	if err != nil {
-		logger.Error("Error querying", err)
-		return nil, err
-	}
-
-	orgChainInfo := &ChainOrgInfo{}
-	if err := json.Unmarshal(data, orgChainInfo); err != nil {
-		logger.Error("Error unmarshaling invitation", err)
-		return nil, err
-	}
-
-	peers = orgChainInfo.Peers
-
-	if len(peers) == 0 {
-		err = errors.New("no peers can be found")
-	}
-	logger.Info("channel:%s, orgName:%s, peers:%s", orgChainInfo.ChannelName, orgChainInfo.OrgName, peers)
-	return
-}
-
-func (c *Channel) orderers(channelName string) (orderers []*sdk.Endpoint, err error) {
-	args := [][]byte{
-		[]byte(getChainOrgInfo),
-		[]byte(channelName),
-		[]byte(c.orgs[0].OrgName),
-	}
-	endorsers := serviceNodesToEndpointList(c.orgs[0].PeerNodes, CreateChannelTimeout, c.orgs[0].OrgCA.TLSCACert())
-	data, err := query(c.orgs[0].Client, PublicChainID, PublicCCName, args, endorsers)
-	if err != nil {
-		logger.Error("Error querying", err)
-		return nil, err
-	}
-
-	orgChainInfo := &ChainOrgInfo{}
-	if err := json.Unmarshal(data, orgChainInfo); err != nil {
-		logger.Error("Error unmarshaling invitation", err)
-		return nil, err
-	}
-	orderers = orgChainInfo.Orderers
-	if len(orderers) == 0 {
-		err = errors.New("no orderers can be found")
-	}
-	logger.Info("channel:%s, orgName:%s, orderers:%s", orgChainInfo.ChannelName, orgChainInfo.OrgName, orderers)
-	return
+	c.publishChainOrgInfoQuietly(channelName)
+	return nil
 }
 
-func (c *Channel) peersAndOrderers(channelName string) (peers, orderers []*sdk.Endpoint, err error) {
-	args := [][]byte{
-		[]byte(getChainOrgInfo),
-		[]byte(channelName),
-		[]byte(c.orgs[0].OrgName),
-	}
-	endorsers := serviceNodesToEndpointList(c.orgs[0].PeerNodes, CreateChannelTimeout, c.orgs[0].OrgCA.TLSCACert())
-	data, err := query(c.orgs[0].Client, PublicChainID, PublicCCName, args, endorsers)
+// joinPeers joins the peers of org to channelName with the genesis block from casters
+func joinPeers(org *OrgInfo, channelName string, casters []*sdk.Endpoint) error {
+	var block *cb.Block
+	endorsers := serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpJoinChannel), org.OrgCA.TLSCACert())
+	err := calls.Try(org.Client.Context(), casters, func(caster *sdk.Endpoint) (err error) {
+		if block, err = org.Client.GetBlockByChannel(channelName, 0, caster); err != nil {
+			logger.Error("Error getting block: %s", err)
+		}
+		return err
+	})
 	if err != nil {
-		logger.Error("Error querying", err)
-		return nil, nil, err
-	}
-	orgChainInfo := &ChainOrgInfo{}
-	if err := json.Unmarshal(data, orgChainInfo); err != nil {
-		logger.Error("Error unmarshaling invitation", err)
-		return nil, nil, err
+		return fmt.Errorf("failed getting block after try all orderers: %w", err)
 	}
-	peers = orgChainInfo.Peers
-	orderers = orgChainInfo.Orderers
-	return
+	return org.Client.JoinChannel(channelName, block, endorsers)
 }
@@ -1,17 +1,13 @@
 package channel
 
 import (
+	"manageChain/protocols"
 	"time"
 
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	"github.com/hyperledger/fabric/sdk"
 )
 
-const (
-	CreateChannelTimeout = 5 * time.Second
-	EndorseTimeout       = 5 * time.Second
-	waitTxTimeout        = 20 * time.Second
-)
 const (
 	addOrgInfo    = "AddOrgInfo"
 	getOrgInfo    = "GetOrgInfo"
@@ -30,6 +26,15 @@ const (
 	updateChainOrgInfo   = "UpdateChainOrgInfo"
 	getChainOrgInfo      = "GetChainOrgInfo"
 	getAllOrgnameOfChain = "GetAllOrgnameOfChain"
+
+	getOrgInfoByPage    = "GetOrgInfoByPage"
+	getOrgnameByPage    = "GetOrgnameByPage"
+	getInvitationByPage = "GetInvitationByPage"
+
+	startRemoval  = "StartRemoval"
+	signRemoval   = "SignRemoval"
+	getRemoval    = "GetRemoval"
+	recordRemoval = "RecordRemoval"
 )
 const (
 	PublicChainID = "publicchain"
@@ -37,6 +42,13 @@ const (
 	// PublicCCName ...
 	PublicCCName      = "publicchaincode"
 	DefaultConsortium = sdk.DefaultConsortium
+
+	DefaultPageSize = 20
+
+	// the public chaincode package bootstrapped by default
+	PublicCCTarPath = "chaincodefile/public.tar.gz"
+	PublicCCPath    = "public"
+	PublicCCVersion = "1.0"
 )
 
 const (
@@ -45,15 +57,38 @@ const (
 
 	acceptState = "Accept"
 	rejectState = "Reject"
+
+	approvedState = "approved"
+	removedState  = "removed"
+	failedState   = "failed"
 )
 
-type ServiceNode struct {
-	ID               string
-	Endpoint         string
-	ExternalEndpoint string
-	Public           bool
-}
+// the types of the responses, shared with the clients through protocols
+type (
+	ServiceNode      = protocols.ServiceNode
+	InvitationFilter = protocols.InvitationFilter
+	PublicOrgInfo    = protocols.PublicOrgInfo
+	Invitation       = protocols.Invitation
+	OrgInfoPage      = protocols.OrgInfoPage
+	OrgnamePage      = protocols.OrgnamePage
+	InvitationPage   = protocols.InvitationPage
+	RemovalProposal  = protocols.RemovalProposal
+	RemovalVote      = protocols.RemovalVote
+	RemovalResult    = protocols.RemovalResult
+	LedgerInfo       = protocols.LedgerInfo
+	BlockInfo        = protocols.BlockInfo
+	TxInfo           = protocols.TxInfo
+	ChannelInfo      = protocols.ChannelInfo
+	PeerChaincodes   = protocols.PeerChaincodes
+	ConfigGroup      = protocols.ConfigGroup
+	ConfigValue      = protocols.ConfigValue
+	ConfigPolicy     = protocols.ConfigPolicy
+	OrgCerts         = protocols.OrgCerts
+	CertInfo         = protocols.CertInfo
+)
 
+// OrgInfo is an org of a request with its crypto material and client, the
+// requests are sent as the types of the same names in protocols
 type OrgInfo struct {
 	OrgName      string
 	MspID        string
@@ -68,11 +103,23 @@ type NewCreateChannelRequest struct {
 	ChannelName string
 }
 
+type BootstrapRequest struct {
+	Orgs      []*OrgInfo
+	CcTarPath string
+	CcVersion string
+	Policy    string
+}
+
 type JoinChannelRequest struct {
 	Orgs        []*OrgInfo
 	ChannelName string
 }
 
+type UpdateChainOrgInfoRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+}
+
 type IdentityRequest struct {
 	Orgs []*OrgInfo
 }
@@ -89,6 +136,48 @@ type DeleteOrgRequest struct {
 	ChannelName string
 }
 
+type ProposeRemovalRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	Target      string
+	DelOrderers []string
+	Reason      string
+}
+
+type VoteRemovalRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	Target      string
+	Accept      bool
+}
+
+type RemovalRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	Target      string
+}
+
+// LedgerRequest reads the ledger of a channel through the peers of the first org
+type LedgerRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	// Number is the block to read, the last one when it is not given
+	Number *uint64
+	TxID   string
+}
+
+// InventoryRequest lists the channels and chaincodes of the peers of orgs
+type InventoryRequest struct {
+	Orgs []*OrgInfo
+}
+
+// ChannelRequest reads the config or the removals of a channel through the
+// peers of the first org
+type ChannelRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+}
+
 type GenCryptoRequest struct {
 	Orgs []*OrgInfo
 }
@@ -98,6 +187,14 @@ type GenGenesisBlockRequest struct {
 	Kafkas []string
 }
 
+type QueryPageRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	PageSize    int32
+	Bookmark    string
+	Filter      *InvitationFilter
+}
+
 type InviteCodeRequest struct {
 	Orgs        []*OrgInfo
 	ChannelName string
@@ -115,6 +212,14 @@ type IdentityCode struct {
 	ChainOrgInfo *ChainOrgInfo
 }
 
+// OrgProfile is the info of an org in the public chain
+type OrgProfile struct {
+	OrgName  string `json:"orgName"`
+	OrgMSP   string `json:"orgMSP"`
+	Founder  bool   `json:"founder"`
+	JoinTime int64  `json:"joinTime"`
+}
+
 type ChainOrgInfo struct {
 	Peers       []*sdk.Endpoint
 	Orderers    []*sdk.Endpoint
@@ -139,6 +244,7 @@ type ccInvitationSignStatus struct {
 	Accepted  string `json:"accepted"`
 	SignTime  int64  `json:"signTime"`
 }
+
 type InviteCode struct {
 	ChannelGenesisBlock []byte
 }
@@ -672,3 +672,103 @@ func TestJoinChannel(t *testing.T) {
 	}
 	t.Log(string(ret))
 }
+
+func TestUpdateChainOrgInfo(t *testing.T) {
+	channelname := "channel1"
+	org1Peers := []*ServiceNode{
+		&ServiceNode{
+			ID:               "peer0",
+			Endpoint:         "172.16.93.215:56051",
+			ExternalEndpoint: "172.16.93.215:56051",
+			Public:           true,
+		},
+	}
+	org1Orderers := []*ServiceNode{
+		&ServiceNode{
+			ID:               "orderer0",
+			Endpoint:         "172.16.93.215:56050",
+			ExternalEndpoint: "172.16.93.215:56050",
+			Public:           true,
+		},
+	}
+
+	orgs := []*OrgInfo{
+		&OrgInfo{
+			OrgName:      "testorg1",
+			OrgMSP:       "testorg1",
+			MspID:        "testorg1",
+			PeerNodes:    org1Peers,
+			OrdererNodes: org1Orderers,
+		},
+	}
+
+	ucr := &UpdateChainOrgInfoRequest{
+		Orgs:        orgs,
+		ChannelName: channelname,
+	}
+
+	data, err := json.Marshal(ucr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrt := bytes.NewBuffer(data)
+
+	resp, err := http.Post("http://127.0.0.1:8080/channel/orginfo", "application/json", wrt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(string(ret))
+}
+
+func TestBootstrapPublicChain(t *testing.T) {
+	org1Peers := []*ServiceNode{
+		&ServiceNode{
+			ID:               "peer0",
+			Endpoint:         "172.16.93.215:56051",
+			ExternalEndpoint: "172.16.93.215:56051",
+			Public:           true,
+		},
+	}
+	org1Orderers := []*ServiceNode{
+		&ServiceNode{
+			ID:               "orderer0",
+			Endpoint:         "172.16.93.215:56050",
+			ExternalEndpoint: "172.16.93.215:56050",
+			Public:           true,
+		},
+	}
+
+	orgs := []*OrgInfo{
+		&OrgInfo{
+			OrgName:      "testorg1",
+			OrgMSP:       "testorg1",
+			MspID:        "testorg1",
+			PeerNodes:    org1Peers,
+			OrdererNodes: org1Orderers,
+		},
+	}
+
+	br := &BootstrapRequest{
+		Orgs: orgs,
+	}
+
+	data, err := json.Marshal(br)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrt := bytes.NewBuffer(data)
+
+	resp, err := http.Post("http://127.0.0.1:8080/channel/bootstrap", "application/json", wrt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(string(ret))
+}
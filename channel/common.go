@@ -3,6 +3,8 @@ package channel
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"manageChain/calls"
 	"time"
 
 	pp "github.com/hyperledger/fabric/protos/peer"
@@ -25,6 +27,11 @@ func Orderers(ordererNodes []*ServiceNode) (orderers []string) {
 	return
 }
 
+// callTimeout returns the timeout of op for the calls made with client
+func callTimeout(client *sdk.Client, op string) time.Duration {
+	return calls.Timeout(client.Context(), op)
+}
+
 func serviceNodesToEndpointList(serviceNodes []*ServiceNode, timeout time.Duration, cert []byte) []*sdk.Endpoint {
 	var endpoints []*sdk.Endpoint
 	for _, sn := range serviceNodes {
@@ -38,31 +45,50 @@ func serviceNodesToEndpointList(serviceNodes []*ServiceNode, timeout time.Durati
 	return endpoints
 }
 
+// externalEndpointList is like serviceNodesToEndpointList, but addresses the
+// nodes the way other orgs reach them.
+func externalEndpointList(serviceNodes []*ServiceNode, timeout time.Duration, cert []byte) []*sdk.Endpoint {
+	var endpoints []*sdk.Endpoint
+	for _, sn := range serviceNodes {
+		address := sn.ExternalEndpoint
+		if address == "" {
+			address = sn.Endpoint
+		}
+		endpoints = append(endpoints, &sdk.Endpoint{
+			Address: address,
+			TLS:     cert,
+			Timeout: timeout,
+		})
+	}
+	return endpoints
+}
+
 func endorseOneOfList(client *sdk.Client, chainID string, chaincode string, args [][]byte, transient map[string][]byte, peerEndpoints []*sdk.Endpoint) (txID string, prop *pp.Proposal, resps []*pp.ProposalResponse, endorser *sdk.Endpoint, err error) {
-	for _, peer := range peerEndpoints {
+	err = calls.Try(client.Context(), peerEndpoints, func(peer *sdk.Endpoint) (err error) {
 		txID, prop, resps, err = client.Endorse(chainID, chaincode, args, transient, []*sdk.Endpoint{peer})
-		if err == nil {
-			endorser = peer
-			break
+		if err != nil {
+			logger.Error("Error endorsing: %s", err)
+			return err
 		}
-		logger.Error("Error endorsing", err)
-	}
+		endorser = peer
+		return nil
+	})
 	if err != nil {
-		return "", nil, nil, nil, errors.New("failed proposing through all peers")
+		return "", nil, nil, nil, fmt.Errorf("failed proposing through all peers: %w", err)
 	}
 	return
 }
 
 func broadcastOneOfList(client *sdk.Client, prop *pp.Proposal, resps []*pp.ProposalResponse, ordererEndpoints []*sdk.Endpoint) (err error) {
-	for _, orderer := range ordererEndpoints {
-		err = client.Broadcast(prop, resps, orderer)
-		if err == nil {
-			break
+	err = calls.Try(client.Context(), ordererEndpoints, func(orderer *sdk.Endpoint) error {
+		err := client.Broadcast(prop, resps, orderer)
+		if err != nil {
+			logger.Error("Error broadcasting: %s", err)
 		}
-		logger.Error("Error broadcasting", err)
-	}
+		return err
+	})
 	if err != nil {
-		return errors.New("failed broadcasting through all orderers")
+		return fmt.Errorf("failed broadcasting through all orderers: %w", err)
 	}
 	return
 }
@@ -70,19 +96,19 @@ func broadcastOneOfList(client *sdk.Client, prop *pp.Proposal, resps []*pp.Propo
 func invoke(client *sdk.Client, chainID string, chaincode string, args [][]byte, peers []*sdk.Endpoint, orderers []*sdk.Endpoint) error {
 	txID, prop, resps, endorder, err := endorseOneOfList(client, chainID, chaincode, args, nil, peers)
 	if err != nil {
-		logger.Error("Error endorsing", err)
+		logger.Error("Error endorsing: %s", err)
 		return err
 	}
 
 	err = broadcastOneOfList(client, prop, resps, orderers)
 	if err != nil {
-		logger.Error("Error broadcasing", err)
+		logger.Error("Error broadcasing: %s", err)
 		return err
 	}
 
-	valid, err := client.WaitTx(chainID, txID, endorder, waitTxTimeout)
+	valid, err := client.WaitTx(chainID, txID, endorder, callTimeout(client, calls.OpWaitTx))
 	if err != nil {
-		logger.Error("Error waiting transaction", err)
+		logger.Error("Error waiting transaction: %s", err)
 		return err
 	}
 
@@ -97,7 +123,7 @@ func invoke(client *sdk.Client, chainID string, chaincode string, args [][]byte,
 func query(client *sdk.Client, chainID string, chaincode string, args [][]byte, peers []*sdk.Endpoint) ([]byte, error) {
 	_, _, resps, _, err := endorseOneOfList(client, chainID, chaincode, args, nil, peers)
 	if err != nil {
-		logger.Error("Error querying", err)
+		logger.Error("Error querying: %s", err)
 		return nil, err
 	}
 	return resps[0].Response.Payload, nil
@@ -0,0 +1,174 @@
+package channel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// configValues are the messages of the values of the config by their keys
+var configValues = map[string]func() proto.Message{
+	channelconfig.HashingAlgorithmKey:          func() proto.Message { return &cb.HashingAlgorithm{} },
+	channelconfig.BlockDataHashingStructureKey: func() proto.Message { return &cb.BlockDataHashingStructure{} },
+	channelconfig.OrdererAddressesKey:          func() proto.Message { return &cb.OrdererAddresses{} },
+	channelconfig.ConsortiumKey:                func() proto.Message { return &cb.Consortium{} },
+	channelconfig.CapabilitiesKey:              func() proto.Message { return &cb.Capabilities{} },
+	channelconfig.ConsensusTypeKey:             func() proto.Message { return &ab.ConsensusType{} },
+	channelconfig.BatchSizeKey:                 func() proto.Message { return &ab.BatchSize{} },
+	channelconfig.BatchTimeoutKey:              func() proto.Message { return &ab.BatchTimeout{} },
+	channelconfig.KafkaBrokersKey:              func() proto.Message { return &ab.KafkaBrokers{} },
+	channelconfig.ChannelRestrictionsKey:       func() proto.Message { return &ab.ChannelRestrictions{} },
+	channelconfig.AnchorPeersKey:               func() proto.Message { return &pb.AnchorPeers{} },
+	channelconfig.ACLsKey:                      func() proto.Message { return &pb.ACLs{} },
+}
+
+// mspValue is the MSP value of an org with the certificates in PEM
+type mspValue struct {
+	Name                 string   `json:"name"`
+	RootCerts            []string `json:"rootCerts"`
+	IntermediateCerts    []string `json:"intermediateCerts"`
+	Admins               []string `json:"admins"`
+	TLSRootCerts         []string `json:"tlsRootCerts"`
+	TLSIntermediateCerts []string `json:"tlsIntermediateCerts"`
+}
+
+// blockConfig returns the config in a config block
+func blockConfig(block *cb.Block) (*ConfigGroup, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	configEnv := &cb.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		logger.Error("Error unmarshaling config envelope: %s", err)
+		return nil, err
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return nil, fmt.Errorf("config block %d has no channel group", block.Header.Number)
+	}
+	return configGroup(configEnv.Config.ChannelGroup), nil
+}
+
+func configGroup(group *cb.ConfigGroup) *ConfigGroup {
+	g := &ConfigGroup{
+		Version:   group.Version,
+		ModPolicy: group.ModPolicy,
+		Groups:    map[string]*ConfigGroup{},
+		Values:    map[string]*ConfigValue{},
+		Policies:  map[string]*ConfigPolicy{},
+	}
+	for name, sub := range group.Groups {
+		g.Groups[name] = configGroup(sub)
+	}
+	for key, value := range group.Values {
+		g.Values[key] = &ConfigValue{Version: value.Version, ModPolicy: value.ModPolicy, Value: decodeValue(key, value.Value)}
+	}
+	for name, policy := range group.Policies {
+		p := &ConfigPolicy{Version: policy.Version, ModPolicy: policy.ModPolicy}
+		if policy.Policy != nil {
+			p.Type = cb.Policy_PolicyType_name[policy.Policy.Type]
+			p.Rule = policyRule(policy.Policy)
+		}
+		g.Policies[name] = p
+	}
+	return g
+}
+
+// decodeValue returns the message of the value of key, or data when it can
+// not be decoded
+func decodeValue(key string, data []byte) interface{} {
+	if key == channelconfig.MSPKey {
+		if v, err := decodeMSP(data); err == nil {
+			return v
+		}
+		return data
+	}
+	newMessage, ok := configValues[key]
+	if !ok {
+		return data
+	}
+	msg := newMessage()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return data
+	}
+	return msg
+}
+
+func decodeMSP(data []byte) (*mspValue, error) {
+	conf := &mb.MSPConfig{}
+	if err := proto.Unmarshal(data, conf); err != nil {
+		return nil, err
+	}
+	fabricConf := &mb.FabricMSPConfig{}
+	if err := proto.Unmarshal(conf.Config, fabricConf); err != nil {
+		return nil, err
+	}
+	return &mspValue{
+		Name:                 fabricConf.Name,
+		RootCerts:            pems(fabricConf.RootCerts),
+		IntermediateCerts:    pems(fabricConf.IntermediateCerts),
+		Admins:               pems(fabricConf.Admins),
+		TLSRootCerts:         pems(fabricConf.TlsRootCerts),
+		TLSIntermediateCerts: pems(fabricConf.TlsIntermediateCerts),
+	}, nil
+}
+
+func pems(certs [][]byte) []string {
+	ret := []string{}
+	for _, cert := range certs {
+		ret = append(ret, string(cert))
+	}
+	return ret
+}
+
+// policyRule describes the rule of an implicit meta or a signature policy
+func policyRule(policy *cb.Policy) string {
+	switch cb.Policy_PolicyType(policy.Type) {
+	case cb.Policy_IMPLICIT_META:
+		imp := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(policy.Value, imp); err != nil {
+			return ""
+		}
+		return fmt.Sprintf("%s %s", imp.Rule, imp.SubPolicy)
+	case cb.Policy_SIGNATURE:
+		env := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(policy.Value, env); err != nil || env.Rule == nil {
+			return ""
+		}
+		return signatureRule(env.Rule, env.Identities)
+	}
+	return ""
+}
+
+func signatureRule(rule *cb.SignaturePolicy, identities []*mb.MSPPrincipal) string {
+	if n := rule.GetNOutOf(); n != nil {
+		rules := []string{}
+		for _, r := range n.Rules {
+			rules = append(rules, signatureRule(r, identities))
+		}
+		return fmt.Sprintf("OutOf(%d, %s)", n.N, strings.Join(rules, ", "))
+	}
+	index := rule.GetSignedBy()
+	if index < 0 || int(index) >= len(identities) {
+		return fmt.Sprintf("SignedBy(%d)", index)
+	}
+	principal := identities[index]
+	if principal.PrincipalClassification == mb.MSPPrincipal_ROLE {
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err == nil {
+			return fmt.Sprintf("'%s.%s'", role.MspIdentifier, strings.ToLower(role.Role.String()))
+		}
+	}
+	return fmt.Sprintf("'%s'", principal.PrincipalClassification)
+}
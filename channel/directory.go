@@ -0,0 +1,195 @@
+package channel
+
+import (
+	"encoding/json"
+	"manageChain/calls"
+	"manageChain/protocols"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// PublishChainOrgInfo writes the ChainOrgInfo of every operating org to the
+// public chaincode, so other members can resolve its peers and orderers for
+// channelName without carrying them in their requests.
+func (c *Channel) PublishChainOrgInfo(channelName string) error {
+	for _, org := range c.orgs {
+		if err := publishChainOrgInfo(org, channelName); err != nil {
+			c.log().Error("Error publishing chain org info of %s: %s", org.OrgName, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// publishChainOrgInfoQuietly is used after a channel operation has already
+// succeeded, a failed publish must not turn that result into an error.
+func (c *Channel) publishChainOrgInfoQuietly(channelName string) {
+	if channelName == PublicChainID {
+		// publicchaincode is instantiated on the public chain afterwards
+		return
+	}
+	if err := c.PublishChainOrgInfo(channelName); err != nil {
+		c.log().Warning("chain org info of channel %s is not published: %s", channelName, err)
+	}
+}
+
+func publishChainOrgInfo(org *OrgInfo, channelName string) error {
+	ic, err := identityCode(org)
+	if err != nil {
+		return err
+	}
+	info := ic.ChainOrgInfo
+	info.ChannelName = channelName
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	args := [][]byte{
+		[]byte(updateChainOrgInfo),
+		[]byte(channelName),
+		[]byte(org.OrgName),
+		data,
+	}
+	if err := invokePublic(org, args); err != nil {
+		return err
+	}
+	logger.Info("Successfully published chain org info, channel:%s, orgName:%s", channelName, org.OrgName)
+	return nil
+}
+
+// invokePublic invokes the public chaincode through the peers of org
+func invokePublic(org *OrgInfo, args [][]byte) error {
+	peers := serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpEndorse), org.OrgCA.TLSCACert())
+	orderers, err := ordererEndpoints(org, PublicChainID, callTimeout(org.Client, calls.OpBroadcast))
+	if err != nil {
+		return err
+	}
+	return invoke(org.Client, PublicChainID, PublicCCName, args, peers, orderers)
+}
+
+// queryPublic queries the public chaincode through the peers of org
+func queryPublic(org *OrgInfo, args [][]byte) ([]byte, error) {
+	peers := serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpEndorse), org.OrgCA.TLSCACert())
+	return query(org.Client, PublicChainID, PublicCCName, args, peers)
+}
+
+// QueryChainOrgInfo returns the ChainOrgInfo orgName published for channelName.
+func QueryChainOrgInfo(client *sdk.Client, peers []*sdk.Endpoint, channelName string, orgName string) (*ChainOrgInfo, error) {
+	args := [][]byte{
+		[]byte(getChainOrgInfo),
+		[]byte(channelName),
+		[]byte(orgName),
+	}
+	data, err := query(client, PublicChainID, PublicCCName, args, peers)
+	if err != nil {
+		logger.Error("Error querying chain org info: %s", err)
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, protocols.Errorf(protocols.CodeNotFound, "org %s has not published its info of channel %s", orgName, channelName)
+	}
+
+	chainOrgInfo := &ChainOrgInfo{}
+	if err := json.Unmarshal(data, chainOrgInfo); err != nil {
+		logger.Error("Error unmarshaling chain org info: %s", err)
+		return nil, err
+	}
+	return chainOrgInfo, nil
+}
+
+// QueryChainOrgnames returns the names of the orgs that published info for channelName.
+func QueryChainOrgnames(client *sdk.Client, peers []*sdk.Endpoint, channelName string) ([]string, error) {
+	args := [][]byte{
+		[]byte(getAllOrgnameOfChain),
+		[]byte(channelName),
+	}
+	data, err := query(client, PublicChainID, PublicCCName, args, peers)
+	if err != nil {
+		logger.Error("Error querying orgnames of chain: %s", err)
+		return nil, err
+	}
+
+	orgnames := []string{}
+	if err := json.Unmarshal(data, &orgnames); err != nil {
+		logger.Error("Error unmarshaling orgnames: %s", err)
+		return nil, err
+	}
+	return orgnames, nil
+}
+
+// ChainOrderers collects the orderers all members of channelName published,
+// with timeout applied to each of them.
+func ChainOrderers(client *sdk.Client, peers []*sdk.Endpoint, channelName string, timeout time.Duration) ([]*sdk.Endpoint, error) {
+	infos, err := chainOrgInfos(client, peers, channelName)
+	if err != nil {
+		return nil, err
+	}
+	var orderers []*sdk.Endpoint
+	for _, info := range infos {
+		orderers = append(orderers, withTimeout(info.Orderers, timeout)...)
+	}
+	if len(orderers) == 0 {
+		return nil, protocols.Errorf(protocols.CodeNotFound, "no orderers of channel %s can be found", channelName)
+	}
+	return orderers, nil
+}
+
+// OrgPeers returns the peers each of orgNames published for channelName, with
+// timeout applied to each of them.
+func OrgPeers(client *sdk.Client, peers []*sdk.Endpoint, channelName string, orgNames []string, timeout time.Duration) ([][]*sdk.Endpoint, error) {
+	var orgPeers [][]*sdk.Endpoint
+	for _, orgName := range orgNames {
+		info, err := QueryChainOrgInfo(client, peers, channelName, orgName)
+		if err != nil {
+			return nil, err
+		}
+		if len(info.Peers) == 0 {
+			return nil, protocols.Errorf(protocols.CodeNotFound, "no peers of %s in channel %s can be found", orgName, channelName)
+		}
+		orgPeers = append(orgPeers, withTimeout(info.Peers, timeout))
+	}
+	return orgPeers, nil
+}
+
+func chainOrgInfos(client *sdk.Client, peers []*sdk.Endpoint, channelName string) ([]*ChainOrgInfo, error) {
+	orgnames, err := QueryChainOrgnames(client, peers, channelName)
+	if err != nil {
+		return nil, err
+	}
+	var infos []*ChainOrgInfo
+	for _, orgname := range orgnames {
+		info, err := QueryChainOrgInfo(client, peers, channelName, orgname)
+		if err != nil {
+			logger.Warning("skip org %s: %s", orgname, err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func withTimeout(endpoints []*sdk.Endpoint, timeout time.Duration) []*sdk.Endpoint {
+	var ret []*sdk.Endpoint
+	for _, ep := range endpoints {
+		e := *ep
+		e.Timeout = timeout
+		ret = append(ret, &e)
+	}
+	return ret
+}
+
+// ordererEndpoints returns the orderers of org given in the request, or the
+// ones published to the public chain for channelName when there are none.
+func ordererEndpoints(org *OrgInfo, channelName string, timeout time.Duration) ([]*sdk.Endpoint, error) {
+	casters := serviceNodesToEndpointList(org.OrdererNodes, timeout, org.OrgCA.TLSCACert())
+	if len(casters) != 0 {
+		return casters, nil
+	}
+	if len(org.PeerNodes) == 0 {
+		return nil, protocols.Errorf(protocols.CodeInvalidRequest, "neither orderers nor peers of %s are given", org.OrgName)
+	}
+	peers := serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpEndorse), org.OrgCA.TLSCACert())
+	return ChainOrderers(org.Client, peers, channelName, timeout)
+}
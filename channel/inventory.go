@@ -0,0 +1,157 @@
+package channel
+
+import (
+	"manageChain/calls"
+	"manageChain/protocols"
+	"sort"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// Channels returns the channels the first peer of the org that answers has
+// joined, with their heights and the orgs of their configs.
+func (c *Channel) Channels() ([]*ChannelInfo, error) {
+	var infos []*ChannelInfo
+	err := c.readLedger(func(client *sdk.Client, peer *sdk.Endpoint) error {
+		channels, err := client.QueryChannels(peer)
+		if err != nil {
+			return err
+		}
+		sort.Strings(channels)
+		infos = []*ChannelInfo{}
+		for _, channelName := range channels {
+			height, block, err := configBlock(client, channelName, peer)
+			if err != nil {
+				return err
+			}
+			members, err := sdk.ApplicationOrgs(block)
+			if err != nil {
+				return err
+			}
+			sort.Strings(members)
+			infos = append(infos, &ChannelInfo{ChannelName: channelName, Peer: peer.Address, Height: height, Members: members})
+		}
+		return nil
+	})
+	return infos, err
+}
+
+// Config returns the current config of channelName
+func (c *Channel) Config(channelName string) (*ConfigGroup, error) {
+	var config *ConfigGroup
+	err := c.readLedger(func(client *sdk.Client, peer *sdk.Endpoint) error {
+		_, block, err := configBlock(client, channelName, peer)
+		if err != nil {
+			return err
+		}
+		config, err = blockConfig(block)
+		return err
+	})
+	return config, err
+}
+
+// Removals returns the removals proposed of the orgs of channelName, without
+// the config updates they carry.
+func (c *Channel) Removals(channelName string) ([]*RemovalProposal, error) {
+	var members []string
+	err := c.readLedger(func(client *sdk.Client, peer *sdk.Endpoint) error {
+		_, block, err := configBlock(client, channelName, peer)
+		if err != nil {
+			return err
+		}
+		members, err = sdk.ApplicationOrgs(block)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(members)
+
+	proposals := []*RemovalProposal{}
+	for _, target := range members {
+		proposal, err := c.GetRemoval(channelName, target)
+		var pe *protocols.Error
+		if sdk.AsError(err, &pe) && pe.Code == protocols.CodeNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		proposal.RawData = ""
+		proposals = append(proposals, proposal)
+	}
+	return proposals, nil
+}
+
+// Chaincodes returns the chaincodes of every peer of the orgs, a peer that
+// can not be queried is returned with the error.
+func (c *Channel) Chaincodes() []*PeerChaincodes {
+	inventory := []*PeerChaincodes{}
+	for _, org := range c.orgs {
+		for _, peer := range serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpEndorse), org.OrgCA.TLSCACert()) {
+			pc, err := peerChaincodes(org.Client, peer)
+			if err != nil {
+				c.log().Error("Error querying chaincodes of %s: %s", peer.Address, err)
+				pc.Error = err.Error()
+			}
+			pc.Org = org.OrgName
+			inventory = append(inventory, pc)
+		}
+	}
+	return inventory
+}
+
+func peerChaincodes(client *sdk.Client, peer *sdk.Endpoint) (*PeerChaincodes, error) {
+	pc := &PeerChaincodes{Peer: peer.Address, Installed: []protocols.Chaincode{}, Instantiated: map[string][]protocols.Chaincode{}}
+	installed, err := client.QueryInstalledChaincodes(peer)
+	if err != nil {
+		return pc, err
+	}
+	pc.Installed = chaincodes(installed)
+	channels, err := client.QueryChannels(peer)
+	if err != nil {
+		return pc, err
+	}
+	for _, channelName := range channels {
+		instantiated, err := client.QueryInstantiatedChaincodes(channelName, peer)
+		if err != nil {
+			return pc, err
+		}
+		pc.Instantiated[channelName] = chaincodes(instantiated)
+	}
+	return pc, nil
+}
+
+func chaincodes(ccs []sdk.Chaincode) []protocols.Chaincode {
+	ret := []protocols.Chaincode{}
+	for _, cc := range ccs {
+		ret = append(ret, protocols.Chaincode{Name: cc.Name, Version: cc.Version})
+	}
+	return ret
+}
+
+// configBlock returns the height of channelName on peer and its last config block
+func configBlock(client *sdk.Client, channelName string, peer *sdk.Endpoint) (uint64, *cb.Block, error) {
+	bi, err := client.QueryChainInfo(channelName, peer)
+	if err != nil {
+		return 0, nil, err
+	}
+	last, err := client.QueryBlock(channelName, bi.Height-1, peer)
+	if err != nil {
+		return 0, nil, err
+	}
+	index, err := utils.GetLastConfigIndexFromBlock(last)
+	if err != nil {
+		return 0, nil, err
+	}
+	if index == last.Header.Number {
+		return bi.Height, last, nil
+	}
+	block, err := client.QueryBlock(channelName, index, peer)
+	if err != nil {
+		return 0, nil, err
+	}
+	return bi.Height, block, nil
+}
@@ -0,0 +1,122 @@
+package channel
+
+import (
+	"encoding/hex"
+	"fmt"
+	"manageChain/calls"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// LedgerInfo returns the height of the ledger of channelName on the first
+// peer of the org that answers.
+func (c *Channel) LedgerInfo(channelName string) (*LedgerInfo, error) {
+	var info *LedgerInfo
+	err := c.readLedger(func(client *sdk.Client, peer *sdk.Endpoint) error {
+		bi, err := client.QueryChainInfo(channelName, peer)
+		if err != nil {
+			return err
+		}
+		info = &LedgerInfo{
+			ChannelName:       channelName,
+			Peer:              peer.Address,
+			Height:            bi.Height,
+			CurrentBlockHash:  hex.EncodeToString(bi.CurrentBlockHash),
+			PreviousBlockHash: hex.EncodeToString(bi.PreviousBlockHash),
+		}
+		return nil
+	})
+	return info, err
+}
+
+// Block returns the block number of channelName, the last block when number is nil
+func (c *Channel) Block(channelName string, number *uint64) (*BlockInfo, error) {
+	var info *BlockInfo
+	err := c.readLedger(func(client *sdk.Client, peer *sdk.Endpoint) error {
+		num := uint64(0)
+		if number != nil {
+			num = *number
+		} else {
+			bi, err := client.QueryChainInfo(channelName, peer)
+			if err != nil {
+				return err
+			}
+			num = bi.Height - 1
+		}
+		block, err := client.QueryBlock(channelName, num, peer)
+		if err != nil {
+			return err
+		}
+		info = blockInfo(block)
+		return nil
+	})
+	return info, err
+}
+
+// Transaction returns the transaction txID of channelName
+func (c *Channel) Transaction(channelName string, txID string) (*TxInfo, error) {
+	var info *TxInfo
+	err := c.readLedger(func(client *sdk.Client, peer *sdk.Endpoint) error {
+		tx, err := client.QueryTransaction(channelName, txID, peer)
+		if err != nil {
+			return err
+		}
+		ctx := sdk.ParseEnvelope(tx.TransactionEnvelope, pb.TxValidationCode(tx.ValidationCode))
+		if ctx == nil {
+			return fmt.Errorf("transaction %s of %s can not be parsed", txID, channelName)
+		}
+		info = txInfo(ctx)
+		return nil
+	})
+	return info, err
+}
+
+// readLedger calls read with the peers of the first org until it succeeds
+func (c *Channel) readLedger(read func(client *sdk.Client, peer *sdk.Endpoint) error) error {
+	org := c.orgs[0]
+	peers := serviceNodesToEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpEndorse), org.OrgCA.TLSCACert())
+	if len(peers) == 0 {
+		return fmt.Errorf("%s has no peers", org.OrgName)
+	}
+	err := calls.Try(org.Client.Context(), peers, func(peer *sdk.Endpoint) error {
+		err := read(org.Client, peer)
+		if err != nil {
+			c.log().Error("Error reading ledger from %s: %s", peer.Address, err)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed reading ledger after try all peers: %w", err)
+	}
+	return nil
+}
+
+func blockInfo(block *cb.Block) *BlockInfo {
+	info := &BlockInfo{Txs: []*TxInfo{}}
+	if block.Header != nil {
+		info.Number = block.Header.Number
+		info.Hash = hex.EncodeToString(block.Header.Hash())
+		info.PreviousHash = hex.EncodeToString(block.Header.PreviousHash)
+		info.DataHash = hex.EncodeToString(block.Header.DataHash)
+	}
+	for _, tx := range sdk.BlockTxs(block) {
+		info.Txs = append(info.Txs, txInfo(tx))
+	}
+	return info
+}
+
+func txInfo(tx *sdk.CommittedTx) *TxInfo {
+	info := &TxInfo{
+		TxID:           tx.TxID,
+		Type:           tx.Type.String(),
+		Timestamp:      tx.Timestamp,
+		Signers:        tx.Signers,
+		ValidationCode: tx.ValidationCode.String(),
+	}
+	if len(tx.Signers) > 0 {
+		info.Creator = tx.Signers[0]
+	}
+	return info
+}
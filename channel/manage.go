@@ -2,9 +2,9 @@ package channel
 
 import (
 	"encoding/json"
-	"errors"
-	"io/ioutil"
-	"os"
+	"fmt"
+	"manageChain/calls"
+	"manageChain/mspstore"
 	"path"
 	"strings"
 
@@ -14,29 +14,30 @@ import (
 	"github.com/hyperledger/fabric/sdk"
 )
 
-const (
-	tmpMSPDir = "tmpMspDir"
-)
-
 const (
 	defaultConsensusType = "kafka"
+	// genesisBlock is the storage key of the genesis block of the orderers
+	genesisBlock = "orderer.block"
 )
 
 func (c *Channel) IdentityCode() (*IdentityCode, error) {
-	mspData, err := c.orgs[0].OrgCA.MSPBytes(c.orgs[0].OrgMSP)
+	return identityCode(c.orgs[0])
+}
+
+func identityCode(org *OrgInfo) (*IdentityCode, error) {
+	mspData, err := org.OrgCA.MSPBytes(org.OrgMSP)
 	if err != nil {
-		logger.Error("Error generating msp bytes", err)
+		logger.Error("Error generating msp bytes: %s", err)
 		return nil, err
 	}
-	orderers := Orderers(c.orgs[0].OrdererNodes)
-	anchors := AnchorPeers(c.orgs[0].PeerNodes)
+	orderers := Orderers(org.OrdererNodes)
+	anchors := AnchorPeers(org.PeerNodes)
 	chainOrgInfo := &ChainOrgInfo{}
-	chainOrgInfo.Peers = serviceNodesToEndpointList(c.orgs[0].PeerNodes, CreateChannelTimeout, c.orgs[0].OrgCA.TLSCACert())
-	chainOrgInfo.Orderers = serviceNodesToEndpointList(c.orgs[0].OrdererNodes, CreateChannelTimeout, c.orgs[0].OrgCA.TLSCACert())
-	chainOrgInfo.OrgName = c.orgs[0].OrgName
-	// chainOrgInfo.ChannelName =
+	chainOrgInfo.Peers = externalEndpointList(org.PeerNodes, callTimeout(org.Client, calls.OpEndorse), org.OrgCA.TLSCACert())
+	chainOrgInfo.Orderers = externalEndpointList(org.OrdererNodes, callTimeout(org.Client, calls.OpBroadcast), org.OrgCA.TLSCACert())
+	chainOrgInfo.OrgName = org.OrgName
 	return &IdentityCode{
-		Org:          c.orgs[0].OrgName,
+		Org:          org.OrgName,
 		OrgMSP:       mspData,
 		Orderers:     orderers,
 		Anchors:      anchors,
@@ -45,20 +46,30 @@ func (c *Channel) IdentityCode() (*IdentityCode, error) {
 }
 
 func (c *Channel) AddOrg(identity []byte, operateOrg []*OrgInfo, channelName string) error {
-	logger.Info("start add org")
+	c.log().Info("start add org")
+	operateOrg = withContext(c.orgs[0].Client.Context(), operateOrg)
 	ic := &IdentityCode{}
 	if err := json.Unmarshal(identity, ic); err != nil {
-		logger.Error("error unmarshal", err)
+		c.log().Error("error unmarshal: %s", err)
+		return err
+	}
+	c.log().Info("adding org %s to channel %s", ic.Org, channelName)
+	bundle, err := mspstore.Put(ic.OrgMSP)
+	if err != nil {
+		c.log().Error("Error storing the msp of %s: %s", ic.Org, err)
 		return err
 	}
-	logger.Info("mspdata:%s", ic.OrgMSP)
-	mspDir, mspID, err := sdk.WriteMSPDir(tmpMSPDir, ic.OrgMSP)
+	mspDir, err := bundle.LocalDir()
 	if err != nil {
-		logger.Error("error writing certs to msp dir", err)
 		return err
 	}
+	mspID := bundle.MSPID
 
-	broadcasters := serviceNodesToEndpointList(operateOrg[0].OrdererNodes, CreateChannelTimeout, operateOrg[0].OrgCA.TLSCACert())
+	broadcasters, err := ordererEndpoints(operateOrg[0], channelName, callTimeout(operateOrg[0].Client, calls.OpBroadcast))
+	if err != nil {
+		c.log().Error("Error resolving orderers: %s", err)
+		return err
+	}
 
 	peerOrgs := []*sdk.Organization{&sdk.Organization{
 		Name:        mspID,
@@ -77,12 +88,12 @@ func (c *Channel) AddOrg(identity []byte, operateOrg []*OrgInfo, channelName str
 
 	systemUpdate, err := c.createAddOrgChannelConfigUpdate(sdk.DefaultSystemChainID, nil, ordererOrgs, consortiumOrgs, ic.Orderers, broadcasters)
 	if err != nil {
-		logger.Error("Error create system channel config update", err)
+		c.log().Error("Error create system channel config update: %s", err)
 		return err
 	}
 	channelUpdate, err := c.createAddOrgChannelConfigUpdate(channelName, peerOrgs, ordererOrgs, nil, ic.Orderers, broadcasters)
 	if err != nil {
-		logger.Error("Error create channel config update", err)
+		c.log().Error("Error create channel config update: %s", err)
 		return err
 	}
 
@@ -92,12 +103,12 @@ func (c *Channel) AddOrg(identity []byte, operateOrg []*OrgInfo, channelName str
 	for _, org := range operateOrg {
 		sSigHeader, sSignedSigHeader, err := org.Client.SignChannelConfigUpdate(systemUpdate)
 		if err != nil {
-			logger.Error("Error signing system config update", err)
+			c.log().Error("Error signing system config update: %s", err)
 			return err
 		}
 		cSigHeader, cSignedSigHeader, err := org.Client.SignChannelConfigUpdate(channelUpdate)
 		if err != nil {
-			logger.Error("Error signing channel config update", err)
+			c.log().Error("Error signing channel config update: %s", err)
 			return err
 		}
 
@@ -113,41 +124,50 @@ func (c *Channel) AddOrg(identity []byte, operateOrg []*OrgInfo, channelName str
 
 	}
 
-	for _, broadcaster := range broadcasters {
+	for _, broadcaster := range calls.Order(operateOrg[0].Client.Context(), broadcasters) {
 		err = operateOrg[0].Client.UpdateChannelByConfigUpdate(sdk.DefaultSystemChainID, systemUpdate, systemSigs, broadcaster)
 		if err != nil {
-			logger.Error("Error update system channel", err)
+			c.log().Error("Error update system channel: %s", err)
 			continue
 		}
 		err = operateOrg[0].Client.UpdateChannelByConfigUpdate(channelName, channelUpdate, channelSigs, broadcaster)
 		if err != nil {
-			logger.Error("Error update channel", err)
+			c.log().Error("Error update channel: %s", err)
+			return err
+		}
+		c.log().Info("Suceesfully add new org")
+		if err := mspstore.Commit(mspID, channelName, bundle.Hash); err != nil {
+			c.log().Error("Error recording the msp of %s: %s", mspID, err)
 			return err
 		}
-		logger.Info("Suceesfully add new org")
 		return nil
 	}
 
-	logger.Info("end add new org.")
+	c.log().Info("end add new org.")
 	return nil
 }
 
 func (c *Channel) DeleteOrg(delOrg string, delOrderers []string, channelName string, operateOrg []*OrgInfo) error {
-	logger.Info("start delete org.")
-	broadcasters := serviceNodesToEndpointList(operateOrg[0].OrdererNodes, CreateChannelTimeout, operateOrg[0].OrgCA.TLSCACert())
+	c.log().Info("start delete org.")
+	operateOrg = withContext(c.orgs[0].Client.Context(), operateOrg)
+	broadcasters, err := ordererEndpoints(operateOrg[0], channelName, callTimeout(operateOrg[0].Client, calls.OpBroadcast))
+	if err != nil {
+		c.log().Error("Error resolving orderers: %s", err)
+		return err
+	}
 
 	systemUpdate, err := c.createDelOrgChannelConfigUpdate(sdk.DefaultSystemChainID, delOrg, delOrderers, broadcasters)
 	if err != nil {
-		logger.Error("Error create system channel config update", err)
+		c.log().Error("Error create system channel config update: %s", err)
 		return err
 	}
-	logger.Info("systemUpdate:%s", systemUpdate)
+	c.log().Info("systemUpdate:%s", systemUpdate)
 	channelUpdate, err := c.createDelOrgChannelConfigUpdate(channelName, delOrg, delOrderers, broadcasters)
 	if err != nil {
-		logger.Error("Error create channle config update", err)
+		c.log().Error("Error create channle config update: %s", err)
 		return err
 	}
-	logger.Info("channelUpdate:%s", channelUpdate)
+	c.log().Info("channelUpdate:%s", channelUpdate)
 
 	// sign
 	systemSigs := []*cb.ConfigSignature{}
@@ -155,12 +175,12 @@ func (c *Channel) DeleteOrg(delOrg string, delOrderers []string, channelName str
 	for _, org := range operateOrg {
 		sSigHeader, sSignedSigHeader, err := org.Client.SignChannelConfigUpdate(systemUpdate)
 		if err != nil {
-			logger.Error("Error signing system config update", err)
+			c.log().Error("Error signing system config update: %s", err)
 			return err
 		}
 		cSigHeader, cSignedSigHeader, err := org.Client.SignChannelConfigUpdate(channelUpdate)
 		if err != nil {
-			logger.Error("Error signing channel config update", err)
+			c.log().Error("Error signing channel config update: %s", err)
 		}
 
 		systemSigs = append(systemSigs, &cb.ConfigSignature{
@@ -174,51 +194,84 @@ func (c *Channel) DeleteOrg(delOrg string, delOrderers []string, channelName str
 		})
 	}
 
-	for _, broadcaster := range broadcasters {
-		err := operateOrg[0].Client.UpdateChannelByConfigUpdate(sdk.DefaultSystemChainID, systemUpdate, systemSigs, broadcaster)
-		if err != nil {
-			logger.Error("Error update system channel", err)
-			continue
+	if _, err := delOrgUpdate(operateOrg[0].Client, channelName, systemUpdate, channelUpdate, systemSigs, channelSigs, broadcasters); err != nil {
+		return err
+	}
+	retireMSP(delOrg, channelName)
+	c.log().Info("end delete org.")
+	return nil
+}
+
+// retireMSP records that the org left channelName, once it has left all of
+// them it can be added again with other certificates
+func retireMSP(mspID string, channelName string) {
+	if err := mspstore.Retire(mspID, channelName); err != nil {
+		logger.Error("Error retiring the msp of %s: %s", mspID, err)
+	}
+}
+
+// delOrgUpdate applies the system channel update, unless it is nil, and then
+// the channel update through the first orderer accepting them, and returns
+// the txIDs of both.
+func delOrgUpdate(client *sdk.Client, channelName string, systemUpdate, channelUpdate []byte, systemSigs, channelSigs []*cb.ConfigSignature, broadcasters []*sdk.Endpoint) (*RemovalResult, error) {
+	result := &RemovalResult{}
+	var lastErr error
+	for _, broadcaster := range calls.Order(client.Context(), broadcasters) {
+		// the txIDs are recorded once applied, a retry resumes after them
+		if systemUpdate != nil {
+			txID, err := client.UpdateChannelByConfigUpdateTx(sdk.DefaultSystemChainID, systemUpdate, systemSigs, broadcaster)
+			if err != nil {
+				logger.Error("Error update system channel: %s", err)
+				lastErr = err
+				continue
+			}
+			result.SystemTxID = txID
 		}
 
-		err = operateOrg[0].Client.UpdateChannelByConfigUpdate(channelName, channelUpdate, channelSigs, broadcaster)
+		txID, err := client.UpdateChannelByConfigUpdateTx(channelName, channelUpdate, channelSigs, broadcaster)
 		if err != nil {
-			logger.Error("Error update channel ", err)
-			return err
+			logger.Error("Error update channel: %s", err)
+			return result, err
 		}
+		result.ChannelTxID = txID
 		logger.Info("Succeesfully delete org.")
+		return result, nil
 	}
-	logger.Info("end delete org.")
-	return nil
+	return result, fmt.Errorf("failed updating system channel after try all orderers: %w", lastErr)
 }
 
 func (c *Channel) createAddOrgChannelConfigUpdate(chainID string, peerOrgs, ordererOrgs []*sdk.Organization, consortiumOrgs map[string][]*sdk.Organization, orderers []string, casters []*sdk.Endpoint) ([]byte, error) {
-	for _, caster := range casters {
-		configBlock, err := c.orgs[0].Client.GetConfigBlockByChannel(chainID, caster)
-		if err != nil {
-			logger.Error("Error getting config block from chain %s: %s", chainID, err)
-			continue
-		}
-		logger.Info("Successfully getting config block from chain %s", chainID)
-
-		return c.orgs[0].Client.GetAddOrgChannelConfigUpdate(chainID, configBlock, ordererOrgs, peerOrgs, consortiumOrgs, orderers)
+	configBlock, err := c.lastConfigBlock(chainID, casters)
+	if err != nil {
+		return nil, fmt.Errorf("failed getAddOrgChannelConfigUpdate after try all orderers: %w", err)
 	}
-	return nil, errors.New("failed getAddOrgChannelConfigUpdate after try all orderers")
+	return c.orgs[0].Client.GetAddOrgChannelConfigUpdate(chainID, configBlock, ordererOrgs, peerOrgs, consortiumOrgs, orderers)
 }
 
 func (c Channel) createDelOrgChannelConfigUpdate(chainID string, delOrg string, delOrderers []string, casters []*sdk.Endpoint) ([]byte, error) {
 	logger.Info("start createDelOrgChannelConfigUpdate.")
-	for _, caster := range casters {
-		configBlock, err := c.orgs[0].Client.GetConfigBlockByChannel(chainID, caster)
-		if err != nil {
-			logger.Error("Error getting config block from chain %s: %s", chainID, err)
-			continue
-		}
-		logger.Info("Successfully getting config block from chain %s", chainID)
-		return c.orgs[0].Client.GetDelOrgChannelConfigUpdate(chainID, configBlock, delOrg, delOrderers)
+	configBlock, err := c.lastConfigBlock(chainID, casters)
+	if err != nil {
+		return nil, fmt.Errorf("failed getDelOrgChannelConfigUpdate after try all orderers: %w", err)
 	}
 	logger.Info("end createDelOrgChannelConfigUpdate.")
-	return nil, errors.New("failed getDelOrgChannelConfigUpdate after try all orderers")
+	return c.orgs[0].Client.GetDelOrgChannelConfigUpdate(chainID, configBlock, delOrg, delOrderers)
+}
+
+// lastConfigBlock returns the last config block of chainID from the first of
+// casters delivering it
+func (c *Channel) lastConfigBlock(chainID string, casters []*sdk.Endpoint) (*cb.Block, error) {
+	client := c.orgs[0].Client
+	var block *cb.Block
+	err := calls.Try(client.Context(), casters, func(caster *sdk.Endpoint) (err error) {
+		if block, err = client.GetConfigBlockByChannel(chainID, caster); err != nil {
+			c.log().Error("Error getting config block from chain %s: %s", chainID, err)
+			return err
+		}
+		c.log().Info("Successfully getting config block from chain %s", chainID)
+		return nil
+	})
+	return block, err
 }
 
 func GenerateCrypto(orgs []*OrgInfo) error {
@@ -228,7 +281,7 @@ func GenerateCrypto(orgs []*OrgInfo) error {
 	for _, org := range orgs {
 		orgCA, err := GetCA(path.Join(mspDir, org.OrgName), org.OrgName)
 		if err != nil {
-			logger.Error("Error getting peer ca", err)
+			logger.Error("Error getting peer ca: %s", err)
 			return err
 		}
 		org.OrgCA = orgCA
@@ -246,7 +299,7 @@ func GenerateCrypto(orgs []*OrgInfo) error {
 				NodeType: sdk.OrdererNode,
 			}}
 			if err := org.OrgCA.GenerateMSP(certs, nil); err != nil {
-				logger.Error("Error generating msp", err)
+				logger.Error("Error generating msp: %s", err)
 				return err
 			}
 		}
@@ -260,7 +313,7 @@ func GenerateCrypto(orgs []*OrgInfo) error {
 				NodeType: sdk.PeerNode,
 			}}
 			if err := org.OrgCA.GenerateMSP(certs, nil); err != nil {
-				logger.Error("Error generating msp", err)
+				logger.Error("Error generating msp: %s", err)
 				return err
 			}
 		}
@@ -268,18 +321,9 @@ func GenerateCrypto(orgs []*OrgInfo) error {
 	return nil
 }
 
+// GetCA returns the CA of msp in dir of the storage, created unless it exists
 func GetCA(dir string, msp string) (*sdk.CA, error) {
-	info, err := os.Stat(dir)
-	if os.IsNotExist(err) {
-		return sdk.NewCA(dir, msp)
-	}
-	if err == nil {
-		if !info.IsDir() {
-			return nil, errors.New("msp path is not a directory, but a file")
-		}
-		return sdk.ConstructCAFromDir(dir)
-	}
-	return nil, err
+	return sdk.OpenCA(dir, msp)
 }
 
 func splitIP(addr string) string {
@@ -310,7 +354,7 @@ func GenGenesisBlock(orgs []*OrgInfo, kafkas []string) (*cb.Block, error) {
 			orderers = append(orderers, orderer.ExternalEndpoint)
 		}
 	}
-	logger.Info("orderers:", orderers)
+	logger.Info("orderers: %v", orderers)
 
 	for _, org := range orgs {
 		peerOrg := &sdk.Organization{
@@ -326,7 +370,7 @@ func GenGenesisBlock(orgs []*OrgInfo, kafkas []string) (*cb.Block, error) {
 		peerOrgs = append(peerOrgs, peerOrg)
 		ordererOrgs = append(ordererOrgs, ordererOrg)
 	}
-	logger.Info("peerorgs,ordererorgs:", peerOrgs, ordererOrgs)
+	logger.Info("peerorgs: %v, ordererorgs: %v", peerOrgs, ordererOrgs)
 
 	conf := &sdk.GenesisConfig{
 		ChainID:                 sdk.DefaultSystemChainID,
@@ -340,12 +384,12 @@ func GenGenesisBlock(orgs []*OrgInfo, kafkas []string) (*cb.Block, error) {
 		ConsortiumName:          sdk.DefaultConsortium,
 		KafkaBrokers:            kafkas,
 	}
-	logger.Info("genesis block conf:", conf)
+	logger.Info("genesis block conf: %v", conf)
 	block := sdk.CreateGenesisBlock(conf)
 
-	err := ioutil.WriteFile("orderer.block", utils.MarshalOrPanic(block), 0644)
+	err := sdk.Store().Write(genesisBlock, utils.MarshalOrPanic(block))
 	if err != nil {
-		logger.Info("write file err:", err)
+		logger.Info("write file err: %s", err)
 	}
 	return block, nil
 }
@@ -0,0 +1,69 @@
+package channel
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// OrgInfoByPage returns one page of the org infos registered for chainID on the public chain.
+func (c *Channel) OrgInfoByPage(chainID string, pageSize int32, bookmark string) (*OrgInfoPage, error) {
+	page := &OrgInfoPage{}
+	if err := c.queryPage(getOrgInfoByPage, chainID, pageSize, bookmark, nil, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// OrgnameByPage returns one page of the org names registered for chainID on the public chain.
+func (c *Channel) OrgnameByPage(chainID string, pageSize int32, bookmark string) (*OrgnamePage, error) {
+	page := &OrgnamePage{}
+	if err := c.queryPage(getOrgnameByPage, chainID, pageSize, bookmark, nil, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// InvitationByPage returns one page of the invitations of chainID matching filter.
+func (c *Channel) InvitationByPage(chainID string, pageSize int32, bookmark string, filter *InvitationFilter) (*InvitationPage, error) {
+	if filter == nil {
+		filter = &InvitationFilter{}
+	}
+	filterBytes, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	page := &InvitationPage{}
+	if err := c.queryPage(getInvitationByPage, chainID, pageSize, bookmark, filterBytes, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (c *Channel) queryPage(fcn string, chainID string, pageSize int32, bookmark string, filter []byte, page interface{}) error {
+	if chainID == "" {
+		chainID = PublicChainID
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	args := [][]byte{
+		[]byte(fcn),
+		[]byte(chainID),
+		[]byte(strconv.Itoa(int(pageSize))),
+		[]byte(bookmark),
+	}
+	if filter != nil {
+		args = append(args, filter)
+	}
+
+	data, err := queryPublic(c.orgs[0], args)
+	if err != nil {
+		c.log().Error("Error querying %s: %s", fcn, err)
+		return err
+	}
+	if err := json.Unmarshal(data, page); err != nil {
+		c.log().Error("Error unmarshaling page: %s", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package channel
+
+import (
+	"encoding/json"
+	"manageChain/calls"
+	"manageChain/protocols"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// removalData is the RawData of a removal proposal: the config updates every
+// voter signs, so they can be applied once the proposal is approved as long
+// as the configs did not change in between.
+type removalData struct {
+	DelOrderers   []string
+	SystemUpdate  []byte
+	ChannelUpdate []byte
+}
+
+// removalSignature is the Signature of an accepting vote
+type removalSignature struct {
+	System  *cb.ConfigSignature
+	Channel *cb.ConfigSignature
+}
+
+// ProposeRemoval proposes in the public chain to remove target from channelName,
+// on behalf of the first org.
+func (c *Channel) ProposeRemoval(channelName string, target string, delOrderers []string, reason string) error {
+	c.log().Info("start propose removal of %s from %s", target, channelName)
+	broadcasters, err := ordererEndpoints(c.orgs[0], channelName, callTimeout(c.orgs[0].Client, calls.OpBroadcast))
+	if err != nil {
+		c.log().Error("Error resolving orderers: %s", err)
+		return err
+	}
+
+	systemUpdate, err := c.createDelOrgChannelConfigUpdate(sdk.DefaultSystemChainID, target, delOrderers, broadcasters)
+	if err != nil {
+		c.log().Error("Error create system channel config update: %s", err)
+		return err
+	}
+	channelUpdate, err := c.createDelOrgChannelConfigUpdate(channelName, target, delOrderers, broadcasters)
+	if err != nil {
+		c.log().Error("Error create channel config update: %s", err)
+		return err
+	}
+	rawData, err := json.Marshal(&removalData{
+		DelOrderers:   delOrderers,
+		SystemUpdate:  systemUpdate,
+		ChannelUpdate: channelUpdate,
+	})
+	if err != nil {
+		return err
+	}
+
+	args := [][]byte{
+		[]byte(startRemoval),
+		[]byte(channelName),
+		[]byte(c.orgs[0].OrgName),
+		[]byte(target),
+		[]byte(reason),
+		rawData,
+	}
+	if err := invokePublic(c.orgs[0], args); err != nil {
+		c.log().Error("Error starting removal: %s", err)
+		return err
+	}
+	c.log().Info("end propose removal")
+	return nil
+}
+
+// GetRemoval returns the proposal to remove target from channelName with its votes.
+func (c *Channel) GetRemoval(channelName string, target string) (*RemovalProposal, error) {
+	args := [][]byte{
+		[]byte(getRemoval),
+		[]byte(channelName),
+		[]byte(target),
+	}
+	data, err := queryPublic(c.orgs[0], args)
+	if err != nil {
+		c.log().Error("Error querying removal: %s", err)
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, protocols.Errorf(protocols.CodeNotFound, "no removal of %s from %s is proposed", target, channelName)
+	}
+	proposal := &RemovalProposal{}
+	if err := json.Unmarshal(data, proposal); err != nil {
+		c.log().Error("Error unmarshaling removal: %s", err)
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// VoteRemoval casts the vote of every org on the removal of target. An accepting
+// vote carries the org's signatures of the config updates. When the votes approve
+// the proposal, the removal is executed right away.
+func (c *Channel) VoteRemoval(channelName string, target string, accept bool) (*RemovalProposal, error) {
+	c.log().Info("start vote removal of %s from %s", target, channelName)
+	proposal, err := c.GetRemoval(channelName, target)
+	if err != nil {
+		return nil, err
+	}
+	data := &removalData{}
+	if err := json.Unmarshal([]byte(proposal.RawData), data); err != nil {
+		c.log().Error("Error unmarshaling removal data: %s", err)
+		return nil, err
+	}
+
+	for _, org := range c.orgs {
+		accepted := rejectState
+		signature := []byte{}
+		if accept {
+			accepted = acceptState
+			sig := &removalSignature{}
+			if sig.System, err = signConfigUpdate(org.Client, data.SystemUpdate); err != nil {
+				c.log().Error("Error signing system config update: %s", err)
+				return nil, err
+			}
+			if sig.Channel, err = signConfigUpdate(org.Client, data.ChannelUpdate); err != nil {
+				c.log().Error("Error signing channel config update: %s", err)
+				return nil, err
+			}
+			if signature, err = json.Marshal(sig); err != nil {
+				return nil, err
+			}
+		}
+
+		args := [][]byte{
+			[]byte(signRemoval),
+			[]byte(channelName),
+			[]byte(target),
+			[]byte(org.OrgName),
+			signature,
+			[]byte(accepted),
+		}
+		if err := invokePublic(org, args); err != nil {
+			c.log().Error("Error voting removal as %s: %s", org.OrgName, err)
+			return nil, err
+		}
+	}
+
+	proposal, err = c.GetRemoval(channelName, target)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status == approvedState {
+		if _, err := c.executeRemoval(proposal, data); err != nil {
+			return nil, err
+		}
+		return c.GetRemoval(channelName, target)
+	}
+	c.log().Info("end vote removal, status: %s", proposal.Status)
+	return proposal, nil
+}
+
+// ExecuteRemoval removes the target of an approved removal, or retries a
+// failed one, and records the outcome in the public chain.
+func (c *Channel) ExecuteRemoval(channelName string, target string) (*RemovalResult, error) {
+	proposal, err := c.GetRemoval(channelName, target)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != approvedState && proposal.Status != failedState {
+		return nil, protocols.Errorf(protocols.CodeConflict, "removal of %s from %s is %s, not %s", target, channelName, proposal.Status, approvedState)
+	}
+	data := &removalData{}
+	if err := json.Unmarshal([]byte(proposal.RawData), data); err != nil {
+		c.log().Error("Error unmarshaling removal data: %s", err)
+		return nil, err
+	}
+	return c.executeRemoval(proposal, data)
+}
+
+// executeRemoval applies the config updates removing the target, computed
+// from the current configs. The votes signed the updates of the proposal,
+// when the configs changed since the orgs of the request that accepted it
+// sign the new ones. A failed removal whose system channel update was
+// applied resumes at the channel update.
+func (c *Channel) executeRemoval(proposal *RemovalProposal, data *removalData) (*RemovalResult, error) {
+	c.log().Info("start execute removal of %s from %s", proposal.Target, proposal.ChainId)
+	broadcasters, err := ordererEndpoints(c.orgs[0], proposal.ChainId, callTimeout(c.orgs[0].Client, calls.OpBroadcast))
+	if err != nil {
+		c.log().Error("Error resolving orderers: %s", err)
+		return nil, err
+	}
+
+	previous := &RemovalResult{}
+	if proposal.Status == failedState && proposal.Result != "" {
+		if err := json.Unmarshal([]byte(proposal.Result), previous); err != nil {
+			c.log().Error("Error unmarshaling the result of the failed removal: %s", err)
+			return nil, err
+		}
+	}
+	var systemUpdate []byte
+	if previous.SystemTxID == "" {
+		if systemUpdate, err = c.createDelOrgChannelConfigUpdate(sdk.DefaultSystemChainID, proposal.Target, data.DelOrderers, broadcasters); err != nil {
+			c.log().Error("Error create system channel config update: %s", err)
+			return nil, err
+		}
+	} else {
+		c.log().Info("the system channel update %s of the removal was applied, resuming at the channel update", previous.SystemTxID)
+	}
+	channelUpdate, err := c.createDelOrgChannelConfigUpdate(proposal.ChainId, proposal.Target, data.DelOrderers, broadcasters)
+	if err != nil {
+		c.log().Error("Error create channel config update: %s", err)
+		return nil, err
+	}
+	var systemSigs, channelSigs []*cb.ConfigSignature
+	if (systemUpdate == nil || sameUpdate(systemUpdate, data.SystemUpdate)) && sameUpdate(channelUpdate, data.ChannelUpdate) {
+		if systemUpdate != nil {
+			systemUpdate = data.SystemUpdate
+		}
+		channelUpdate = data.ChannelUpdate
+		systemSigs, channelSigs, err = voteSignatures(proposal)
+	} else {
+		c.log().Warning("the configs changed since the removal of %s from %s was proposed, signing it again", proposal.Target, proposal.ChainId)
+		systemSigs, channelSigs, err = c.signAccepted(proposal, systemUpdate, channelUpdate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := removedState
+	result, updateErr := delOrgUpdate(c.orgs[0].Client, proposal.ChainId, systemUpdate, channelUpdate, systemSigs, channelSigs, broadcasters)
+	if systemUpdate == nil {
+		result.SystemTxID = previous.SystemTxID
+	}
+	if updateErr != nil {
+		status = failedState
+		result.Error = updateErr.Error()
+	} else {
+		retireMSP(proposal.Target, proposal.ChainId)
+	}
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	args := [][]byte{
+		[]byte(recordRemoval),
+		[]byte(proposal.ChainId),
+		[]byte(proposal.Target),
+		[]byte(status),
+		resultBytes,
+	}
+	if err := invokePublic(c.orgs[0], args); err != nil {
+		c.log().Error("Error recording removal: %s", err)
+		return result, err
+	}
+	c.log().Info("end execute removal, status: %s", status)
+	return result, updateErr
+}
+
+// voteSignatures returns the signatures of the updates of the proposal the
+// accepting votes carry
+func voteSignatures(proposal *RemovalProposal) (systemSigs, channelSigs []*cb.ConfigSignature, err error) {
+	for _, vote := range proposal.Votes {
+		if vote.Accepted != acceptState {
+			continue
+		}
+		sig := &removalSignature{}
+		if err := json.Unmarshal([]byte(vote.Signature), sig); err != nil {
+			logger.Error("Error unmarshaling signature of %s: %s", vote.Signer, err)
+			return nil, nil, err
+		}
+		systemSigs = append(systemSigs, sig.System)
+		channelSigs = append(channelSigs, sig.Channel)
+	}
+	return systemSigs, channelSigs, nil
+}
+
+// signAccepted signs the updates with the orgs of c that accepted the
+// proposal, the system channel update unless it is nil
+func (c *Channel) signAccepted(proposal *RemovalProposal, systemUpdate, channelUpdate []byte) (systemSigs, channelSigs []*cb.ConfigSignature, err error) {
+	accepted := make(map[string]bool)
+	for _, vote := range proposal.Votes {
+		accepted[vote.Signer] = vote.Accepted == acceptState
+	}
+	for _, org := range c.orgs {
+		if !accepted[org.OrgName] {
+			continue
+		}
+		if systemUpdate != nil {
+			systemSig, err := signConfigUpdate(org.Client, systemUpdate)
+			if err != nil {
+				c.log().Error("Error signing system config update: %s", err)
+				return nil, nil, err
+			}
+			systemSigs = append(systemSigs, systemSig)
+		}
+		channelSig, err := signConfigUpdate(org.Client, channelUpdate)
+		if err != nil {
+			c.log().Error("Error signing channel config update: %s", err)
+			return nil, nil, err
+		}
+		channelSigs = append(channelSigs, channelSig)
+	}
+	if len(channelSigs) == 0 {
+		return nil, nil, protocols.Errorf(protocols.CodeConflict, "the configs changed since the removal of %s from %s was voted, execute it with the orgs that accepted it to sign it again", proposal.Target, proposal.ChainId)
+	}
+	return systemSigs, channelSigs, nil
+}
+
+// sameUpdate tells whether the config updates a and b make the same changes
+func sameUpdate(a, b []byte) bool {
+	updateA, updateB := &cb.ConfigUpdate{}, &cb.ConfigUpdate{}
+	if proto.Unmarshal(a, updateA) != nil || proto.Unmarshal(b, updateB) != nil {
+		return false
+	}
+	return proto.Equal(updateA, updateB)
+}
+
+func signConfigUpdate(client *sdk.Client, update []byte) (*cb.ConfigSignature, error) {
+	sigHeader, signedSigHeader, err := client.SignChannelConfigUpdate(update)
+	if err != nil {
+		return nil, err
+	}
+	return &cb.ConfigSignature{
+		SignatureHeader: sigHeader,
+		Signature:       signedSigHeader,
+	}, nil
+}
@@ -0,0 +1,419 @@
+// Package cli is the command-line client of the REST API, run as
+// manageChain <group> <command>. A command sends the request of its route to
+// the server of -server, or serves it in process with the same controllers
+// and MSPDir when there is no server. The request files are in the shape of
+// the REST payloads, the responses are rendered as tables or JSON.
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"manageChain/jobs"
+	"manageChain/openapi"
+	"manageChain/protocols"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ServerEnv is the environment variable of the default -server
+const ServerEnv = "MANAGECHAIN_SERVER"
+
+// environment variables of the default -tlsca, -tlscert and -tlskey
+const (
+	TLSCAEnv   = "MANAGECHAIN_TLSCA"
+	TLSCertEnv = "MANAGECHAIN_TLSCERT"
+	TLSKeyEnv  = "MANAGECHAIN_TLSKEY"
+)
+
+// outputs of -o
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// command sends a request to a route of the REST API, the POST routes with
+// the request file of -f as body.
+type command struct {
+	name   string
+	method string
+	// path may have {name} parameters, given as the arguments of the command
+	path    string
+	summary string
+	// async tells whether the route runs the operation in a job with -async
+	async bool
+	// params are the query parameters, set with flags of the same names
+	params []string
+	// run replaces sending the request, e.g. to poll
+	run func(inv *invocation, args []string) int
+}
+
+type group struct {
+	name     string
+	summary  string
+	commands []*command
+}
+
+var groups = []*group{
+	{name: "network", summary: "declarative networks and the deployment of the nodes", commands: []*command{
+		{name: "plan", method: "POST", path: "/network/plan", summary: "show the steps bringing the network to a spec"},
+		{name: "apply", method: "POST", path: "/network/apply", summary: "run the steps bringing the network to a spec", async: true},
+		{name: "health", method: "GET", path: "/health", summary: "show the last check of the monitored nodes"},
+		{name: "ha", method: "GET", path: "/ha", summary: "show whether the server is the leader of its replicas, and the leader"},
+		{name: "genesis", method: "POST", path: "/gengenesisblock", summary: "generate the genesis block of the system channel"},
+		{name: "compose", method: "POST", path: "/gencompose", summary: "generate docker-compose files running the nodes of orgs"},
+		{name: "manifests", method: "POST", path: "/genmanifests", summary: "generate kubernetes manifests running the nodes of orgs"},
+		{name: "nodeconfig", method: "POST", path: "/gennodeconfig", summary: "generate the configuration bundles of the nodes of orgs"},
+	}},
+	{name: "org", summary: "the crypto material and the directory of orgs", commands: []*command{
+		{name: "crypto", method: "POST", path: "/gencrypto", summary: "generate the crypto material of orgs under MSPDir"},
+		{name: "certs", method: "GET", path: "/orgs", summary: "list the orgs under MSPDir with the expiry of their certificates"},
+		{name: "identity", method: "POST", path: "/channel/identity", summary: "show the identity code an org joins channels with"},
+		{name: "list", method: "POST", path: "/public/orginfo", summary: "list a page of the orgs of the public chain"},
+		{name: "names", method: "POST", path: "/public/orgname", summary: "list a page of the names of the orgs of the public chain"},
+	}},
+	{name: "channel", summary: "channels and their members", commands: []*command{
+		{name: "list", method: "POST", path: "/channel/list", summary: "list the channels the peers of the first org have joined"},
+		{name: "config", method: "POST", path: "/channel/config", summary: "show the current config of a channel"},
+		{name: "create", method: "POST", path: "/channel/create", summary: "create a channel", async: true},
+		{name: "join", method: "POST", path: "/channel/join", summary: "join the peers of orgs to a channel", async: true},
+		{name: "bootstrap", method: "POST", path: "/channel/bootstrap", summary: "create the public chain and deploy the public chaincode", async: true},
+		{name: "addorg", method: "POST", path: "/channel/addorg", summary: "add an org to a channel", async: true},
+		{name: "deleteorg", method: "POST", path: "/channel/deleteorg", summary: "remove an org from a channel", async: true},
+		{name: "publish", method: "POST", path: "/channel/orginfo", summary: "publish the nodes of orgs to the public chain", async: true},
+		{name: "invitations", method: "POST", path: "/public/invitation", summary: "list a page of the invitations of the public chain"},
+		{name: "propose-removal", method: "POST", path: "/channel/removal/propose", summary: "start a vote on removing an org from a channel", async: true},
+		{name: "vote-removal", method: "POST", path: "/channel/removal/vote", summary: "vote on a removal, executing it once approved", async: true},
+		{name: "get-removal", method: "POST", path: "/channel/removal/get", summary: "show a removal proposal with its votes"},
+		{name: "removals", method: "POST", path: "/channel/removal/list", summary: "list the removals proposed of the orgs of a channel"},
+		{name: "execute-removal", method: "POST", path: "/channel/removal/execute", summary: "apply an approved removal or retry a failed one", async: true},
+	}},
+	{name: "chaincode", summary: "chaincodes", commands: []*command{
+		{name: "list", method: "POST", path: "/chaincode/list", summary: "list the chaincodes of the peers of orgs"},
+		{name: "install", method: "POST", path: "/chaincode/install", summary: "install a chaincode on peers", async: true},
+		{name: "instantiate", method: "POST", path: "/chaincode/instantiate", summary: "instantiate a chaincode on a channel", async: true},
+		{name: "invoke", method: "POST", path: "/chaincode/invoke", summary: "invoke a chaincode", async: true},
+	}},
+	{name: "ledger", summary: "the ledgers of the channels", commands: []*command{
+		{name: "info", method: "POST", path: "/ledger/info", summary: "show the height of the ledger of a channel"},
+		{name: "block", method: "POST", path: "/ledger/block", summary: "show a block of a channel with its transactions"},
+		{name: "tx", method: "POST", path: "/ledger/tx", summary: "show a transaction of a channel"},
+	}},
+	{name: "jobs", summary: "the operations run with -async", commands: []*command{
+		{name: "list", method: "GET", path: "/jobs", summary: "list the jobs, the most recent first", params: []string{"status"}},
+		{name: "get", method: "GET", path: "/jobs/{id}", summary: "show a job"},
+		{name: "wait", method: "GET", path: "/jobs/{id}", summary: "wait for a job to finish and show it", run: waitJob},
+	}},
+}
+
+// IsGroup tells whether name is a group of commands
+func IsGroup(name string) bool {
+	return findGroup(name) != nil
+}
+
+func findGroup(name string) *group {
+	for _, g := range groups {
+		if g.name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+func (g *group) command(name string) *command {
+	for _, c := range g.commands {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Usage writes the groups of commands
+func Usage(w io.Writer) {
+	for _, g := range groups {
+		fmt.Fprintf(w, "  %-10s %s\n", g.name, g.summary)
+	}
+}
+
+func (g *group) usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: manageChain %s <command> [flags]\n\ncommands:\n", g.name)
+	for _, c := range g.commands {
+		fmt.Fprintf(w, "  %-16s %s\n", c.name+c.arguments(), c.summary)
+	}
+	fmt.Fprintf(w, "\nthe POST commands send the request file of -f, - for stdin, see manageChain %s <command> -h\n", g.name)
+}
+
+// arguments are the path parameters of c, e.g. " <id>"
+func (c *command) arguments() string {
+	var args string
+	for _, s := range strings.Split(c.path, "/") {
+		if strings.HasPrefix(s, "{") {
+			args += " <" + strings.Trim(s, "{}") + ">"
+		}
+	}
+	return args
+}
+
+// invocation is a command being run
+type invocation struct {
+	t      transport
+	output string
+	stdout io.Writer
+	stderr io.Writer
+	// interval is the period jobs wait polls at
+	interval time.Duration
+}
+
+// Run runs the command of group in args, returning the exit status: 0 when
+// the request succeeded, 1 when it failed and 2 when the command is wrong.
+func Run(groupName string, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	g := findGroup(groupName)
+	if g == nil {
+		fmt.Fprintf(stderr, "unknown command group %s\n", groupName)
+		return 2
+	}
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		g.usage(stderr)
+		return 2
+	}
+	c := g.command(args[0])
+	if c == nil {
+		fmt.Fprintf(stderr, "unknown command %s %s\n\n", g.name, args[0])
+		g.usage(stderr)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("manageChain "+g.name+" "+c.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: manageChain %s %s%s [flags]\n\n%s\n\nflags:\n", g.name, c.name, c.arguments(), c.summary)
+		fs.PrintDefaults()
+	}
+	server := fs.String("server", os.Getenv(ServerEnv), "URL of the manageChain server, the requests are served in process against MSPDir when empty, $"+ServerEnv+" by default")
+	output := fs.String("o", outputTable, "output format, table or json")
+	timeout := fs.Duration("timeout", 10*time.Minute, "timeout of the requests to the server")
+	tlsCA := fs.String("tlsca", os.Getenv(TLSCAEnv), "PEM of the CAs of an https server, the system ones by default, $"+TLSCAEnv+" by default")
+	tlsCert := fs.String("tlscert", os.Getenv(TLSCertEnv), "PEM of the client certificate sent to an https server, $"+TLSCertEnv+" by default")
+	tlsKey := fs.String("tlskey", os.Getenv(TLSKeyEnv), "PEM of the key of -tlscert, $"+TLSKeyEnv+" by default")
+	var file *string
+	if c.method == "POST" {
+		file = fs.String("f", "", "request file in the shape of the REST payload, JSON or YAML for network, - for stdin")
+	}
+	var async *bool
+	if c.async {
+		async = fs.Bool("async", false, "run the operation in a job and show the job, see manageChain jobs wait")
+	}
+	params := make(map[string]*string)
+	for _, p := range c.params {
+		params[p] = fs.String(p, "", "query parameter "+p)
+	}
+	// the overrides of the timeouts, retries and failover order of the calls to the nodes
+	if op := openapi.Get().Operation(c.method, c.path); op != nil {
+		for _, p := range op.Parameters {
+			if _, ok := params[p.Name]; !ok && p.In == "query" && p.Name != "async" {
+				params[p.Name] = fs.String(p.Name, "", p.Description)
+			}
+		}
+	}
+	inv := &invocation{stdout: stdout, stderr: stderr}
+	if c.run != nil {
+		fs.DurationVar(&inv.interval, "interval", 2*time.Second, "period of the polls")
+	}
+	positional, err := parse(fs, args[1:])
+	if err != nil {
+		return 2
+	}
+	if *output != outputTable && *output != outputJSON {
+		fmt.Fprintf(stderr, "unknown output %s, table or json\n", *output)
+		return 2
+	}
+	inv.output = *output
+
+	path, err := c.expand(positional)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		fs.Usage()
+		return 2
+	}
+	query := url.Values{}
+	for p, v := range params {
+		if *v != "" {
+			query.Set(p, *v)
+		}
+	}
+	if async != nil && *async {
+		query.Set("async", "true")
+	}
+	if len(query) != 0 {
+		path += "?" + query.Encode()
+	}
+
+	if *server != "" {
+		tlsConfig, err := newTLSConfig(*tlsCA, *tlsCert, *tlsKey)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		inv.t = newRemote(*server, *timeout, tlsConfig)
+	} else {
+		if async != nil && *async {
+			fmt.Fprintln(stderr, "-async needs a -server, the job would end with the command")
+			return 2
+		}
+		inv.t = newInProcess(stderr)
+	}
+
+	if c.run != nil {
+		return c.run(inv, []string{path})
+	}
+	var body []byte
+	contentType := "application/json"
+	if file != nil {
+		if *file == "" {
+			fmt.Fprintln(stderr, "missing the request file, -f")
+			return 2
+		}
+		if body, err = readFile(*file, stdin); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		if ext := filepath.Ext(*file); ext == ".yaml" || ext == ".yml" {
+			contentType = "application/x-yaml"
+		}
+	}
+	resp, err := inv.t.do(c.method, path, contentType, body)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return inv.print(resp)
+}
+
+// parse parses the flags of args, which may follow the arguments, and
+// returns the arguments.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// expand returns the path of c with its parameters set to args
+func (c *command) expand(args []string) (string, error) {
+	segments := strings.Split(c.path, "/")
+	n := 0
+	for i, s := range segments {
+		if !strings.HasPrefix(s, "{") {
+			continue
+		}
+		if n == len(args) {
+			return "", fmt.Errorf("missing %s", s)
+		}
+		segments[i] = url.PathEscape(args[n])
+		n++
+	}
+	if n != len(args) {
+		return "", fmt.Errorf("unexpected arguments %v", args[n:])
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+func readFile(name string, stdin io.Reader) ([]byte, error) {
+	if name == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(name)
+}
+
+// print prints a response, returning 1 when the request failed
+func (inv *invocation) print(resp *response) int {
+	failed := resp.status >= 300
+	if !strings.Contains(resp.contentType, "json") {
+		w := inv.stdout
+		if failed {
+			w = inv.stderr
+		}
+		w.Write(resp.body)
+		if failed {
+			return 1
+		}
+		return 0
+	}
+	msg := &protocols.ErrorMessage{}
+	if failed && json.Unmarshal(resp.body, msg) == nil && msg.Code != "" && inv.output == outputTable {
+		fmt.Fprintf(inv.stderr, "error %s: %s\n", msg.Code, msg.Message)
+		for _, f := range msg.Fields {
+			fmt.Fprintf(inv.stderr, "  %s: %s\n", f.Field, f.Message)
+		}
+		return 1
+	}
+	if err := inv.render(resp.body); err != nil {
+		fmt.Fprintf(inv.stderr, "error rendering the response: %s\n", err)
+		return 1
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+func (inv *invocation) render(data []byte) error {
+	if inv.output == outputJSON {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, err := buf.WriteTo(inv.stdout)
+		return err
+	}
+	v, err := decode(data)
+	if err != nil {
+		return err
+	}
+	return renderTable(inv.stdout, v)
+}
+
+// waitJob polls the job of args[0], the path of the job, until it is finished
+func waitJob(inv *invocation, args []string) int {
+	for {
+		resp, err := inv.t.do("GET", args[0], "", nil)
+		if err != nil {
+			fmt.Fprintln(inv.stderr, err)
+			return 1
+		}
+		if resp.status != 200 {
+			return inv.print(resp)
+		}
+		j := &jobs.Job{}
+		if err := json.Unmarshal(resp.body, j); err != nil {
+			fmt.Fprintf(inv.stderr, "error parsing the job: %s\n", err)
+			return 1
+		}
+		if j.Done() {
+			if err := inv.render(resp.body); err != nil {
+				fmt.Fprintf(inv.stderr, "error rendering the response: %s\n", err)
+				return 1
+			}
+			if j.Status == jobs.Failed {
+				return 1
+			}
+			return 0
+		}
+		time.Sleep(inv.interval)
+	}
+}
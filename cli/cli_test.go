@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"manageChain/audit"
+	"manageChain/channel"
+	"manageChain/fabrictest"
+	"manageChain/jobs"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+const testOrgDir = "../msp/testorg1"
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "cli")
+	if err != nil {
+		panic(err)
+	}
+	beego.AppConfig.Set("MSPDir", "../msp")
+	beego.AppConfig.Set("AuditPrefix", filepath.Join(dir, "audit"))
+	status := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(status)
+}
+
+func run(t *testing.T, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	status := Run(args[0], args[1:], strings.NewReader(stdin), &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestRenderTable(t *testing.T) {
+	for _, c := range []struct {
+		data  string
+		table string
+	}{
+		{`"OK"`, "OK\n"},
+		{`[]`, ""},
+		{`["a", "b"]`, "a\nb\n"},
+		{`[{"id": "1", "status": "running"}, {"id": "22", "error": {"code": "timeout"}}]`,
+			"ID  STATUS   ERROR\n1   running  \n22           {\"code\":\"timeout\"}\n"},
+		{`{"number": 3, "signers": ["org1", "org2"], "txs": [{"txId": "a", "type": "CONFIG"}], "empty": []}`,
+			"number:   3\nsigners:  org1,org2\nempty:    \n\ntxs:\nTXID  TYPE\na     CONFIG\n"},
+	} {
+		v, err := decode([]byte(c.data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := renderTable(&buf, v); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != c.table {
+			t.Fatalf("%s: expected\n%q\ngot\n%q", c.data, c.table, buf.String())
+		}
+	}
+	if _, err := decode([]byte(`{} {}`)); err == nil {
+		t.Fatal("two values should not decode")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	if status, _, stderr := run(t, "", "jobs"); status != 2 || !strings.Contains(stderr, "wait <id>") {
+		t.Fatalf("unexpected usage %d %s", status, stderr)
+	}
+	if status, _, _ := run(t, "", "channel", "unknown"); status != 2 {
+		t.Fatalf("unexpected status %d", status)
+	}
+	if status, _, stderr := run(t, "", "channel", "create"); status != 2 || !strings.Contains(stderr, "-f") {
+		t.Fatalf("a request file should be required: %d %s", status, stderr)
+	}
+	if status, _, _ := run(t, "", "channel", "create", "-f", "req.json", "-async"); status != 2 {
+		t.Fatal("-async should need a server")
+	}
+	if status, _, _ := run(t, "", "jobs", "get"); status != 2 {
+		t.Fatal("the ID of a job should be required")
+	}
+}
+
+func TestInProcess(t *testing.T) {
+	// the request is validated as by the server
+	status, stdout, stderr := run(t, `{"Orgs": []}`, "org", "identity", "-f", "-")
+	if status != 1 || stdout != "" || !strings.Contains(stderr, "error invalid_request") || !strings.Contains(stderr, "  Orgs: ") {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	status, stdout, _ = run(t, `{"Orgs": []}`, "org", "identity", "-f", "-", "-o", "json")
+	msg := map[string]interface{}{}
+	if status != 1 || json.Unmarshal([]byte(stdout), &msg) != nil || msg["code"] != "invalid_request" {
+		t.Fatalf("unexpected output %d %q", status, stdout)
+	}
+	if status, _, stderr := run(t, "", "jobs", "get", "unknown"); status != 1 || !strings.Contains(stderr, "error not_found") {
+		t.Fatalf("unexpected output %d %q", status, stderr)
+	}
+
+	n := fabrictest.NewNetwork()
+	defer n.Stop()
+	peerAddr := startNetwork(t, n)
+	req := fmt.Sprintf(`{"Orgs": [{"OrgName": "testorg1", "OrgMSP": "testorg1", "PeerNodes": [{"Endpoint": %q}]}], "ChannelName": "clichannel"}`, peerAddr)
+	status, stdout, stderr = run(t, req, "ledger", "info", "-f", "-")
+	if status != 0 || !strings.Contains(stdout, "height:") || !strings.Contains(stdout, peerAddr) {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	status, stdout, stderr = run(t, req, "ledger", "block", "-f", "-", "-o", "json")
+	block := map[string]interface{}{}
+	if status != 0 || json.Unmarshal([]byte(stdout), &block) != nil {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	txs := block["txs"].([]interface{})
+	if len(txs) != 1 || txs[0].(map[string]interface{})["type"] != "CONFIG" {
+		t.Fatalf("unexpected block %s", stdout)
+	}
+
+	inventoryReq := fmt.Sprintf(`{"Orgs": [{"OrgName": "testorg1", "OrgMSP": "testorg1", "PeerNodes": [{"Endpoint": %q}]}]}`, peerAddr)
+	status, stdout, stderr = run(t, inventoryReq, "channel", "list", "-f", "-", "-o", "json")
+	var channels []*channel.ChannelInfo
+	if status != 0 || json.Unmarshal([]byte(stdout), &channels) != nil {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	if len(channels) != 1 || channels[0].ChannelName != "clichannel" || channels[0].Height != 1 || !reflect.DeepEqual(channels[0].Members, []string{"testorg1"}) {
+		t.Fatalf("unexpected channels %s", stdout)
+	}
+	status, stdout, stderr = run(t, req, "channel", "config", "-f", "-", "-o", "json")
+	config := &channel.ConfigGroup{}
+	if status != 0 || json.Unmarshal([]byte(stdout), config) != nil {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	app := config.Groups["Application"]
+	if app == nil || app.Groups["testorg1"] == nil || app.Policies["Admins"].Rule != "MAJORITY Admins" {
+		t.Fatalf("unexpected config %s", stdout)
+	}
+	if msp, ok := app.Groups["testorg1"].Values["MSP"].Value.(map[string]interface{}); !ok || msp["name"] != "testorg1" {
+		t.Fatalf("unexpected MSP value %v", app.Groups["testorg1"].Values["MSP"])
+	}
+	status, stdout, stderr = run(t, inventoryReq, "chaincode", "list", "-f", "-", "-o", "json")
+	var inventory []*channel.PeerChaincodes
+	if status != 0 || json.Unmarshal([]byte(stdout), &inventory) != nil {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	if len(inventory) != 1 || inventory[0].Peer != peerAddr || inventory[0].Error != "" || inventory[0].Instantiated["clichannel"] == nil {
+		t.Fatalf("unexpected inventory %s", stdout)
+	}
+}
+
+func TestOrgCerts(t *testing.T) {
+	status, stdout, stderr := run(t, "", "org", "certs", "-o", "json")
+	var orgs []*channel.OrgCerts
+	if status != 0 || json.Unmarshal([]byte(stdout), &orgs) != nil {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	if len(orgs) == 0 || orgs[0].OrgName != "testorg1" || orgs[0].NotAfter.IsZero() {
+		t.Fatalf("unexpected orgs %s", stdout)
+	}
+	kinds := map[string]bool{}
+	for _, cert := range orgs[0].Certs {
+		kinds[cert.Kind] = true
+		if cert.NotAfter.Before(orgs[0].NotAfter) {
+			t.Fatalf("%s expires before the org", cert.Name)
+		}
+	}
+	for _, kind := range []string{"ca", "tlsca", "admin", "peer", "peer-tls", "orderer", "orderer-tls"} {
+		if !kinds[kind] {
+			t.Fatalf("no %s certificate is listed: %s", kind, stdout)
+		}
+	}
+}
+
+// startNetwork starts a peer that joined clichannel, returning its address
+func startNetwork(t *testing.T, n *fabrictest.Network) string {
+	ca, err := sdk.ConstructCAFromDir(testOrgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := sdk.NewClient(ca.AdminCommonName(), "testorg1", ca.AdminMSPDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ordererAddr, err := n.StartOrderer(testOrgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerAddr, err := n.StartPeer(testOrgDir, "testorg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := &sdk.Organization{Name: "testorg1", ID: "testorg1", MSPDir: ca.MSPDir()}
+	if err := n.Bootstrap(sdk.CreateGenesisBlock(&sdk.GenesisConfig{
+		ChainID:                 sdk.DefaultSystemChainID,
+		OrdererType:             "kafka",
+		Addresses:               []string{ordererAddr},
+		KafkaBrokers:            []string{"127.0.0.1:9092"},
+		AdminsPolicy:            sdk.PolicyMajorityAdmins,
+		WritersPolicy:           sdk.PolicyAnyWriters,
+		ReadersPolicy:           sdk.PolicyAnyReaders,
+		OrdererOrganizations:    []*sdk.Organization{org},
+		ConsortiumOrganizations: []*sdk.Organization{org},
+		ConsortiumName:          sdk.DefaultConsortium,
+	})); err != nil {
+		t.Fatal(err)
+	}
+	orderer := &sdk.Endpoint{Address: ordererAddr, TLS: ca.TLSCACert(), Timeout: 3 * time.Second}
+	if err := client.CreateChannel(&sdk.ChannelConfig{
+		ChainID:       "clichannel",
+		Consortium:    sdk.DefaultConsortium,
+		AdminsPolicy:  sdk.PolicyMajorityAdmins,
+		WritersPolicy: sdk.PolicyAnyWriters,
+		ReadersPolicy: sdk.PolicyAnyReaders,
+		Organizations: []*sdk.Organization{org},
+	}, orderer); err != nil {
+		t.Fatal(err)
+	}
+	block, err := client.GetBlockByChannel("clichannel", 0, orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer := &sdk.Endpoint{Address: peerAddr, TLS: ca.TLSCACert(), Timeout: 3 * time.Second}
+	if err := client.JoinChannel("clichannel", block, []*sdk.Endpoint{peer}); err != nil {
+		t.Fatal(err)
+	}
+	return peerAddr
+}
+
+func TestRemoteAsync(t *testing.T) {
+	newInProcess(ioutil.Discard)
+	server := httptest.NewServer(beego.BeeApp.Handlers)
+	defer server.Close()
+
+	// the package does not exist, the job fails after the response
+	req := `{"Org": "testorg1", "CcTarPath": "missing.tar.gz", "CcPath": "kv", "CcName": "kv", "CcVersion": "1.0", "PeerNodes": [{"Endpoint": "127.0.0.1:7051"}]}`
+	status, stdout, stderr := run(t, req, "chaincode", "install", "-f", "-", "-async", "-server", server.URL, "-o", "json")
+	j := &jobs.Job{}
+	if status != 0 || json.Unmarshal([]byte(stdout), j) != nil || j.Status != jobs.Running || j.Operation != "/chaincode/install" {
+		t.Fatalf("unexpected output %d %q %q", status, stdout, stderr)
+	}
+	status, stdout, _ = run(t, "", "jobs", "wait", j.ID, "-interval", "10ms", "-server", server.URL)
+	if status != 1 || !strings.Contains(stdout, "status:     failed") || !strings.Contains(stdout, "missing.tar.gz") {
+		t.Fatalf("unexpected output %d %q", status, stdout)
+	}
+	status, stdout, _ = run(t, "", "jobs", "list", "-status", "failed", "-server", server.URL)
+	if status != 0 || !strings.Contains(stdout, j.ID) {
+		t.Fatalf("unexpected output %d %q", status, stdout)
+	}
+
+	// the audit record of the request is appended when the job finishes
+	s, err := audit.GetStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := s.Records(&audit.Query{Operation: "/chaincode/install"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].RequestID != j.RequestID || records[0].Outcome != audit.Failure {
+		t.Fatalf("unexpected records %+v", records)
+	}
+}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// object is a JSON object keeping the order of its fields, the order the
+// server marshals the structs in.
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// decode decodes JSON into strings, json.Numbers, bools, nil, []interface{}
+// and *objects.
+func decode(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	v, err := decodeValue(d)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+func decodeValue(d *json.Decoder) (interface{}, error) {
+	t, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case json.Delim('{'):
+		o := &object{values: make(map[string]interface{})}
+		for d.More() {
+			key, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeValue(d)
+			if err != nil {
+				return nil, err
+			}
+			o.keys = append(o.keys, key.(string))
+			o.values[key.(string)] = v
+		}
+		_, err := d.Token()
+		return o, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for d.More() {
+			v, err := decodeValue(d)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		_, err := d.Token()
+		return list, err
+	}
+	return t, nil
+}
+
+// isTable tells whether v is a list of objects, rendered as a table
+func isTable(v interface{}) bool {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+	for _, e := range list {
+		if _, ok := e.(*object); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// cell renders a value in one line, the lists of scalars joined by commas
+// and the other lists and objects in JSON.
+func cell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	case []interface{}:
+		var cells []string
+		for _, e := range v {
+			switch e.(type) {
+			case *object, []interface{}:
+				return compact(v)
+			}
+			cells = append(cells, cell(e))
+		}
+		return strings.Join(cells, ",")
+	}
+	return compact(v)
+}
+
+func compact(v interface{}) string {
+	data, _ := json.Marshal(plain(v))
+	return string(data)
+}
+
+// plain returns v with its objects as maps, for json.Marshal
+func plain(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *object:
+		m := make(map[string]interface{}, len(v.keys))
+		for _, k := range v.keys {
+			m[k] = plain(v.values[k])
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, e := range v {
+			list[i] = plain(e)
+		}
+		return list
+	}
+	return v
+}
+
+// renderTable renders a response: a list of objects as a table with a column
+// per field, an object as a field per line followed by a table for each of
+// its fields that is a list of objects, anything else on one line.
+func renderTable(w io.Writer, v interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	switch v := v.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		if !isTable(v) {
+			for _, e := range v {
+				fmt.Fprintln(tw, cell(e))
+			}
+			break
+		}
+		writeRows(tw, v)
+	case *object:
+		var tables []string
+		for _, k := range v.keys {
+			if isTable(v.values[k]) {
+				tables = append(tables, k)
+				continue
+			}
+			fmt.Fprintf(tw, "%s:\t%s\n", k, cell(v.values[k]))
+		}
+		for _, k := range tables {
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "\n%s:\n", k)
+			writeRows(tw, v.values[k].([]interface{}))
+		}
+	default:
+		fmt.Fprintln(tw, cell(v))
+	}
+	return tw.Flush()
+}
+
+// writeRows writes a list of objects with a column per field, in the order
+// the fields first appear.
+func writeRows(tw *tabwriter.Writer, list []interface{}) {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, e := range list {
+		for _, k := range e.(*object).keys {
+			if !seen[k] {
+				seen[k] = true
+				columns = append(columns, k)
+			}
+		}
+	}
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, e := range list {
+		o := e.(*object)
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = cell(o.values[c])
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+}
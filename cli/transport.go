@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"manageChain/audit"
+	"manageChain/calls"
+	"manageChain/logging"
+	_ "manageChain/routers"
+	"manageChain/storage"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// response is the response of the REST API to a request
+type response struct {
+	status      int
+	contentType string
+	body        []byte
+}
+
+// transport sends the requests of the commands
+type transport interface {
+	do(method string, path string, contentType string, body []byte) (*response, error)
+	// local tells whether the requests are served in process
+	local() bool
+}
+
+// remote sends the requests to a manageChain server
+type remote struct {
+	server string
+	client *http.Client
+}
+
+func newRemote(server string, timeout time.Duration, tlsConfig *tls.Config) *remote {
+	if !strings.Contains(server, "://") {
+		server = "http://" + server
+	}
+	client := &http.Client{Timeout: timeout}
+	if tlsConfig != nil {
+		client.Transport = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig}
+	}
+	return &remote{
+		server: strings.TrimSuffix(server, "/"),
+		client: client,
+	}
+}
+
+// newTLSConfig returns the TLS config of an https server whose certificate
+// is issued by the CAs of caFile, presenting the client certificate of
+// certFile when given. It is nil when none of the files are given.
+func newTLSConfig(caFile string, certFile string, keyFile string) (*tls.Config, error) {
+	if caFile == "" && certFile == "" {
+		return nil, nil
+	}
+	conf := &tls.Config{}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
+
+func (r *remote) do(method string, path string, contentType string, body []byte) (*response, error) {
+	req, err := http.NewRequest(method, r.server+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the response of %s %s: %s", method, path, err)
+	}
+	return &response{status: resp.StatusCode, contentType: resp.Header.Get("Content-Type"), body: data}, nil
+}
+
+func (r *remote) local() bool {
+	return false
+}
+
+// inProcess serves the requests with the routes of the server, running the
+// channel and chaincode operations against MSPDir of app.conf.
+type inProcess struct{}
+
+var setupOnce sync.Once
+
+// newInProcess sets up what the server sets up before serving, the logs
+// going to logs so the output of the command stays parseable.
+func newInProcess(logs io.Writer) *inProcess {
+	setupOnce.Do(func() {
+		logging.SetOutput(logs)
+		logging.SetLevel("", logging.LevelWarning)
+		if err := logging.Setup(); err != nil {
+			fmt.Fprintf(logs, "error setting up logging: %s\n", err)
+		}
+		if err := calls.Setup(); err != nil {
+			fmt.Fprintf(logs, "error setting up calls: %s\n", err)
+		}
+		if err := storage.Setup(); err != nil {
+			fmt.Fprintf(logs, "error setting up storage: %s\n", err)
+		}
+		// the audited routes are refused when the audit log cannot be opened
+		if err := audit.Start(); err != nil {
+			fmt.Fprintf(logs, "error starting audit: %s\n", err)
+		}
+		beego.BConfig.CopyRequestBody = true
+	})
+	return &inProcess{}
+}
+
+func (p *inProcess) do(method string, path string, contentType string, body []byte) (*response, error) {
+	req, err := http.NewRequest(method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, req)
+	return &response{status: w.Code, contentType: w.Header().Get("Content-Type"), body: w.Body.Bytes()}, nil
+}
+
+func (p *inProcess) local() bool {
+	return true
+}
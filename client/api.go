@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"io"
+	"manageChain/protocols"
+)
+
+// post sends req to path, the read-only routes being idempotent
+func (c *Client) post(ctx context.Context, path string, req interface{}, idempotent bool, result interface{}) error {
+	return c.call(ctx, &request{method: "POST", path: path, body: req, idempotent: idempotent}, result)
+}
+
+// start runs the operation of path in a job, see Wait
+func (c *Client) start(ctx context.Context, path string, req interface{}) (*protocols.Job, error) {
+	j := &protocols.Job{}
+	if err := c.post(ctx, path+"?async=true", req, false, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	return c.call(ctx, &request{method: "GET", path: path, idempotent: true}, result)
+}
+
+// download writes the response of the GET of path to w
+func (c *Client) download(ctx context.Context, path string, w io.Writer) error {
+	resp, err := c.send(ctx, &request{method: "GET", path: path, idempotent: true})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// GenCrypto generates the crypto material of orgs under MSPDir of the server
+func (c *Client) GenCrypto(ctx context.Context, req *protocols.GenCryptoRequest) error {
+	return c.post(ctx, "/gencrypto", req, false, nil)
+}
+
+// GenGenesisBlock generates the genesis block of the system channel
+func (c *Client) GenGenesisBlock(ctx context.Context, req *protocols.GenGenesisBlockRequest) error {
+	return c.post(ctx, "/gengenesisblock", req, false, nil)
+}
+
+// GenCompose generates docker-compose files, returning the files written
+func (c *Client) GenCompose(ctx context.Context, req *protocols.ComposeRequest) ([]string, error) {
+	var files []string
+	return files, c.post(ctx, "/gencompose", req, false, &files)
+}
+
+// GenManifests generates kubernetes manifests, returning the files written
+func (c *Client) GenManifests(ctx context.Context, req *protocols.ManifestRequest) ([]string, error) {
+	var files []string
+	return files, c.post(ctx, "/genmanifests", req, false, &files)
+}
+
+// GenNodeConfig generates the configuration bundles of the nodes, returning
+// the files written
+func (c *Client) GenNodeConfig(ctx context.Context, req *protocols.NodeConfigRequest) ([]string, error) {
+	var files []string
+	return files, c.post(ctx, "/gennodeconfig", req, false, &files)
+}
+
+// PlanNetwork returns the steps bringing the network to spec
+func (c *Client) PlanNetwork(ctx context.Context, spec *protocols.Spec) (*protocols.Plan, error) {
+	plan := &protocols.Plan{}
+	return plan, c.post(ctx, "/network/plan", spec, true, plan)
+}
+
+// ApplyNetwork runs the steps bringing the network to spec
+func (c *Client) ApplyNetwork(ctx context.Context, spec *protocols.Spec) (*protocols.Plan, error) {
+	plan := &protocols.Plan{}
+	return plan, c.post(ctx, "/network/apply", spec, false, plan)
+}
+
+// ApplyNetworkAsync is ApplyNetwork in a job, its result is a *protocols.Plan
+func (c *Client) ApplyNetworkAsync(ctx context.Context, spec *protocols.Spec) (*protocols.Job, error) {
+	return c.start(ctx, "/network/apply", spec)
+}
+
+// Health returns the last check of the monitored nodes, unhealthy nodes are
+// not an error.
+func (c *Client) Health(ctx context.Context) (*protocols.Summary, error) {
+	health := &protocols.Summary{}
+	return health, c.call(ctx, &request{method: "GET", path: "/health", idempotent: true, accept: []int{503}}, health)
+}
+
+// HA returns whether the server is the leader of its replicas, and the leader
+func (c *Client) HA(ctx context.Context) (*protocols.HAStatus, error) {
+	st := &protocols.HAStatus{}
+	return st, c.get(ctx, "/ha", st)
+}
+
+// Metrics writes the metrics of the server in the Prometheus text format to w
+func (c *Client) Metrics(ctx context.Context, w io.Writer) error {
+	return c.download(ctx, "/metrics", w)
+}
+
+// OpenAPI writes the OpenAPI document of the REST API to w
+func (c *Client) OpenAPI(ctx context.Context, w io.Writer) error {
+	return c.download(ctx, "/openapi.json", w)
+}
+
+// QueryAudit returns the audit records matching q
+func (c *Client) QueryAudit(ctx context.Context, q *protocols.AuditQuery) ([]*protocols.AuditRecord, error) {
+	var records []*protocols.AuditRecord
+	return records, c.post(ctx, "/audit/query", q, true, &records)
+}
+
+// ExportAudit writes the audit log as it is stored to w
+func (c *Client) ExportAudit(ctx context.Context, w io.Writer) error {
+	return c.download(ctx, "/audit/export", w)
+}
+
+// VerifyAudit verifies the audit log, the problems found are in the result
+func (c *Client) VerifyAudit(ctx context.Context) (*protocols.AuditVerification, error) {
+	v := &protocols.AuditVerification{}
+	return v, c.get(ctx, "/audit/verify", v)
+}
+
+// Orgs returns the orgs under MSPDir of the server with their certificates
+func (c *Client) Orgs(ctx context.Context) ([]*protocols.OrgCerts, error) {
+	var orgs []*protocols.OrgCerts
+	return orgs, c.get(ctx, "/orgs", &orgs)
+}
+
+// Channels returns the channels the peers of the first org have joined
+func (c *Client) Channels(ctx context.Context, req *protocols.InventoryRequest) ([]*protocols.ChannelInfo, error) {
+	var channels []*protocols.ChannelInfo
+	return channels, c.post(ctx, "/channel/list", req, true, &channels)
+}
+
+// ChannelConfig returns the current config of a channel
+func (c *Client) ChannelConfig(ctx context.Context, req *protocols.ChannelRequest) (*protocols.ConfigGroup, error) {
+	config := &protocols.ConfigGroup{}
+	return config, c.post(ctx, "/channel/config", req, true, config)
+}
+
+// Identity returns the identity code the first org joins channels with
+func (c *Client) Identity(ctx context.Context, req *protocols.IdentityRequest) (*protocols.IdentityCode, error) {
+	code := &protocols.IdentityCode{}
+	return code, c.post(ctx, "/channel/identity", req, true, code)
+}
+
+// AddOrg adds an org to a channel
+func (c *Client) AddOrg(ctx context.Context, req *protocols.AddOrgRequest) error {
+	return c.post(ctx, "/channel/addorg", req, false, nil)
+}
+
+// AddOrgAsync is AddOrg in a job
+func (c *Client) AddOrgAsync(ctx context.Context, req *protocols.AddOrgRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/addorg", req)
+}
+
+// DeleteOrg removes an org from a channel
+func (c *Client) DeleteOrg(ctx context.Context, req *protocols.DeleteOrgRequest) error {
+	return c.post(ctx, "/channel/deleteorg", req, false, nil)
+}
+
+// DeleteOrgAsync is DeleteOrg in a job
+func (c *Client) DeleteOrgAsync(ctx context.Context, req *protocols.DeleteOrgRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/deleteorg", req)
+}
+
+// CreateChannel creates a channel
+func (c *Client) CreateChannel(ctx context.Context, req *protocols.NewCreateChannelRequest) error {
+	return c.post(ctx, "/channel/create", req, false, nil)
+}
+
+// CreateChannelAsync is CreateChannel in a job
+func (c *Client) CreateChannelAsync(ctx context.Context, req *protocols.NewCreateChannelRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/create", req)
+}
+
+// JoinChannel joins the peers of orgs to a channel
+func (c *Client) JoinChannel(ctx context.Context, req *protocols.JoinChannelRequest) error {
+	return c.post(ctx, "/channel/join", req, false, nil)
+}
+
+// JoinChannelAsync is JoinChannel in a job
+func (c *Client) JoinChannelAsync(ctx context.Context, req *protocols.JoinChannelRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/join", req)
+}
+
+// BootstrapPublicChain creates the public chain and deploys the public chaincode
+func (c *Client) BootstrapPublicChain(ctx context.Context, req *protocols.BootstrapRequest) error {
+	return c.post(ctx, "/channel/bootstrap", req, false, nil)
+}
+
+// BootstrapPublicChainAsync is BootstrapPublicChain in a job
+func (c *Client) BootstrapPublicChainAsync(ctx context.Context, req *protocols.BootstrapRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/bootstrap", req)
+}
+
+// UpdateChainOrgInfo publishes the nodes of orgs to the public chain
+func (c *Client) UpdateChainOrgInfo(ctx context.Context, req *protocols.UpdateChainOrgInfoRequest) error {
+	return c.post(ctx, "/channel/orginfo", req, false, nil)
+}
+
+// UpdateChainOrgInfoAsync is UpdateChainOrgInfo in a job
+func (c *Client) UpdateChainOrgInfoAsync(ctx context.Context, req *protocols.UpdateChainOrgInfoRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/orginfo", req)
+}
+
+// ProposeRemoval starts a vote on removing an org from a channel
+func (c *Client) ProposeRemoval(ctx context.Context, req *protocols.ProposeRemovalRequest) error {
+	return c.post(ctx, "/channel/removal/propose", req, false, nil)
+}
+
+// ProposeRemovalAsync is ProposeRemoval in a job
+func (c *Client) ProposeRemovalAsync(ctx context.Context, req *protocols.ProposeRemovalRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/removal/propose", req)
+}
+
+// VoteRemoval votes on a removal, executing it once approved
+func (c *Client) VoteRemoval(ctx context.Context, req *protocols.VoteRemovalRequest) (*protocols.RemovalProposal, error) {
+	proposal := &protocols.RemovalProposal{}
+	return proposal, c.post(ctx, "/channel/removal/vote", req, false, proposal)
+}
+
+// VoteRemovalAsync is VoteRemoval in a job, its result is a *protocols.RemovalProposal
+func (c *Client) VoteRemovalAsync(ctx context.Context, req *protocols.VoteRemovalRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/removal/vote", req)
+}
+
+// GetRemoval returns a removal proposal with its votes
+func (c *Client) GetRemoval(ctx context.Context, req *protocols.RemovalRequest) (*protocols.RemovalProposal, error) {
+	proposal := &protocols.RemovalProposal{}
+	return proposal, c.post(ctx, "/channel/removal/get", req, true, proposal)
+}
+
+// Removals returns the removals proposed of the orgs of a channel
+func (c *Client) Removals(ctx context.Context, req *protocols.ChannelRequest) ([]*protocols.RemovalProposal, error) {
+	var proposals []*protocols.RemovalProposal
+	return proposals, c.post(ctx, "/channel/removal/list", req, true, &proposals)
+}
+
+// ExecuteRemoval applies an approved removal or retries a failed one
+func (c *Client) ExecuteRemoval(ctx context.Context, req *protocols.RemovalRequest) (*protocols.RemovalResult, error) {
+	result := &protocols.RemovalResult{}
+	return result, c.post(ctx, "/channel/removal/execute", req, false, result)
+}
+
+// ExecuteRemovalAsync is ExecuteRemoval in a job, its result is a *protocols.RemovalResult
+func (c *Client) ExecuteRemovalAsync(ctx context.Context, req *protocols.RemovalRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/channel/removal/execute", req)
+}
+
+// QueryOrgInfo returns a page of the orgs of the public chain
+func (c *Client) QueryOrgInfo(ctx context.Context, req *protocols.QueryPageRequest) (*protocols.OrgInfoPage, error) {
+	page := &protocols.OrgInfoPage{}
+	return page, c.post(ctx, "/public/orginfo", req, true, page)
+}
+
+// QueryOrgname returns a page of the names of the orgs of the public chain
+func (c *Client) QueryOrgname(ctx context.Context, req *protocols.QueryPageRequest) (*protocols.OrgnamePage, error) {
+	page := &protocols.OrgnamePage{}
+	return page, c.post(ctx, "/public/orgname", req, true, page)
+}
+
+// QueryInvitation returns a page of the invitations of the public chain
+func (c *Client) QueryInvitation(ctx context.Context, req *protocols.QueryPageRequest) (*protocols.InvitationPage, error) {
+	page := &protocols.InvitationPage{}
+	return page, c.post(ctx, "/public/invitation", req, true, page)
+}
+
+// InstallChaincode installs a chaincode on peers
+func (c *Client) InstallChaincode(ctx context.Context, req *protocols.InstallChaincodeRequest) error {
+	return c.post(ctx, "/chaincode/install", req, false, nil)
+}
+
+// InstallChaincodeAsync is InstallChaincode in a job
+func (c *Client) InstallChaincodeAsync(ctx context.Context, req *protocols.InstallChaincodeRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/chaincode/install", req)
+}
+
+// InstantiateChaincode instantiates a chaincode on a channel
+func (c *Client) InstantiateChaincode(ctx context.Context, req *protocols.InstantiateChaincodeRequest) error {
+	return c.post(ctx, "/chaincode/instantiate", req, false, nil)
+}
+
+// InstantiateChaincodeAsync is InstantiateChaincode in a job
+func (c *Client) InstantiateChaincodeAsync(ctx context.Context, req *protocols.InstantiateChaincodeRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/chaincode/instantiate", req)
+}
+
+// Invoke invokes a chaincode
+func (c *Client) Invoke(ctx context.Context, req *protocols.InvokeRequest) error {
+	return c.post(ctx, "/chaincode/invoke", req, false, nil)
+}
+
+// InvokeAsync is Invoke in a job
+func (c *Client) InvokeAsync(ctx context.Context, req *protocols.InvokeRequest) (*protocols.Job, error) {
+	return c.start(ctx, "/chaincode/invoke", req)
+}
+
+// Chaincodes returns the chaincodes installed on the peers of orgs and those
+// instantiated on the channels they have joined
+func (c *Client) Chaincodes(ctx context.Context, req *protocols.InventoryRequest) ([]*protocols.PeerChaincodes, error) {
+	var inventory []*protocols.PeerChaincodes
+	return inventory, c.post(ctx, "/chaincode/list", req, true, &inventory)
+}
+
+// LedgerInfo returns the height of the ledger of a channel
+func (c *Client) LedgerInfo(ctx context.Context, req *protocols.LedgerRequest) (*protocols.LedgerInfo, error) {
+	info := &protocols.LedgerInfo{}
+	return info, c.post(ctx, "/ledger/info", req, true, info)
+}
+
+// Block returns a block of a channel with its transactions
+func (c *Client) Block(ctx context.Context, req *protocols.LedgerRequest) (*protocols.BlockInfo, error) {
+	block := &protocols.BlockInfo{}
+	return block, c.post(ctx, "/ledger/block", req, true, block)
+}
+
+// Transaction returns a transaction of a channel with its validation code
+func (c *Client) Transaction(ctx context.Context, req *protocols.LedgerRequest) (*protocols.TxInfo, error) {
+	tx := &protocols.TxInfo{}
+	return tx, c.post(ctx, "/ledger/tx", req, true, tx)
+}
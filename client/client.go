@@ -0,0 +1,292 @@
+// Package client is the Go client of the REST API of manageChain. Its
+// methods take and return the types of the channel, chaincode and other
+// packages the server marshals, every call is bounded by a context.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"manageChain/protocols"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls a manageChain server, it is safe for concurrent use
+type Client struct {
+	// Server is the URL of the server, e.g. http://127.0.0.1:8080
+	Server string
+	// Token is sent as a bearer token in the Authorization header when not
+	// empty, e.g. for a server behind an authenticating proxy
+	Token string
+	// Timeout bounds each attempt of a call when not zero, the context of
+	// the call bounds all of them
+	Timeout time.Duration
+	// Retries is the number of times an idempotent call is retried when the
+	// server cannot be reached or answers unavailable or timeout
+	Retries int
+	// RetryBackoff is the wait before the first retry, doubled for each one
+	RetryBackoff time.Duration
+	HTTPClient   *http.Client
+}
+
+// defaults of New
+const (
+	DefaultRetries      = 2
+	DefaultRetryBackoff = 500 * time.Millisecond
+)
+
+// New returns a client of the server of url, retrying the idempotent calls
+// DefaultRetries times.
+func New(url string) *Client {
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	return &Client{
+		Server:       strings.TrimSuffix(url, "/"),
+		Retries:      DefaultRetries,
+		RetryBackoff: DefaultRetryBackoff,
+		HTTPClient:   http.DefaultClient,
+	}
+}
+
+// Error is a call the server answered with an error, StatusCode is 0 for
+// the error of a job.
+type Error struct {
+	StatusCode int
+	protocols.ErrorMessage
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsCode tells whether err is an Error of code, one of the protocols.Code constants
+func IsCode(err error, code string) bool {
+	e, ok := err.(*Error)
+	return ok && e.Code == code
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose calls send id as their correlation
+// ID, the logs and the audit records of the server carry it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// CallOptions override for the calls of a context the timeouts, retries
+// and failover order the server calls the nodes with, the zero ones being
+// left as configured in its app.conf.
+type CallOptions struct {
+	// Timeouts are by operation, one of the calls.Op constants
+	Timeouts map[string]time.Duration
+	// Retries is the number of times the nodes are tried again after all
+	// of them timed out or were unavailable, when not nil
+	Retries      *int
+	RetryBackoff time.Duration
+	// Failover is one of the calls.Failover constants
+	Failover string
+}
+
+// query returns the query parameters of o
+func (o *CallOptions) query() url.Values {
+	query := url.Values{}
+	var timeouts []string
+	for op, timeout := range o.Timeouts {
+		timeouts = append(timeouts, op+"="+timeout.String())
+	}
+	if len(timeouts) != 0 {
+		sort.Strings(timeouts)
+		query.Set("timeouts", strings.Join(timeouts, ","))
+	}
+	if o.Retries != nil {
+		query.Set("retries", strconv.Itoa(*o.Retries))
+	}
+	if o.RetryBackoff != 0 {
+		query.Set("retryBackoff", o.RetryBackoff.String())
+	}
+	if o.Failover != "" {
+		query.Set("failover", o.Failover)
+	}
+	return query
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context whose calls have the server call the
+// nodes with opts.
+func WithCallOptions(ctx context.Context, opts *CallOptions) context.Context {
+	return context.WithValue(ctx, callOptionsKey{}, opts)
+}
+
+// request is a call to the REST API
+type request struct {
+	method string
+	path   string
+	// body is marshalled to JSON unless it is raw bytes
+	body        interface{}
+	contentType string
+	// idempotent calls are retried
+	idempotent bool
+	// accept are statuses whose response is the result besides the 2xx ones
+	accept []int
+}
+
+func (r *request) accepts(status int) bool {
+	if status < 300 {
+		return true
+	}
+	for _, s := range r.accept {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// call sends r and unmarshals the JSON response into result, if not nil
+func (c *Client) call(ctx context.Context, r *request, result interface{}) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result == nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("error parsing the response of %s %s: %s", r.method, r.path, err)
+	}
+	return nil
+}
+
+// send sends r, retrying it when it is idempotent, and returns the response
+// when it succeeded, an *Error when the server answered with an error.
+func (c *Client) send(ctx context.Context, r *request) (*http.Response, error) {
+	var body []byte
+	contentType := r.contentType
+	switch b := r.body.(type) {
+	case nil:
+	case []byte:
+		body = b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		body, contentType = data, "application/json"
+	}
+
+	backoff := c.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(ctx, r.method, r.path, contentType, body)
+		if err == nil && r.accepts(resp.StatusCode) {
+			return resp, nil
+		}
+		if err == nil {
+			err = readError(resp)
+		}
+		if !r.idempotent || attempt >= c.Retries || !retryable(ctx, err) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, method string, path string, contentType string, body []byte) (*http.Response, error) {
+	cancel := func() {}
+	if c.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+	}
+	if opts, ok := ctx.Value(callOptionsKey{}).(*CallOptions); ok {
+		if query := opts.query(); len(query) != 0 {
+			sep := "?"
+			if strings.Contains(path, "?") {
+				sep = "&"
+			}
+			path += sep + query.Encode()
+		}
+	}
+	req, err := http.NewRequest(method, c.Server+path, bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		req.Header.Set(protocols.RequestIDHeader, id)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody cancels the timeout of an attempt once its body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// readError returns the Error of a response that is not successful
+func readError(resp *http.Response) error {
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	e := &Error{StatusCode: resp.StatusCode}
+	if err != nil || json.Unmarshal(data, &e.ErrorMessage) != nil || e.Code == "" {
+		e.Code = protocols.CodeInternal
+		e.Message = strings.TrimSpace(string(data))
+		if e.Message == "" {
+			e.Message = resp.Status
+		}
+	}
+	return e
+}
+
+// retryable tells whether a call failing with err may succeed when retried:
+// the server could not be reached, is unavailable or a node timed out.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if e, ok := err.(*Error); ok {
+		return e.Code == protocols.CodeUnavailable || e.Code == protocols.CodeTimeout
+	}
+	return true
+}
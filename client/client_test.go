@@ -0,0 +1,253 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"manageChain/audit"
+	"manageChain/channel"
+	"manageChain/deploy"
+	"manageChain/openapi"
+	"manageChain/protocols"
+	_ "manageChain/routers"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// startServer serves the REST API with an audit store in a temporary directory
+func startServer(t *testing.T) (*httptest.Server, func()) {
+	dir, err := ioutil.TempDir("", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := audit.Open(sdk.NewFileStorage(dir), "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	audit.SetStore(s)
+	beego.BConfig.CopyRequestBody = true
+	beego.AppConfig.Set("MSPDir", "../msp")
+	server := httptest.NewServer(beego.BeeApp.Handlers)
+	return server, func() {
+		server.Close()
+		audit.SetStore(nil)
+		s.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCalls(t *testing.T) {
+	server, stop := startServer(t)
+	defer stop()
+	c := New(server.URL)
+	ctx := context.Background()
+
+	buf := &bytes.Buffer{}
+	if err := c.OpenAPI(ctx, buf); err != nil {
+		t.Fatal(err)
+	}
+	doc := &openapi.Document{}
+	if err := json.Unmarshal(buf.Bytes(), doc); err != nil || doc.Operation("POST", "/channel/create") == nil {
+		t.Fatalf("unexpected document: %v", err)
+	}
+	_, err := c.Identity(ctx, &protocols.IdentityRequest{})
+	e, ok := err.(*Error)
+	if !ok || e.StatusCode != 400 || !IsCode(err, protocols.CodeInvalidRequest) || len(e.Fields) != 1 || e.Fields[0].Field != "Orgs" {
+		t.Fatalf("unexpected error %#v", err)
+	}
+	if _, err := c.Job(ctx, "unknown"); !IsCode(err, protocols.CodeNotFound) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the package does not exist, the job fails after the response
+	j, err := c.InstallChaincodeAsync(WithRequestID(ctx, "clienttest"), &protocols.InstallChaincodeRequest{
+		Org:       "testorg1",
+		CcTarPath: "missing.tar.gz",
+		CcPath:    "kv",
+		CcName:    "kv",
+		CcVersion: "1.0",
+		PeerNodes: []*protocols.ServiceNode{{Endpoint: "127.0.0.1:7051"}},
+	})
+	if err != nil || j.RequestID != "clienttest" || j.Operation != "/chaincode/install" {
+		t.Fatalf("unexpected job %+v: %v", j, err)
+	}
+	if err := c.Wait(ctx, j, 10*time.Millisecond, nil); !IsCode(err, protocols.CodeInternal) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	list, err := c.Jobs(ctx, "failed")
+	if err != nil || len(list) == 0 || list[0].ID != j.ID {
+		t.Fatalf("unexpected jobs %+v: %v", list, err)
+	}
+	records, err := c.QueryAudit(ctx, &protocols.AuditQuery{Operation: "/chaincode/install"})
+	if err != nil || len(records) != 1 || records[0].RequestID != "clienttest" {
+		t.Fatalf("unexpected records %+v: %v", records, err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get(protocols.RequestIDHeader) != "retried" {
+			w.WriteHeader(401)
+			return
+		}
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(503)
+			w.Write([]byte(`{"code": "unavailable", "message": "audit log is unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"chainId": "mychannel", "target": "org2"}`))
+	}))
+	defer server.Close()
+	c := New(server.URL)
+	c.Token = "secret"
+	c.RetryBackoff = time.Millisecond
+	ctx := WithRequestID(context.Background(), "retried")
+
+	proposal, err := c.GetRemoval(ctx, &protocols.RemovalRequest{})
+	if err != nil || proposal.Target != "org2" || attempts != 3 {
+		t.Fatalf("unexpected proposal %+v after %d attempts: %v", proposal, attempts, err)
+	}
+	// the calls that change the network are not retried
+	attempts = 0
+	if _, err := c.ExecuteRemoval(ctx, &protocols.RemovalRequest{}); !IsCode(err, protocols.CodeUnavailable) || attempts != 1 {
+		t.Fatalf("unexpected error %v after %d attempts", err, attempts)
+	}
+	attempts = 0
+	c.Retries = 1
+	if _, err := c.GetRemoval(ctx, &protocols.RemovalRequest{}); !IsCode(err, protocols.CodeUnavailable) || attempts != 2 {
+		t.Fatalf("unexpected error %v after %d attempts", err, attempts)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(503)
+			w.Write([]byte(`{"Healthy": false}`))
+			return
+		}
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	c := New(server.URL)
+	c.Retries = 0
+
+	// unhealthy nodes are not an error of the call
+	if health, err := c.Health(context.Background()); err != nil || health.Healthy {
+		t.Fatalf("unexpected health %+v: %v", health, err)
+	}
+	c.Timeout = 50 * time.Millisecond
+	if _, err := c.LedgerInfo(context.Background(), &protocols.LedgerRequest{}); err == nil {
+		t.Fatal("a call should time out")
+	}
+	c.Timeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Block(ctx, &protocols.LedgerRequest{}); err == nil {
+		t.Fatal("a call should end with its context")
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"id": "1", "status": "running"}`))
+	}))
+	defer server.Close()
+	c := New(server.URL)
+
+	retries := 0
+	ctx := WithCallOptions(context.Background(), &CallOptions{
+		Timeouts: map[string]time.Duration{"waittx": 30 * time.Second, "install": 2 * time.Minute},
+		Retries:  &retries,
+		Failover: "random",
+	})
+	if _, err := c.InstallChaincodeAsync(ctx, &protocols.InstallChaincodeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if query != "async=true&failover=random&retries=0&timeouts=install%3D2m0s%2Cwaittx%3D30s" {
+		t.Fatalf("unexpected query %s", query)
+	}
+}
+
+// shape describes the JSON encoding of t, leaving out the fields of the
+// server only, the crypto material and client of the orgs
+func shape(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return shape(t.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return "[]" + shape(t.Elem())
+	case reflect.Map:
+		return "map[" + shape(t.Key()) + "]" + shape(t.Elem())
+	case reflect.Struct:
+		if t.PkgPath() == "time" {
+			return t.String()
+		}
+		var fields []string
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" || f.Name == "OrgCA" || f.Name == "Client" {
+				continue
+			}
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = f.Name
+			}
+			fields = append(fields, name+":"+shape(f.Type))
+		}
+		sort.Strings(fields)
+		return "{" + strings.Join(fields, ",") + "}"
+	}
+	return t.Kind().String()
+}
+
+// TestWireTypes checks that the types of the client encode the requests as
+// the types the server decodes them into
+func TestWireTypes(t *testing.T) {
+	for _, types := range [][2]interface{}{
+		{&protocols.GenCryptoRequest{}, &channel.GenCryptoRequest{}},
+		{&protocols.GenGenesisBlockRequest{}, &channel.GenGenesisBlockRequest{}},
+		{&protocols.NewCreateChannelRequest{}, &channel.NewCreateChannelRequest{}},
+		{&protocols.BootstrapRequest{}, &channel.BootstrapRequest{}},
+		{&protocols.JoinChannelRequest{}, &channel.JoinChannelRequest{}},
+		{&protocols.UpdateChainOrgInfoRequest{}, &channel.UpdateChainOrgInfoRequest{}},
+		{&protocols.IdentityRequest{}, &channel.IdentityRequest{}},
+		{&protocols.IdentityCode{}, &channel.IdentityCode{}},
+		{&protocols.AddOrgRequest{}, &channel.AddOrgRequest{}},
+		{&protocols.DeleteOrgRequest{}, &channel.DeleteOrgRequest{}},
+		{&protocols.ProposeRemovalRequest{}, &channel.ProposeRemovalRequest{}},
+		{&protocols.VoteRemovalRequest{}, &channel.VoteRemovalRequest{}},
+		{&protocols.RemovalRequest{}, &channel.RemovalRequest{}},
+		{&protocols.LedgerRequest{}, &channel.LedgerRequest{}},
+		{&protocols.InventoryRequest{}, &channel.InventoryRequest{}},
+		{&protocols.ChannelRequest{}, &channel.ChannelRequest{}},
+		{&protocols.QueryPageRequest{}, &channel.QueryPageRequest{}},
+		{&protocols.ComposeRequest{}, &deploy.ComposeRequest{}},
+		{&protocols.NodeConfigRequest{}, &deploy.NodeConfigRequest{}},
+		{&protocols.ManifestRequest{}, &deploy.ManifestRequest{}},
+	} {
+		client, server := reflect.TypeOf(types[0]), reflect.TypeOf(types[1])
+		if shape(client) != shape(server) {
+			t.Errorf("%s is sent as\n%s\nbut decoded from\n%s", server, shape(client), shape(server))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"manageChain/protocols"
+	"net/url"
+	"time"
+)
+
+// DefaultPollInterval is the period WaitJob polls at when none is given
+const DefaultPollInterval = 2 * time.Second
+
+// Jobs returns the jobs of status, all of them when it is empty, the most
+// recent first.
+func (c *Client) Jobs(ctx context.Context, status string) ([]*protocols.Job, error) {
+	path := "/jobs"
+	if status != "" {
+		path += "?status=" + url.QueryEscape(status)
+	}
+	var list []*protocols.Job
+	return list, c.get(ctx, path, &list)
+}
+
+// Job returns the job id
+func (c *Client) Job(ctx context.Context, id string) (*protocols.Job, error) {
+	j := &protocols.Job{}
+	return j, c.get(ctx, "/jobs/"+url.PathEscape(id), j)
+}
+
+// WaitJob polls the job id every interval until it is finished or ctx is
+// done, and returns it.
+func (c *Client) WaitJob(ctx context.Context, id string, interval time.Duration) (*protocols.Job, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		j, err := c.Job(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if j.Done() {
+			return j, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Result returns the error of a failed job as an *Error, or unmarshals the
+// result of a succeeded one into result, if not nil, e.g. a
+// *protocols.Plan for ApplyNetworkAsync.
+func Result(j *protocols.Job, result interface{}) error {
+	if j.Error != nil {
+		return &Error{ErrorMessage: *j.Error}
+	}
+	if result == nil || j.Result == nil {
+		return nil
+	}
+	data, err := json.Marshal(j.Result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// Wait waits for the job j started and returns its outcome as Result does
+func (c *Client) Wait(ctx context.Context, j *protocols.Job, interval time.Duration, result interface{}) error {
+	done, err := c.WaitJob(ctx, j.ID, interval)
+	if err != nil {
+		return err
+	}
+	return Result(done, result)
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"encoding/json"
+	"manageChain/audit"
+)
+
+type AuditController struct {
+	BaseController
+}
+
+// Query returns the records of the administrative requests matching the query
+func (c *AuditController) Query() error {
+	q := &audit.Query{}
+	if len(c.Ctx.Input.RequestBody) != 0 {
+		if err := json.Unmarshal(c.Ctx.Input.RequestBody, q); err != nil {
+			c.ReturnErrorMsg(err)
+			return nil
+		}
+	}
+	s, err := audit.GetStore()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	records, err := s.Records(q)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(records)
+	return nil
+}
+
+// Export serves the audit log as it is stored, one JSON record per line
+func (c *AuditController) Export() error {
+	s, err := audit.GetStore()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.Ctx.Output.Header("Content-Type", "application/x-ndjson")
+	c.Ctx.Output.Header("Content-Disposition", "attachment; filename=audit.log")
+	if err := s.Export(c.Ctx.ResponseWriter); err != nil {
+		c.log().Error("Error exporting audit log: %s", err)
+	}
+	return nil
+}
+
+// Verify verifies the audit log, the problems found are in the result
+func (c *AuditController) Verify() error {
+	s, err := audit.GetStore()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	v, err := s.Verify()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(v)
+	return nil
+}
@@ -2,33 +2,95 @@ package controllers
 
 import (
 	// "fmt"
+	"context"
+	"manageChain/audit"
+	"manageChain/calls"
+	"manageChain/jobs"
+	"manageChain/logging"
+	"manageChain/openapi"
 	"manageChain/protocols"
 
 	"github.com/astaxie/beego"
-	logger "github.com/astaxie/beego/logs"
 )
 
+var logger = logging.GetLogger("controllers")
+
 type BaseController struct {
 	beego.Controller
+	ctx context.Context
+}
+
+// Prepare validates the request against the OpenAPI document of its route,
+// responding 400 with the invalid fields instead of running the action.
+func (c *BaseController) Prepare() {
+	pattern, _ := c.Ctx.Input.GetData("RouterPattern").(string)
+	msg := openapi.ValidateRequest(c.Ctx.Input.Method(), pattern, c.Ctx.Input.RequestBody)
+	if msg == nil {
+		msg = c.prepareCalls()
+	}
+	if msg == nil {
+		return
+	}
+	c.log().Warning("Invalid request to %s: %s", pattern, msg.Message)
+	audit.SetError(c.Ctx, msg.Message)
+	c.Ctx.Output.SetStatus(errorStatus(msg.Code))
+	c.Data["json"] = msg
+	c.ServeJSON()
+}
+
+// prepareCalls sets the context of the calls made for the request, with the
+// options of app.conf overridden by the query parameters of the request. It
+// is canceled when the client goes away, or for a request running in a job
+// when the server aborts the jobs.
+func (c *BaseController) prepareCalls() *protocols.ErrorMessage {
+	opts, err := calls.Default().Parse(c.GetString("timeouts"), c.GetString("retries"), c.GetString("retryBackoff"), c.GetString("failover"))
+	if err != nil {
+		return &protocols.ErrorMessage{Code: protocols.CodeInvalidRequest, Message: err.Error()}
+	}
+	ctx := c.Ctx.Request.Context()
+	if async, _ := c.GetBool("async"); async {
+		ctx = audit.WithRecordOf(logging.WithRequestID(jobs.Context(), logging.RequestID(ctx)), ctx)
+	}
+	c.ctx = calls.WithOptions(ctx, opts)
+	return nil
+}
+
+// callContext returns the context of the calls made for the request
+func (c *BaseController) callContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
 }
 
+// log returns the logger of the request, its logs carry its correlation ID
+func (c *BaseController) log() *logging.Logger {
+	if c.ctx == nil {
+		return logger.WithContext(c.Ctx.Request.Context())
+	}
+	return logger.WithContext(c.ctx)
+}
+
+// ReturnErrorCode returns msg with code, one of the protocols.Code constants,
+// and the HTTP status of code.
 func (c *BaseController) ReturnErrorCode(code string, msg string) {
-	logger.Error("Code: ", code)
-	c.Ctx.Output.SetStatus(500)
-	c.Data["json"] = &protocols.ErrorMessage{
+	c.returnError(&protocols.ErrorMessage{
 		Code:    code,
 		Message: msg,
-	}
-	c.ServeJSON()
+	})
 }
 
-// ReturnErrorMsg return given message to the front end
+// ReturnErrorMsg return given message to the front end, with the code and
+// HTTP status its type tells.
 func (c *BaseController) ReturnErrorMsg(err error) {
-	logger.Error("Got error: ", err)
-	c.Ctx.Output.SetStatus(500)
-	c.Data["json"] = &protocols.ErrorMessage{
-		Message: err.Error(),
-	}
+	c.returnError(errorMessage(err))
+}
+
+func (c *BaseController) returnError(msg *protocols.ErrorMessage) {
+	c.log().Error("Got error %s: %s", msg.Code, msg.Message)
+	audit.SetError(c.Ctx, msg.Message)
+	c.Ctx.Output.SetStatus(errorStatus(msg.Code))
+	c.Data["json"] = msg
 	c.ServeJSON()
 }
 
@@ -38,3 +100,35 @@ func (c *BaseController) ReturnOKMsg(data interface{}) {
 	c.Data["json"] = data
 	c.ServeJSON()
 }
+
+// run runs action and returns its result. When the request asks for it with
+// async=true, action runs in a job instead, the job is returned with 202 and
+// the audit record of the request is appended when the job finishes. action
+// must not use the controller, it may run after the response.
+func (c *BaseController) run(action func() (interface{}, error)) {
+	if async, _ := c.GetBool("async"); !async {
+		result, err := action()
+		if err != nil {
+			c.ReturnErrorMsg(err)
+			return
+		}
+		c.ReturnOKMsg(result)
+		return
+	}
+	operation, _ := c.Ctx.Input.GetData("RouterPattern").(string)
+	finish := audit.Detach(c.Ctx)
+	job := jobs.Submit(operation, logging.RequestID(c.callContext()), func() (interface{}, *protocols.ErrorMessage) {
+		result, err := action()
+		if err != nil {
+			msg := errorMessage(err)
+			c.log().Error("Job of %s got error %s: %s", operation, msg.Code, msg.Message)
+			finish(errorStatus(msg.Code), msg.Message)
+			return nil, msg
+		}
+		finish(200, "")
+		return result, nil
+	})
+	c.Ctx.Output.SetStatus(202)
+	c.Data["json"] = job
+	c.ServeJSON()
+}
@@ -1,14 +1,15 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
+	"manageChain/calls"
 	"manageChain/chaincode"
 	"manageChain/channel"
 	"path"
 	"time"
 
 	"github.com/astaxie/beego"
-	logger "github.com/astaxie/beego/logs"
 	"github.com/hyperledger/fabric/sdk"
 )
 
@@ -17,7 +18,7 @@ type ChaincodeController struct {
 }
 
 func (c *ChaincodeController) InstallChaincode() error {
-	logger.Info("start Install Chaincode")
+	c.log().Info("start Install Chaincode")
 
 	icq := &chaincode.InstallChaincodeRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, icq)
@@ -32,41 +33,44 @@ func (c *ChaincodeController) InstallChaincode() error {
 	ccName := icq.CcName
 	ccVersion := icq.CcVersion
 
-	newchaincode, err := newChaincode(org, ccTarPath, ccPath, ccName, ccVersion)
+	newchaincode, err := newChaincode(c.callContext(), org, ccTarPath, ccPath, ccName, ccVersion)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
 
 	orgCA := newchaincode.GetOrgCA()
-	endorsers := serviceNodesToEndpointList(icq.PeerNodes, chaincode.InstallChaincodeTimeout, orgCA.TLSCACert())
-
-	err = newchaincode.InstallChaincode(endorsers)
-	if err != nil {
-		c.ReturnErrorMsg(err)
-		return nil
-	}
-
-	c.ReturnOKMsg("OK")
-	logger.Info("successfully Install Chaincode")
+	endorsers := serviceNodesToEndpointList(icq.PeerNodes, calls.Timeout(c.callContext(), calls.OpInstall), orgCA.TLSCACert())
+
+	c.run(func() (interface{}, error) {
+		if err := newchaincode.InstallChaincode(endorsers); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully Install Chaincode")
+		return "OK", nil
+	})
 	return nil
 }
 
-func newChaincode(org string, ccTarPath string, ccPath string, ccName string, ccVersion string) (*chaincode.Chaincode, error) {
+func newChaincode(ctx context.Context, org string, ccTarPath string, ccPath string, ccName string, ccVersion string) (*chaincode.Chaincode, error) {
 	mspDir := beego.AppConfig.String("MSPDir")
 	gm, _ := beego.AppConfig.Bool("GM")
 
 	orgCA, err := channel.GetCA(path.Join(mspDir, org), org)
 	if err != nil {
-		logger.Error("Error getting peer ca", err)
+		logger.WithContext(ctx).Error("Error getting peer ca: %s", err)
 		return nil, err
 	}
 
-	return chaincode.NewChaincode(org, ccTarPath, ccPath, ccName, ccVersion, orgCA, gm)
+	cc, err := chaincode.NewChaincode(org, ccTarPath, ccPath, ccName, ccVersion, orgCA, gm)
+	if err != nil {
+		return nil, err
+	}
+	return cc.WithContext(ctx), nil
 }
 
 func (c *ChaincodeController) InstantiateChaincode() error {
-	logger.Info("start Instantiate Chaincode")
+	c.log().Info("start Instantiate Chaincode")
 
 	icq := &chaincode.InstantiateChaincodeRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, icq)
@@ -80,7 +84,7 @@ func (c *ChaincodeController) InstantiateChaincode() error {
 	ccName := icq.CcName
 	ccVersion := icq.CcVersion
 
-	newchaincode, err := newChaincode(org, ccTarPath, ccPath, ccName, ccVersion)
+	newchaincode, err := newChaincode(c.callContext(), org, ccTarPath, ccPath, ccName, ccVersion)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
@@ -90,21 +94,24 @@ func (c *ChaincodeController) InstantiateChaincode() error {
 	policy := icq.Policy
 	args := icq.Args
 	orgCA := newchaincode.GetOrgCA()
-	endorsers := serviceNodesToEndpointList(icq.PeerNodes, chaincode.InstantiateChaincodeTimeout, orgCA.TLSCACert())
-	casters := serviceNodesToEndpointList(icq.OrdererNodes, chaincode.InstantiateChaincodeTimeout, orgCA.TLSCACert())
-	err = newchaincode.InstantiateChaincode(endorsers, casters, channelName, policy, args)
+	endorsers := serviceNodesToEndpointList(icq.PeerNodes, calls.Timeout(c.callContext(), calls.OpInstantiate), orgCA.TLSCACert())
+	casters, err := ordererEndpoints(newchaincode, icq.OrdererNodes, endorsers, channelName)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
-
-	c.ReturnOKMsg("OK")
-	logger.Info("successfully Instantiate Chaincode")
+	c.run(func() (interface{}, error) {
+		if err := newchaincode.InstantiateChaincode(endorsers, casters, channelName, policy, args); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully Instantiate Chaincode")
+		return "OK", nil
+	})
 	return nil
 }
 
 func (c *ChaincodeController) Invoke() error {
-	logger.Info("start Invoke Chaincode")
+	c.log().Info("start Invoke Chaincode")
 
 	iq := &chaincode.InvokeRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, iq)
@@ -118,7 +125,7 @@ func (c *ChaincodeController) Invoke() error {
 	ccName := iq.CcName
 	ccVersion := ""
 
-	newchaincode, err := newChaincode(org, ccTarPath, ccPath, ccName, ccVersion)
+	newchaincode, err := newChaincode(c.callContext(), org, ccTarPath, ccPath, ccName, ccVersion)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
@@ -128,16 +135,44 @@ func (c *ChaincodeController) Invoke() error {
 	args := iq.Args
 
 	orgCA := newchaincode.GetOrgCA()
-	endorsers := serviceNodesToEndpointList(iq.PeerNodes, chaincode.InstantiateChaincodeTimeout, orgCA.TLSCACert())
-	casters := serviceNodesToEndpointList(iq.OrdererNodes, chaincode.InstantiateChaincodeTimeout, orgCA.TLSCACert())
-	err = newchaincode.Invoke(channelName, endorsers, casters, args)
+	endorsers := serviceNodesToEndpointList(iq.PeerNodes, calls.Timeout(c.callContext(), calls.OpEndorse), orgCA.TLSCACert())
+	casters, err := ordererEndpoints(newchaincode, iq.OrdererNodes, endorsers, channelName)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
+	var orgPeers [][]*sdk.Endpoint
+	if len(iq.EndorsingOrgs) != 0 {
+		orgPeers, err = channel.OrgPeers(newchaincode.GetClient(), endorsers, channelName, iq.EndorsingOrgs, calls.Timeout(c.callContext(), calls.OpEndorse))
+		if err != nil {
+			c.ReturnErrorMsg(err)
+			return nil
+		}
+	}
+	c.run(func() (interface{}, error) {
+		if err := newchaincode.Invoke(channelName, endorsers, orgPeers, casters, args); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully Invoke Chaincode")
+		return "OK", nil
+	})
+	return nil
+}
 
-	c.ReturnOKMsg("OK")
-	logger.Info("successfully Invoke Chaincode")
+// Chaincodes returns the chaincodes installed on the peers of orgs and those
+// instantiated on the channels they have joined
+func (c *ChaincodeController) Chaincodes() error {
+	req := &channel.InventoryRequest{}
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, req); err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(newChannel.Chaincodes())
 	return nil
 }
 
@@ -153,3 +188,13 @@ func serviceNodesToEndpointList(serviceNodes []*chaincode.ServiceNode, timeout t
 	}
 	return endpoints
 }
+
+// ordererEndpoints falls back to the orderers published to the public chain
+// when the request carries none.
+func ordererEndpoints(cc *chaincode.Chaincode, ordererNodes []*chaincode.ServiceNode, endorsers []*sdk.Endpoint, channelName string) ([]*sdk.Endpoint, error) {
+	timeout := calls.Timeout(cc.GetClient().Context(), calls.OpBroadcast)
+	if len(ordererNodes) != 0 {
+		return serviceNodesToEndpointList(ordererNodes, timeout, cc.GetOrgCA().TLSCACert()), nil
+	}
+	return channel.ChainOrderers(cc.GetClient(), endorsers, channelName, timeout)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"manageChain/channel"
 	"net/url"
@@ -8,7 +9,6 @@ import (
 	"strconv"
 
 	"github.com/astaxie/beego"
-	logger "github.com/astaxie/beego/logs"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 )
 
@@ -16,7 +16,7 @@ type ChannelController struct {
 	BaseController
 }
 
-func newChannel(orgs []*channel.OrgInfo) (*channel.Channel, error) {
+func newChannel(ctx context.Context, orgs []*channel.OrgInfo) (*channel.Channel, error) {
 	mspDir := beego.AppConfig.String("MSPDir")
 	gm, _ := beego.AppConfig.Bool("GM")
 	var orginfo []*channel.OrgInfo
@@ -24,18 +24,22 @@ func newChannel(orgs []*channel.OrgInfo) (*channel.Channel, error) {
 	for _, org := range orgs {
 		orgCA, err := channel.GetCA(path.Join(mspDir, org.OrgName), org.OrgName)
 		if err != nil {
-			logger.Error("Error getting peer ca", err)
+			logger.WithContext(ctx).Error("Error getting peer ca: %s", err)
 			return nil, err
 		}
 		org.OrgCA = orgCA
 		orginfo = append(orginfo, org)
 	}
 
-	return channel.NewChannel(orginfo, gm)
+	ch, err := channel.NewChannel(orginfo, gm)
+	if err != nil {
+		return nil, err
+	}
+	return ch.WithContext(ctx), nil
 }
 
 func (c *ChannelController) CreateChannel() error {
-	logger.Info("start create channel")
+	c.log().Info("start create channel")
 
 	ccr := &channel.NewCreateChannelRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, ccr)
@@ -45,24 +49,24 @@ func (c *ChannelController) CreateChannel() error {
 	}
 
 	channelName := ccr.ChannelName
-	channel, err := newChannel(ccr.Orgs)
+	channel, err := newChannel(c.callContext(), ccr.Orgs)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
 
-	err = channel.CreateChannel(channelName)
-	if err != nil {
-		c.ReturnErrorMsg(err)
-		return nil
-	}
-	c.ReturnOKMsg("OK")
-	logger.Info("successfully create channel")
+	c.run(func() (interface{}, error) {
+		if err := channel.CreateChannel(channelName); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully create channel")
+		return "OK", nil
+	})
 	return nil
 }
 
 func (c *ChannelController) JoinChannel() error {
-	logger.Info("start join channel")
+	c.log().Info("start join channel")
 
 	jcr := &channel.JoinChannelRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, jcr)
@@ -72,24 +76,80 @@ func (c *ChannelController) JoinChannel() error {
 	}
 
 	channelName := jcr.ChannelName
-	channel, err := newChannel(jcr.Orgs)
+	channel, err := newChannel(c.callContext(), jcr.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	c.run(func() (interface{}, error) {
+		if err := channel.JoinChannel(channelName); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully join channel")
+		return "OK", nil
+	})
+	return nil
+}
+
+// BootstrapPublicChain creates the public chain with the founding orgs of a new
+// network and deploys the public chaincode on it.
+func (c *ChannelController) BootstrapPublicChain() error {
+	c.log().Info("start bootstrap public chain")
+
+	br := &channel.BootstrapRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, br)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
 
-	err = channel.JoinChannel(channelName)
+	channel, err := newChannel(c.callContext(), br.Orgs)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
-	c.ReturnOKMsg("OK")
-	logger.Info("successfully join channel")
+
+	c.run(func() (interface{}, error) {
+		if err := channel.BootstrapPublicChain(br.CcTarPath, br.CcVersion, br.Policy); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully bootstrap public chain")
+		return "OK", nil
+	})
+	return nil
+}
+
+// UpdateChainOrgInfo republishes the org's peers and orderers of a channel
+// to the public chain, e.g. after its endpoints changed.
+func (c *ChannelController) UpdateChainOrgInfo() error {
+	c.log().Info("start update chain org info")
+
+	ucr := &channel.UpdateChainOrgInfoRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, ucr)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	channel, err := newChannel(c.callContext(), ucr.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	c.run(func() (interface{}, error) {
+		if err := channel.PublishChainOrgInfo(ucr.ChannelName); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully update chain org info")
+		return "OK", nil
+	})
 	return nil
 }
 
 func (c *ChannelController) GenCrypto() error {
-	logger.Info("start generate crypto config")
+	c.log().Info("start generate crypto config")
 	genCryptoReq := &channel.GenCryptoRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, genCryptoReq)
 	if err != nil {
@@ -99,18 +159,18 @@ func (c *ChannelController) GenCrypto() error {
 	orgs := genCryptoReq.Orgs
 	err = channel.GenerateCrypto(orgs)
 	if err != nil {
-		logger.Error("Error generate crypto")
+		c.log().Error("Error generate crypto")
 		c.ReturnErrorMsg(err)
 		return nil
 	}
 
-	logger.Info("end generate crypto config")
+	c.log().Info("end generate crypto config")
 	return nil
 }
 
 // Generate genesis block
 func (c *ChannelController) GenGenesisBlock() error {
-	logger.Info("start generate Genesis block")
+	c.log().Info("start generate Genesis block")
 	genGbReq := &channel.GenGenesisBlockRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, genGbReq)
 	if err != nil {
@@ -125,7 +185,7 @@ func (c *ChannelController) GenGenesisBlock() error {
 	for _, org := range orgs {
 		orgCA, err := channel.GetCA(path.Join(mspDir, org.OrgName), org.OrgName)
 		if err != nil {
-			logger.Error("Error getting peer ca", err)
+			c.log().Error("Error getting peer ca: %s", err)
 			c.ReturnErrorMsg(err)
 			return nil
 		}
@@ -134,13 +194,13 @@ func (c *ChannelController) GenGenesisBlock() error {
 	}
 	block, err := channel.GenGenesisBlock(orginfos, kafkas)
 	if err != nil {
-		logger.Error("error generate genesis block:", err)
+		c.log().Error("error generate genesis block: %s", err)
 		c.ReturnErrorMsg(err)
 		return nil
 	}
-	logger.Info("genesis block:", block)
+	c.log().Info("genesis block: %v", block)
 	c.ReturnOKMsg("generate config block ok")
-	logger.Info("end genetate Genesis block")
+	c.log().Info("end genetate Genesis block")
 	return nil
 }
 
@@ -149,7 +209,7 @@ func parseURL(rawURL string) *localconfig.AnchorPeer {
 	anchor := &localconfig.AnchorPeer{}
 	addr, err := url.Parse(rawURL)
 	if err != nil {
-		logger.Error("err parse", err)
+		logger.Error("err parse: %s", err)
 		return nil
 	}
 	anchor.Host = addr.Hostname()
@@ -159,7 +219,7 @@ func parseURL(rawURL string) *localconfig.AnchorPeer {
 
 // Identity ...
 func (c *ChannelController) Identity() error {
-	logger.Info("start generate org identity")
+	c.log().Info("start generate org identity")
 
 	idr := &channel.IdentityRequest{}
 	// logger.Info("reqbody:", c.Ctx.Input.RequestBody)
@@ -170,7 +230,7 @@ func (c *ChannelController) Identity() error {
 	}
 
 	orgs := idr.Orgs
-	newChannel, err := newChannel(orgs)
+	newChannel, err := newChannel(c.callContext(), orgs)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
@@ -182,12 +242,12 @@ func (c *ChannelController) Identity() error {
 		return nil
 	}
 	c.ReturnOKMsg(id)
-	logger.Info("successfully generate identity")
+	c.log().Info("successfully generate identity")
 	return nil
 }
 
 func (c *ChannelController) AddOrg() error {
-	logger.Info("start add org.")
+	c.log().Info("start add org.")
 	addOrgReq := &channel.AddOrgRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, &addOrgReq)
 	if err != nil {
@@ -196,25 +256,24 @@ func (c *ChannelController) AddOrg() error {
 	}
 	orgs := addOrgReq.Orgs
 	channelName := addOrgReq.ChannelName
-	newChannel, err := newChannel(orgs)
+	newChannel, err := newChannel(c.callContext(), orgs)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
 	id := addOrgReq.Identity
-	err = newChannel.AddOrg(id, orgs, channelName)
-	if err != nil {
-		c.ReturnErrorMsg(err)
-		return nil
-	}
-
-	c.ReturnOKMsg("OK")
-	logger.Info("successfully add org.")
+	c.run(func() (interface{}, error) {
+		if err := newChannel.AddOrg(id, orgs, channelName); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully add org.")
+		return "OK", nil
+	})
 	return nil
 }
 
 func (c *ChannelController) DeleteOrg() error {
-	logger.Info("start delete org")
+	c.log().Info("start delete org")
 	delOrgReq := &channel.DeleteOrgRequest{}
 	err := json.Unmarshal(c.Ctx.Input.RequestBody, delOrgReq)
 	if err != nil {
@@ -225,18 +284,189 @@ func (c *ChannelController) DeleteOrg() error {
 	delOrderers := delOrgReq.DelOrderers
 	channelName := delOrgReq.ChannelName
 	operateOrg := delOrgReq.Orgs
-	newChannel, err := newChannel(operateOrg)
+	newChannel, err := newChannel(c.callContext(), operateOrg)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.run(func() (interface{}, error) {
+		if err := newChannel.DeleteOrg(delOrg, delOrderers, channelName, operateOrg); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully delete org.")
+		return "OK", nil
+	})
+	return nil
+}
+
+// ProposeRemoval starts a vote in the public chain on removing an org from a channel
+func (c *ChannelController) ProposeRemoval() error {
+	c.log().Info("start propose removal")
+	req := &channel.ProposeRemovalRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, req)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.run(func() (interface{}, error) {
+		if err := newChannel.ProposeRemoval(req.ChannelName, req.Target, req.DelOrderers, req.Reason); err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully propose removal.")
+		return "OK", nil
+	})
+	return nil
+}
+
+// VoteRemoval votes on a removal for every org of the request, the removal is
+// executed once it is approved.
+func (c *ChannelController) VoteRemoval() error {
+	c.log().Info("start vote removal")
+	req := &channel.VoteRemovalRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, req)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.run(func() (interface{}, error) {
+		proposal, err := newChannel.VoteRemoval(req.ChannelName, req.Target, req.Accept)
+		if err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully vote removal.")
+		return proposal, nil
+	})
+	return nil
+}
+
+// GetRemoval returns a removal proposal with its votes
+func (c *ChannelController) GetRemoval() error {
+	req := &channel.RemovalRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, req)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	proposal, err := newChannel.GetRemoval(req.ChannelName, req.Target)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	c.ReturnOKMsg(proposal)
+	return nil
+}
+
+// ExecuteRemoval applies an approved removal or retries a failed one
+func (c *ChannelController) ExecuteRemoval() error {
+	c.log().Info("start execute removal")
+	req := &channel.RemovalRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, req)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.run(func() (interface{}, error) {
+		result, err := newChannel.ExecuteRemoval(req.ChannelName, req.Target)
+		if err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully execute removal.")
+		return result, nil
+	})
+	return nil
+}
+
+// Channels returns the channels the peers of the first org have joined
+func (c *ChannelController) Channels() error {
+	req := &channel.InventoryRequest{}
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, req); err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
-	err = newChannel.DeleteOrg(delOrg, delOrderers, channelName, operateOrg)
+	channels, err := newChannel.Channels()
 	if err != nil {
 		c.ReturnErrorMsg(err)
 		return nil
 	}
+	c.ReturnOKMsg(channels)
+	return nil
+}
+
+// Config returns the current config of a channel
+func (c *ChannelController) Config() error {
+	req := &channel.ChannelRequest{}
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, req); err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	config, err := newChannel.Config(req.ChannelName)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(config)
+	return nil
+}
 
-	c.ReturnOKMsg("OK")
-	logger.Info("successfully delete org.")
+// Removals returns the removals proposed of the orgs of a channel
+func (c *ChannelController) Removals() error {
+	req := &channel.ChannelRequest{}
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, req); err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	newChannel, err := newChannel(c.callContext(), req.Orgs)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	proposals, err := newChannel.Removals(req.ChannelName)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(proposals)
+	return nil
+}
+
+// Orgs returns the certificates of the orgs under MSPDir
+func (c *ChannelController) Orgs() error {
+	orgs, err := channel.ListOrgs(beego.AppConfig.String("MSPDir"))
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(orgs)
 	return nil
 }
@@ -8,8 +8,9 @@ type MainController struct {
 	beego.Controller
 }
 
+// Get serves the management console, a page reading the REST API with the
+// orgs kept by the browser.
 func (c *MainController) Get() {
-	c.Data["Website"] = "beego.me"
-	c.Data["Email"] = "astaxie@gmail.com"
-	c.TplName = "index.tpl"
+	c.Data["AppName"] = beego.BConfig.AppName
+	c.TplName = "console.tpl"
 }
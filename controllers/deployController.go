@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"encoding/json"
+	"manageChain/channel"
+	"manageChain/deploy"
+	"manageChain/protocols"
+	"path"
+
+	"github.com/astaxie/beego"
+)
+
+type DeployController struct {
+	BaseController
+}
+
+// withCA sets the CA of every org from MSPDir, the deployments mount the
+// crypto material generated by /gencrypto.
+func withCA(orgs []*channel.OrgInfo) error {
+	mspDir := beego.AppConfig.String("MSPDir")
+	for _, org := range orgs {
+		orgCA, err := channel.GetCA(path.Join(mspDir, org.OrgName), org.OrgName)
+		if err != nil {
+			logger.Error("Error getting org ca: %s", err)
+			return err
+		}
+		org.OrgCA = orgCA
+	}
+	return nil
+}
+
+// outputDir resolves the OutputDir of a request under DeployDir
+func outputDir(dir string) (string, error) {
+	ret, err := deploy.OutputDir(beego.AppConfig.String("DeployDir"), dir)
+	if err != nil {
+		return "", protocols.Errorf(protocols.CodeInvalidRequest, "%w", err)
+	}
+	return ret, nil
+}
+
+// GenCompose writes the docker-compose projects running the nodes of the orgs
+func (c *DeployController) GenCompose() error {
+	c.log().Info("start generate docker-compose")
+	cr := &deploy.ComposeRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, cr)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	if err := withCA(cr.Orgs); err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	dir, err := outputDir(cr.OutputDir)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	gm, _ := beego.AppConfig.Bool("GM")
+	opts := &deploy.Options{
+		GenesisBlock: cr.GenesisBlock,
+		Kafkas:       cr.Kafkas,
+		ZooKeepers:   cr.ZooKeepers,
+		CouchDB:      cr.CouchDB,
+		GM:           gm,
+		Images:       cr.Images,
+	}
+	files, err := deploy.WriteComposes(cr.Orgs, cr.PerOrg, dir, opts)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(files)
+	c.log().Info("successfully generate docker-compose")
+	return nil
+}
+
+// GenManifests writes the kubernetes manifests of the peers and orderers of the orgs
+func (c *DeployController) GenManifests() error {
+	c.log().Info("start generate kubernetes manifests")
+	mr := &deploy.ManifestRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, mr)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	if err := withCA(mr.Orgs); err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	dir, err := outputDir(mr.OutputDir)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	gm, _ := beego.AppConfig.Bool("GM")
+	opts := &deploy.Options{
+		GenesisBlock: mr.GenesisBlock,
+		CouchDB:      mr.CouchDB,
+		GM:           gm,
+		Images:       mr.Images,
+	}
+	files, err := deploy.WriteManifests(mr.Orgs, dir, mr.Tarball, opts, mr.K8sOptions)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(files)
+	c.log().Info("successfully generate kubernetes manifests")
+	return nil
+}
+
+// GenNodeConfig writes the environment of every peer and orderer of the orgs,
+// which configures the node the way manageChain generated its crypto material.
+func (c *DeployController) GenNodeConfig() error {
+	c.log().Info("start generate node config")
+	ncr := &deploy.NodeConfigRequest{}
+	err := json.Unmarshal(c.Ctx.Input.RequestBody, ncr)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	if err := withCA(ncr.Orgs); err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	dir, err := outputDir(ncr.OutputDir)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	gm, _ := beego.AppConfig.Bool("GM")
+	opts := &deploy.Options{
+		GenesisBlock: ncr.GenesisBlock,
+		Kafkas:       ncr.Kafkas,
+		CouchDB:      ncr.CouchDB,
+		GM:           gm,
+		Images:       ncr.Images,
+	}
+	files, err := deploy.WriteNodeConfigs(ncr.Orgs, dir, opts)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(files)
+	c.log().Info("successfully generate node config")
+	return nil
+}
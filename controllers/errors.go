@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"encoding/json"
+	"manageChain/protocols"
+
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// statuses are the HTTP statuses of the codes of the ErrorMessages
+var statuses = map[string]int{
+	protocols.CodeInvalidRequest:     400,
+	protocols.CodePermissionDenied:   403,
+	protocols.CodePolicyNotSatisfied: 403,
+	protocols.CodeNotFound:           404,
+	protocols.CodeTxInvalid:          409,
+	protocols.CodeConflict:           409,
+	protocols.CodeInternal:           500,
+	protocols.CodeEndorsementFailed:  502,
+	protocols.CodeOrdererRejected:    502,
+	protocols.CodeUnavailable:        503,
+	protocols.CodeTimeout:            504,
+}
+
+// errorStatus returns the HTTP status of the code of an ErrorMessage
+func errorStatus(code string) int {
+	if status, ok := statuses[code]; ok {
+		return status
+	}
+	return 500
+}
+
+// errorMessage returns the ErrorMessage of err, its code told by the type
+// of err or of the errors it wraps.
+func errorMessage(err error) *protocols.ErrorMessage {
+	msg := &protocols.ErrorMessage{Code: protocols.CodeInternal, Message: err.Error()}
+	var (
+		pe *protocols.Error
+		te *sdk.TxInvalidError
+		oe *sdk.OrdererError
+		ee *sdk.EndorsementError
+		se *json.SyntaxError
+		ue *json.UnmarshalTypeError
+	)
+	switch {
+	case sdk.AsError(err, &pe):
+		msg.Code = pe.Code
+	case sdk.AsError(err, &te):
+		msg.Code = protocols.CodeTxInvalid
+		if te.Code == pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE {
+			msg.Code = protocols.CodePolicyNotSatisfied
+		}
+		msg.TxID, msg.ValidationCode = te.TxID, te.Code.String()
+	case sdk.IsUnavailable(err):
+		// unreachable nodes, or an orderer without leader, may be retried
+		msg.Code = protocols.CodeUnavailable
+		if sdk.AsError(err, &oe) {
+			msg.OrdererStatus = oe.Status.String()
+		}
+	case sdk.AsError(err, &oe):
+		switch {
+		case oe.PolicyNotSatisfied():
+			msg.Code = protocols.CodePolicyNotSatisfied
+		case oe.Status == common.Status_FORBIDDEN:
+			msg.Code = protocols.CodePermissionDenied
+		default:
+			msg.Code = protocols.CodeOrdererRejected
+		}
+		msg.OrdererStatus = oe.Status.String()
+	case sdk.AsError(err, &ee):
+		msg.Code = protocols.CodeEndorsementFailed
+		if ee.PermissionDenied() {
+			msg.Code = protocols.CodePermissionDenied
+		}
+		msg.EndorserStatus = ee.Status
+	case sdk.IsTimeout(err):
+		msg.Code = protocols.CodeTimeout
+	case sdk.AsError(err, &se), sdk.AsError(err, &ue):
+		msg.Code = protocols.CodeInvalidRequest
+	}
+	return msg
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"manageChain/protocols"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/sdk"
+	pkgerrors "github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorMessage(t *testing.T) {
+	for _, c := range []struct {
+		err    error
+		code   string
+		status int
+	}{
+		{errors.New("failed"), protocols.CodeInternal, 500},
+		{json.Unmarshal([]byte("{"), &struct{}{}), protocols.CodeInvalidRequest, 400},
+		{protocols.Errorf(protocols.CodeNotFound, "no removal of %s", "org2"), protocols.CodeNotFound, 404},
+		{fmt.Errorf("failed broadcasting through all orderers: %w", &sdk.OrdererError{Status: common.Status_BAD_REQUEST}), protocols.CodeOrdererRejected, 502},
+		{&sdk.OrdererError{Status: common.Status_FORBIDDEN}, protocols.CodePermissionDenied, 403},
+		{&sdk.OrdererError{Status: common.Status_BAD_REQUEST, Info: "policy for [Group] /Channel/Application not satisfied"}, protocols.CodePolicyNotSatisfied, 403},
+		{pkgerrors.WithMessage(&sdk.EndorsementError{Status: 500, Message: "chaincode error"}, "failed"), protocols.CodeEndorsementFailed, 502},
+		{&sdk.EndorsementError{Status: 500, Message: "access denied: channel [mychannel] creator org [org2]"}, protocols.CodePermissionDenied, 403},
+		{&sdk.TxInvalidError{TxID: "tx", Code: pb.TxValidationCode_MVCC_READ_CONFLICT}, protocols.CodeTxInvalid, 409},
+		{&sdk.TxInvalidError{TxID: "tx", Code: pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE}, protocols.CodePolicyNotSatisfied, 403},
+		{&sdk.TimeoutError{Op: sdk.OpWaitTx}, protocols.CodeTimeout, 504},
+		{pkgerrors.WithMessage(pkgerrors.WithStack(context.DeadlineExceeded), "failed to create new connection"), protocols.CodeTimeout, 504},
+		{fmt.Errorf("failed broadcasting through all orderers: %w", &sdk.OrdererError{Status: common.Status_SERVICE_UNAVAILABLE}), protocols.CodeUnavailable, 503},
+		{pkgerrors.WithMessage(status.Error(codes.Unavailable, "connection refused"), "failed to endorse"), protocols.CodeUnavailable, 503},
+	} {
+		msg := errorMessage(c.err)
+		if msg.Code != c.code || errorStatus(msg.Code) != c.status || msg.Message != c.err.Error() {
+			t.Fatalf("%v: unexpected error %+v", c.err, msg)
+		}
+	}
+
+	msg := errorMessage(fmt.Errorf("invoke failed: %w", &sdk.TxInvalidError{TxID: "tx", Code: pb.TxValidationCode_MVCC_READ_CONFLICT}))
+	if msg.TxID != "tx" || msg.ValidationCode != "MVCC_READ_CONFLICT" {
+		t.Fatalf("unexpected error %+v", msg)
+	}
+	msg = errorMessage(&sdk.OrdererError{Status: common.Status_BAD_REQUEST})
+	if msg.OrdererStatus != "BAD_REQUEST" {
+		t.Fatalf("unexpected error %+v", msg)
+	}
+}
@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"manageChain/ha"
+)
+
+// HAController serves the role of the replica
+type HAController struct {
+	BaseController
+}
+
+// Status returns whether the replica is the leader, and the leader
+func (c *HAController) Status() error {
+	c.ReturnOKMsg(ha.Current())
+	return nil
+}
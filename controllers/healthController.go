@@ -0,0 +1,22 @@
+package controllers
+
+import (
+	"manageChain/monitor"
+)
+
+type HealthController struct {
+	BaseController
+}
+
+// Health returns the last check of the monitored nodes, with 503 when any of them is unhealthy
+func (c *HealthController) Health() error {
+	health := monitor.Health()
+	if !health.Healthy {
+		c.Ctx.Output.SetStatus(503)
+		c.Data["json"] = health
+		c.ServeJSON()
+		return nil
+	}
+	c.ReturnOKMsg(health)
+	return nil
+}
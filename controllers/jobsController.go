@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"manageChain/jobs"
+	"manageChain/protocols"
+)
+
+// JobsController serves the jobs of the requests run with async=true
+type JobsController struct {
+	BaseController
+}
+
+// List returns the jobs, of the status of the query if any, the most recent first
+func (c *JobsController) List() error {
+	c.ReturnOKMsg(jobs.List(c.GetString("status")))
+	return nil
+}
+
+// Job returns a job with its outcome once it is finished
+func (c *JobsController) Job() error {
+	id := c.Ctx.Input.Param(":id")
+	j := jobs.Get(id)
+	if j == nil {
+		c.ReturnErrorCode(protocols.CodeNotFound, "job "+id+" does not exist")
+		return nil
+	}
+	c.ReturnOKMsg(j)
+	return nil
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"encoding/json"
+	"manageChain/channel"
+	"manageChain/protocols"
+)
+
+// LedgerController reads the ledgers of the channels
+type LedgerController struct {
+	BaseController
+}
+
+func (c *LedgerController) parseLedgerRequest() (*channel.LedgerRequest, *channel.Channel, error) {
+	lr := &channel.LedgerRequest{}
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, lr); err != nil {
+		return nil, nil, err
+	}
+	ch, err := newChannel(c.callContext(), lr.Orgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lr, ch, nil
+}
+
+// Info returns the height of the ledger of a channel
+func (c *LedgerController) Info() error {
+	lr, ch, err := c.parseLedgerRequest()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	info, err := ch.LedgerInfo(lr.ChannelName)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(info)
+	return nil
+}
+
+// Block returns a block of a channel with its transactions
+func (c *LedgerController) Block() error {
+	lr, ch, err := c.parseLedgerRequest()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	block, err := ch.Block(lr.ChannelName, lr.Number)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(block)
+	return nil
+}
+
+// Tx returns a transaction of a channel with its validation code
+func (c *LedgerController) Tx() error {
+	lr, ch, err := c.parseLedgerRequest()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	if lr.TxID == "" {
+		c.ReturnErrorCode(protocols.CodeInvalidRequest, "TxID is required")
+		return nil
+	}
+	tx, err := ch.Transaction(lr.ChannelName, lr.TxID)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(tx)
+	return nil
+}
@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"manageChain/metrics"
+)
+
+type MetricsController struct {
+	BaseController
+}
+
+// Metrics serves the metrics in the Prometheus text format
+func (c *MetricsController) Metrics() error {
+	c.Ctx.Output.Header("Content-Type", "text/plain; version=0.0.4")
+	c.Ctx.WriteString(metrics.Text())
+	return nil
+}
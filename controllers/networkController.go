@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"manageChain/network"
+	"manageChain/protocols"
+
+	"github.com/astaxie/beego"
+)
+
+type NetworkController struct {
+	BaseController
+}
+
+// newNetwork parses the spec in the request body, in YAML or JSON
+func (c *NetworkController) newNetwork() (*network.Network, error) {
+	spec, err := network.ParseSpec(c.Ctx.Input.RequestBody)
+	if err != nil {
+		return nil, protocols.Errorf(protocols.CodeInvalidRequest, "%w", err)
+	}
+	mspDir := beego.AppConfig.String("MSPDir")
+	gm, _ := beego.AppConfig.Bool("GM")
+	return network.NewNetwork(c.callContext(), spec, mspDir, gm), nil
+}
+
+// Plan returns the steps bringing the network to the spec, without running them
+func (c *NetworkController) Plan() error {
+	c.log().Info("start plan network")
+	n, err := c.newNetwork()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	plan, err := n.Plan()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(plan)
+	c.log().Info("successfully plan network")
+	return nil
+}
+
+// Apply runs the steps bringing the network to the spec
+func (c *NetworkController) Apply() error {
+	c.log().Info("start apply network")
+	n, err := c.newNetwork()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.run(func() (interface{}, error) {
+		plan, err := n.Apply()
+		if err != nil {
+			return nil, err
+		}
+		c.log().Info("successfully apply network")
+		return plan, nil
+	})
+	return nil
+}
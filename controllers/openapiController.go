@@ -0,0 +1,15 @@
+package controllers
+
+import (
+	"manageChain/openapi"
+)
+
+type OpenAPIController struct {
+	BaseController
+}
+
+// Document serves the OpenAPI document of the REST API
+func (c *OpenAPIController) Document() error {
+	c.ReturnOKMsg(openapi.Get())
+	return nil
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"encoding/json"
+	"manageChain/channel"
+)
+
+// PublicController serves the records kept by the public chaincode
+type PublicController struct {
+	BaseController
+}
+
+func (c *PublicController) parseQueryPageRequest() (*channel.QueryPageRequest, *channel.Channel, error) {
+	qpr := &channel.QueryPageRequest{}
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, qpr); err != nil {
+		return nil, nil, err
+	}
+	ch, err := newChannel(c.callContext(), qpr.Orgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return qpr, ch, nil
+}
+
+func (c *PublicController) QueryOrgInfo() error {
+	c.log().Info("start query org info")
+	qpr, ch, err := c.parseQueryPageRequest()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	page, err := ch.OrgInfoByPage(qpr.ChannelName, qpr.PageSize, qpr.Bookmark)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(page)
+	c.log().Info("successfully query org info")
+	return nil
+}
+
+func (c *PublicController) QueryOrgname() error {
+	c.log().Info("start query orgname")
+	qpr, ch, err := c.parseQueryPageRequest()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	page, err := ch.OrgnameByPage(qpr.ChannelName, qpr.PageSize, qpr.Bookmark)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(page)
+	c.log().Info("successfully query orgname")
+	return nil
+}
+
+func (c *PublicController) QueryInvitation() error {
+	c.log().Info("start query invitation")
+	qpr, ch, err := c.parseQueryPageRequest()
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+
+	page, err := ch.InvitationByPage(qpr.ChannelName, qpr.PageSize, qpr.Bookmark, qpr.Filter)
+	if err != nil {
+		c.ReturnErrorMsg(err)
+		return nil
+	}
+	c.ReturnOKMsg(page)
+	c.log().Info("successfully query invitation")
+	return nil
+}
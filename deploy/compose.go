@@ -0,0 +1,402 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"manageChain/channel"
+
+	"gopkg.in/yaml.v2"
+)
+
+const composeVersion = "2"
+
+// GenerateCompose generates one docker-compose project running the nodes of
+// all orgs, with the kafka, zookeeper and couchdb services selected in opts.
+func GenerateCompose(orgs []*channel.OrgInfo, opts *Options) (*Compose, error) {
+	var nodes []*node
+	for _, org := range orgs {
+		nodes = append(nodes, append(orderers(org), peers(org)...)...)
+	}
+	if err := validatePorts(nodes, true); err != nil {
+		return nil, err
+	}
+	compose, err := generateKafkaCompose(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range orgs {
+		orgCompose, err := GenerateOrgCompose(org, opts)
+		if err != nil {
+			return nil, err
+		}
+		for name, service := range orgCompose.Services {
+			if _, ok := compose.Services[name]; ok {
+				return nil, fmt.Errorf("service %s is duplicated", name)
+			}
+			compose.Services[name] = service
+		}
+	}
+	return compose, nil
+}
+
+// GenerateOrgCompose generates the docker-compose project running the peers
+// and orderers of org.
+func GenerateOrgCompose(org *channel.OrgInfo, opts *Options) (*Compose, error) {
+	if err := validatePorts(append(orderers(org), peers(org)...), true); err != nil {
+		return nil, err
+	}
+	images := withDefaults(opts.Images)
+	compose := newCompose()
+	for _, n := range orderers(org) {
+		service, err := ordererService(n, opts, images)
+		if err != nil {
+			return nil, err
+		}
+		compose.Services[n.name()] = service
+	}
+
+	bootstrap := ""
+	if len(org.PeerNodes) != 0 {
+		bootstrap = peers(org)[0].externalEndpoint()
+	}
+	for _, n := range peers(org) {
+		service, err := peerService(n, bootstrap, opts, images)
+		if err != nil {
+			return nil, err
+		}
+		if opts.CouchDB {
+			couchdb := "couchdb." + n.name()
+			compose.Services[couchdb] = &Service{
+				ContainerName: couchdb,
+				Image:         images.CouchDB,
+			}
+			service.Environment = append(service.Environment, couchDBEnv(couchdb)...)
+			service.DependsOn = append(service.DependsOn, couchdb)
+		}
+		compose.Services[n.name()] = service
+	}
+	return compose, nil
+}
+
+// WriteComposes writes the docker-compose projects of orgs under outputDir,
+// one project for the network or, with perOrg, one per org plus one for kafka.
+// It returns the paths of the files written.
+func WriteComposes(orgs []*channel.OrgInfo, perOrg bool, outputDir string, opts *Options) ([]string, error) {
+	if outputDir == "" {
+		outputDir = defaultOutputDir
+	}
+	if !perOrg {
+		compose, err := GenerateCompose(orgs, opts)
+		if err != nil {
+			return nil, err
+		}
+		file, err := writeCompose(outputDir, compose)
+		if err != nil {
+			return nil, err
+		}
+		return []string{file}, nil
+	}
+
+	var files []string
+	for _, org := range orgs {
+		compose, err := GenerateOrgCompose(org, opts)
+		if err != nil {
+			return nil, err
+		}
+		file, err := writeCompose(filepath.Join(outputDir, org.OrgName), compose)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(opts.Kafkas) != 0 || len(opts.ZooKeepers) != 0 {
+		compose, err := generateKafkaCompose(opts)
+		if err != nil {
+			return nil, err
+		}
+		file, err := writeCompose(filepath.Join(outputDir, kafkaProject), compose)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func writeCompose(dir string, compose *Compose) (string, error) {
+	data, err := yaml.Marshal(compose)
+	if err != nil {
+		return "", err
+	}
+	return writeFile(dir, composeFile, data, 0644)
+}
+
+func newCompose() *Compose {
+	return &Compose{
+		Version:  composeVersion,
+		Services: make(map[string]*Service),
+	}
+}
+
+func peerService(n *node, bootstrap string, opts *Options, images *Images) (*Service, error) {
+	if err := validateNode(n, opts); err != nil {
+		return nil, err
+	}
+	port, err := n.port()
+	if err != nil {
+		return nil, err
+	}
+	address, err := chaincodeAddress(n)
+	if err != nil {
+		return nil, err
+	}
+	mspDir, err := n.mspDir()
+	if err != nil {
+		return nil, err
+	}
+	tlsDir, err := n.tlsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	env := peerEnv(n, bootstrap, opts, images)
+	env = append(env, "CORE_PEER_CHAINCODEADDRESS="+address)
+	return &Service{
+		ContainerName: n.name(),
+		Image:         images.Peer,
+		Environment:   env,
+		Volumes: []string{
+			"/var/run/:/host/var/run/",
+			mspDir + ":" + peerMSPDir,
+			tlsDir + ":" + peerTLSDir,
+		},
+		Ports: []string{
+			fmt.Sprintf("%d:%d", port, peerListenPort),
+			fmt.Sprintf("%d:%d", port+1, peerChaincodePort),
+			fmt.Sprintf("%d:%d", port+2, peerEventPort),
+		},
+		WorkingDir: "/opt/gopath/src/github.com/hyperledger/fabric/peer",
+		Command:    "peer node start",
+	}, nil
+}
+
+// peerEnv is the environment of a peer container, but the chaincode address
+// which depends on how the peer is deployed.
+func peerEnv(n *node, bootstrap string, opts *Options, images *Images) []string {
+	env := []string{
+		"CORE_VM_ENDPOINT=unix:///host/var/run/docker.sock",
+		"CORE_PEER_ID=" + n.name(),
+		"CORE_PEER_ADDRESSAUTODETECT=false",
+		"CORE_PEER_ADDRESS=" + n.externalEndpoint(),
+		fmt.Sprintf("CORE_PEER_LISTENADDRESS=0.0.0.0:%d", peerListenPort),
+		"CORE_PEER_GOSSIP_EXTERNALENDPOINT=" + n.externalEndpoint(),
+		"CORE_PEER_GOSSIP_BOOTSTRAP=" + bootstrap,
+		fmt.Sprintf("CORE_PEER_CHAINCODELISTENADDRESS=0.0.0.0:%d", peerChaincodePort),
+		"CORE_PEER_GOSSIP_ORGLEADER=false",
+		"CORE_PEER_GOSSIP_USELEADERELECTION=true",
+		"CORE_PEER_GOSSIP_SKIPHANDSHAKE=true",
+		"CORE_PEER_PROFILE_ENABLED=true",
+		"CORE_PEER_MSPCONFIGPATH=" + peerMSPDir,
+		"CORE_PEER_LOCALMSPID=" + n.org.OrgMSP,
+		"CORE_LOGGING_LEVEL=INFO",
+		"CORE_CHAINCODE_BUILDER=" + images.CCEnv,
+		"CORE_CHAINCODE_GOLANG_RUNTIME=" + images.BaseOS,
+		"CORE_PEER_TLS_ENABLED=true",
+		"CORE_PEER_TLS_CERT_FILE=" + peerTLSDir + "/server.crt",
+		"CORE_PEER_TLS_KEY_FILE=" + peerTLSDir + "/server.key",
+		"CORE_PEER_TLS_ROOTCERT_FILE=" + peerTLSDir + "/ca.crt",
+	}
+	if opts.GM {
+		env = append(env, "CORE_PEER_BCCSP_DEFAULT="+gmProvider)
+	}
+	return env
+}
+
+func ordererService(n *node, opts *Options, images *Images) (*Service, error) {
+	if err := validateNode(n, opts); err != nil {
+		return nil, err
+	}
+	port, err := n.port()
+	if err != nil {
+		return nil, err
+	}
+	mspDir, err := n.mspDir()
+	if err != nil {
+		return nil, err
+	}
+	tlsDir, err := n.tlsDir()
+	if err != nil {
+		return nil, err
+	}
+	genesisBlock, err := genesisBlockPath(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := ordererEnv(n, port, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		ContainerName: n.name(),
+		Image:         images.Orderer,
+		Environment:   env,
+		Volumes: []string{
+			genesisBlock + ":" + ordererGenesis,
+			mspDir + ":" + ordererMSPDir,
+			tlsDir + ":" + ordererTLSDir,
+		},
+		Ports:      []string{fmt.Sprintf("%d:%d", port, port)},
+		WorkingDir: "/opt/gopath/src/github.com/hyperledger/fabric/orderer",
+		Command:    "orderer",
+	}, nil
+}
+
+// couchDBEnv keeps the state of a peer in the couchdb on host
+func couchDBEnv(host string) []string {
+	return []string{
+		"CORE_LEDGER_STATE_STATEDATABASE=CouchDB",
+		fmt.Sprintf("CORE_LEDGER_STATE_COUCHDBCONFIG_COUCHDBADDRESS=%s:%d", host, couchDBPort),
+	}
+}
+
+// ordererEnv is the environment of an orderer container listening on port.
+// The orderer takes its consensus type and kafka brokers from the genesis
+// block, the kafka brokers of opts must be among the ones of the block.
+func ordererEnv(n *node, port int, opts *Options) ([]string, error) {
+	c, err := genesisConsensus(opts)
+	if err != nil {
+		return nil, err
+	}
+	env := []string{
+		"ORDERER_GENERAL_LOGLEVEL=info",
+		"ORDERER_GENERAL_LOCALMSPDIR=" + ordererMSPDir,
+		"ORDERER_GENERAL_LOCALMSPID=" + n.org.OrgMSP,
+		"ORDERER_GENERAL_LISTENADDRESS=0.0.0.0",
+		fmt.Sprintf("ORDERER_GENERAL_LISTENPORT=%d", port),
+		"ORDERER_GENERAL_LEDGERTYPE=file",
+		"ORDERER_GENERAL_GENESISMETHOD=file",
+		"ORDERER_GENERAL_GENESISFILE=" + ordererGenesis,
+		"ORDERER_GENERAL_TLS_ENABLED=true",
+		"ORDERER_GENERAL_TLS_PRIVATEKEY=" + ordererTLSDir + "/server.key",
+		"ORDERER_GENERAL_TLS_CERTIFICATE=" + ordererTLSDir + "/server.crt",
+		"ORDERER_GENERAL_TLS_ROOTCAS=[" + ordererTLSDir + "/ca.crt]",
+	}
+	if c.Type == consensusKafka {
+		for _, kafka := range opts.Kafkas {
+			if !contains(c.Brokers, kafka) {
+				return nil, fmt.Errorf("kafka broker %s is not in the genesis block, the orderers connect to %s", kafka, strings.Join(c.Brokers, ","))
+			}
+		}
+		// the brokers generated with the compose projects are plaintext
+		env = append(env,
+			"ORDERER_KAFKA_RETRY_SHORTINTERVAL=1s",
+			"ORDERER_KAFKA_RETRY_SHORTTOTAL=30s",
+			"ORDERER_KAFKA_VERBOSE=true",
+			"ORDERER_KAFKA_TLS_ENABLED=false",
+		)
+	}
+	if opts.GM {
+		env = append(env, "ORDERER_GENERAL_BCCSP_DEFAULT="+gmProvider)
+	}
+	return env, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// generateKafkaCompose generates the kafka and zookeeper services of opts,
+// the i-th zookeeper uses 2888+i and 3888+i on its host for the ensemble.
+func generateKafkaCompose(opts *Options) (*Compose, error) {
+	images := withDefaults(opts.Images)
+	compose := newCompose()
+	if len(opts.Kafkas) != 0 && len(opts.ZooKeepers) == 0 {
+		return nil, errors.New("kafka brokers need zookeepers")
+	}
+
+	var servers []string
+	var zookeepers []string
+	for i, zk := range opts.ZooKeepers {
+		host, err := endpointHost(zk)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, fmt.Sprintf("server.%d=%s:%d:%d", i+1, host, zookeeperPeerPort+i, zookeeperElectPort+i))
+	}
+	for i, zk := range opts.ZooKeepers {
+		port, err := endpointPort(zk)
+		if err != nil {
+			return nil, err
+		}
+		// a zookeeper reaches itself on the ports of its container
+		ensemble := make([]string, len(servers))
+		copy(ensemble, servers)
+		ensemble[i] = fmt.Sprintf("server.%d=0.0.0.0:%d:%d", i+1, zookeeperPeerPort, zookeeperElectPort)
+
+		name := fmt.Sprintf("zookeeper%d", i)
+		zookeepers = append(zookeepers, name)
+		compose.Services[name] = &Service{
+			ContainerName: name,
+			Image:         images.ZooKeeper,
+			Environment: []string{
+				fmt.Sprintf("ZOO_MY_ID=%d", i+1),
+				"ZOO_SERVERS=" + strings.Join(ensemble, " "),
+			},
+			Ports: []string{
+				fmt.Sprintf("%d:%d", port, zookeeperClientPort),
+				fmt.Sprintf("%d:%d", zookeeperPeerPort+i, zookeeperPeerPort),
+				fmt.Sprintf("%d:%d", zookeeperElectPort+i, zookeeperElectPort),
+			},
+		}
+	}
+
+	replicas := len(opts.Kafkas)
+	if replicas > 3 {
+		replicas = 3
+	}
+	minInsync := 1
+	if replicas == 3 {
+		minInsync = 2
+	}
+	for i, kafka := range opts.Kafkas {
+		host, err := endpointHost(kafka)
+		if err != nil {
+			return nil, err
+		}
+		port, err := endpointPort(kafka)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("kafka%d", i)
+		compose.Services[name] = &Service{
+			ContainerName: name,
+			Image:         images.Kafka,
+			Environment: []string{
+				"KAFKA_LOG_RETENTION_MS=-1",
+				"KAFKA_MESSAGE_MAX_BYTES=103809024",
+				"KAFKA_REPLICA_FETCH_MAX_BYTES=103809024",
+				fmt.Sprintf("KAFKA_BROKER_ID=%d", i),
+				fmt.Sprintf("KAFKA_LISTENERS=PLAINTEXT://:%d", port),
+				fmt.Sprintf("KAFKA_PORT=%d", port),
+				"KAFKA_ADVERTISED_HOST_NAME=" + host,
+				"KAFKA_ZOOKEEPER_CONNECT=" + strings.Join(opts.ZooKeepers, ","),
+				"KAFKA_UNCLEAN_LEADER_ELECTION_ENABLE=false",
+				fmt.Sprintf("KAFKA_DEFAULT_REPLICATION_FACTOR=%d", replicas),
+				fmt.Sprintf("KAFKA_MIN_INSYNC_REPLICAS=%d", minInsync),
+			},
+			Ports:     []string{fmt.Sprintf("%d:%d", port, port)},
+			DependsOn: zookeepers,
+		}
+	}
+	return compose, nil
+}
@@ -0,0 +1,73 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	"manageChain/channel"
+)
+
+func TestGenerateKafkaCompose(t *testing.T) {
+	opts := &Options{
+		Kafkas:     []string{"172.16.93.215:9092", "172.16.93.215:9093", "172.16.93.215:9094"},
+		ZooKeepers: []string{"172.16.93.215:2181", "172.16.93.215:2182", "172.16.93.215:2183"},
+	}
+	compose, err := generateKafkaCompose(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compose.Services) != 6 {
+		t.Fatalf("expected 6 services, got %d", len(compose.Services))
+	}
+
+	zk := compose.Services["zookeeper1"]
+	want := "ZOO_SERVERS=server.1=172.16.93.215:2888:3888 server.2=0.0.0.0:2888:3888 server.3=172.16.93.215:2890:3890"
+	if zk.Environment[1] != want {
+		t.Fatalf("expected %s, got %s", want, zk.Environment[1])
+	}
+	if zk.Ports[0] != "2182:2181" {
+		t.Fatalf("unexpected client port %s", zk.Ports[0])
+	}
+	if kafka := compose.Services["kafka2"]; len(kafka.DependsOn) != 3 || kafka.Ports[0] != "9094:9094" {
+		t.Fatalf("unexpected kafka2: %+v", kafka)
+	}
+
+	if _, err := generateKafkaCompose(&Options{Kafkas: opts.Kafkas}); err == nil {
+		t.Fatal("expected an error without zookeepers")
+	}
+}
+
+func TestGenerateOrgCompose(t *testing.T) {
+	org := testOrg(t, []*channel.ServiceNode{
+		testNode("peer0", "172.16.93.215:56051", "172.16.93.215:56051"),
+	}, []*channel.ServiceNode{
+		testNode("orderer0", "172.16.93.215:56050", "172.16.93.215:56050"),
+	})
+
+	compose, err := GenerateOrgCompose(org, &Options{GenesisBlock: "../orderer.block", CouchDB: true, GM: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compose.Services) != 3 {
+		t.Fatalf("expected 3 services, got %d", len(compose.Services))
+	}
+
+	peer := compose.Services["peer0.testorg1"]
+	if peer.Ports[0] != "56051:7051" || peer.Ports[1] != "56052:7052" {
+		t.Fatalf("unexpected peer ports %v", peer.Ports)
+	}
+	env := strings.Join(peer.Environment, "\n")
+	for _, want := range []string{"CORE_PEER_BCCSP_DEFAULT=GM", "CORE_LEDGER_STATE_STATEDATABASE=CouchDB", "CORE_PEER_LOCALMSPID=testorg1"} {
+		if !strings.Contains(env, want) {
+			t.Fatalf("%s is missing in peer environment", want)
+		}
+	}
+	if !strings.HasSuffix(peer.Volumes[2], "msp/testorg1/peers/peer0/tls:"+peerTLSDir) {
+		t.Fatalf("unexpected tls volume %s", peer.Volumes[2])
+	}
+
+	orderer := compose.Services["orderer0.testorg1"]
+	if orderer.Ports[0] != "56050:56050" {
+		t.Fatalf("unexpected orderer ports %v", orderer.Ports)
+	}
+}
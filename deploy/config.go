@@ -0,0 +1,98 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"manageChain/channel"
+)
+
+const envSuffix = ".env"
+
+// NodeEnv returns the environment of the peer or orderer id of org. It
+// overrides the core.yaml or orderer.yaml in the image with the MSP ID, the
+// TLS paths, the endpoints and the BCCSP provider manageChain generated the
+// crypto material and the genesis block for.
+func NodeEnv(org *channel.OrgInfo, id string, opts *Options) ([]string, error) {
+	images := withDefaults(opts.Images)
+	for _, n := range orderers(org) {
+		if n.sn.ID == id {
+			if err := validateNode(n, opts); err != nil {
+				return nil, err
+			}
+			port, err := n.port()
+			if err != nil {
+				return nil, err
+			}
+			return ordererEnv(n, port, opts)
+		}
+	}
+	for _, n := range peers(org) {
+		if n.sn.ID == id {
+			if err := validateNode(n, opts); err != nil {
+				return nil, err
+			}
+			env := peerEnv(n, peers(org)[0].externalEndpoint(), opts, images)
+			address, err := chaincodeAddress(n)
+			if err != nil {
+				return nil, err
+			}
+			env = append(env, "CORE_PEER_CHAINCODEADDRESS="+address)
+			if opts.CouchDB {
+				// the couchdb service the compose projects run next to the peer
+				env = append(env, couchDBEnv("couchdb."+n.name())...)
+			}
+			return env, nil
+		}
+	}
+	return nil, fmt.Errorf("org %s has no node %s", org.OrgName, id)
+}
+
+// WriteNodeConfigs writes the environment of every node of orgs in the
+// docker env-file format, as outputDir/<org>/<node>.env.
+// It returns the paths of the files written.
+func WriteNodeConfigs(orgs []*channel.OrgInfo, outputDir string, opts *Options) ([]string, error) {
+	if outputDir == "" {
+		outputDir = defaultOutputDir
+	}
+	var nodes []*node
+	for _, org := range orgs {
+		nodes = append(nodes, append(orderers(org), peers(org)...)...)
+	}
+	if err := validatePorts(nodes, false); err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, org := range orgs {
+		for _, n := range append(orderers(org), peers(org)...) {
+			env, err := NodeEnv(org, n.sn.ID, opts)
+			if err != nil {
+				return nil, err
+			}
+			var buf bytes.Buffer
+			buf.WriteString(strings.Join(env, "\n"))
+			buf.WriteString("\n")
+			file, err := writeFile(filepath.Join(outputDir, org.OrgName), n.name()+envSuffix, buf.Bytes(), 0644)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// chaincodeAddress is where the chaincode containers reach the peer on its host
+func chaincodeAddress(n *node) (string, error) {
+	port, err := n.port()
+	if err != nil {
+		return "", err
+	}
+	host, err := endpointHost(n.externalEndpoint())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d", host, port+1), nil
+}
@@ -0,0 +1,72 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	"manageChain/channel"
+)
+
+func TestNodeEnv(t *testing.T) {
+	org := testOrg(t, []*channel.ServiceNode{
+		testNode("peer0", "172.16.93.215:56051", "172.16.93.215:56051"),
+		testNode("peer9", "172.16.93.215:56951", "172.16.93.215:56951"),
+	}, []*channel.ServiceNode{
+		testNode("orderer0", "172.16.93.215:56050", "172.16.93.215:56050"),
+	})
+	opts := &Options{
+		GenesisBlock: "../orderer.block",
+		Kafkas:       []string{"172.16.93.215:9092", "172.16.93.215:9093"},
+		GM:           true,
+	}
+
+	env, err := NodeEnv(org, "peer0", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined := strings.Join(env, "\n")
+	for _, want := range []string{
+		"CORE_PEER_BCCSP_DEFAULT=GM",
+		"CORE_PEER_LOCALMSPID=testorg1",
+		"CORE_PEER_GOSSIP_BOOTSTRAP=172.16.93.215:56051",
+		"CORE_PEER_CHAINCODEADDRESS=172.16.93.215:56052",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("%s is missing", want)
+		}
+	}
+
+	env, err = NodeEnv(org, "orderer0", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined = strings.Join(env, "\n")
+	for _, want := range []string{
+		"ORDERER_GENERAL_BCCSP_DEFAULT=GM",
+		"ORDERER_GENERAL_LISTENPORT=56050",
+		"ORDERER_KAFKA_RETRY_SHORTTOTAL=30s",
+		"ORDERER_KAFKA_VERBOSE=true",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("%s is missing", want)
+		}
+	}
+	if strings.Contains(joined, "CONFIGTX_") {
+		t.Fatalf("unexpected configtxgen settings in %s", joined)
+	}
+	// the orderers only reach the brokers of the genesis block
+	if _, err := NodeEnv(org, "orderer0", &Options{GenesisBlock: "../orderer.block", Kafkas: []string{"172.16.93.215:9192"}}); err == nil {
+		t.Fatal("expected an error for a broker missing in the genesis block")
+	}
+
+	// peer9 has no crypto material
+	if _, err := NodeEnv(org, "peer9", opts); err == nil {
+		t.Fatal("expected an error for a node without bundle")
+	}
+	if _, err := NodeEnv(org, "orderer0", &Options{GenesisBlock: "missing.block"}); err == nil {
+		t.Fatal("expected an error without genesis block")
+	}
+	if _, err := NodeEnv(org, "peer1", opts); err == nil {
+		t.Fatal("expected an error for an unknown node")
+	}
+}
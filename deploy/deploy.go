@@ -0,0 +1,307 @@
+package deploy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"manageChain/logging"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"manageChain/channel"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var logger = logging.GetLogger("deploy")
+
+// node is a peer or orderer of an org with the paths of its crypto material
+type node struct {
+	org      *channel.OrgInfo
+	sn       *channel.ServiceNode
+	nodeType sdk.NodeType
+}
+
+// name is the name of the node's service and container
+func (n *node) name() string {
+	return n.sn.ID + "." + n.org.OrgName
+}
+
+// port is the port the node listens on at its host
+func (n *node) port() (int, error) {
+	return endpointPort(n.sn.Endpoint)
+}
+
+// externalEndpoint is the address other nodes reach the node at
+func (n *node) externalEndpoint() string {
+	if n.sn.ExternalEndpoint != "" {
+		return n.sn.ExternalEndpoint
+	}
+	return n.sn.Endpoint
+}
+
+func (n *node) mspDir() (string, error) {
+	return filepath.Abs(n.org.OrgCA.NodeMSPDir(n.sn.ID, n.nodeType))
+}
+
+func (n *node) tlsDir() (string, error) {
+	return filepath.Abs(n.org.OrgCA.NodeTLSDir(n.sn.ID, n.nodeType))
+}
+
+func peers(org *channel.OrgInfo) []*node {
+	var nodes []*node
+	for _, sn := range org.PeerNodes {
+		nodes = append(nodes, &node{org: org, sn: sn, nodeType: sdk.PeerNode})
+	}
+	return nodes
+}
+
+func orderers(org *channel.OrgInfo) []*node {
+	var nodes []*node
+	for _, sn := range org.OrdererNodes {
+		nodes = append(nodes, &node{org: org, sn: sn, nodeType: sdk.OrdererNode})
+	}
+	return nodes
+}
+
+func endpointPort(endpoint string) (int, error) {
+	_, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("invalid endpoint %s: %s", endpoint, err)
+	}
+	return strconv.Atoi(port)
+}
+
+func endpointHost(endpoint string) (string, error) {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %s: %s", endpoint, err)
+	}
+	return host, nil
+}
+
+// hostPorts are the ports n publishes on its host, a peer publishing its
+// chaincode and event ports right after its listen port
+func hostPorts(n *node) ([]int, error) {
+	port, err := n.port()
+	if err != nil {
+		return nil, err
+	}
+	if n.nodeType == sdk.PeerNode {
+		return []int{port, port + 1, port + 2}, nil
+	}
+	return []int{port}, nil
+}
+
+// validatePorts checks that no two nodes publish the same port on a host.
+// With sameHost all the nodes run on one host, as the services of a compose
+// project do, otherwise the host is the one of their external endpoints.
+func validatePorts(nodes []*node, sameHost bool) error {
+	used := make(map[string]*node)
+	for _, n := range nodes {
+		ports, err := hostPorts(n)
+		if err != nil {
+			return err
+		}
+		host := ""
+		if !sameHost {
+			if host, err = endpointHost(n.externalEndpoint()); err != nil {
+				return err
+			}
+		}
+		for _, port := range ports {
+			key := net.JoinHostPort(host, strconv.Itoa(port))
+			if other, ok := used[key]; ok && other != n {
+				return fmt.Errorf("port %d of %s is used by %s as well, the peers use the two ports after their endpoint for chaincode and events", port, n.name(), other.name())
+			}
+			used[key] = n
+		}
+	}
+	return nil
+}
+
+// withDefaults fills the images not given with the default ones
+func withDefaults(images *Images) *Images {
+	def := DefaultImages()
+	if images == nil {
+		return def
+	}
+	ret := *images
+	for _, f := range []struct{ v, d *string }{
+		{&ret.Peer, &def.Peer},
+		{&ret.Orderer, &def.Orderer},
+		{&ret.CCEnv, &def.CCEnv},
+		{&ret.BaseOS, &def.BaseOS},
+		{&ret.Kafka, &def.Kafka},
+		{&ret.ZooKeeper, &def.ZooKeeper},
+		{&ret.CouchDB, &def.CouchDB},
+	} {
+		if *f.v == "" {
+			*f.v = *f.d
+		}
+	}
+	return &ret
+}
+
+// OutputDir resolves dir of a request under base, the directory the
+// deployments are written to. dir must be a relative path staying in base.
+func OutputDir(base string, dir string) (string, error) {
+	if base == "" {
+		base = defaultOutputDir
+	}
+	if dir == "" {
+		return base, nil
+	}
+	if filepath.IsAbs(dir) || strings.HasPrefix(dir, "/") {
+		return "", fmt.Errorf("output dir %s is absolute", dir)
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(dir), "/") {
+		if elem == ".." {
+			return "", fmt.Errorf("output dir %s is outside of the deployments", dir)
+		}
+	}
+	return filepath.Join(base, dir), nil
+}
+
+// writeFile writes data to dir/name with perm, 0600 for the files carrying
+// private keys
+func writeFile(dir string, name string, data []byte, perm os.FileMode) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, data, perm); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(file, perm); err != nil {
+		return "", err
+	}
+	logger.Info("Successfully wrote %s", file)
+	return file, nil
+}
+
+func genesisBlockPath(opts *Options) (string, error) {
+	genesisBlock := opts.GenesisBlock
+	if genesisBlock == "" {
+		genesisBlock = defaultGenesisBlock
+	}
+	file, err := sdk.Store().Checkout(genesisBlock)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(file)
+}
+
+func withK8sDefaults(k8s *K8sOptions) *K8sOptions {
+	ret := K8sOptions{}
+	if k8s != nil {
+		ret = *k8s
+	}
+	if ret.ServiceType == "" {
+		ret.ServiceType = defaultServiceType
+	}
+	if ret.StorageSize == "" {
+		ret.StorageSize = defaultStorageSize
+	}
+	return &ret
+}
+
+// tarDir packs the files under dir into the gzipped tarball file, only
+// readable by the owner as the manifests carry private keys
+func tarDir(dir string, file string) (string, error) {
+	f, err := os.OpenFile(file, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := f.Chmod(0600); err != nil {
+		return "", err
+	}
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := gw.Close(); err != nil {
+		return "", err
+	}
+	logger.Info("Successfully packed %s", file)
+	return file, nil
+}
+
+// the files the environment of a node refers to, under its msp and tls directories
+var (
+	mspRequired = []string{"admincerts", "cacerts", "keystore", "signcerts"}
+	tlsRequired = []string{"server.crt", "server.key", "ca.crt"}
+)
+
+// validateNode checks that the bundle of n holds every file its configuration
+// refers to, and the genesis block for an orderer.
+func validateNode(n *node, opts *Options) error {
+	mspDir, err := n.mspDir()
+	if err != nil {
+		return err
+	}
+	for _, name := range mspRequired {
+		files, err := ioutil.ReadDir(filepath.Join(mspDir, name))
+		if err != nil || len(files) == 0 {
+			return fmt.Errorf("%s of %s is missing in %s", name, n.name(), mspDir)
+		}
+	}
+
+	tlsDir, err := n.tlsDir()
+	if err != nil {
+		return err
+	}
+	for _, name := range tlsRequired {
+		if _, err := os.Stat(filepath.Join(tlsDir, name)); err != nil {
+			return fmt.Errorf("%s of %s is missing in %s", name, n.name(), tlsDir)
+		}
+	}
+
+	if n.nodeType == sdk.OrdererNode {
+		genesisBlock, err := genesisBlockPath(opts)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(genesisBlock); err != nil {
+			return fmt.Errorf("genesis block of %s is missing: %s", n.name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package deploy
+
+import (
+	"manageChain/channel"
+	"manageChain/protocols"
+)
+
+const (
+	// paths of the crypto material and the genesis block in the containers
+	peerMSPDir     = "/var/hyperledger/msp"
+	peerTLSDir     = "/var/hyperledger/tls"
+	ordererMSPDir  = "/var/hyperledger/orderer/msp"
+	ordererTLSDir  = "/var/hyperledger/orderer/tls"
+	ordererGenesis = "/var/hyperledger/orderer/orderer.genesis.block"
+
+	peerListenPort      = 7051
+	peerChaincodePort   = 7052
+	peerEventPort       = 7053
+	couchDBPort         = 5984
+	zookeeperClientPort = 2181
+	zookeeperPeerPort   = 2888
+	zookeeperElectPort  = 3888
+
+	gmProvider = "GM"
+
+	defaultGenesisBlock = "orderer.block"
+	defaultOutputDir    = "deployments"
+	composeFile         = "docker-compose.yaml"
+	defaultServiceType  = "ClusterIP"
+	defaultStorageSize  = "10Gi"
+	// the default node port range of kubernetes, --service-node-port-range
+	minNodePort = 30000
+	maxNodePort = 32767
+	// kafkaProject holds the kafka and zookeeper services when composes are generated per org
+	kafkaProject = "kafka"
+)
+
+// the options of the requests, see protocols
+type (
+	Images     = protocols.Images
+	K8sOptions = protocols.K8sOptions
+)
+
+// DefaultImages ...
+func DefaultImages() *Images {
+	return &Images{
+		Peer:      "hyperledger/fabric-peer",
+		Orderer:   "hyperledger/fabric-orderer",
+		CCEnv:     "hyperledger/fabric-ccenv:latest",
+		BaseOS:    "hyperledger/fabric-baseos:latest",
+		Kafka:     "hyperledger/fabric-kafka",
+		ZooKeeper: "hyperledger/fabric-zookeeper",
+		CouchDB:   "hyperledger/fabric-couchdb",
+	}
+}
+
+// Options selects the optional services and settings of a deployment.
+// Kafkas and ZooKeepers are the host:port addresses of the brokers and the
+// client ports of the zookeepers to run, no such services are generated when empty.
+type Options struct {
+	GenesisBlock string
+	Kafkas       []string
+	ZooKeepers   []string
+	CouchDB      bool
+	GM           bool
+	Images       *Images
+}
+
+// ComposeRequest, NodeConfigRequest and ManifestRequest are sent as the
+// types of the same names in protocols
+type ComposeRequest struct {
+	Orgs         []*channel.OrgInfo
+	PerOrg       bool
+	OutputDir    string
+	GenesisBlock string
+	Kafkas       []string
+	ZooKeepers   []string
+	CouchDB      bool
+	Images       *Images
+}
+
+type NodeConfigRequest struct {
+	Orgs         []*channel.OrgInfo
+	OutputDir    string
+	GenesisBlock string
+	Kafkas       []string
+	CouchDB      bool
+	Images       *Images
+}
+
+type ManifestRequest struct {
+	Orgs         []*channel.OrgInfo
+	OutputDir    string
+	Tarball      bool
+	GenesisBlock string
+	CouchDB      bool
+	Images       *Images
+	K8sOptions   *K8sOptions
+}
+
+// Compose is a docker-compose project
+type Compose struct {
+	Version  string              `yaml:"version"`
+	Services map[string]*Service `yaml:"services"`
+}
+
+// Service is a docker-compose service
+type Service struct {
+	ContainerName string   `yaml:"container_name"`
+	Image         string   `yaml:"image"`
+	Environment   []string `yaml:"environment,omitempty"`
+	Volumes       []string `yaml:"volumes,omitempty"`
+	Ports         []string `yaml:"ports,omitempty"`
+	WorkingDir    string   `yaml:"working_dir,omitempty"`
+	Command       string   `yaml:"command,omitempty"`
+	DependsOn     []string `yaml:"depends_on,omitempty"`
+}
@@ -0,0 +1,97 @@
+package deploy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"manageChain/channel"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// testOrg is testorg1 of the msp directory with the peers and orderers given
+func testOrg(t *testing.T, peerNodes []*channel.ServiceNode, ordererNodes []*channel.ServiceNode) *channel.OrgInfo {
+	orgCA, err := sdk.ConstructCAFromDir("../msp/testorg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &channel.OrgInfo{
+		OrgName:      "testorg1",
+		OrgMSP:       "testorg1",
+		OrgCA:        orgCA,
+		PeerNodes:    peerNodes,
+		OrdererNodes: ordererNodes,
+	}
+}
+
+func testNode(id string, endpoint string, externalEndpoint string) *channel.ServiceNode {
+	return &channel.ServiceNode{ID: id, Endpoint: endpoint, ExternalEndpoint: externalEndpoint}
+}
+
+func TestOutputDir(t *testing.T) {
+	for _, c := range []struct {
+		base, dir, want string
+	}{
+		{"", "", defaultOutputDir},
+		{"/srv/deployments", "", "/srv/deployments"},
+		{"/srv/deployments", "net1/k8s", "/srv/deployments/net1/k8s"},
+		{"/srv/deployments", "net1/./k8s/", "/srv/deployments/net1/k8s"},
+	} {
+		if dir, err := OutputDir(c.base, c.dir); err != nil || dir != c.want {
+			t.Fatalf("OutputDir(%q, %q) = %s, %v", c.base, c.dir, dir, err)
+		}
+	}
+	for _, dir := range []string{"/etc", "..", "../etc", "net1/../../etc"} {
+		if _, err := OutputDir("/srv/deployments", dir); err == nil {
+			t.Fatalf("expected an error for %s", dir)
+		}
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	org := testOrg(t, []*channel.ServiceNode{
+		testNode("peer0", "172.16.93.215:7051", "172.16.93.215:7051"),
+		testNode("peer1", "172.16.93.216:7052", "172.16.93.216:7052"),
+	}, []*channel.ServiceNode{
+		testNode("orderer0", "172.16.93.215:7050", "172.16.93.215:7050"),
+	})
+	// the chaincode port of peer0 is the listen port of peer1 on the host of the project
+	if err := validatePorts(peers(org), true); err == nil {
+		t.Fatal("expected an error for the ports of peer0 and peer1")
+	}
+	// they run on two hosts
+	if err := validatePorts(append(orderers(org), peers(org)...), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GenerateOrgCompose(org, &Options{GenesisBlock: "../orderer.block"}); err == nil {
+		t.Fatal("expected an error for the ports of peer0 and peer1")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "peer0.yaml"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := writeFile(dir, "peer0.yaml", []byte("key"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(file); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("unexpected mode %v %v", info.Mode(), err)
+	}
+	tarball, err := tarDir(dir, dir+".tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tarball)
+	if info, err := os.Stat(tarball); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("unexpected mode %v %v", info.Mode(), err)
+	}
+}
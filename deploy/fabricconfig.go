@@ -0,0 +1,364 @@
+package deploy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// configDir holds the core.yaml or orderer.yaml of a node in kubernetes,
+	// FABRIC_CFG_PATH points the node at it
+	configDir     = "/etc/hyperledger/manageChain"
+	coreFile      = "core.yaml"
+	ordererFile   = "orderer.yaml"
+	corePrefix    = "CORE_"
+	ordererPrefix = "ORDERER_"
+)
+
+// coreDefaults are the settings of the core.yaml of fabric 1.2 the peers
+// need, the settings of a peer are applied on them.
+const coreDefaults = `
+peer:
+  id: jdoe
+  networkId: dev
+  listenAddress: 0.0.0.0:7051
+  address: 0.0.0.0:7051
+  addressAutoDetect: false
+  gomaxprocs: -1
+  keepalive:
+    minInterval: 60s
+    client:
+      interval: 60s
+      timeout: 20s
+    deliveryClient:
+      interval: 60s
+      timeout: 20s
+  gossip:
+    bootstrap: 127.0.0.1:7051
+    useLeaderElection: true
+    orgLeader: false
+    endpoint:
+    maxBlockCountToStore: 100
+    maxPropagationBurstLatency: 10ms
+    maxPropagationBurstSize: 10
+    propagateIterations: 1
+    propagatePeerNum: 3
+    pullInterval: 4s
+    pullPeerNum: 3
+    requestStateInfoInterval: 4s
+    publishStateInfoInterval: 4s
+    stateInfoRetentionInterval:
+    publishCertPeriod: 10s
+    skipBlockVerification: false
+    dialTimeout: 3s
+    connTimeout: 2s
+    recvBuffSize: 20
+    sendBuffSize: 200
+    digestWaitTime: 1s
+    requestWaitTime: 1500ms
+    responseWaitTime: 2s
+    aliveTimeInterval: 5s
+    aliveExpirationTimeout: 25s
+    reconnectInterval: 25s
+    externalEndpoint:
+    election:
+      startupGracePeriod: 15s
+      membershipSampleInterval: 1s
+      leaderAliveThreshold: 10s
+      leaderElectionDuration: 5s
+    pvtData:
+      pullRetryThreshold: 60s
+      transientstoreMaxBlockRetention: 1000
+      pushAckTimeout: 3s
+      btlPullMargin: 10
+  events:
+    address: 0.0.0.0:7053
+    buffersize: 100
+    timeout: 10ms
+    timewindow: 15m
+    keepalive:
+      minInterval: 60s
+    sendTimeout: 60s
+  tls:
+    enabled: false
+    clientAuthRequired: false
+    cert:
+      file: tls/server.crt
+    key:
+      file: tls/server.key
+    rootcert:
+      file: tls/ca.crt
+    clientRootCAs:
+      files:
+      - tls/ca.crt
+    clientKey:
+      file:
+    clientCert:
+      file:
+  authentication:
+    timewindow: 15m
+  fileSystemPath: /var/hyperledger/production
+  BCCSP:
+    Default: SW
+    SW:
+      Hash: SHA2
+      Security: 256
+      FileKeyStore:
+        KeyStore:
+  mspConfigPath: msp
+  localMspId: SampleOrg
+  client:
+    connTimeout: 3s
+  deliveryclient:
+    reconnectTotalTimeThreshold: 3600s
+    connTimeout: 3s
+    reConnectBackoffThreshold: 3600s
+  localMspType: bccsp
+  profile:
+    enabled: false
+    listenAddress: 0.0.0.0:6060
+  handlers:
+    authFilters:
+    - name: DefaultAuth
+    - name: ExpirationCheck
+    decorators:
+    - name: DefaultDecorator
+    endorsers:
+      escc:
+        name: DefaultEndorsement
+        library:
+    validators:
+      vscc:
+        name: DefaultValidation
+        library:
+  validatorPoolSize:
+  discovery:
+    enabled: true
+    authCacheEnabled: true
+    authCacheMaxSize: 1000
+    authCachePurgeRetentionRatio: 0.75
+    orgMembersAllowedAccess: false
+vm:
+  endpoint: unix:///var/run/docker.sock
+  docker:
+    tls:
+      enabled: false
+      ca:
+        file: docker/ca.crt
+      cert:
+        file: docker/tls.crt
+      key:
+        file: docker/tls.key
+    attachStdout: false
+    hostConfig:
+      NetworkMode: host
+      Dns:
+      LogConfig:
+        Type: json-file
+        Config:
+          max-size: "50m"
+          max-file: "5"
+      Memory: 2147483648
+chaincode:
+  id:
+    path:
+    name:
+  builder: hyperledger/fabric-ccenv:latest
+  pull: false
+  golang:
+    runtime: hyperledger/fabric-baseos:latest
+    dynamicLink: false
+  car:
+    runtime: hyperledger/fabric-baseos:latest
+  java:
+    runtime: hyperledger/fabric-javaenv:latest
+  node:
+    runtime: hyperledger/fabric-baseimage:latest
+  startuptimeout: 300s
+  executetimeout: 30s
+  mode: net
+  keepalive: 0
+  system:
+    cscc: enable
+    lscc: enable
+    escc: enable
+    vscc: enable
+    qscc: enable
+  logging:
+    level: info
+    shim: warning
+    format: '%{color}%{time:2006-01-02 15:04:05.000 MST} [%{module}] %{shortfunc} -> %{level:.4s} %{id:03x}%{color:reset} %{message}'
+ledger:
+  state:
+    stateDatabase: goleveldb
+    totalQueryLimit: 100000
+    couchDBConfig:
+      couchDBAddress: 127.0.0.1:5984
+      username:
+      password:
+      maxRetries: 3
+      maxRetriesOnStartup: 10
+      requestTimeout: 35s
+      internalQueryLimit: 1000
+      maxBatchUpdateSize: 1000
+      warmIndexesAfterNBlocks: 1
+  history:
+    enableHistoryDatabase: true
+logging:
+  level: info
+  cauthdsl: warning
+  gossip: warning
+  grpc: error
+  ledger: info
+  msp: warning
+  policies: warning
+  peer:
+    gossip: warning
+  format: '%{color}%{time:2006-01-02 15:04:05.000 MST} [%{module}] %{shortfunc} -> %{level:.4s} %{id:03x}%{color:reset} %{message}'
+metrics:
+  enabled: false
+`
+
+// ordererDefaults are the settings of the orderer.yaml of fabric 1.2, the
+// settings of an orderer are applied on them.
+const ordererDefaults = `
+General:
+  LedgerType: file
+  ListenAddress: 127.0.0.1
+  ListenPort: 7050
+  TLS:
+    Enabled: false
+    PrivateKey: tls/server.key
+    Certificate: tls/server.crt
+    RootCAs:
+    - tls/ca.crt
+    ClientAuthRequired: false
+    ClientRootCAs:
+  Keepalive:
+    ServerMinInterval: 60s
+    ServerInterval: 7200s
+    ServerTimeout: 20s
+  LogLevel: info
+  LogFormat: '%{color}%{time:2006-01-02 15:04:05.000 MST} [%{module}] %{shortfunc} -> %{level:.4s} %{id:03x}%{color:reset} %{message}'
+  GenesisMethod: provisional
+  GenesisProfile: SampleInsecureSolo
+  GenesisFile: genesisblock
+  LocalMSPDir: msp
+  LocalMSPID: SampleOrg
+  Profile:
+    Enabled: false
+    Address: 0.0.0.0:6060
+  BCCSP:
+    Default: SW
+    SW:
+      Hash: SHA2
+      Security: 256
+      FileKeyStore:
+        KeyStore:
+  Authentication:
+    TimeWindow: 15m
+FileLedger:
+  Location: /var/hyperledger/production/orderer
+  Prefix: hyperledger-fabric-ordererledger
+RAMLedger:
+  HistorySize: 1000
+Kafka:
+  Retry:
+    ShortInterval: 5s
+    ShortTotal: 10m
+    LongInterval: 5m
+    LongTotal: 12h
+    NetworkTimeouts:
+      DialTimeout: 10s
+      ReadTimeout: 10s
+      WriteTimeout: 10s
+    Metadata:
+      RetryBackoff: 250ms
+      RetryMax: 3
+    Producer:
+      RetryBackoff: 100ms
+      RetryMax: 3
+    Consumer:
+      RetryBackoff: 2s
+  Topic:
+    ReplicationFactor: 3
+  Verbose: false
+  TLS:
+    Enabled: false
+    PrivateKey:
+    Certificate:
+    RootCAs:
+  Version: 0.10.2.0
+Debug:
+  BroadcastTraceDir:
+  DeliverTraceDir:
+`
+
+// nodeConfig renders the core.yaml of a peer or the orderer.yaml of an
+// orderer with the settings of env, the environment of the node. A variable
+// sets the key its name is the path of after the prefix, as the nodes read
+// their environment, the keys being case insensitive.
+func nodeConfig(nodeEnv []string, ordererNode bool) (string, []byte, error) {
+	file, defaults, prefix := coreFile, coreDefaults, corePrefix
+	if ordererNode {
+		file, defaults, prefix = ordererFile, ordererDefaults, ordererPrefix
+	}
+	config := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(defaults), &config); err != nil {
+		return "", nil, err
+	}
+	for _, kv := range nodeEnv {
+		i := strings.Index(kv, "=")
+		if i < 0 || !strings.HasPrefix(kv, prefix) {
+			return "", nil, fmt.Errorf("invalid setting %s of %s", kv, file)
+		}
+		path := strings.Split(strings.ToLower(kv[len(prefix):i]), "_")
+		config = setConfig(config, path, configValue(kv[i+1:]))
+	}
+	data, err := yaml.Marshal(config)
+	return file, data, err
+}
+
+// setConfig sets the key of path in config, creating the maps missing
+func setConfig(config yaml.MapSlice, path []string, value interface{}) yaml.MapSlice {
+	for i, item := range config {
+		key, _ := item.Key.(string)
+		if strings.ToLower(key) != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			config[i].Value = value
+		} else {
+			sub, _ := item.Value.(yaml.MapSlice)
+			config[i].Value = setConfig(sub, path[1:], value)
+		}
+		return config
+	}
+	if len(path) == 1 {
+		return append(config, yaml.MapItem{Key: path[0], Value: value})
+	}
+	return append(config, yaml.MapItem{Key: path[0], Value: setConfig(nil, path[1:], value)})
+}
+
+// configValue is the yaml value of a setting, [a,b] being a list
+func configValue(s string) interface{} {
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		var values []string
+		for _, v := range strings.Split(s[1:len(s)-1], ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		return values
+	}
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package deploy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+const (
+	consensusSolo  = "solo"
+	consensusKafka = "kafka"
+)
+
+// consensus is the ordering service the genesis block configures, the
+// orderers take their consensus type and kafka brokers from it
+type consensus struct {
+	Type    string
+	Brokers []string
+}
+
+// genesisConsensus reads the consensus of the genesis block of opts
+func genesisConsensus(opts *Options) (*consensus, error) {
+	genesisBlock, err := genesisBlockPath(opts)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(genesisBlock)
+	if err != nil {
+		return nil, err
+	}
+	block := &cb.Block{}
+	if err := proto.Unmarshal(data, block); err != nil {
+		return nil, fmt.Errorf("invalid genesis block %s: %s", genesisBlock, err)
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	configEnv := &cb.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return nil, err
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return nil, fmt.Errorf("genesis block %s has no channel group", genesisBlock)
+	}
+	orderer := configEnv.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if orderer == nil || orderer.Values[channelconfig.ConsensusTypeKey] == nil {
+		return nil, fmt.Errorf("genesis block %s has no consensus type", genesisBlock)
+	}
+
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(orderer.Values[channelconfig.ConsensusTypeKey].Value, consensusType); err != nil {
+		return nil, err
+	}
+	ret := &consensus{Type: consensusType.Type}
+	switch ret.Type {
+	case consensusSolo:
+	case consensusKafka:
+		brokers := &ab.KafkaBrokers{}
+		if value := orderer.Values[channelconfig.KafkaBrokersKey]; value != nil {
+			if err := proto.Unmarshal(value.Value, brokers); err != nil {
+				return nil, err
+			}
+		}
+		if len(brokers.Brokers) == 0 {
+			return nil, fmt.Errorf("genesis block %s has no kafka brokers", genesisBlock)
+		}
+		ret.Brokers = brokers.Brokers
+	default:
+		return nil, fmt.Errorf("consensus type %s of genesis block %s is not supported", ret.Type, genesisBlock)
+	}
+	return ret, nil
+}
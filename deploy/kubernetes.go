@@ -0,0 +1,482 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"manageChain/channel"
+
+	"github.com/hyperledger/fabric/sdk"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	dataDir        = "/var/hyperledger/production"
+	dockerSocket   = "/var/run/docker.sock"
+	genesisKey     = "orderer.genesis.block"
+	manifestSuffix = ".yaml"
+)
+
+type k8sMeta struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+type k8sSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMeta           `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	Data       map[string]string `yaml:"data"`
+}
+
+type k8sConfigMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMeta           `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+}
+
+type k8sService struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   k8sMeta        `yaml:"metadata"`
+	Spec       k8sServiceSpec `yaml:"spec"`
+}
+
+type k8sServiceSpec struct {
+	Type           string            `yaml:"type"`
+	Selector       map[string]string `yaml:"selector"`
+	Ports          []k8sServicePort  `yaml:"ports"`
+	LoadBalancerIP string            `yaml:"loadBalancerIP,omitempty"`
+}
+
+type k8sServicePort struct {
+	Name       string `yaml:"name"`
+	Port       int    `yaml:"port"`
+	TargetPort int    `yaml:"targetPort"`
+	NodePort   int    `yaml:"nodePort,omitempty"`
+}
+
+type k8sStatefulSet struct {
+	APIVersion string             `yaml:"apiVersion"`
+	Kind       string             `yaml:"kind"`
+	Metadata   k8sMeta            `yaml:"metadata"`
+	Spec       k8sStatefulSetSpec `yaml:"spec"`
+}
+
+type k8sStatefulSetSpec struct {
+	ServiceName          string         `yaml:"serviceName"`
+	Replicas             int            `yaml:"replicas"`
+	Selector             k8sSelector    `yaml:"selector"`
+	Template             k8sPodTemplate `yaml:"template"`
+	VolumeClaimTemplates []k8sPVC       `yaml:"volumeClaimTemplates"`
+}
+
+type k8sSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type k8sPodTemplate struct {
+	Metadata k8sMeta    `yaml:"metadata"`
+	Spec     k8sPodSpec `yaml:"spec"`
+}
+
+type k8sPodSpec struct {
+	Containers []k8sContainer `yaml:"containers"`
+	Volumes    []k8sVolume    `yaml:"volumes"`
+}
+
+type k8sContainer struct {
+	Name         string           `yaml:"name"`
+	Image        string           `yaml:"image"`
+	Command      []string         `yaml:"command,omitempty"`
+	WorkingDir   string           `yaml:"workingDir,omitempty"`
+	Env          []k8sEnvVar      `yaml:"env,omitempty"`
+	Ports        []k8sPort        `yaml:"ports,omitempty"`
+	VolumeMounts []k8sVolumeMount `yaml:"volumeMounts,omitempty"`
+}
+
+type k8sEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type k8sPort struct {
+	ContainerPort int `yaml:"containerPort"`
+}
+
+type k8sVolumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	SubPath   string `yaml:"subPath,omitempty"`
+}
+
+type k8sVolume struct {
+	Name      string              `yaml:"name"`
+	Secret    *k8sSecretVolume    `yaml:"secret,omitempty"`
+	ConfigMap *k8sConfigMapVolume `yaml:"configMap,omitempty"`
+	HostPath  *k8sHostPathVolume  `yaml:"hostPath,omitempty"`
+}
+
+type k8sSecretVolume struct {
+	SecretName string         `yaml:"secretName"`
+	Items      []k8sKeyToPath `yaml:"items,omitempty"`
+}
+
+type k8sConfigMapVolume struct {
+	Name string `yaml:"name"`
+}
+
+type k8sKeyToPath struct {
+	Key  string `yaml:"key"`
+	Path string `yaml:"path"`
+}
+
+type k8sHostPathVolume struct {
+	Path string `yaml:"path"`
+}
+
+type k8sPVC struct {
+	Metadata k8sMeta    `yaml:"metadata"`
+	Spec     k8sPVCSpec `yaml:"spec"`
+}
+
+type k8sPVCSpec struct {
+	AccessModes      []string     `yaml:"accessModes"`
+	StorageClassName string       `yaml:"storageClassName,omitempty"`
+	Resources        k8sResources `yaml:"resources"`
+}
+
+type k8sResources struct {
+	Requests map[string]string `yaml:"requests"`
+}
+
+// WriteManifests writes the kubernetes manifests of the nodes of orgs under
+// outputDir, one directory per org and one file per node. With tarball the
+// directory is packed into outputDir.tar.gz as well.
+// It returns the paths of the files written.
+func WriteManifests(orgs []*channel.OrgInfo, outputDir string, tarball bool, opts *Options, k8s *K8sOptions) ([]string, error) {
+	if outputDir == "" {
+		outputDir = defaultOutputDir
+	}
+	k8s = withK8sDefaults(k8s)
+	var files []string
+	for _, org := range orgs {
+		manifests, err := GenerateManifests(org, opts, k8s)
+		if err != nil {
+			return nil, err
+		}
+		for name, data := range manifests {
+			// the Secrets carry the private keys of the nodes
+			file, err := writeFile(filepath.Join(outputDir, org.OrgName), name, data, 0600)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	if tarball {
+		file, err := tarDir(outputDir, outputDir+".tar.gz")
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// GenerateManifests renders the manifests of the peers and orderers of org,
+// keyed by file name. Every node gets a StatefulSet with its ledger on a PVC,
+// a Service of k8s.ServiceType, a ConfigMap of its core.yaml or orderer.yaml
+// and Secrets of its MSP and TLS material. The orderers share a Secret of the genesis block.
+func GenerateManifests(org *channel.OrgInfo, opts *Options, k8s *K8sOptions) (map[string][]byte, error) {
+	images := withDefaults(opts.Images)
+	k8s = withK8sDefaults(k8s)
+	manifests := make(map[string][]byte)
+	if k8s.ServiceType == "NodePort" {
+		if err := validateNodePorts(append(orderers(org), peers(org)...)); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(org.OrdererNodes) != 0 {
+		genesisBlock, err := genesisBlockPath(opts)
+		if err != nil {
+			return nil, err
+		}
+		block, err := ioutil.ReadFile(genesisBlock)
+		if err != nil {
+			return nil, err
+		}
+		secret := newSecret(genesisSecret(org), k8s.Namespace, map[string][]byte{genesisKey: block})
+		if manifests[genesisSecret(org)+manifestSuffix], err = marshalDocuments(secret); err != nil {
+			return nil, err
+		}
+	}
+
+	bootstrap := ""
+	if len(org.PeerNodes) != 0 {
+		bootstrap = peers(org)[0].externalEndpoint()
+	}
+	for _, n := range append(orderers(org), peers(org)...) {
+		docs, err := nodeManifests(n, bootstrap, opts, k8s, images)
+		if err != nil {
+			return nil, err
+		}
+		if manifests[k8sName(n)+manifestSuffix], err = marshalDocuments(docs...); err != nil {
+			return nil, err
+		}
+	}
+	return manifests, nil
+}
+
+// validateNodePorts checks that the ports of the external endpoints of the
+// nodes, their node ports, are in the node port range and that no two nodes
+// use the same one, the node ports being open on every node of the cluster.
+func validateNodePorts(nodes []*node) error {
+	used := make(map[int]*node)
+	for _, n := range nodes {
+		port, err := endpointPort(n.externalEndpoint())
+		if err != nil {
+			return err
+		}
+		if port < minNodePort || port > maxNodePort {
+			return fmt.Errorf("the port %d of the external endpoint of %s is its node port, it must be in %d-%d", port, n.name(), minNodePort, maxNodePort)
+		}
+		if other, ok := used[port]; ok {
+			return fmt.Errorf("node port %d of %s is used by %s as well", port, n.name(), other.name())
+		}
+		used[port] = n
+	}
+	return nil
+}
+
+func nodeManifests(n *node, bootstrap string, opts *Options, k8s *K8sOptions, images *Images) ([]interface{}, error) {
+	name := k8sName(n)
+	labels := map[string]string{"app": name, "org": n.org.OrgName}
+	if err := validateNode(n, opts); err != nil {
+		return nil, err
+	}
+
+	mspDir, err := n.mspDir()
+	if err != nil {
+		return nil, err
+	}
+	tlsDir, err := n.tlsDir()
+	if err != nil {
+		return nil, err
+	}
+	mspFiles, mspItems, err := readDir(mspDir)
+	if err != nil {
+		return nil, err
+	}
+	tlsFiles, tlsItems, err := readDir(tlsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	container := k8sContainer{Name: name}
+	volumes := []k8sVolume{
+		{Name: "msp", Secret: &k8sSecretVolume{SecretName: name + "-msp", Items: mspItems}},
+		{Name: "tls", Secret: &k8sSecretVolume{SecretName: name + "-tls", Items: tlsItems}},
+	}
+	var env []string
+	var containerPort int
+	var servicePorts []k8sServicePort
+
+	externalPort, err := endpointPort(n.externalEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	if n.nodeType == sdk.PeerNode {
+		containerPort = peerListenPort
+		env = peerEnv(n, bootstrap, opts, images)
+		// chaincode containers are started by the docker daemon of the node,
+		// they call back through the service of the peer
+		env = append(env, fmt.Sprintf("CORE_PEER_CHAINCODEADDRESS=%s:%d", name, peerChaincodePort))
+		if opts.CouchDB {
+			env = append(env, couchDBEnv("localhost")...)
+		}
+		container.Image = images.Peer
+		container.Command = []string{"peer", "node", "start"}
+		container.WorkingDir = "/opt/gopath/src/github.com/hyperledger/fabric/peer"
+		container.Ports = []k8sPort{{peerListenPort}, {peerChaincodePort}, {peerEventPort}}
+		container.VolumeMounts = []k8sVolumeMount{
+			{Name: "msp", MountPath: peerMSPDir},
+			{Name: "tls", MountPath: peerTLSDir},
+			{Name: "docker", MountPath: "/host" + dockerSocket},
+			{Name: "data", MountPath: dataDir},
+		}
+		volumes = append(volumes, k8sVolume{Name: "docker", HostPath: &k8sHostPathVolume{Path: dockerSocket}})
+		servicePorts = append(servicePorts,
+			k8sServicePort{Name: "grpc", Port: externalPort, TargetPort: peerListenPort},
+			k8sServicePort{Name: "chaincode", Port: peerChaincodePort, TargetPort: peerChaincodePort},
+			k8sServicePort{Name: "event", Port: peerEventPort, TargetPort: peerEventPort},
+		)
+	} else {
+		if containerPort, err = n.port(); err != nil {
+			return nil, err
+		}
+		if env, err = ordererEnv(n, containerPort, opts); err != nil {
+			return nil, err
+		}
+		container.Image = images.Orderer
+		container.Command = []string{"orderer"}
+		container.WorkingDir = "/opt/gopath/src/github.com/hyperledger/fabric/orderer"
+		container.Ports = []k8sPort{{containerPort}}
+		container.VolumeMounts = []k8sVolumeMount{
+			{Name: "msp", MountPath: ordererMSPDir},
+			{Name: "tls", MountPath: ordererTLSDir},
+			{Name: "genesis", MountPath: ordererGenesis, SubPath: genesisKey},
+			{Name: "data", MountPath: dataDir},
+		}
+		volumes = append(volumes, k8sVolume{Name: "genesis", Secret: &k8sSecretVolume{SecretName: genesisSecret(n.org)}})
+		servicePorts = append(servicePorts, k8sServicePort{Name: "grpc", Port: externalPort, TargetPort: containerPort})
+	}
+	// the node reads the core.yaml or orderer.yaml of its ConfigMap instead of the one of the image
+	configFile, config, err := nodeConfig(env, n.nodeType == sdk.OrdererNode)
+	if err != nil {
+		return nil, err
+	}
+	container.Env = []k8sEnvVar{{Name: "FABRIC_CFG_PATH", Value: configDir}}
+	container.VolumeMounts = append(container.VolumeMounts, k8sVolumeMount{Name: "config", MountPath: configDir})
+	volumes = append(volumes, k8sVolume{Name: "config", ConfigMap: &k8sConfigMapVolume{Name: name + "-config"}})
+
+	containers := []k8sContainer{container}
+	if n.nodeType == sdk.PeerNode && opts.CouchDB {
+		containers = append(containers, k8sContainer{
+			Name:  "couchdb",
+			Image: images.CouchDB,
+			Ports: []k8sPort{{couchDBPort}},
+		})
+	}
+
+	service := &k8sService{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   k8sMeta{Name: name, Namespace: k8s.Namespace, Labels: labels},
+		Spec: k8sServiceSpec{
+			Type:     k8s.ServiceType,
+			Selector: labels,
+			Ports:    servicePorts,
+		},
+	}
+	// the external endpoint is served by the node port or the load balancer
+	switch k8s.ServiceType {
+	case "NodePort":
+		service.Spec.Ports[0].NodePort = externalPort
+	case "LoadBalancer":
+		if host, err := endpointHost(n.externalEndpoint()); err == nil && net.ParseIP(host) != nil {
+			service.Spec.LoadBalancerIP = host
+		}
+	}
+
+	statefulSet := &k8sStatefulSet{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Metadata:   k8sMeta{Name: name, Namespace: k8s.Namespace, Labels: labels},
+		Spec: k8sStatefulSetSpec{
+			ServiceName: name,
+			Replicas:    1,
+			Selector:    k8sSelector{MatchLabels: labels},
+			Template: k8sPodTemplate{
+				Metadata: k8sMeta{Name: name, Labels: labels},
+				Spec:     k8sPodSpec{Containers: containers, Volumes: volumes},
+			},
+			VolumeClaimTemplates: []k8sPVC{{
+				Metadata: k8sMeta{Name: "data"},
+				Spec: k8sPVCSpec{
+					AccessModes:      []string{"ReadWriteOnce"},
+					StorageClassName: k8s.StorageClass,
+					Resources:        k8sResources{Requests: map[string]string{"storage": k8s.StorageSize}},
+				},
+			}},
+		},
+	}
+
+	return []interface{}{
+		newSecret(name+"-msp", k8s.Namespace, mspFiles),
+		newSecret(name+"-tls", k8s.Namespace, tlsFiles),
+		newConfigMap(name+"-config", k8s.Namespace, map[string]string{configFile: string(config)}),
+		service,
+		statefulSet,
+	}, nil
+}
+
+// k8sName is the name of the node's objects, k8s names can't contain dots
+func k8sName(n *node) string {
+	return strings.ToLower(strings.Replace(n.name(), ".", "-", -1))
+}
+
+func genesisSecret(org *channel.OrgInfo) string {
+	return strings.ToLower(org.OrgName) + "-genesis"
+}
+
+func newSecret(name string, namespace string, files map[string][]byte) *k8sSecret {
+	data := make(map[string]string)
+	for k, v := range files {
+		data[k] = base64.StdEncoding.EncodeToString(v)
+	}
+	return &k8sSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   k8sMeta{Name: name, Namespace: namespace},
+		Type:       "Opaque",
+		Data:       data,
+	}
+}
+
+func newConfigMap(name string, namespace string, data map[string]string) *k8sConfigMap {
+	return &k8sConfigMap{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   k8sMeta{Name: name, Namespace: namespace},
+		Data:       data,
+	}
+}
+
+// readDir reads the files under dir for a Secret. Secret keys are flat, the
+// items put every file back at its path under dir when the Secret is mounted.
+func readDir(dir string) (map[string][]byte, []k8sKeyToPath, error) {
+	files := make(map[string][]byte)
+	var items []k8sKeyToPath
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		key := strings.Replace(filepath.ToSlash(rel), "/", "_", -1)
+		files[key] = data
+		items = append(items, k8sKeyToPath{Key: key, Path: filepath.ToSlash(rel)})
+		return nil
+	})
+	return files, items, err
+}
+
+func marshalDocuments(docs ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,140 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	"manageChain/channel"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestNodeManifests(t *testing.T) {
+	org := testOrg(t, []*channel.ServiceNode{
+		testNode("peer0", "172.16.93.215:56051", "172.16.93.215:31051"),
+	}, nil)
+	n := peers(org)[0]
+	k8s := withK8sDefaults(&K8sOptions{Namespace: "fabric", ServiceType: "NodePort"})
+
+	docs, err := nodeManifests(n, n.externalEndpoint(), &Options{CouchDB: true}, k8s, DefaultImages())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 5 {
+		t.Fatalf("expected 5 objects, got %d", len(docs))
+	}
+
+	msp := docs[0].(*k8sSecret)
+	if msp.Metadata.Name != "peer0-testorg1-msp" || len(msp.Data) == 0 {
+		t.Fatalf("unexpected msp secret %s with %d files", msp.Metadata.Name, len(msp.Data))
+	}
+	for key := range msp.Data {
+		if strings.Contains(key, "/") {
+			t.Fatalf("invalid secret key %s", key)
+		}
+	}
+
+	config := docs[2].(*k8sConfigMap)
+	core := struct {
+		Peer struct {
+			LocalMspID string `yaml:"localMspId"`
+			TLS        struct {
+				Enabled bool
+			}
+			FileSystemPath string `yaml:"fileSystemPath"`
+		}
+		Ledger struct {
+			State struct {
+				CouchDBConfig struct {
+					CouchDBAddress string `yaml:"couchDBAddress"`
+				} `yaml:"couchDBConfig"`
+			}
+		}
+	}{}
+	if err := yaml.Unmarshal([]byte(config.Data[coreFile]), &core); err != nil {
+		t.Fatal(err)
+	}
+	if core.Ledger.State.CouchDBConfig.CouchDBAddress != "localhost:5984" {
+		t.Fatalf("unexpected couchdb address %s", core.Ledger.State.CouchDBConfig.CouchDBAddress)
+	}
+	if core.Peer.LocalMspID != "testorg1" || !core.Peer.TLS.Enabled || core.Peer.FileSystemPath != dataDir {
+		t.Fatalf("unexpected peer config %+v", core.Peer)
+	}
+
+	service := docs[3].(*k8sService)
+	if service.Spec.Type != "NodePort" || service.Spec.Ports[0].NodePort != 31051 || service.Spec.Ports[0].TargetPort != peerListenPort {
+		t.Fatalf("unexpected service %+v", service.Spec)
+	}
+
+	statefulSet := docs[4].(*k8sStatefulSet)
+	if len(statefulSet.Spec.Template.Spec.Containers) != 2 {
+		t.Fatal("expected a couchdb container")
+	}
+	if c := statefulSet.Spec.Template.Spec.Containers[0]; c.Env[0].Value != configDir || c.VolumeMounts[len(c.VolumeMounts)-1].MountPath != configDir {
+		t.Fatalf("the core.yaml of the ConfigMap is not used: %+v", c)
+	}
+	if statefulSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests["storage"] != defaultStorageSize {
+		t.Fatal("expected the default storage size")
+	}
+}
+
+func TestValidateNodePorts(t *testing.T) {
+	org := testOrg(t, []*channel.ServiceNode{
+		testNode("peer0", "172.16.93.215:7051", "172.16.93.215:31051"),
+		testNode("peer1", "172.16.93.216:7051", "172.16.93.216:31052"),
+	}, nil)
+	if err := validateNodePorts(peers(org)); err != nil {
+		t.Fatal(err)
+	}
+	// the node ports are open on every node of the cluster
+	org.PeerNodes[1].ExternalEndpoint = "172.16.93.216:31051"
+	if err := validateNodePorts(peers(org)); err == nil {
+		t.Fatal("expected an error for the node ports of peer0 and peer1")
+	}
+	org.PeerNodes[1].ExternalEndpoint = "172.16.93.216:7051"
+	if _, err := GenerateManifests(org, &Options{}, &K8sOptions{ServiceType: "NodePort"}); err == nil {
+		t.Fatal("expected an error for the node port out of range")
+	}
+}
+
+func TestNodeConfig(t *testing.T) {
+	_, data, err := nodeConfig([]string{
+		"ORDERER_GENERAL_LISTENPORT=7050",
+		"ORDERER_GENERAL_TLS_ROOTCAS=[/var/hyperledger/orderer/tls/ca.crt]",
+		"ORDERER_KAFKA_VERBOSE=true",
+		"ORDERER_GENERAL_BCCSP_DEFAULT=GM",
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderer := struct {
+		General struct {
+			ListenPort int `yaml:"ListenPort"`
+			TLS        struct {
+				RootCAs []string `yaml:"RootCAs"`
+			} `yaml:"TLS"`
+			BCCSP struct {
+				Default string `yaml:"Default"`
+			} `yaml:"BCCSP"`
+		} `yaml:"General"`
+		Kafka struct {
+			Verbose bool `yaml:"Verbose"`
+			Retry   struct {
+				LongTotal string `yaml:"LongTotal"`
+			} `yaml:"Retry"`
+		} `yaml:"Kafka"`
+	}{}
+	if err := yaml.Unmarshal(data, &orderer); err != nil {
+		t.Fatal(err)
+	}
+	if orderer.General.ListenPort != 7050 || len(orderer.General.TLS.RootCAs) != 1 || orderer.General.BCCSP.Default != "GM" {
+		t.Fatalf("unexpected general config %+v", orderer.General)
+	}
+	// the defaults of the keys not set are kept
+	if !orderer.Kafka.Verbose || orderer.Kafka.Retry.LongTotal != "12h" {
+		t.Fatalf("unexpected kafka config %+v", orderer.Kafka)
+	}
+	if _, _, err := nodeConfig([]string{"CORE_PEER_ID=peer0"}, true); err == nil {
+		t.Fatal("expected an error for a setting of a peer")
+	}
+}
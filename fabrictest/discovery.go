@@ -0,0 +1,149 @@
+package fabrictest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/gossip"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// Discover answers the config and peer membership queries of the channels
+// the peer has joined, the peers of the network being alive members.
+func (p *peer) Discover(ctx context.Context, sr *discovery.SignedRequest) (*discovery.Response, error) {
+	req := &discovery.Request{}
+	if err := proto.Unmarshal(sr.Payload, req); err != nil {
+		return nil, err
+	}
+
+	p.network.mutex.Lock()
+	defer p.network.mutex.Unlock()
+	resp := &discovery.Response{}
+	for _, q := range req.Queries {
+		resp.Results = append(resp.Results, p.discover(q))
+	}
+	return resp, nil
+}
+
+func (p *peer) discover(q *discovery.Query) *discovery.QueryResult {
+	if !p.joined[q.Channel] {
+		return discoveryError(fmt.Errorf("access denied"))
+	}
+	l := p.network.ledgers[q.Channel]
+	switch q.Query.(type) {
+	case *discovery.Query_ConfigQuery:
+		config, err := configResult(l.config.ChannelGroup)
+		if err != nil {
+			return discoveryError(err)
+		}
+		return &discovery.QueryResult{Result: &discovery.QueryResult_ConfigResult{ConfigResult: config}}
+	case *discovery.Query_PeerQuery:
+		members := &discovery.PeerMembershipResult{PeersByOrg: make(map[string]*discovery.Peers)}
+		for _, member := range p.network.peers {
+			if !member.joined[q.Channel] {
+				continue
+			}
+			if members.PeersByOrg[member.mspID] == nil {
+				members.PeersByOrg[member.mspID] = &discovery.Peers{}
+			}
+			peers := members.PeersByOrg[member.mspID]
+			peers.Peers = append(peers.Peers, member.discoveryPeer(l))
+		}
+		return &discovery.QueryResult{Result: &discovery.QueryResult_Members{Members: members}}
+	}
+	return discoveryError(fmt.Errorf("unsupported query %T", q.Query))
+}
+
+// discoveryPeer is the peer as gossip sees it on the channel of l
+func (p *peer) discoveryPeer(l *ledger) *discovery.Peer {
+	pkiID := []byte(p.address)
+	timestamp := &gossip.PeerTime{IncNum: 1, SeqNum: 1}
+	properties := &gossip.Properties{LedgerHeight: l.height()}
+	for _, name := range sortedKeys(l.chaincodes) {
+		cc := l.chaincodes[name]
+		if _, ok := p.installed[cc.Name+":"+cc.Version]; ok {
+			properties.Chaincodes = append(properties.Chaincodes, &gossip.Chaincode{Name: cc.Name, Version: cc.Version})
+		}
+	}
+	alive := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_AliveMsg{AliveMsg: &gossip.AliveMessage{
+			Membership: &gossip.Member{Endpoint: p.address, PkiId: pkiID},
+			Timestamp:  timestamp,
+		}},
+	}
+	stateInfo := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{StateInfo: &gossip.StateInfo{
+			Timestamp:  timestamp,
+			PkiId:      pkiID,
+			Properties: properties,
+		}},
+	}
+	return &discovery.Peer{
+		MembershipInfo: &gossip.Envelope{Payload: utils.MarshalOrPanic(alive)},
+		StateInfo:      &gossip.Envelope{Payload: utils.MarshalOrPanic(stateInfo)},
+		Identity:       p.identity,
+	}
+}
+
+// configResult is the MSPs of the orgs of a channel and its orderers
+func configResult(channelGroup *cb.ConfigGroup) (*discovery.ConfigResult, error) {
+	result := &discovery.ConfigResult{
+		Msps:     make(map[string]*mspprotos.FabricMSPConfig),
+		Orderers: make(map[string]*discovery.Endpoints),
+	}
+	addresses := &cb.OrdererAddresses{}
+	if value, ok := channelGroup.Values[channelconfig.OrdererAddressesKey]; ok {
+		if err := proto.Unmarshal(value.Value, addresses); err != nil {
+			return nil, err
+		}
+	}
+	endpoints := &discovery.Endpoints{}
+	for _, address := range addresses.Addresses {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		portNum, err := strconv.ParseUint(port, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		endpoints.Endpoint = append(endpoints.Endpoint, &discovery.Endpoint{Host: host, Port: uint32(portNum)})
+	}
+
+	for _, groupKey := range []string{channelconfig.ApplicationGroupKey, channelconfig.OrdererGroupKey} {
+		group, ok := channelGroup.Groups[groupKey]
+		if !ok {
+			continue
+		}
+		for _, org := range group.Groups {
+			value, ok := org.Values[channelconfig.MSPKey]
+			if !ok {
+				continue
+			}
+			mspConfig := &mspprotos.MSPConfig{}
+			if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+				return nil, err
+			}
+			fabricConfig := &mspprotos.FabricMSPConfig{}
+			if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+				return nil, err
+			}
+			result.Msps[fabricConfig.Name] = fabricConfig
+			if groupKey == channelconfig.OrdererGroupKey {
+				result.Orderers[fabricConfig.Name] = endpoints
+			}
+		}
+	}
+	return result, nil
+}
+
+func discoveryError(err error) *discovery.QueryResult {
+	return &discovery.QueryResult{Result: &discovery.QueryResult_Error{Error: &discovery.Error{Content: err.Error()}}}
+}
@@ -0,0 +1,275 @@
+// Package fabrictest runs a fake fabric network in process for tests. Its
+// orderers serve AtomicBroadcast and its peers serve the endorser, deliver and
+// discovery services over TLS on 127.0.0.1, sharing the channel ledgers kept
+// in memory, so the manageChain flows run without docker or live nodes.
+package fabrictest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"manageChain/logging"
+	"math/big"
+	"net"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+var logger = logging.GetLogger("fabrictest")
+
+// Network is a fake fabric network, its nodes share the ledgers of the
+// channels and the chaincodes they run.
+type Network struct {
+	mutex         sync.Mutex
+	systemChannel string
+	ledgers       map[string]*ledger
+	chaincodes    map[string]shim.Chaincode
+	peers         []*peer
+	servers       []*comm.GRPCServer
+	// rejected are the channels whose next config update is rejected
+	rejected map[string]bool
+}
+
+// NewNetwork returns a network without nodes, Bootstrap creates its system channel
+func NewNetwork() *Network {
+	return &Network{
+		ledgers:    make(map[string]*ledger),
+		chaincodes: make(map[string]shim.Chaincode),
+		rejected:   make(map[string]bool),
+	}
+}
+
+// RejectConfigUpdate makes the orderers reject the next config update of
+// chainID, e.g. to fail an operation halfway
+func (n *Network) RejectConfigUpdate(chainID string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.rejected[chainID] = true
+}
+
+// Bootstrap creates the system channel of the orderers from its genesis block
+func (n *Network) Bootstrap(genesis *cb.Block) error {
+	l, err := newLedger(genesis)
+	if err != nil {
+		return err
+	}
+	chainID, err := utils.GetChainIDFromBlock(genesis)
+	if err != nil {
+		return err
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.systemChannel = chainID
+	n.ledgers[chainID] = l
+	return nil
+}
+
+// RegisterChaincode runs cc for the chaincode name once it is installed and instantiated
+func (n *Network) RegisterChaincode(name string, cc shim.Chaincode) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.chaincodes[name] = cc
+}
+
+// StartOrderer starts an orderer with a TLS certificate issued by the TLS CA
+// of the org under orgDir, and returns its address.
+func (n *Network) StartOrderer(orgDir string) (string, error) {
+	server, err := n.serve(orgDir)
+	if err != nil {
+		return "", err
+	}
+	ab.RegisterAtomicBroadcastServer(server.Server(), &orderer{network: n})
+	return n.start(server), nil
+}
+
+// StartPeer starts a peer of the org mspID with a TLS certificate issued by
+// the TLS CA of the org under orgDir, and returns its address.
+func (n *Network) StartPeer(orgDir string, mspID string) (string, error) {
+	server, err := n.serve(orgDir)
+	if err != nil {
+		return "", err
+	}
+	cert, err := x509.ParseCertificate(server.ServerCertificate().Certificate[0])
+	if err != nil {
+		return "", err
+	}
+	identity, err := proto.Marshal(&mspprotos.SerializedIdentity{
+		Mspid:   mspID,
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+	})
+	if err != nil {
+		return "", err
+	}
+	p := &peer{
+		network:   n,
+		mspID:     mspID,
+		address:   server.Address(),
+		identity:  identity,
+		joined:    make(map[string]bool),
+		installed: make(map[string]*pb.ChaincodeInfo),
+	}
+	pb.RegisterEndorserServer(server.Server(), p)
+	pb.RegisterDeliverServer(server.Server(), p)
+	discovery.RegisterDiscoveryServer(server.Server(), p)
+
+	n.mutex.Lock()
+	n.peers = append(n.peers, p)
+	n.mutex.Unlock()
+	return n.start(server), nil
+}
+
+// Stop stops every node of the network
+func (n *Network) Stop() {
+	n.mutex.Lock()
+	servers := n.servers
+	n.servers = nil
+	n.mutex.Unlock()
+	for _, server := range servers {
+		server.Stop()
+	}
+}
+
+func (n *Network) serve(orgDir string) (*comm.GRPCServer, error) {
+	cert, key, err := serverCert(filepath.Join(orgDir, "tlsca"))
+	if err != nil {
+		logger.Error("Error issuing server certificate: %s", err)
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	server, err := comm.NewGRPCServerFromListener(listener, comm.ServerConfig{
+		SecOpts: &comm.SecureOptions{
+			UseTLS:      true,
+			Certificate: cert,
+			Key:         key,
+		},
+	})
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return server, nil
+}
+
+func (n *Network) start(server *comm.GRPCServer) string {
+	n.mutex.Lock()
+	n.servers = append(n.servers, server)
+	n.mutex.Unlock()
+	go server.Start()
+	return server.Address()
+}
+
+// ledger returns the ledger of chainID, n.mutex must be held
+func (n *Network) ledger(chainID string) (*ledger, error) {
+	l, ok := n.ledgers[chainID]
+	if !ok {
+		return nil, fmt.Errorf("channel %s does not exist", chainID)
+	}
+	return l, nil
+}
+
+// serverCert issues a certificate for 127.0.0.1 from the TLS CA under caDir
+func serverCert(caDir string) ([]byte, []byte, error) {
+	caCert, caKey, err := readCA(caDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// readCA reads the certificate and the key of a CA generated by the sdk
+func readCA(caDir string) (*x509.Certificate, crypto.Signer, error) {
+	certs, err := filepath.Glob(filepath.Join(caDir, "*-cert.pem"))
+	if err != nil {
+		return nil, nil, err
+	}
+	keys, err := filepath.Glob(filepath.Join(caDir, "*_sk"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(certs) == 0 || len(keys) == 0 {
+		return nil, nil, fmt.Errorf("no CA under %s", caDir)
+	}
+	cert, err := readPEM(certs[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	caCert, err := x509.ParseCertificate(cert)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := readPEM(keys[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	caKey, err := x509.ParsePKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := caKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("CA key is not a signer")
+	}
+	return caCert, signer, nil
+}
+
+func readPEM(file string) ([]byte, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", file)
+	}
+	return block.Bytes, nil
+}
@@ -0,0 +1,330 @@
+package fabrictest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+const (
+	testOrgDir  = "../msp/testorg1"
+	testMSP     = "testorg1"
+	testChannel = "mychannel"
+)
+
+// kvChaincode puts the pairs of its init args, then puts and gets keys
+type kvChaincode struct{}
+
+func (cc *kvChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetStringArgs()
+	for i := 0; i+1 < len(args); i += 2 {
+		if err := stub.PutState(args[i], []byte(args[i+1])); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+	return shim.Success(nil)
+}
+
+func (cc *kvChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	fcn, args := stub.GetFunctionAndParameters()
+	switch {
+	case fcn == "put" && len(args) == 2:
+		if err := stub.PutState(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case fcn == "get" && len(args) == 1:
+		value, err := stub.GetState(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(value)
+	}
+	return shim.Error("unknown function " + fcn)
+}
+
+type testNetwork struct {
+	*Network
+	ca      *sdk.CA
+	client  *sdk.Client
+	orderer *sdk.Endpoint
+	peer    *sdk.Endpoint
+}
+
+func startNetwork(t *testing.T) *testNetwork {
+	ca, err := sdk.ConstructCAFromDir(testOrgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := sdk.NewClient(ca.AdminCommonName(), testMSP, ca.AdminMSPDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := NewNetwork()
+	ordererAddr, err := n.StartOrderer(testOrgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerAddr, err := n.StartPeer(testOrgDir, testMSP)
+	if err != nil {
+		n.Stop()
+		t.Fatal(err)
+	}
+	org := &sdk.Organization{Name: testMSP, ID: testMSP, MSPDir: ca.MSPDir()}
+	genesis := sdk.CreateGenesisBlock(&sdk.GenesisConfig{
+		ChainID:                 sdk.DefaultSystemChainID,
+		OrdererType:             "kafka",
+		Addresses:               []string{ordererAddr},
+		KafkaBrokers:            []string{"127.0.0.1:9092"},
+		AdminsPolicy:            sdk.PolicyMajorityAdmins,
+		WritersPolicy:           sdk.PolicyAnyWriters,
+		ReadersPolicy:           sdk.PolicyAnyReaders,
+		OrdererOrganizations:    []*sdk.Organization{org},
+		ConsortiumOrganizations: []*sdk.Organization{org},
+		ConsortiumName:          sdk.DefaultConsortium,
+	})
+	if err := n.Bootstrap(genesis); err != nil {
+		n.Stop()
+		t.Fatal(err)
+	}
+	n.RegisterChaincode("kv", &kvChaincode{})
+	return &testNetwork{
+		Network: n,
+		ca:      ca,
+		client:  client,
+		orderer: &sdk.Endpoint{Address: ordererAddr, TLS: ca.TLSCACert(), Timeout: 3 * time.Second},
+		peer:    &sdk.Endpoint{Address: peerAddr, TLS: ca.TLSCACert(), Timeout: 3 * time.Second},
+	}
+}
+
+// createChannel creates testChannel and joins the peer to it
+func (n *testNetwork) createChannel(t *testing.T) {
+	err := n.client.CreateChannel(&sdk.ChannelConfig{
+		ChainID:       testChannel,
+		Consortium:    sdk.DefaultConsortium,
+		AdminsPolicy:  sdk.PolicyMajorityAdmins,
+		WritersPolicy: sdk.PolicyAnyWriters,
+		ReadersPolicy: sdk.PolicyAnyReaders,
+		Organizations: []*sdk.Organization{{Name: testMSP, ID: testMSP, MSPDir: n.ca.MSPDir()}},
+	}, n.orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := n.client.GetBlockByChannel(testChannel, 0, n.orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.client.JoinChannel(testChannel, block, []*sdk.Endpoint{n.peer}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (n *testNetwork) get(t *testing.T, key string) string {
+	_, _, resps, err := n.client.Endorse(testChannel, "kv", [][]byte{[]byte("get"), []byte(key)}, nil, []*sdk.Endpoint{n.peer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resps[0].Response.Status != shim.OK {
+		t.Fatalf("get %s failed: %s", key, resps[0].Response.Message)
+	}
+	return string(resps[0].Response.Payload)
+}
+
+func TestChannel(t *testing.T) {
+	n := startNetwork(t)
+	defer n.Stop()
+	n.createChannel(t)
+
+	channels, err := n.client.QueryChannels(n.peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(channels, []string{testChannel}) {
+		t.Fatalf("unexpected channels: %v", channels)
+	}
+	block, err := n.client.GetBlockByChannel(testChannel, 0, n.orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.client.JoinChannel(testChannel, block, []*sdk.Endpoint{n.peer}); err == nil {
+		t.Fatal("joining a channel twice should fail")
+	}
+
+	// a config update adds an org to the channel
+	block, err = n.client.GetConfigBlockByChannel(testChannel, n.orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca2, err := sdk.ConstructCAFromDir("../msp/testorg2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	org2 := &sdk.Organization{Name: "testorg2", ID: "testorg2", MSPDir: ca2.MSPDir()}
+	if err := n.client.UpdateChannel(testChannel, block, nil, []*sdk.Organization{org2}, nil, nil, n.orderer); err != nil {
+		t.Fatal(err)
+	}
+	block, err = n.client.GetConfigBlockByChannel(testChannel, n.orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgs, err := sdk.ApplicationOrgs(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 2 {
+		t.Fatalf("unexpected orgs: %v", orgs)
+	}
+
+	if _, err := n.client.GetBlockByChannel("nonexistent", 0, n.orderer); err == nil {
+		t.Fatal("a channel that does not exist should not be delivered")
+	}
+}
+
+func TestChaincode(t *testing.T) {
+	n := startNetwork(t)
+	defer n.Stop()
+	n.createChannel(t)
+
+	if err := n.client.InstallChaincode("kv", "1.0", "kv", []byte("code"), []*sdk.Endpoint{n.peer}); err != nil {
+		t.Fatal(err)
+	}
+	installed, err := n.client.QueryInstalledChaincodes(n.peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(installed, []sdk.Chaincode{{Name: "kv", Version: "1.0"}}) {
+		t.Fatalf("unexpected installed chaincodes: %v", installed)
+	}
+	txID, err := n.client.InstantiateChaincodeTx(testChannel, "kv", "1.0", [][]byte{[]byte("a"), []byte("1")}, "OR('testorg1.member')", nil, n.peer, []*sdk.Endpoint{n.orderer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := n.client.WaitTx(testChannel, txID, n.peer, 5*time.Second)
+	if err != nil || !valid {
+		t.Fatalf("instantiation is not committed: %v", err)
+	}
+	if value := n.get(t, "a"); value != "1" {
+		t.Fatalf("unexpected value %s", value)
+	}
+
+	txID, prop, resps, err := n.client.Endorse(testChannel, "kv", [][]byte{[]byte("put"), []byte("a"), []byte("2")}, nil, []*sdk.Endpoint{n.peer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the state changes once the transaction is ordered
+	if value := n.get(t, "a"); value != "1" {
+		t.Fatalf("unexpected value %s", value)
+	}
+	if err := n.client.Broadcast(prop, resps, n.orderer); err != nil {
+		t.Fatal(err)
+	}
+	if valid, err := n.client.WaitTx(testChannel, txID, n.peer, 5*time.Second); err != nil || !valid {
+		t.Fatalf("invocation is not committed: %v", err)
+	}
+	if valid, err := n.client.ValidTx(testChannel, txID, n.peer); err != nil || !valid {
+		t.Fatalf("invocation is not valid: %v", err)
+	}
+	if value := n.get(t, "a"); value != "2" {
+		t.Fatalf("unexpected value %s", value)
+	}
+
+	instantiated, err := n.client.QueryInstantiatedChaincodes(testChannel, n.peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(instantiated, []sdk.Chaincode{{Name: "kv", Version: "1.0"}}) {
+		t.Fatalf("unexpected instantiated chaincodes: %v", instantiated)
+	}
+
+	// the invocation is the last block of the ledger
+	info, err := n.client.QueryChainInfo(testChannel, n.peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := n.client.QueryBlock(testChannel, info.Height-1, n.peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs := sdk.BlockTxs(block)
+	if len(txs) != 1 || txs[0].TxID != txID || txs[0].Type != cb.HeaderType_ENDORSER_TRANSACTION ||
+		txs[0].ValidationCode != pb.TxValidationCode_VALID || !reflect.DeepEqual(txs[0].Signers, []string{testMSP}) {
+		t.Fatalf("unexpected transactions %+v", txs)
+	}
+	tx, err := n.client.QueryTransaction(testChannel, txID, n.peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx := sdk.ParseEnvelope(tx.TransactionEnvelope, pb.TxValidationCode(tx.ValidationCode)); ctx == nil || ctx.TxID != txID || ctx.Timestamp.IsZero() {
+		t.Fatalf("unexpected transaction %+v", ctx)
+	}
+	if _, err := n.client.QueryBlock(testChannel, info.Height, n.peer); err == nil {
+		t.Fatal("a block beyond the height should not be found")
+	}
+}
+
+func TestDiscovery(t *testing.T) {
+	n := startNetwork(t)
+	defer n.Stop()
+	n.createChannel(t)
+
+	msps, err := n.client.DiscoveryChannel(testChannel, n.peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, ok := msps[testMSP]
+	if !ok {
+		t.Fatalf("%s is not discovered: %v", testMSP, msps)
+	}
+	if len(conf.RootCert) == 0 || len(conf.TLSCert) == 0 {
+		t.Fatal("certificates of the msp are not discovered")
+	}
+	if _, ok := conf.Nodes[n.peer.Address]; !ok {
+		t.Fatalf("peer is not discovered: %v", conf.Nodes)
+	}
+	if _, ok := conf.Nodes[n.orderer.Address]; !ok {
+		t.Fatalf("orderer is not discovered: %v", conf.Nodes)
+	}
+
+	if _, err := n.client.DiscoveryChannel("nonexistent", n.peer); err == nil {
+		t.Fatal("a channel the peer has not joined should not be discovered")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	n := startNetwork(t)
+	defer n.Stop()
+	n.createChannel(t)
+
+	err := n.client.CreateChannel(&sdk.ChannelConfig{
+		ChainID:       testChannel,
+		Consortium:    sdk.DefaultConsortium,
+		AdminsPolicy:  sdk.PolicyMajorityAdmins,
+		WritersPolicy: sdk.PolicyAnyWriters,
+		ReadersPolicy: sdk.PolicyAnyReaders,
+		Organizations: []*sdk.Organization{{Name: testMSP, ID: testMSP, MSPDir: n.ca.MSPDir()}},
+	}, n.orderer)
+	var oe *sdk.OrdererError
+	if !sdk.AsError(err, &oe) || oe.Status != cb.Status_BAD_REQUEST || oe.Address != n.orderer.Address {
+		t.Fatalf("creating a channel twice should be rejected by the orderer: %v", err)
+	}
+
+	block, err := n.client.GetBlockByChannel(testChannel, 0, n.orderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = n.client.JoinChannel(testChannel, block, []*sdk.Endpoint{n.peer})
+	var ee *sdk.EndorsementError
+	if !sdk.AsError(err, &ee) || ee.Address != n.peer.Address {
+		t.Fatalf("joining a channel twice should not be endorsed: %v", err)
+	}
+
+	_, err = n.client.WaitTx(testChannel, "unknown", n.peer, 100*time.Millisecond)
+	if _, ok := err.(*sdk.TimeoutError); !ok || !sdk.IsTimeout(err) {
+		t.Fatalf("waiting for a transaction that is not sent should time out: %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package fabrictest
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// ledger is the chain of a channel with its config and the state of its
+// chaincodes, every transaction is valid.
+type ledger struct {
+	blocks     []*cb.Block
+	lastConfig uint64
+	config     *cb.Config
+	txs        map[string]*pb.ProcessedTransaction
+	// instantiated chaincodes by name, and their state
+	chaincodes map[string]*pb.ChaincodeInfo
+	state      map[string]map[string][]byte
+	// simulation results of the endorsed transactions not ordered yet, by tx ID
+	pending map[string]*simulation
+	// signal is closed when a block is appended
+	signal chan struct{}
+}
+
+// simulation is the result of endorsing a transaction: the state written by
+// a chaincode, nil values being deleted, and the chaincode it instantiates.
+type simulation struct {
+	chaincode string
+	writes    map[string][]byte
+	deploy    *pb.ChaincodeInfo
+}
+
+func newLedger(genesis *cb.Block) (*ledger, error) {
+	config, err := blockConfig(genesis)
+	if err != nil {
+		return nil, err
+	}
+	return &ledger{
+		blocks:     []*cb.Block{genesis},
+		config:     config,
+		txs:        make(map[string]*pb.ProcessedTransaction),
+		chaincodes: make(map[string]*pb.ChaincodeInfo),
+		state:      make(map[string]map[string][]byte),
+		pending:    make(map[string]*simulation),
+		signal:     make(chan struct{}),
+	}, nil
+}
+
+// blockConfig returns the config a config block carries
+func blockConfig(block *cb.Block) (*cb.Config, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	configEnv := &cb.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return nil, err
+	}
+	if configEnv.Config == nil {
+		return nil, fmt.Errorf("block %d is not a config block", block.Header.Number)
+	}
+	return configEnv.Config, nil
+}
+
+func (l *ledger) height() uint64 {
+	return uint64(len(l.blocks))
+}
+
+// block returns block num, or nil and a channel closed once more blocks are appended
+func (l *ledger) block(num uint64) (*cb.Block, <-chan struct{}) {
+	if num < l.height() {
+		return l.blocks[num], nil
+	}
+	return nil, l.signal
+}
+
+// configBlock returns the last config block
+func (l *ledger) configBlock() *cb.Block {
+	return l.blocks[l.lastConfig]
+}
+
+// updateConfig appends a CONFIG transaction carrying configEnv
+func (l *ledger) updateConfig(chainID string, configEnv *cb.ConfigEnvelope) error {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, chainID, nil, configEnv, 0, 0)
+	if err != nil {
+		return err
+	}
+	l.lastConfig = l.height()
+	l.config = configEnv.Config
+	l.append(env)
+	return nil
+}
+
+// commit appends an endorser transaction and applies what it simulated
+func (l *ledger) commit(txID string, env *cb.Envelope) {
+	if sim, ok := l.pending[txID]; ok {
+		delete(l.pending, txID)
+		if sim.deploy != nil {
+			l.chaincodes[sim.deploy.Name] = sim.deploy
+		}
+		state := l.state[sim.chaincode]
+		if state == nil {
+			state = make(map[string][]byte)
+			l.state[sim.chaincode] = state
+		}
+		for key, value := range sim.writes {
+			if value == nil {
+				delete(state, key)
+			} else {
+				state[key] = value
+			}
+		}
+	}
+	l.txs[txID] = &pb.ProcessedTransaction{
+		TransactionEnvelope: env,
+		ValidationCode:      int32(pb.TxValidationCode_VALID),
+	}
+	l.append(env)
+}
+
+// append cuts a block of env and wakes up the deliveries waiting for it
+func (l *ledger) append(env *cb.Envelope) {
+	prev := l.blocks[len(l.blocks)-1]
+	block := cb.NewBlock(l.height(), prev.Header.Hash())
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: l.lastConfig}),
+	})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(pb.TxValidationCode_VALID)}
+	l.blocks = append(l.blocks, block)
+
+	close(l.signal)
+	l.signal = make(chan struct{})
+}
+
+// stateOf copies the state of a chaincode
+func (l *ledger) stateOf(chaincode string) map[string][]byte {
+	state := make(map[string][]byte)
+	for key, value := range l.state[chaincode] {
+		state[key] = value
+	}
+	return state
+}
@@ -0,0 +1,325 @@
+package fabrictest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// orderer serves AtomicBroadcast, ordering every transaction in a block of its own
+type orderer struct {
+	network *Network
+}
+
+func (o *orderer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	for {
+		env, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp := &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
+		if err := o.network.order(env); err != nil {
+			logger.Error("Error ordering transaction: %s", err)
+			resp = &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+		}
+		if err := srv.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (o *orderer) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
+	for {
+		env, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		status, err := o.network.deliver(srv.Context(), env, nil, func(block *cb.Block) error {
+			return srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Block{Block: block}})
+		})
+		if err != nil {
+			return err
+		}
+		if err := srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Status{Status: status}}); err != nil {
+			return err
+		}
+	}
+}
+
+// order appends env to the ledger of its channel. A config update creates
+// the channel when it does not exist.
+func (n *Network) order(env *cb.Envelope) error {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return err
+	}
+	if payload.Header == nil {
+		return fmt.Errorf("missing header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return err
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG_UPDATE:
+		if _, ok := n.ledgers[chdr.ChannelId]; !ok {
+			return n.createChannel(chdr.ChannelId, env)
+		}
+		return n.updateConfig(chdr.ChannelId, env)
+	case cb.HeaderType_ENDORSER_TRANSACTION:
+		l, err := n.ledger(chdr.ChannelId)
+		if err != nil {
+			return err
+		}
+		l.commit(chdr.TxId, env)
+		return nil
+	}
+	return fmt.Errorf("transactions of type %s are not supported", cb.HeaderType(chdr.Type))
+}
+
+// updateConfig applies a config update to an existing channel, every
+// signature set satisfies the policies.
+func (n *Network) updateConfig(chainID string, env *cb.Envelope) error {
+	if n.rejected[chainID] {
+		delete(n.rejected, chainID)
+		return fmt.Errorf("the config update of %s is rejected", chainID)
+	}
+	l := n.ledgers[chainID]
+	validator, err := configtx.NewValidatorImpl(chainID, l.config, channelconfig.RootGroupKey, acceptAll{})
+	if err != nil {
+		return err
+	}
+	configEnv, err := validator.ProposeConfigUpdate(env)
+	if err != nil {
+		return err
+	}
+	return l.updateConfig(chainID, configEnv)
+}
+
+// createChannel creates a channel of a consortium of the system channel the
+// way orderers do, applying the config update to a template built from the
+// consortium.
+func (n *Network) createChannel(chainID string, env *cb.Envelope) error {
+	system, ok := n.ledgers[n.systemChannel]
+	if !ok {
+		return fmt.Errorf("the network is not bootstrapped")
+	}
+	template, err := channelTemplate(system.config.ChannelGroup, env)
+	if err != nil {
+		return err
+	}
+	validator, err := configtx.NewValidatorImpl(chainID, template, channelconfig.RootGroupKey, acceptAll{})
+	if err != nil {
+		return err
+	}
+	configEnv, err := validator.ProposeConfigUpdate(env)
+	if err != nil {
+		return err
+	}
+	genesis, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, chainID, nil, configEnv, 0, 0)
+	if err != nil {
+		return err
+	}
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(genesis)}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 0}),
+	})
+	l, err := newLedger(block)
+	if err != nil {
+		return err
+	}
+	n.ledgers[chainID] = l
+	logger.Info("channel %s is created", chainID)
+	return nil
+}
+
+// channelTemplate is the config a channel creation update applies to: the
+// orderer config of the system channel and the orgs of the consortium
+// the update names.
+func channelTemplate(systemGroup *cb.ConfigGroup, env *cb.Envelope) (*cb.Config, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	updateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	update, err := configtx.UnmarshalConfigUpdate(updateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if update.WriteSet == nil {
+		return nil, fmt.Errorf("config update has no write set")
+	}
+	value, ok := update.WriteSet.Values[channelconfig.ConsortiumKey]
+	if !ok {
+		return nil, fmt.Errorf("config update does not name a consortium")
+	}
+	consortium := &cb.Consortium{}
+	if err := proto.Unmarshal(value.Value, consortium); err != nil {
+		return nil, err
+	}
+	consortiums, ok := systemGroup.Groups[channelconfig.ConsortiumsGroupKey]
+	if !ok {
+		return nil, fmt.Errorf("the system channel has no consortiums")
+	}
+	consortiumGroup, ok := consortiums.Groups[consortium.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown consortium %s", consortium.Name)
+	}
+
+	creationPolicy := &cb.Policy{}
+	if value, ok := consortiumGroup.Values[channelconfig.ChannelCreationPolicyKey]; ok {
+		if err := proto.Unmarshal(value.Value, creationPolicy); err != nil {
+			return nil, err
+		}
+	}
+	application := cb.NewConfigGroup()
+	application.Policies[channelconfig.ChannelCreationPolicyKey] = &cb.ConfigPolicy{Policy: creationPolicy}
+	application.ModPolicy = channelconfig.ChannelCreationPolicyKey
+	if writeApp, ok := update.WriteSet.Groups[channelconfig.ApplicationGroupKey]; ok {
+		for orgName := range writeApp.Groups {
+			org, ok := consortiumGroup.Groups[orgName]
+			if !ok {
+				return nil, fmt.Errorf("org %s is not a member of consortium %s", orgName, consortium.Name)
+			}
+			application.Groups[orgName] = proto.Clone(org).(*cb.ConfigGroup)
+		}
+	}
+
+	channelGroup := cb.NewConfigGroup()
+	for key, value := range systemGroup.Values {
+		channelGroup.Values[key] = proto.Clone(value).(*cb.ConfigValue)
+	}
+	for key, policy := range systemGroup.Policies {
+		channelGroup.Policies[key] = proto.Clone(policy).(*cb.ConfigPolicy)
+	}
+	channelGroup.Groups[channelconfig.OrdererGroupKey] = proto.Clone(systemGroup.Groups[channelconfig.OrdererGroupKey]).(*cb.ConfigGroup)
+	channelGroup.Groups[channelconfig.ApplicationGroupKey] = application
+	channelGroup.Values[channelconfig.ConsortiumKey] = &cb.ConfigValue{
+		Value:     utils.MarshalOrPanic(&cb.Consortium{Name: consortium.Name}),
+		ModPolicy: channelconfig.AdminsPolicyKey,
+	}
+	channelGroup.ModPolicy = systemGroup.ModPolicy
+	zeroVersions(channelGroup)
+	return &cb.Config{ChannelGroup: channelGroup}, nil
+}
+
+// zeroVersions resets the versions of a template, the versions of the system
+// channel do not apply to a new channel.
+func zeroVersions(group *cb.ConfigGroup) {
+	group.Version = 0
+	for _, value := range group.Values {
+		value.Version = 0
+	}
+	for _, policy := range group.Policies {
+		policy.Version = 0
+	}
+	for _, sub := range group.Groups {
+		zeroVersions(sub)
+	}
+}
+
+// deliver sends the blocks env seeks, waiting for the blocks not cut yet
+// unless the seek fails if not ready, and returns the final status. The
+// channel must be joined when joined is set.
+func (n *Network) deliver(ctx context.Context, env *cb.Envelope, joined func(string) bool, send func(*cb.Block) error) (cb.Status, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil || payload.Header == nil {
+		return cb.Status_BAD_REQUEST, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return cb.Status_BAD_REQUEST, nil
+	}
+	seek := &ab.SeekInfo{}
+	if err := proto.Unmarshal(payload.Data, seek); err != nil || seek.Start == nil || seek.Stop == nil {
+		return cb.Status_BAD_REQUEST, nil
+	}
+
+	n.mutex.Lock()
+	l, err := n.ledger(chdr.ChannelId)
+	if err != nil || (joined != nil && !joined(chdr.ChannelId)) {
+		n.mutex.Unlock()
+		return cb.Status_NOT_FOUND, nil
+	}
+	start := seekNumber(seek.Start, l.height())
+	stop := seekNumber(seek.Stop, l.height())
+	n.mutex.Unlock()
+	if start > stop {
+		return cb.Status_BAD_REQUEST, nil
+	}
+
+	for num := start; ; num++ {
+		n.mutex.Lock()
+		block, signal := l.block(num)
+		n.mutex.Unlock()
+		for block == nil {
+			if seek.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY {
+				return cb.Status_NOT_FOUND, nil
+			}
+			select {
+			case <-signal:
+			case <-ctx.Done():
+				return cb.Status_SERVICE_UNAVAILABLE, ctx.Err()
+			}
+			n.mutex.Lock()
+			block, signal = l.block(num)
+			n.mutex.Unlock()
+		}
+		if err := send(block); err != nil {
+			return cb.Status_SERVICE_UNAVAILABLE, err
+		}
+		if num == stop {
+			return cb.Status_SUCCESS, nil
+		}
+	}
+}
+
+func seekNumber(pos *ab.SeekPosition, height uint64) uint64 {
+	switch t := pos.Type.(type) {
+	case *ab.SeekPosition_Oldest:
+		return 0
+	case *ab.SeekPosition_Specified:
+		return t.Specified.Number
+	case *ab.SeekPosition_Newest:
+		return height - 1
+	}
+	return math.MaxUint64
+}
+
+// acceptAll is a policy manager whose policies every signature set satisfies
+type acceptAll struct{}
+
+func (m acceptAll) GetPolicy(id string) (policies.Policy, bool) {
+	return m, true
+}
+
+func (m acceptAll) Manager(path []string) (policies.Manager, bool) {
+	return m, true
+}
+
+func (m acceptAll) Evaluate(signatureSet []*cb.SignedData) error {
+	return nil
+}
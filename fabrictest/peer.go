@@ -0,0 +1,469 @@
+package fabrictest
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// peer serves the endorser and deliver services for the channels it has
+// joined, running the system chaincodes and the registered chaincodes.
+type peer struct {
+	network  *Network
+	mspID    string
+	address  string
+	identity []byte
+	joined   map[string]bool
+	// installed chaincodes, by name:version
+	installed map[string]*pb.ChaincodeInfo
+}
+
+func (p *peer) ProcessProposal(ctx context.Context, sp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	prop, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return nil, err
+	}
+	spec := cis.ChaincodeSpec
+	if spec == nil || spec.ChaincodeId == nil || spec.Input == nil {
+		return nil, fmt.Errorf("invalid chaincode spec")
+	}
+
+	p.network.mutex.Lock()
+	var resp pb.Response
+	switch spec.ChaincodeId.Name {
+	case "cscc":
+		resp = p.cscc(spec.Input.Args)
+	case "lscc":
+		resp = p.lscc(chdr, spec.Input.Args)
+	case "qscc":
+		resp = p.qscc(spec.Input.Args)
+	default:
+		resp = p.invoke(chdr, spec.ChaincodeId.Name, spec.Input.Args, sp)
+	}
+	p.network.mutex.Unlock()
+
+	if resp.Status >= shim.ERRORTHRESHOLD {
+		logger.Error("Error endorsing %s on %s: %s", spec.ChaincodeId.Name, p.address, resp.Message)
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: resp.Message}}, nil
+	}
+	hash := sha256.Sum256(append(append([]byte{}, prop.Header...), prop.Payload...))
+	payload, err := utils.GetBytesProposalResponsePayload(hash[:], &resp, nil, nil, spec.ChaincodeId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ProposalResponse{
+		Version:     1,
+		Response:    &resp,
+		Payload:     payload,
+		Endorsement: &pb.Endorsement{Endorser: p.identity},
+	}, nil
+}
+
+// cscc joins the peer to channels and reads the channels it has joined
+func (p *peer) cscc(args [][]byte) pb.Response {
+	if len(args) == 0 {
+		return shim.Error("missing function")
+	}
+	switch string(args[0]) {
+	case "JoinChain":
+		if len(args) < 2 {
+			return shim.Error("missing genesis block")
+		}
+		block, err := utils.UnmarshalBlock(args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		chainID, err := utils.GetChainIDFromBlock(block)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if p.joined[chainID] {
+			return shim.Error(fmt.Sprintf("ledger [%s] already exists with state [ACTIVE]", chainID))
+		}
+		if _, ok := p.network.ledgers[chainID]; !ok {
+			l, err := newLedger(block)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			p.network.ledgers[chainID] = l
+		}
+		p.joined[chainID] = true
+		return shim.Success(nil)
+	case "GetChannels":
+		resp := &pb.ChannelQueryResponse{}
+		for _, chainID := range p.channels() {
+			resp.Channels = append(resp.Channels, &pb.ChannelInfo{ChannelId: chainID})
+		}
+		return success(resp)
+	case "GetConfigBlock":
+		l, err := p.ledger(args)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return success(l.configBlock())
+	}
+	return shim.Error(fmt.Sprintf("unknown function %s of cscc", args[0]))
+}
+
+// lscc installs and instantiates chaincodes and reads them
+func (p *peer) lscc(chdr *cb.ChannelHeader, args [][]byte) pb.Response {
+	if len(args) == 0 {
+		return shim.Error("missing function")
+	}
+	switch string(args[0]) {
+	case "install":
+		if len(args) < 2 {
+			return shim.Error("missing chaincode deployment spec")
+		}
+		// the code package is kept as is, it never runs
+		cds := &pb.ChaincodeDeploymentSpec{}
+		if err := proto.Unmarshal(args[1], cds); err != nil || cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil {
+			return shim.Error("invalid chaincode deployment spec")
+		}
+		id := cds.ChaincodeSpec.ChaincodeId
+		key := id.Name + ":" + id.Version
+		if _, ok := p.installed[key]; ok {
+			return shim.Error(fmt.Sprintf("chaincode %s is already installed", key))
+		}
+		p.installed[key] = &pb.ChaincodeInfo{Name: id.Name, Version: id.Version, Path: id.Path}
+		return shim.Success([]byte("OK"))
+	case "deploy", "upgrade":
+		if len(args) < 3 {
+			return shim.Error("missing chaincode deployment spec")
+		}
+		l, err := p.ledger(args)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		cds := &pb.ChaincodeDeploymentSpec{}
+		if err := proto.Unmarshal(args[2], cds); err != nil || cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil {
+			return shim.Error("invalid chaincode deployment spec")
+		}
+		id := cds.ChaincodeSpec.ChaincodeId
+		info, ok := p.installed[id.Name+":"+id.Version]
+		if !ok {
+			return shim.Error(fmt.Sprintf("chaincode %s:%s is not installed", id.Name, id.Version))
+		}
+		_, exists := l.chaincodes[id.Name]
+		if exists && string(args[0]) == "deploy" {
+			return shim.Error(fmt.Sprintf("chaincode %s already exists", id.Name))
+		}
+		if !exists && string(args[0]) == "upgrade" {
+			return shim.Error(fmt.Sprintf("chaincode %s is not instantiated", id.Name))
+		}
+		var input [][]byte
+		if cds.ChaincodeSpec.Input != nil {
+			input = cds.ChaincodeSpec.Input.Args
+		}
+		resp, sim := p.simulate(l, chdr, id.Name, input, nil)
+		if resp.Status >= shim.ERRORTHRESHOLD {
+			return resp
+		}
+		sim.deploy = info
+		l.pending[chdr.TxId] = sim
+		return shim.Success(nil)
+	case "getinstalledchaincodes":
+		resp := &pb.ChaincodeQueryResponse{}
+		for _, key := range sortedKeys(p.installed) {
+			resp.Chaincodes = append(resp.Chaincodes, p.installed[key])
+		}
+		return success(resp)
+	case "getchaincodes":
+		if !p.joined[chdr.ChannelId] {
+			return shim.Error(fmt.Sprintf("peer has not joined channel %s", chdr.ChannelId))
+		}
+		l := p.network.ledgers[chdr.ChannelId]
+		resp := &pb.ChaincodeQueryResponse{}
+		for _, name := range sortedKeys(l.chaincodes) {
+			resp.Chaincodes = append(resp.Chaincodes, l.chaincodes[name])
+		}
+		return success(resp)
+	}
+	return shim.Error(fmt.Sprintf("unknown function %s of lscc", args[0]))
+}
+
+// qscc reads the ledgers of the joined channels
+func (p *peer) qscc(args [][]byte) pb.Response {
+	if len(args) == 0 {
+		return shim.Error("missing function")
+	}
+	l, err := p.ledger(args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	switch string(args[0]) {
+	case "GetChainInfo":
+		last := l.blocks[l.height()-1]
+		return success(&cb.BlockchainInfo{
+			Height:            l.height(),
+			CurrentBlockHash:  last.Header.Hash(),
+			PreviousBlockHash: last.Header.PreviousHash,
+		})
+	case "GetBlockByNumber":
+		if len(args) < 3 {
+			return shim.Error("missing block number")
+		}
+		num, err := strconv.ParseUint(string(args[2]), 10, 64)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		block, _ := l.block(num)
+		if block == nil {
+			return shim.Error(fmt.Sprintf("block %d does not exist", num))
+		}
+		return success(block)
+	case "GetTransactionByID":
+		if len(args) < 3 {
+			return shim.Error("missing transaction ID")
+		}
+		tx, ok := l.txs[string(args[2])]
+		if !ok {
+			return shim.Error(fmt.Sprintf("transaction %s does not exist", args[2]))
+		}
+		return success(tx)
+	}
+	return shim.Error(fmt.Sprintf("unknown function %s of qscc", args[0]))
+}
+
+// invoke simulates a chaincode installed on the peer and instantiated on the channel
+func (p *peer) invoke(chdr *cb.ChannelHeader, name string, args [][]byte, sp *pb.SignedProposal) pb.Response {
+	if !p.joined[chdr.ChannelId] {
+		return shim.Error(fmt.Sprintf("peer has not joined channel %s", chdr.ChannelId))
+	}
+	l := p.network.ledgers[chdr.ChannelId]
+	info, ok := l.chaincodes[name]
+	if !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s is not instantiated on %s", name, chdr.ChannelId))
+	}
+	if _, ok := p.installed[info.Name+":"+info.Version]; !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s:%s is not installed", info.Name, info.Version))
+	}
+	resp, sim := p.simulate(l, chdr, name, args, sp)
+	if resp.Status < shim.ERRORTHRESHOLD && len(sim.writes) > 0 {
+		l.pending[chdr.TxId] = sim
+	}
+	return resp
+}
+
+// simulate runs a registered chaincode on a copy of its state, initializing
+// it when sp is nil, and returns what it writes.
+func (p *peer) simulate(l *ledger, chdr *cb.ChannelHeader, name string, args [][]byte, sp *pb.SignedProposal) (pb.Response, *simulation) {
+	cc, ok := p.network.chaincodes[name]
+	if !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s is not registered", name)), nil
+	}
+	if sp != nil {
+		creator, err := proposalCreator(sp)
+		if err != nil {
+			return shim.Error(err.Error()), nil
+		}
+		cc = &signedChaincode{Chaincode: cc, creator: creator, timestamp: chdr.Timestamp}
+	}
+	stub := shim.NewMockStub(name, cc)
+	stub.ChannelID = chdr.ChannelId
+	stub.TxTimestamp = chdr.Timestamp
+	state := l.stateOf(name)
+	stub.MockTransactionStart(chdr.TxId)
+	for key, value := range state {
+		stub.PutState(key, value)
+	}
+	stub.MockTransactionEnd(chdr.TxId)
+
+	var resp pb.Response
+	if sp == nil {
+		resp = stub.MockInit(chdr.TxId, args)
+	} else {
+		resp = stub.MockInvokeWithSignedProposal(chdr.TxId, args, sp)
+	}
+
+	sim := &simulation{chaincode: name, writes: make(map[string][]byte)}
+	for key, value := range stub.State {
+		if old, ok := state[key]; !ok || string(old) != string(value) {
+			sim.writes[key] = value
+		}
+	}
+	for key := range state {
+		if _, ok := stub.State[key]; !ok {
+			sim.writes[key] = nil
+		}
+	}
+	return resp, sim
+}
+
+// proposalCreator returns the identity that signed sp
+func proposalCreator(sp *pb.SignedProposal) ([]byte, error) {
+	prop, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return nil, err
+	}
+	shdr, err := utils.GetSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	return shdr.Creator, nil
+}
+
+// signedChaincode gives cc the creator and the timestamp of the proposal,
+// which the MockStub does not
+type signedChaincode struct {
+	shim.Chaincode
+	creator   []byte
+	timestamp *timestamp.Timestamp
+}
+
+func (c *signedChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return c.Chaincode.Invoke(&signedStub{ChaincodeStubInterface: stub, chaincode: c})
+}
+
+type signedStub struct {
+	shim.ChaincodeStubInterface
+	chaincode *signedChaincode
+}
+
+func (s *signedStub) GetCreator() ([]byte, error) {
+	return s.chaincode.creator, nil
+}
+
+func (s *signedStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	if s.chaincode.timestamp == nil {
+		return s.ChaincodeStubInterface.GetTxTimestamp()
+	}
+	return s.chaincode.timestamp, nil
+}
+
+// ledger returns the ledger of the joined channel named by args[1]
+func (p *peer) ledger(args [][]byte) (*ledger, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("missing channel name")
+	}
+	chainID := string(args[1])
+	if !p.joined[chainID] {
+		return nil, fmt.Errorf("peer has not joined channel %s", chainID)
+	}
+	return p.network.ledgers[chainID], nil
+}
+
+// channels returns the joined channels, sorted
+func (p *peer) channels() []string {
+	var channels []string
+	for chainID := range p.joined {
+		channels = append(channels, chainID)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// hasJoined reports whether the peer has joined chainID, n.mutex must be held
+func (p *peer) hasJoined(chainID string) bool {
+	return p.joined[chainID]
+}
+
+func (p *peer) Deliver(srv pb.Deliver_DeliverServer) error {
+	for {
+		env, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		status, err := p.network.deliver(srv.Context(), env, p.hasJoined, func(block *cb.Block) error {
+			return srv.Send(&pb.DeliverResponse{Type: &pb.DeliverResponse_Block{Block: block}})
+		})
+		if err != nil {
+			return err
+		}
+		if err := srv.Send(&pb.DeliverResponse{Type: &pb.DeliverResponse_Status{Status: status}}); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *peer) DeliverFiltered(srv pb.Deliver_DeliverFilteredServer) error {
+	for {
+		env, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		status, err := p.network.deliver(srv.Context(), env, p.hasJoined, func(block *cb.Block) error {
+			filtered, err := filterBlock(block)
+			if err != nil {
+				return err
+			}
+			return srv.Send(&pb.DeliverResponse{Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: filtered}})
+		})
+		if err != nil {
+			return err
+		}
+		if err := srv.Send(&pb.DeliverResponse{Type: &pb.DeliverResponse_Status{Status: status}}); err != nil {
+			return err
+		}
+	}
+}
+
+func filterBlock(block *cb.Block) (*pb.FilteredBlock, error) {
+	filtered := &pb.FilteredBlock{Number: block.Header.Number}
+	for i := range block.Data.Data {
+		env, err := utils.ExtractEnvelope(block, i)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil {
+			return nil, err
+		}
+		filtered.ChannelId = chdr.ChannelId
+		filtered.FilteredTransactions = append(filtered.FilteredTransactions, &pb.FilteredTransaction{
+			Txid:             chdr.TxId,
+			Type:             cb.HeaderType(chdr.Type),
+			TxValidationCode: pb.TxValidationCode_VALID,
+		})
+	}
+	return filtered, nil
+}
+
+func success(msg proto.Message) pb.Response {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(payload)
+}
+
+func sortedKeys(m map[string]*pb.ChaincodeInfo) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,150 @@
+package ha
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"manageChain/protocols"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+)
+
+// ForwardedHeader marks the requests a replica forwarded to the leader, which
+// are never forwarded again
+const ForwardedHeader = "X-ManageChain-Forwarded"
+
+// CallerHeader carries the caller a replica verified for a request it
+// forwarded, the leader taking it from the replicas only
+const CallerHeader = "X-ManageChain-Caller"
+
+var (
+	// transport reaches the leader
+	transport http.RoundTripper = http.DefaultTransport
+	// replicas are the common names of the client certificates the
+	// replicas forward with
+	replicas = map[string]bool{}
+)
+
+// setupTransport reaches the leader with the CAs of HATLSCAFile, presenting
+// the client certificate of HATLSCertFile and HATLSKeyFile when given. The
+// replicas are the ones presenting the certificates named in HAReplicaNames,
+// or one with the name of HATLSCertFile.
+func setupTransport() error {
+	caFile := beego.AppConfig.String("HATLSCAFile")
+	certFile := beego.AppConfig.String("HATLSCertFile")
+	names := map[string]bool{}
+	for _, name := range strings.Split(beego.AppConfig.String("HAReplicaNames"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	replicas = names
+	if caFile == "" && certFile == "" {
+		transport = http.DefaultTransport
+		return nil
+	}
+	conf := &tls.Config{}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates in %s", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, beego.AppConfig.String("HATLSKeyFile"))
+		if err != nil {
+			return err
+		}
+		conf.Certificates = []tls.Certificate{cert}
+		if len(names) == 0 {
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				return err
+			}
+			names[leaf.Subject.CommonName] = true
+		}
+	}
+	transport = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: conf}
+	return nil
+}
+
+// certName is the subject of the verified client certificate of r, empty
+// when the client is not authenticated
+func certName(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+	return r.TLS.PeerCertificates[0].Subject.CommonName
+}
+
+// Caller is the subject of the client certificate of r, or the caller the
+// replica forwarding r verified, empty when the client is not authenticated.
+func Caller(r *http.Request) string {
+	name := certName(r)
+	if name != "" && replicas[name] && r.Header.Get(ForwardedHeader) != "" {
+		return r.Header.Get(CallerHeader)
+	}
+	return name
+}
+
+func unavailable(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	fmt.Fprintf(w, `{"code":%q,"message":%q}`, protocols.CodeUnavailable, message)
+}
+
+// Forward is a beego.BeforeRouter filter of the mutating routes and of the
+// jobs, it proxies them to the leader when the replica is not the leader. It
+// answers 503 while no leader is elected.
+func Forward(ctx *context.Context) {
+	if IsLeader() {
+		return
+	}
+	st := Current()
+	if st.LeaderURL == "" {
+		unavailable(ctx.ResponseWriter, "no leader is elected")
+		return
+	}
+	if ctx.Input.Header(ForwardedHeader) != "" {
+		unavailable(ctx.ResponseWriter, "the request was forwarded to a replica that is not the leader")
+		return
+	}
+	target, err := url.Parse(st.LeaderURL)
+	if err != nil {
+		unavailable(ctx.ResponseWriter, fmt.Sprintf("invalid URL of the leader %s: %s", st.LeaderURL, err))
+		return
+	}
+
+	req := ctx.Request
+	// the body was read into RequestBody by CopyRequestBody
+	if body := ctx.Input.RequestBody; body != nil {
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		req.Header.Del("Content-Encoding")
+	}
+	req.Header.Del(CallerHeader)
+	if caller := Caller(req); caller != "" {
+		req.Header.Set(CallerHeader, caller)
+	}
+	req.Header.Set(ForwardedHeader, ID())
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = transport
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		logger.Error("Error forwarding %s %s to the leader %s: %s", r.Method, r.URL.Path, st.LeaderID, err)
+		unavailable(w, fmt.Sprintf("error reaching the leader %s: %s", st.LeaderID, err))
+	}
+	proxy.ServeHTTP(ctx.ResponseWriter, req)
+}
@@ -0,0 +1,352 @@
+// Package ha runs replicas of manageChain sharing a storage. The replica
+// holding the leader lock of the storage runs the mutating operations, their
+// jobs and the services started with Lead, the others serve the reads and
+// forward the mutating requests and the requests of the jobs to it, so that
+// the config updates and the certificates are never computed from the same
+// state twice.
+package ha
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"manageChain/logging"
+	"manageChain/protocols"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var logger = logging.GetLogger("ha")
+
+const (
+	// leaderLock is the lock of the storage the leader holds
+	leaderLock = "ha/leader"
+	// leaderKey is the storage key of the leader record
+	leaderKey       = "ha/leader.json"
+	defaultInterval = 5 * time.Second
+	// staleHeartbeats is the number of intervals without heartbeat after
+	// which the leader is taken as gone
+	staleHeartbeats = 3
+)
+
+// Status is the role of the replica and the leader it knows of
+type Status = protocols.HAStatus
+
+// record is the leader record of the storage
+type record struct {
+	ID        string
+	URL       string
+	Since     time.Time
+	Heartbeat time.Time
+}
+
+var (
+	lock    sync.RWMutex
+	leading bool
+	leader  *record
+	unlock  func()
+	// lost is closed when the lease of the leader lock could not be
+	// renewed, nil for the locks held until released
+	lost <-chan struct{}
+	// resigned is closed when the replica stops leading
+	resigned chan struct{}
+	stop     chan struct{}
+	stopped  chan struct{}
+
+	// electing makes the elections, the loss of the lease and Stop change
+	// the state one at a time
+	electing sync.Mutex
+	services []*service
+)
+
+// service runs on the leader only
+type service struct {
+	name  string
+	start func() error
+	stop  func()
+}
+
+// leaser is a storage whose locks are leases, which are lost when their
+// renewal fails
+type leaser interface {
+	TryLease(name string) (unlock func(), lost <-chan struct{}, err error)
+}
+
+// Enabled tells whether HAEnable of app.conf is set
+func Enabled() bool {
+	enabled, _ := beego.AppConfig.Bool("HAEnable")
+	return enabled
+}
+
+// ID identifies the replica, HAID of app.conf, its host and process by
+// default
+func ID() string {
+	if id := beego.AppConfig.String("HAID"); id != "" {
+		return id
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Interval is the time between two elections or heartbeats, HAInterval of
+// app.conf, 5s by default
+func Interval() time.Duration {
+	if d, err := time.ParseDuration(beego.AppConfig.String("HAInterval")); err == nil && d > 0 {
+		return d
+	}
+	return defaultInterval
+}
+
+// IsLeader tells whether the replica runs the mutating operations, always
+// when HA is not enabled
+func IsLeader() bool {
+	if !Enabled() {
+		return true
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	return leading && !isLost(lost)
+}
+
+// isLost tells whether the lease of l was lost
+func isLost(l <-chan struct{}) bool {
+	select {
+	case <-l:
+		return true
+	default:
+		return false
+	}
+}
+
+// Lead runs start whenever the replica becomes the leader and stop when it
+// no longer is, e.g. the notifications and the monitors which would alert
+// from every replica. Without HA start runs right away.
+func Lead(name string, start func() error, stop func()) {
+	s := &service{name: name, start: start, stop: stop}
+	electing.Lock()
+	defer electing.Unlock()
+	services = append(services, s)
+	if IsLeader() {
+		s.run()
+	}
+}
+
+func (s *service) run() {
+	if err := s.start(); err != nil {
+		logger.Error("Error starting %s: %s", s.name, err)
+	}
+}
+
+// Current returns the status of the replica
+func Current() *Status {
+	lock.RLock()
+	defer lock.RUnlock()
+	st := &Status{Enabled: Enabled(), ID: ID(), Leader: (leading && !isLost(lost)) || !Enabled()}
+	if leader != nil {
+		since, heartbeat := leader.Since, leader.Heartbeat
+		st.LeaderID, st.LeaderURL, st.Since, st.Heartbeat = leader.ID, leader.URL, &since, &heartbeat
+	}
+	return st
+}
+
+// Start takes part in the elections every Interval when HAEnable is set, the
+// other replicas reaching the leader at HAAdvertiseURL.
+func Start() error {
+	if !Enabled() {
+		return nil
+	}
+	advertised := beego.AppConfig.String("HAAdvertiseURL")
+	if u, err := url.Parse(advertised); err != nil || u.Host == "" {
+		return fmt.Errorf("HAEnable needs the URL of the replica in HAAdvertiseURL, got %q", advertised)
+	}
+	if err := setupTransport(); err != nil {
+		return err
+	}
+	lock.Lock()
+	stop, stopped = make(chan struct{}), make(chan struct{})
+	lock.Unlock()
+	elect(advertised)
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(Interval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				elect(advertised)
+			}
+		}
+	}()
+	return nil
+}
+
+func readRecord() (*record, error) {
+	data, err := sdk.Store().Read(leaderKey)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := &record{}
+	return r, json.Unmarshal(data, r)
+}
+
+func writeRecord(r *record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return sdk.Store().Write(leaderKey, data)
+}
+
+// set sets the state of the replica, starting the services of the leader
+// when it becomes the leader and stopping them when it no longer is. The
+// caller holds electing.
+func set(isLeading bool, r *record, release func(), l <-chan struct{}) {
+	lock.Lock()
+	was := leading
+	leading, leader, unlock, lost = isLeading, r, release, l
+	switch {
+	case isLeading && !was:
+		resigned = make(chan struct{})
+		if l != nil {
+			go watch(l, resigned)
+		}
+	case !isLeading && was:
+		close(resigned)
+	}
+	lock.Unlock()
+
+	for _, s := range services {
+		switch {
+		case isLeading && !was:
+			s.run()
+		case !isLeading && was:
+			s.stop()
+		}
+	}
+}
+
+// watch steps down as soon as the lease of the leader is lost, unless the
+// replica resigned before
+func watch(l <-chan struct{}, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-l:
+		electing.Lock()
+		defer electing.Unlock()
+		lock.RLock()
+		current, release := lost, unlock
+		lock.RUnlock()
+		if current != l {
+			return
+		}
+		logger.Warning("Stepping down, the leader lock was lost")
+		set(false, nil, nil, nil)
+		release()
+	}
+}
+
+// tryLock takes the leader lock, the lease of the storages holding leases
+func tryLock() (func(), <-chan struct{}, error) {
+	if s, ok := sdk.Store().(leaser); ok {
+		return s.TryLease(leaderLock)
+	}
+	release, err := sdk.Store().TryLock(leaderLock)
+	return release, nil, err
+}
+
+// elect renews the heartbeat of the leader while it holds the lock, and
+// tries to take the lock otherwise. The storage is called unlocked.
+func elect(advertised string) {
+	electing.Lock()
+	defer electing.Unlock()
+	lock.RLock()
+	isLeading, current, release, l := leading, leader, unlock, lost
+	lock.RUnlock()
+	id, now := ID(), time.Now()
+	if isLeading {
+		// the lease decides, another may hold it once it was lost whatever
+		// the record says
+		if isLost(l) {
+			logger.Warning("Stepping down, the leader lock was lost")
+			set(false, nil, nil, nil)
+			release()
+			return
+		}
+		r := *current
+		r.Heartbeat = now
+		if err := writeRecord(&r); err != nil {
+			logger.Error("Error writing the heartbeat of the leader: %s", err)
+		}
+		set(true, &r, release, l)
+		return
+	}
+
+	release, l, err := tryLock()
+	if err == nil {
+		r := &record{ID: id, URL: advertised, Since: now, Heartbeat: now}
+		if err := writeRecord(r); err != nil {
+			logger.Error("Error writing the leader: %s", err)
+			release()
+			return
+		}
+		set(true, r, release, l)
+		logger.Info("Elected leader, serving the mutating operations at %s", advertised)
+		return
+	}
+	if !errors.Is(err, sdk.ErrLocked) {
+		logger.Error("Error taking the leader lock: %s", err)
+		return
+	}
+	r, err := readRecord()
+	if err != nil {
+		logger.Error("Error reading the leader: %s", err)
+		return
+	}
+	if r != nil && now.Sub(r.Heartbeat) > staleHeartbeats*Interval() {
+		// the lock is held but the leader does not renew its record
+		r = nil
+	}
+	if r != nil && (current == nil || current.ID != r.ID) {
+		logger.Info("Following the leader %s at %s", r.ID, r.URL)
+	}
+	set(false, r, nil, nil)
+}
+
+// Stop stops taking part in the elections and releases the leadership, for
+// another replica to take it at once.
+func Stop() error {
+	lock.Lock()
+	s, done := stop, stopped
+	stop = nil
+	lock.Unlock()
+	if s == nil {
+		return nil
+	}
+	close(s)
+	<-done
+
+	electing.Lock()
+	defer electing.Unlock()
+	lock.RLock()
+	isLeading, release := leading, unlock
+	lock.RUnlock()
+	if !isLeading {
+		return nil
+	}
+	set(false, nil, nil, nil)
+	defer release()
+	if r, err := readRecord(); err == nil && r != nil && r.ID == ID() {
+		return sdk.Store().Remove(leaderKey)
+	}
+	return nil
+}
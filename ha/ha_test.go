@@ -0,0 +1,255 @@
+package ha
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+func setup(t *testing.T, id string) {
+	dir, err := ioutil.TempDir("", "ha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sdk.Store()
+	sdk.SetStorage(sdk.NewFileStorage(dir))
+	for key, value := range map[string]string{"HAEnable": "true", "HAID": id, "HAInterval": "100ms"} {
+		beego.AppConfig.Set(key, value)
+	}
+	t.Cleanup(func() {
+		if unlock != nil {
+			unlock()
+		}
+		electing.Lock()
+		set(false, nil, nil, nil)
+		services = nil
+		electing.Unlock()
+		for _, key := range []string{"HAEnable", "HAID", "HAInterval"} {
+			beego.AppConfig.Set(key, "")
+		}
+		sdk.SetStorage(s)
+		os.RemoveAll(dir)
+	})
+}
+
+// follow makes another replica the leader
+func follow(t *testing.T, id string, url string) func() {
+	unlock, err := sdk.Store().TryLock(leaderLock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeRecord(&record{ID: id, URL: url, Since: time.Now(), Heartbeat: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	elect("http://a:8080")
+	return unlock
+}
+
+func TestElection(t *testing.T) {
+	setup(t, "a")
+	elect("http://a:8080")
+	if st := Current(); !IsLeader() || st.LeaderID != "a" || st.LeaderURL != "http://a:8080" {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	// another replica does not get the lock
+	if _, err := sdk.Store().TryLock(leaderLock); err != sdk.ErrLocked {
+		t.Fatalf("unexpected error %v", err)
+	}
+	elect("http://a:8080")
+	if !IsLeader() {
+		t.Fatal("the leader stepped down")
+	}
+
+	// it takes over once the leader stopped
+	stop, stopped = make(chan struct{}), make(chan struct{})
+	close(stopped)
+	if err := Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := readRecord(); err != nil || r != nil {
+		t.Fatalf("the leader is still recorded: %v %v", r, err)
+	}
+	unlock, err := sdk.Store().TryLock(leaderLock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
+
+// leasing is a storage whose leader lease the tests lose
+type leasing struct {
+	*sdk.FileStorage
+	lost chan struct{}
+}
+
+func (s *leasing) TryLease(name string) (func(), <-chan struct{}, error) {
+	unlock, err := s.TryLock(name)
+	return unlock, s.lost, err
+}
+
+func TestStepDown(t *testing.T) {
+	setup(t, "a")
+	s := &leasing{FileStorage: sdk.Store().(*sdk.FileStorage), lost: make(chan struct{})}
+	sdk.SetStorage(s)
+	running := make(chan bool, 2)
+	Lead("test", func() error {
+		running <- true
+		return nil
+	}, func() {
+		running <- false
+	})
+	elect("http://a:8080")
+	if !IsLeader() || !<-running {
+		t.Fatal("the leader does not run the services")
+	}
+
+	// the renewal of the lease failed, another may hold it
+	close(s.lost)
+	if IsLeader() {
+		t.Fatal("the leader lost its lease")
+	}
+	select {
+	case r := <-running:
+		if r {
+			t.Fatal("the services started again")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the services of the leader still run")
+	}
+	if st := Current(); st.Leader {
+		t.Fatalf("unexpected status %+v", st)
+	}
+}
+
+func TestFollower(t *testing.T) {
+	setup(t, "a")
+	unlock := follow(t, "b", "http://b:8080")
+	defer unlock()
+	if st := Current(); IsLeader() || st.LeaderID != "b" || st.LeaderURL != "http://b:8080" {
+		t.Fatalf("unexpected status %+v", st)
+	}
+
+	// the leader holding the lock without heartbeat is gone
+	if err := writeRecord(&record{ID: "b", URL: "http://b:8080", Heartbeat: time.Now().Add(-time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+	elect("http://a:8080")
+	if st := Current(); IsLeader() || st.LeaderID != "" {
+		t.Fatalf("unexpected status %+v", st)
+	}
+}
+
+func forward(body string, header string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/channel/addorg?async=true", strings.NewReader(body))
+	if header != "" {
+		r.Header.Set(ForwardedHeader, header)
+	}
+	w := httptest.NewRecorder()
+	ctx := context.NewContext()
+	ctx.Reset(w, r)
+	ctx.Input.CopyBody(1 << 20)
+	Forward(ctx)
+	return w
+}
+
+func TestForward(t *testing.T) {
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(r.URL.String() + " " + r.Header.Get(ForwardedHeader) + " " + string(body)))
+	}))
+	defer leader.Close()
+	setup(t, "a")
+
+	if w := forward("{}", ""); w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "no leader") {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body)
+	}
+	unlock := follow(t, "b", leader.URL)
+	if w := forward(`{"Channel":"mychannel"}`, ""); w.Code != http.StatusAccepted || w.Body.String() != `/channel/addorg?async=true a {"Channel":"mychannel"}` {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body)
+	}
+	// a request forwarded to a replica that is not the leader is not forwarded again
+	if w := forward("{}", "c"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body)
+	}
+
+	// the leader serves the requests
+	unlock()
+	elect("http://a:8080")
+	if w := forward("{}", ""); w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body)
+	}
+}
+
+// request is a request from a client presenting a certificate of name
+func request(name string, forwarded string, caller string) *http.Request {
+	r := httptest.NewRequest("POST", "/channel/addorg", strings.NewReader("{}"))
+	if name != "" {
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: name}}}}
+	}
+	if forwarded != "" {
+		r.Header.Set(ForwardedHeader, forwarded)
+	}
+	if caller != "" {
+		r.Header.Set(CallerHeader, caller)
+	}
+	return r
+}
+
+func TestCaller(t *testing.T) {
+	replicas = map[string]bool{"replica": true}
+	defer func() { replicas = map[string]bool{} }()
+
+	for _, c := range []struct {
+		r      *http.Request
+		caller string
+	}{
+		{request("", "", ""), ""},
+		{request("alice", "", ""), "alice"},
+		{request("alice", "", "admin"), "alice"},
+		{request("replica", "b", "alice"), "alice"},
+		{request("replica", "b", ""), ""},
+		// only the replicas forward the callers
+		{request("mallory", "b", "alice"), "mallory"},
+		{request("", "b", "alice"), ""},
+	} {
+		if caller := Caller(c.r); caller != c.caller {
+			t.Fatalf("expect caller %q of %v %v, got %q", c.caller, c.r.Header, c.r.TLS != nil, caller)
+		}
+	}
+
+	// the follower forwards the caller it verified
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get(CallerHeader)))
+	}))
+	defer leader.Close()
+	setup(t, "a")
+	unlock := follow(t, "b", leader.URL)
+	defer unlock()
+	for _, c := range []struct {
+		r      *http.Request
+		caller string
+	}{
+		{request("alice", "", "admin"), "alice"},
+		{request("", "", "admin"), ""},
+	} {
+		w := httptest.NewRecorder()
+		ctx := context.NewContext()
+		ctx.Reset(w, c.r)
+		Forward(ctx)
+		if w.Body.String() != c.caller {
+			t.Fatalf("expect the caller %q forwarded, got %q", c.caller, w.Body)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Package jobs runs the operations a request asks to run asynchronously and
+// keeps their outcome for the clients polling them.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"manageChain/logging"
+	"manageChain/protocols"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var logger = logging.GetLogger("jobs")
+
+// statuses of a Job
+const (
+	Running     = protocols.JobRunning
+	Succeeded   = protocols.JobSucceeded
+	Failed      = protocols.JobFailed
+	Interrupted = protocols.JobInterrupted
+)
+
+// MaxFinished is the number of finished jobs kept, the oldest are forgotten
+const MaxFinished = 1000
+
+// Job is an operation running after the response to the request of it
+type Job = protocols.Job
+
+var (
+	lock     sync.RWMutex
+	jobs     = make(map[string]*Job)
+	finished []string
+	// running is the number of jobs running, idle is closed when it is 0
+	running int
+	idle    = closedChan()
+)
+
+// the context of the calls the jobs make
+var callCtx, cancelCalls = context.WithCancel(context.Background())
+
+func closedChan() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Submit runs run in a job of operation, of the request of the correlation
+// ID requestID, and returns the job as submitted. run is meant to make its
+// calls with Context, carrying requestID with logging.WithRequestID.
+func Submit(operation string, requestID string, run func() (interface{}, *protocols.ErrorMessage)) *Job {
+	j := &Job{
+		ID:        newID(),
+		Operation: operation,
+		RequestID: requestID,
+		Status:    Running,
+		Created:   time.Now().UTC(),
+	}
+	lock.Lock()
+	jobs[j.ID] = j
+	submitted := *j
+	if running == 0 {
+		idle = make(chan struct{})
+	}
+	running++
+	lock.Unlock()
+
+	logger.Info("job %s of %s submitted", j.ID, operation)
+	save()
+	go func() {
+		result, errMsg := run()
+		finish(j, result, errMsg)
+	}()
+	return &submitted
+}
+
+func finish(j *Job, result interface{}, errMsg *protocols.ErrorMessage) {
+	defer save()
+	lock.Lock()
+	defer lock.Unlock()
+	now := time.Now().UTC()
+	j.Finished = &now
+	j.Result, j.Error = result, errMsg
+	j.Status = Succeeded
+	if errMsg != nil {
+		j.Status = Failed
+	}
+	forget(j.ID)
+	if running--; running == 0 {
+		close(idle)
+	}
+	logger.Info("job %s of %s %s", j.ID, j.Operation, j.Status)
+}
+
+// forget adds id to the finished jobs, forgetting the oldest beyond MaxFinished
+func forget(id string) {
+	finished = append(finished, id)
+	if len(finished) > MaxFinished {
+		delete(jobs, finished[0])
+		finished = finished[1:]
+	}
+}
+
+// Context is the context of the calls the jobs make, canceled by Cancel
+func Context() context.Context {
+	return callCtx
+}
+
+// Cancel cancels the calls of the running jobs, they fail with the error of
+// their calls.
+func Cancel() {
+	cancelCalls()
+}
+
+// Wait waits for the running jobs to finish, it returns the error of ctx
+// when it is done first.
+func Wait(ctx context.Context) error {
+	lock.RLock()
+	c := idle
+	lock.RUnlock()
+	select {
+	case <-c:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Get returns the job id, nil when it does not exist or is forgotten
+func Get(id string) *Job {
+	lock.RLock()
+	defer lock.RUnlock()
+	j, ok := jobs[id]
+	if !ok {
+		return nil
+	}
+	c := *j
+	return &c
+}
+
+// List returns the jobs of status, all of them when it is empty, the most
+// recent first.
+func List(status string) []*Job {
+	lock.RLock()
+	list := []*Job{}
+	for _, j := range jobs {
+		if status == "" || j.Status == status {
+			c := *j
+			list = append(list, &c)
+		}
+	}
+	lock.RUnlock()
+	sort.Slice(list, func(a, b int) bool {
+		return list[a].Created.After(list[b].Created)
+	})
+	return list
+}
+
+const defaultKey = "jobs.json"
+
+// Key is the key the jobs are kept at in the storage, JobsKey of app.conf,
+// jobs.json by default
+func Key() string {
+	return beego.AppConfig.DefaultString("JobsKey", defaultKey)
+}
+
+var (
+	saveLock sync.Mutex
+	// saving is set while the replica keeps the jobs, see Start
+	saving bool
+)
+
+// save checkpoints the jobs after they changed, logging the error
+func save() {
+	if err := Checkpoint(); err != nil {
+		logger.Error("Error checkpointing the jobs: %s", err)
+	}
+}
+
+// Checkpoint writes the jobs to Key of the storage, the running ones as
+// Interrupted, so that the clients polling them after a restart or on the
+// replica leading after a failover get their outcome. The jobs are
+// checkpointed whenever they are submitted or finish.
+func Checkpoint() error {
+	saveLock.Lock()
+	defer saveLock.Unlock()
+	if !saving {
+		return nil
+	}
+	list := List("")
+	for _, j := range list {
+		if j.Status == Running {
+			j.Status = Interrupted
+			j.Error = &protocols.ErrorMessage{Code: protocols.CodeUnavailable, Message: "the server stopped while the job was running, its operation may be partially applied"}
+		}
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	if err := sdk.Store().Write(Key(), data); err != nil {
+		return err
+	}
+	logger.Debug("%d jobs checkpointed to %s", len(list), Key())
+	return nil
+}
+
+// Start restores the jobs of the last Checkpoint, if any, and checkpoints
+// them from then on. It is run by the leader, the other replicas forward
+// the jobs to it.
+func Start() error {
+	key := Key()
+	data, err := sdk.Store().Read(key)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		var list []*Job
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		restore(list)
+		logger.Info("%d jobs restored from %s", len(list), key)
+	}
+	saveLock.Lock()
+	saving = true
+	saveLock.Unlock()
+	return nil
+}
+
+func restore(list []*Job) {
+	lock.Lock()
+	defer lock.Unlock()
+	// the oldest first, they are forgotten first
+	for i := len(list) - 1; i >= 0; i-- {
+		j := list[i]
+		if _, ok := jobs[j.ID]; ok || !j.Done() {
+			continue
+		}
+		jobs[j.ID] = j
+		forget(j.ID)
+	}
+}
+
+// Stop checkpoints the jobs a last time, the replica no longer keeping them
+func Stop() {
+	save()
+	saveLock.Lock()
+	saving = false
+	saveLock.Unlock()
+}
@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"manageChain/protocols"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+func wait(t *testing.T, id string) *Job {
+	for i := 0; i < 100; i++ {
+		if j := Get(id); j != nil && j.Done() {
+			return j
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s does not finish", id)
+	return nil
+}
+
+func TestSubmit(t *testing.T) {
+	release := make(chan struct{})
+	j := Submit("/channel/join", "jobstest", func() (interface{}, *protocols.ErrorMessage) {
+		<-release
+		return "joined", nil
+	})
+	if j.Status != Running || Get(j.ID).Status != Running {
+		t.Fatalf("unexpected job %+v", j)
+	}
+	if list := List(Running); len(list) != 1 || list[0].ID != j.ID {
+		t.Fatalf("unexpected running jobs %+v", list)
+	}
+	close(release)
+	j = wait(t, j.ID)
+	if j.Status != Succeeded || j.Result != "joined" || j.RequestID != "jobstest" || j.Finished == nil {
+		t.Fatalf("unexpected job %+v", j)
+	}
+
+	failed := Submit("/chaincode/invoke", "", func() (interface{}, *protocols.ErrorMessage) {
+		return nil, &protocols.ErrorMessage{Code: protocols.CodeTimeout, Message: "timed out"}
+	})
+	failed = wait(t, failed.ID)
+	if failed.Status != Failed || failed.Error.Code != protocols.CodeTimeout {
+		t.Fatalf("unexpected job %+v", failed)
+	}
+	if list := List(""); len(list) != 2 || list[0].ID != failed.ID {
+		t.Fatalf("unexpected jobs %+v", list)
+	}
+	if Get("unknown") != nil {
+		t.Fatal("an unknown job is found")
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer sdk.SetStorage(sdk.Store())
+	st := sdk.NewFileStorage(dir)
+	sdk.SetStorage(st)
+	if err := Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer Stop()
+
+	release := make(chan struct{})
+	running := Submit("/channel/addorg", "", func() (interface{}, *protocols.ErrorMessage) {
+		select {
+		case <-release:
+		case <-Context().Done():
+		}
+		return nil, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("waiting a running job: %v", err)
+	}
+
+	// the replica leading after a failover gets the running job as interrupted
+	data, err := st.Read(Key())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list []*Job
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) == 0 || list[0].ID != running.ID || list[0].Status != Interrupted || list[0].Error.Code != protocols.CodeUnavailable {
+		t.Fatalf("unexpected checkpoint %s", data)
+	}
+
+	close(release)
+	if err := Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the restarted server gets the jobs of the checkpoint
+	lock.Lock()
+	delete(jobs, running.ID)
+	lock.Unlock()
+	if err := Start(); err != nil {
+		t.Fatal(err)
+	}
+	j := Get(running.ID)
+	if j == nil || j.Status != Succeeded || !j.Done() {
+		t.Fatalf("unexpected job %+v", j)
+	}
+}
@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/astaxie/beego"
+	beelogs "github.com/astaxie/beego/logs"
+	"github.com/hyperledger/fabric/sdk"
+	gologging "github.com/op/go-logging"
+)
+
+// sdkBackend writes the logs of the sdk and of the other fabric modules,
+// which log through flogging, with the module they are logged by.
+type sdkBackend struct{}
+
+func sdkLevel(level gologging.Level) Level {
+	switch level {
+	case gologging.CRITICAL, gologging.ERROR:
+		return LevelError
+	case gologging.WARNING, gologging.NOTICE:
+		return LevelWarning
+	case gologging.INFO:
+		return LevelInfo
+	}
+	return LevelDebug
+}
+
+func (b *sdkBackend) Log(level gologging.Level, calldepth int, rec *gologging.Record) error {
+	write(rec.Module, sdkLevel(level), "", "", rec.Message())
+	return nil
+}
+
+func (b *sdkBackend) GetLevel(module string) gologging.Level {
+	switch GetLevel(module) {
+	case LevelError:
+		return gologging.ERROR
+	case LevelWarning:
+		return gologging.WARNING
+	case LevelInfo:
+		return gologging.INFO
+	}
+	return gologging.DEBUG
+}
+
+// SetLevel ignores the levels flogging sets, they are set by this package
+func (b *sdkBackend) SetLevel(level gologging.Level, module string) {}
+
+func (b *sdkBackend) IsEnabledFor(level gologging.Level, module string) bool {
+	return IsEnabledFor(module, sdkLevel(level))
+}
+
+// sdkCallLog writes the logs of the sdk calls made with ctx, with the
+// correlation ID it carries
+func sdkCallLog(ctx context.Context, module string, level gologging.Level, msg string) {
+	write(module, sdkLevel(level), RequestID(ctx), "", msg)
+}
+
+func redirectSDK() {
+	gologging.SetBackend(&sdkBackend{})
+	sdk.SetCallLogger(sdkCallLog)
+}
+
+const beegoModule = "beego"
+
+// beegoAdapter is a beego logs adapter writing the logs of beego, they come
+// as "[I] [router.go:12] message" when beego logs the caller.
+type beegoAdapter struct{}
+
+var beegoPrefix = regexp.MustCompile(`^\[[MACEWNID]\] (?:\[([^\]\s]+:\d+)\] )?`)
+
+func beegoLevel(level int) Level {
+	switch {
+	case level <= beelogs.LevelError:
+		return LevelError
+	case level <= beelogs.LevelNotice:
+		return LevelWarning
+	case level == beelogs.LevelInformational:
+		return LevelInfo
+	}
+	return LevelDebug
+}
+
+func (a *beegoAdapter) Init(config string) error {
+	return nil
+}
+
+func (a *beegoAdapter) WriteMsg(when time.Time, msg string, level int) error {
+	if !IsEnabledFor(beegoModule, beegoLevel(level)) {
+		return nil
+	}
+	var caller string
+	if m := beegoPrefix.FindStringSubmatch(msg); m != nil {
+		msg, caller = msg[len(m[0]):], m[1]
+	}
+	write(beegoModule, beegoLevel(level), "", caller, msg)
+	return nil
+}
+
+func (a *beegoAdapter) Destroy() {}
+
+func (a *beegoAdapter) Flush() {}
+
+const beegoAdapterName = "managechain"
+
+func init() {
+	beelogs.Register(beegoAdapterName, func() beelogs.Logger { return &beegoAdapter{} })
+}
+
+func redirectBeego() error {
+	beego.BeeLogger.DelLogger(beelogs.AdapterConsole)
+	return beego.BeeLogger.SetLogger(beegoAdapterName)
+}
@@ -0,0 +1,203 @@
+// Package logging writes the logs of manageChain, of the sdk and of beego as
+// JSON lines, each with the module it comes from and the correlation ID of
+// the request it is written for.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// Level is the severity of a log, the higher the more verbose
+type Level int
+
+// levels
+const (
+	LevelError Level = iota
+	LevelWarning
+	LevelInfo
+	LevelDebug
+)
+
+var levelNames = []string{"error", "warning", "info", "debug"}
+
+func (l Level) String() string {
+	if l < LevelError || l > LevelDebug {
+		return "level(" + strconv.Itoa(int(l)) + ")"
+	}
+	return levelNames[l]
+}
+
+// ParseLevel parses the name of a level, warn and notice being warning
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "error", "critical", "alert", "emergency":
+		return LevelError, nil
+	case "warning", "warn", "notice":
+		return LevelWarning, nil
+	case "info":
+		return LevelInfo, nil
+	case "debug":
+		return LevelDebug, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %s", name)
+}
+
+var (
+	lock         sync.RWMutex
+	output       io.Writer = os.Stdout
+	defaultLevel           = LevelInfo
+	moduleLevels           = make(map[string]Level)
+)
+
+// SetOutput sets where the logs are written, stdout by default
+func SetOutput(w io.Writer) {
+	lock.Lock()
+	defer lock.Unlock()
+	output = w
+}
+
+// SetLevel sets the level of module, the default level when module is empty
+func SetLevel(module string, level Level) {
+	lock.Lock()
+	defer lock.Unlock()
+	if module == "" {
+		defaultLevel = level
+		return
+	}
+	moduleLevels[module] = level
+}
+
+// GetLevel returns the level of module
+func GetLevel(module string) Level {
+	lock.RLock()
+	defer lock.RUnlock()
+	if level, ok := moduleLevels[module]; ok {
+		return level
+	}
+	return defaultLevel
+}
+
+// IsEnabledFor tells whether module writes the logs of level
+func IsEnabledFor(module string, level Level) bool {
+	return level <= GetLevel(module)
+}
+
+// SetLevels sets the levels from a spec of comma separated module=level
+// pairs, a level without module being the default one, e.g. "info,sdk=warning".
+func SetLevels(spec string) error {
+	for _, field := range strings.Split(spec, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		module, name := "", field
+		if i := strings.Index(field, "="); i >= 0 {
+			module, name = strings.TrimSpace(field[:i]), field[i+1:]
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return err
+		}
+		SetLevel(module, level)
+	}
+	return nil
+}
+
+// Setup sets the levels from LogLevel of app.conf, and writes the logs of
+// the sdk and of beego through this package.
+func Setup() error {
+	if err := SetLevels(beego.AppConfig.String("LogLevel")); err != nil {
+		return err
+	}
+	redirectSDK()
+	return redirectBeego()
+}
+
+// entry is a log line
+type entry struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Module    string `json:"module"`
+	RequestID string `json:"requestId,omitempty"`
+	Caller    string `json:"caller,omitempty"`
+	Msg       string `json:"msg"`
+}
+
+func write(module string, level Level, requestID string, caller string, msg string) {
+	data, err := json.Marshal(&entry{
+		Time:      time.Now().Format(time.RFC3339Nano),
+		Level:     level.String(),
+		Module:    module,
+		RequestID: requestID,
+		Caller:    caller,
+		Msg:       Redact(msg),
+	})
+	if err != nil {
+		return
+	}
+	lock.RLock()
+	defer lock.RUnlock()
+	output.Write(append(data, '\n'))
+}
+
+// Logger writes the logs of a module
+type Logger struct {
+	module    string
+	requestID string
+}
+
+// GetLogger returns the logger of module
+func GetLogger(module string) *Logger {
+	return &Logger{module: module}
+}
+
+// WithContext returns a copy of l whose logs carry the correlation ID of ctx
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	return &Logger{module: l.module, requestID: RequestID(ctx)}
+}
+
+func (l *Logger) log(level Level, format string, v ...interface{}) {
+	if !IsEnabledFor(l.module, level) {
+		return
+	}
+	msg := format
+	if len(v) > 0 {
+		msg = fmt.Sprintf(format, v...)
+	}
+	var caller string
+	if _, file, line, ok := runtime.Caller(2); ok {
+		caller = filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+	write(l.module, level, l.requestID, caller, msg)
+}
+
+// Error ...
+func (l *Logger) Error(format string, v ...interface{}) {
+	l.log(LevelError, format, v...)
+}
+
+// Warning ...
+func (l *Logger) Warning(format string, v ...interface{}) {
+	l.log(LevelWarning, format, v...)
+}
+
+// Info ...
+func (l *Logger) Info(format string, v ...interface{}) {
+	l.log(LevelInfo, format, v...)
+}
+
+// Debug ...
+func (l *Logger) Debug(format string, v ...interface{}) {
+	l.log(LevelDebug, format, v...)
+}
@@ -0,0 +1,18 @@
+package logging
+
+import "regexp"
+
+const redacted = "[REDACTED]"
+
+var (
+	privateKeyPEM = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+	// the msp bundle of an IdentityCode, and the certificate files of an msp bundle
+	identityFields = regexp.MustCompile(`"(OrgMSP|AdminCerts|CACerts|TLSCACerts|PrivateKey)"\s*:\s*("(?:[^"\\]|\\.)*"|\{[^{}]*\})`)
+)
+
+// Redact removes the private keys and the msp bundles of IdentityCode
+// payloads from msg.
+func Redact(msg string) string {
+	msg = privateKeyPEM.ReplaceAllString(msg, redacted)
+	return identityFields.ReplaceAllString(msg, `"$1":"`+redacted+`"`)
+}
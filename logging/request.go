@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"manageChain/protocols"
+
+	beecontext "github.com/astaxie/beego/context"
+)
+
+// RequestIDHeader carries the correlation ID of a request, it is generated
+// when the client does not send one.
+const RequestIDHeader = protocols.RequestIDHeader
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the correlation ID id, the
+// logs of the calls made with it carry the ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the correlation ID ctx carries, if any
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// StartRequest is a beego.BeforeRouter filter setting the correlation ID of
+// the request in the context of the request, and echoing it in the response.
+func StartRequest(ctx *beecontext.Context) {
+	id := ctx.Input.Header(RequestIDHeader)
+	if id == "" {
+		id = newRequestID()
+	}
+	ctx.Output.Header(RequestIDHeader, id)
+	ctx.Request = ctx.Request.WithContext(WithRequestID(ctx.Request.Context(), id))
+}
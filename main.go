@@ -1,9 +1,148 @@
 package main
 
 import (
+	"fmt"
+	"manageChain/audit"
+	"manageChain/calls"
+	"manageChain/cli"
+	"manageChain/ha"
+	"manageChain/jobs"
+	"manageChain/logging"
+	"manageChain/monitor"
+	"manageChain/mspstore"
+	"manageChain/notify"
+	_ "manageChain/routers"
+	"manageChain/server"
+	"manageChain/storage"
+	"os"
+
 	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
 )
 
+var logger = logging.GetLogger("main")
+
+const usage = `usage: manageChain                            serve the REST API
+       manageChain verify-audit [file]        verify the audit log in the Storage of app.conf, or a file it exported
+       manageChain import-storage [key...]    copy files of the working directory into the Storage of app.conf,
+                                              MSPDir, ForeignMSPDir and orderer.block by default
+       manageChain <group> <command> [flags]  call the REST API, see manageChain <group> -h
+
+groups:`
+
 func main() {
-	beego.Run()
+	if len(os.Args) > 1 {
+		os.Exit(command(os.Args[1], os.Args[2:]))
+	}
+	if err := logging.Setup(); err != nil {
+		logger.Error("Error setting up logging: %s", err)
+	}
+	if err := calls.Setup(); err != nil {
+		logger.Error("Error setting up calls: %s", err)
+	}
+	if err := storage.Setup(); err != nil {
+		logger.Error("Error setting up storage: %s", err)
+		os.Exit(1)
+	}
+	if err := audit.Start(); err != nil {
+		logger.Error("Error starting audit: %s", err)
+	}
+	if err := mspstore.Start(); err != nil {
+		logger.Error("Error cleaning up the foreign MSPs: %s", err)
+	}
+	// replicas not taking part in the elections would run operations too
+	if err := ha.Start(); err != nil {
+		logger.Error("Error starting ha: %s", err)
+		os.Exit(1)
+	}
+	// every replica would alert, the leader notifies and monitors
+	ha.Lead("notify", notify.Start, notify.Stop)
+	ha.Lead("monitor", monitor.Start, monitor.Stop)
+	// the leader runs the jobs, the one after a failover restores them
+	ha.Lead("jobs", jobs.Start, jobs.Stop)
+	server.OnReload(calls.Setup)
+	server.OnReload(func() error {
+		return logging.SetLevels(beego.AppConfig.String("LogLevel"))
+	})
+	server.WatchReload()
+	server.OnShutdown(func() error {
+		notify.Stop()
+		monitor.Stop()
+		jobs.Stop()
+		return nil
+	})
+	server.OnShutdown(audit.Stop)
+	server.OnShutdown(ha.Stop)
+	server.OnShutdown(storage.Stop)
+	server.WatchShutdown()
+	server.Run()
+}
+
+// command runs a command instead of serving, returning the exit status
+func command(name string, args []string) int {
+	switch name {
+	case "verify-audit":
+		return verifyAudit(args)
+	case "import-storage":
+		return importStorage(args)
+	}
+	if cli.IsGroup(name) {
+		return cli.Run(name, args, os.Stdin, os.Stdout, os.Stderr)
+	}
+	fmt.Fprintln(os.Stderr, usage)
+	cli.Usage(os.Stderr)
+	return 2
+}
+
+func verifyAudit(args []string) int {
+	var (
+		name = audit.Prefix()
+		v    *audit.Verification
+		err  error
+	)
+	if len(args) > 0 {
+		name = args[0]
+		v, err = audit.VerifyFile(name)
+	} else {
+		var st sdk.Storage
+		if st, err = storage.Open(); err == nil {
+			defer storage.Close(st)
+			v, err = audit.VerifyStorage(st, name)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	for _, p := range v.Problems {
+		fmt.Println(p)
+	}
+	if len(v.Problems) != 0 {
+		fmt.Printf("%s does not verify: %d problems in %d records\n", name, len(v.Problems), v.LastSeq)
+		return 1
+	}
+	fmt.Printf("%s verifies: %d records, last hash %s\n", name, v.LastSeq, v.LastHash)
+	return 0
+}
+
+func importStorage(keys []string) int {
+	dst, err := storage.Open()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	defer storage.Close(dst)
+	if len(keys) == 0 {
+		keys = []string{beego.AppConfig.String("MSPDir"), mspstore.Dir(), "orderer.block"}
+	}
+	src := sdk.NewFileStorage(".")
+	for _, key := range keys {
+		n, err := storage.Copy(dst, src, key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error importing %s: %s\n", key, err)
+			return 1
+		}
+		fmt.Printf("imported %d files of %s\n", n, key)
+	}
+	return 0
 }
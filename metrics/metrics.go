@@ -0,0 +1,208 @@
+// Package metrics keeps counters and histograms of manageChain and writes
+// them in the Prometheus text format.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the buckets of the durations
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+type collector interface {
+	write(w io.Writer)
+}
+
+var (
+	registryLock sync.Mutex
+	registry     []collector
+)
+
+func register(c collector) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry = append(registry, c)
+}
+
+// WriteText writes every registered metric in the Prometheus text format
+func WriteText(w io.Writer) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	for _, c := range registry {
+		c.write(w)
+	}
+}
+
+// Text returns every registered metric in the Prometheus text format
+func Text() string {
+	buf := &bytes.Buffer{}
+	WriteText(buf)
+	return buf.String()
+}
+
+// vec is a metric family, its series keyed by their label values
+type vec struct {
+	name   string
+	help   string
+	labels []string
+	mutex  sync.Mutex
+}
+
+func (v *vec) key(values []string) string {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s has labels %v, got values %v", v.name, v.labels, values))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func (v *vec) header(w io.Writer, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", v.name, v.help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", v.name, typ)
+}
+
+// labelPairs formats the labels of a series, with the extra pairs appended
+func (v *vec) labelPairs(values []string, extra ...string) string {
+	var pairs []string
+	for i, label := range v.labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label, escape(values[i])))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extra[i], escape(extra[i+1])))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+type counter struct {
+	values []string
+	value  float64
+}
+
+// CounterVec is a family of counters partitioned by labels
+type CounterVec struct {
+	vec
+	series map[string]*counter
+}
+
+// NewCounterVec registers a family of counters
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		vec:    vec{name: name, help: help, labels: labels},
+		series: make(map[string]*counter),
+	}
+	register(c)
+	return c
+}
+
+// Add adds delta to the counter with the label values
+func (c *CounterVec) Add(delta float64, values ...string) {
+	key := c.key(values)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	s, ok := c.series[key]
+	if !ok {
+		s = &counter{values: values}
+		c.series[key] = s
+	}
+	s.value += delta
+}
+
+// Inc adds 1 to the counter with the label values
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.header(w, "counter")
+	var keys []string
+	for key := range c.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := c.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(s.values), formatFloat(s.value))
+	}
+}
+
+type histogram struct {
+	values []string
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// HistogramVec is a family of histograms partitioned by labels
+type HistogramVec struct {
+	vec
+	buckets []float64
+	series  map[string]*histogram
+}
+
+// NewHistogramVec registers a family of histograms with the bucket upper bounds, in increasing order
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{
+		vec:     vec{name: name, help: help, labels: labels},
+		buckets: buckets,
+		series:  make(map[string]*histogram),
+	}
+	register(h)
+	return h
+}
+
+// Observe adds an observation to the histogram with the label values
+func (h *HistogramVec) Observe(v float64, values ...string) {
+	key := h.key(values)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogram{values: values, counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.header(w, "histogram")
+	var keys []string
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := h.series[key]
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(s.values, "le", formatFloat(bound)), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(s.values, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(s.values), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(s.values), s.count)
+	}
+}
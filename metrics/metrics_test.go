@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"fmt"
+	"manageChain/fabrictest"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+func TestText(t *testing.T) {
+	c := NewCounterVec("test_requests_total", "Test requests.", "path")
+	c.Inc("/a")
+	c.Add(2, `/"b"`)
+	h := NewHistogramVec("test_duration_seconds", "Test durations.", []float64{0.1, 1}, "path")
+	h.Observe(0.5, "/a")
+	h.Observe(2, "/a")
+
+	text := Text()
+	for _, line := range []string{
+		"# TYPE test_requests_total counter",
+		`test_requests_total{path="/a"} 1`,
+		`test_requests_total{path="/\"b\""} 2`,
+		"# TYPE test_duration_seconds histogram",
+		`test_duration_seconds_bucket{path="/a",le="0.1"} 0`,
+		`test_duration_seconds_bucket{path="/a",le="1"} 1`,
+		`test_duration_seconds_bucket{path="/a",le="+Inf"} 2`,
+		`test_duration_seconds_sum{path="/a"} 2.5`,
+		`test_duration_seconds_count{path="/a"} 2`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Fatalf("%s is not in\n%s", line, text)
+		}
+	}
+}
+
+func TestObserveFabric(t *testing.T) {
+	const orgDir = "../msp/testorg1"
+	ca, err := sdk.ConstructCAFromDir(orgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := sdk.NewClient(ca.AdminCommonName(), "testorg1", ca.AdminMSPDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := fabrictest.NewNetwork()
+	defer n.Stop()
+	peerAddr, err := n.StartPeer(orgDir, "testorg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.QueryChannels(&sdk.Endpoint{Address: peerAddr, TLS: ca.TLSCACert()}); err != nil {
+		t.Fatal(err)
+	}
+	// nothing listens on a closed listener
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis.Close()
+	closedAddr := lis.Addr().String()
+	if _, err := client.QueryChannels(&sdk.Endpoint{Address: closedAddr, TLS: ca.TLSCACert(), Timeout: time.Second}); err == nil {
+		t.Fatal("a closed endpoint should not endorse")
+	}
+
+	text := Text()
+	for _, line := range []string{
+		fmt.Sprintf(`managechain_fabric_requests_total{operation="endorse",channel="",org="testorg1",endpoint="%s"} 1`, peerAddr),
+		fmt.Sprintf(`managechain_fabric_request_duration_seconds_count{operation="endorse",channel="",org="testorg1",endpoint="%s"} 1`, peerAddr),
+		fmt.Sprintf(`managechain_fabric_request_failures_total{operation="endorse",channel="",org="testorg1",endpoint="%s"} 1`, closedAddr),
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Fatalf("%s is not in\n%s", line, text)
+		}
+	}
+	if strings.Contains(text, fmt.Sprintf(`managechain_fabric_request_failures_total{operation="endorse",channel="",org="testorg1",endpoint="%s"}`, peerAddr)) {
+		t.Fatalf("the endorsement of %s should not fail", peerAddr)
+	}
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/astaxie/beego/context"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var (
+	fabricRequests = NewCounterVec("managechain_fabric_requests_total",
+		"Calls to the peers and orderers.", "operation", "channel", "org", "endpoint")
+	fabricFailures = NewCounterVec("managechain_fabric_request_failures_total",
+		"Failed calls to the peers and orderers.", "operation", "channel", "org", "endpoint")
+	fabricDuration = NewHistogramVec("managechain_fabric_request_duration_seconds",
+		"Duration of the calls to the peers and orderers.", DefaultBuckets, "operation", "channel", "org", "endpoint")
+
+	httpRequests = NewCounterVec("managechain_http_requests_total",
+		"Requests to the REST API.", "operation", "method", "code")
+	httpDuration = NewHistogramVec("managechain_http_request_duration_seconds",
+		"Duration of the requests to the REST API.", DefaultBuckets, "operation", "method")
+)
+
+func init() {
+	sdk.SetObserver(ObserveFabric)
+}
+
+// ObserveFabric counts a call of the sdk to a peer or an orderer
+func ObserveFabric(op string, chainID string, mspID string, endpoint string, elapsed time.Duration, err error) {
+	fabricRequests.Inc(op, chainID, mspID, endpoint)
+	if err != nil {
+		fabricFailures.Inc(op, chainID, mspID, endpoint)
+	}
+	fabricDuration.Observe(elapsed.Seconds(), op, chainID, mspID, endpoint)
+}
+
+const startKey = "metricsStart"
+
+// StartRequest is a beego.BeforeRouter filter marking the start of a request
+func StartRequest(ctx *context.Context) {
+	ctx.Input.SetData(startKey, time.Now())
+}
+
+// ObserveRequest is a beego.FinishRouter filter counting the request by its route
+func ObserveRequest(ctx *context.Context) {
+	start, ok := ctx.Input.GetData(startKey).(time.Time)
+	if !ok {
+		return
+	}
+	operation, _ := ctx.Input.GetData("RouterPattern").(string)
+	code := ctx.ResponseWriter.Status
+	if code == 0 {
+		code = 200
+	}
+	httpRequests.Inc(operation, ctx.Input.Method(), strconv.Itoa(code))
+	httpDuration.Observe(time.Since(start).Seconds(), operation, ctx.Input.Method())
+}
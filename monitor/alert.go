@@ -0,0 +1,32 @@
+package monitor
+
+import "fmt"
+
+// alert sends the problems found since the last check and the ones that
+// are solved, a problem lasting over several checks is sent once.
+func (m *Monitor) alert(summary *Summary) {
+	found := make(map[string]string)
+	for _, ns := range summary.Nodes {
+		for _, p := range ns.Problems {
+			key := ns.String() + "/" + key(p)
+			found[key] = fmt.Sprintf("[%s] %s: %s", p.Check, ns, p.Message)
+			if _, ok := m.alerted[key]; ok {
+				continue
+			}
+			logger.Warning("%s", found[key])
+			if m.alerter != nil {
+				m.alerter.Error("%s", found[key])
+			}
+		}
+	}
+	for key, msg := range m.alerted {
+		if _, ok := found[key]; ok {
+			continue
+		}
+		logger.Info("solved %s", msg)
+		if m.alerter != nil {
+			m.alerter.Notice("solved %s", msg)
+		}
+	}
+	m.alerted = found
+}
@@ -0,0 +1,89 @@
+package monitor
+
+import (
+	"manageChain/alerting"
+	"manageChain/logging"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+var logger = logging.GetLogger("monitor")
+
+const (
+	defaultInterval = 60 * time.Second
+	defaultMaxLag   = 10
+)
+
+// ServiceNode is a peer or orderer the monitor probes
+type ServiceNode = alerting.ServiceNode
+
+// OrgConfig configures the monitoring of the nodes of one org.
+// Channels are the channels to check, the channels the peers have joined when empty.
+// MaxLag is the number of blocks a peer may be behind its channel, defaultMaxLag when 0.
+// The alerts are sent through Adapters.
+type OrgConfig struct {
+	alerting.OrgConfig
+	OrdererNodes []*ServiceNode
+	Channels     []string
+	MaxLag       uint64
+}
+
+var (
+	monitorsLock sync.Mutex
+	monitors     []*Monitor
+)
+
+// Start monitors the nodes of every org configured in the file given by
+// MonitorConfig of app.conf every MonitorInterval seconds, it does nothing
+// when MonitorConfig is not set.
+func Start() error {
+	file := beego.AppConfig.String("MonitorConfig")
+	if file == "" {
+		return nil
+	}
+	var configs []*OrgConfig
+	if err := alerting.LoadConfig(file, &configs); err != nil {
+		logger.Error("Error loading monitor config %s: %s", file, err)
+		return err
+	}
+	interval := defaultInterval
+	if seconds, err := beego.AppConfig.Int("MonitorInterval"); err == nil && seconds > 0 {
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	monitorsLock.Lock()
+	defer monitorsLock.Unlock()
+	for _, conf := range configs {
+		m, err := NewMonitor(conf)
+		if err != nil {
+			logger.Error("Error creating monitor of %s: %s", conf.OrgName, err)
+			return err
+		}
+		m.Start(interval)
+		monitors = append(monitors, m)
+	}
+	return nil
+}
+
+// Stop stops all monitors started by Start
+func Stop() {
+	monitorsLock.Lock()
+	defer monitorsLock.Unlock()
+	for _, m := range monitors {
+		m.Stop()
+	}
+	monitors = nil
+}
+
+// Health merges the last summaries of all monitors started by Start
+func Health() *Summary {
+	monitorsLock.Lock()
+	defer monitorsLock.Unlock()
+	health := &Summary{Healthy: true, Channels: make(map[string]uint64)}
+	for _, m := range monitors {
+		merge(health, m.Summary())
+	}
+	return health
+}
@@ -0,0 +1,185 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"manageChain/alerting"
+	"manageChain/fabrictest"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+const (
+	testMSPDir  = "../msp"
+	testOrgDir  = "../msp/testorg1"
+	testMSP     = "testorg1"
+	testChannel = "mychannel"
+)
+
+// startNetwork runs an orderer and a peer of testorg1, the peer joined to testChannel
+func startNetwork(t *testing.T) (*fabrictest.Network, string, string) {
+	ca, err := sdk.ConstructCAFromDir(testOrgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := sdk.NewClient(ca.AdminCommonName(), testMSP, ca.AdminMSPDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := fabrictest.NewNetwork()
+	ordererAddr, err := n.StartOrderer(testOrgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerAddr, err := n.StartPeer(testOrgDir, testMSP)
+	if err != nil {
+		n.Stop()
+		t.Fatal(err)
+	}
+	org := &sdk.Organization{Name: testMSP, ID: testMSP, MSPDir: ca.MSPDir()}
+	genesis := sdk.CreateGenesisBlock(&sdk.GenesisConfig{
+		ChainID:                 sdk.DefaultSystemChainID,
+		OrdererType:             "kafka",
+		Addresses:               []string{ordererAddr},
+		KafkaBrokers:            []string{"127.0.0.1:9092"},
+		AdminsPolicy:            sdk.PolicyMajorityAdmins,
+		WritersPolicy:           sdk.PolicyAnyWriters,
+		ReadersPolicy:           sdk.PolicyAnyReaders,
+		OrdererOrganizations:    []*sdk.Organization{org},
+		ConsortiumOrganizations: []*sdk.Organization{org},
+		ConsortiumName:          sdk.DefaultConsortium,
+	})
+	if err := n.Bootstrap(genesis); err != nil {
+		n.Stop()
+		t.Fatal(err)
+	}
+
+	orderer := &sdk.Endpoint{Address: ordererAddr, TLS: ca.TLSCACert()}
+	err = client.CreateChannel(&sdk.ChannelConfig{
+		ChainID:       testChannel,
+		Consortium:    sdk.DefaultConsortium,
+		AdminsPolicy:  sdk.PolicyMajorityAdmins,
+		WritersPolicy: sdk.PolicyAnyWriters,
+		ReadersPolicy: sdk.PolicyAnyReaders,
+		Organizations: []*sdk.Organization{org},
+	}, orderer)
+	if err != nil {
+		n.Stop()
+		t.Fatal(err)
+	}
+	block, err := client.GetBlockByChannel(testChannel, 0, orderer)
+	if err != nil {
+		n.Stop()
+		t.Fatal(err)
+	}
+	if err := client.JoinChannel(testChannel, block, []*sdk.Endpoint{{Address: peerAddr, TLS: ca.TLSCACert()}}); err != nil {
+		n.Stop()
+		t.Fatal(err)
+	}
+	return n, ordererAddr, peerAddr
+}
+
+// closedAddress is an address nothing listens on
+func closedAddress(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis.Close()
+	return lis.Addr().String()
+}
+
+// receiveAlerts listens for the alerts of the conn adapter
+func receiveAlerts(t *testing.T) (string, <-chan string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alerts := make(chan string, 10)
+	go func() {
+		defer lis.Close()
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			alerts <- scanner.Text()
+		}
+	}()
+	return lis.Addr().String(), alerts
+}
+
+func TestCheck(t *testing.T) {
+	n, ordererAddr, peerAddr := startNetwork(t)
+	defer n.Stop()
+	alertAddr, alerts := receiveAlerts(t)
+
+	m, err := newMonitor(&OrgConfig{
+		OrgConfig: alerting.OrgConfig{
+			OrgName: testMSP,
+			OrgMSP:  testMSP,
+			PeerNodes: []*ServiceNode{
+				{ID: "peer0", Endpoint: peerAddr},
+				{ID: "peer1", Endpoint: closedAddress(t)},
+			},
+			Adapters: map[string]string{"conn": fmt.Sprintf(`{"net":"tcp","addr":"%s"}`, alertAddr)},
+		},
+		OrdererNodes: []*ServiceNode{{ID: "orderer0", Endpoint: ordererAddr}},
+	}, testMSPDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	summary := m.Check()
+	if summary.Healthy {
+		t.Fatal("an unreachable peer should be unhealthy")
+	}
+	if summary.Channels[testChannel] != 1 {
+		t.Fatalf("unexpected channel heights: %v", summary.Channels)
+	}
+	nodes := make(map[string]*NodeStatus)
+	for _, ns := range summary.Nodes {
+		nodes[ns.ID] = ns
+	}
+	for _, id := range []string{"peer0", "orderer0"} {
+		ns := nodes[id]
+		if !ns.Reachable || len(ns.Problems) != 0 || ns.Heights[testChannel] != 1 || ns.CertNotAfter == 0 {
+			t.Fatalf("unexpected status of %s: %+v, problems: %v", id, ns, ns.Problems)
+		}
+	}
+	peer1 := nodes["peer1"]
+	if peer1.Reachable || len(peer1.Problems) != 1 || peer1.Problems[0].Check != CheckReachable {
+		t.Fatalf("unexpected status of peer1: %+v", peer1)
+	}
+
+	select {
+	case alert := <-alerts:
+		if !strings.Contains(alert, "peer1") {
+			t.Fatalf("unexpected alert: %s", alert)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no alert is sent")
+	}
+	// a lasting problem is alerted once
+	m.Check()
+	select {
+	case alert := <-alerts:
+		t.Fatalf("unexpected alert: %s", alert)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestLag(t *testing.T) {
+	peer := &NodeStatus{Heights: map[string]uint64{"ch1": 5, "ch2": 20}}
+	lag(peer, map[string]uint64{"ch1": 30, "ch2": 25}, 10)
+	if len(peer.Problems) != 1 || peer.Problems[0].Check != CheckLag || peer.Problems[0].Channel != "ch1" {
+		t.Fatalf("unexpected problems: %+v", peer.Problems)
+	}
+}
@@ -0,0 +1,329 @@
+package monitor
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	logs "gglogs"
+	"io/ioutil"
+	"manageChain/alerting"
+	"manageChain/channel"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+const (
+	probeTimeout = 5 * time.Second
+	// certWarning is how long before it expires a TLS certificate is flagged
+	certWarning = 30 * 24 * time.Hour
+)
+
+// Monitor probes the peers and orderers of an org, and alerts the org when
+// a node gets unhealthy or recovers.
+type Monitor struct {
+	conf    *OrgConfig
+	ca      *sdk.CA
+	client  *sdk.Client
+	alerter *logs.BeeLogger
+
+	mutex   sync.Mutex
+	summary *Summary
+	// alerted are the alerts of the problems of the last check, by node and problem key
+	alerted map[string]string
+
+	stopC chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewMonitor ...
+func NewMonitor(conf *OrgConfig) (*Monitor, error) {
+	gm, _ := beego.AppConfig.Bool("GM")
+	return newMonitor(conf, beego.AppConfig.String("MSPDir"), gm)
+}
+
+func newMonitor(conf *OrgConfig, mspDir string, gm bool) (*Monitor, error) {
+	orgCA, err := channel.GetCA(path.Join(mspDir, conf.OrgName), conf.OrgName)
+	if err != nil {
+		logger.Error("Error getting org ca: %s", err)
+		return nil, err
+	}
+	client, err := sdk.NewClient(orgCA.AdminCommonName(), conf.OrgMSP, orgCA.AdminMSPDir(), gm)
+	if err != nil {
+		logger.Error("Error creating client for org: %s", err)
+		return nil, err
+	}
+
+	var a *logs.BeeLogger
+	if len(conf.Adapters) != 0 {
+		if a, err = alerting.NewLogger(conf.Adapters); err != nil {
+			return nil, err
+		}
+	}
+	return &Monitor{
+		conf:    conf,
+		ca:      orgCA,
+		client:  client,
+		alerter: a,
+		alerted: make(map[string]string),
+		stopC:   make(chan struct{}),
+	}, nil
+}
+
+// Start checks the nodes right away, then every interval
+func (m *Monitor) Start(interval time.Duration) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		for {
+			m.Check()
+			select {
+			case <-m.stopC:
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
+
+// Stop ...
+func (m *Monitor) Stop() {
+	close(m.stopC)
+	m.wg.Wait()
+	if m.alerter != nil {
+		m.alerter.Close()
+	}
+}
+
+// Summary returns the result of the last check, nil before any check
+func (m *Monitor) Summary() *Summary {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.summary
+}
+
+// Check probes every node of the org once, alerts the changes since the
+// last check and returns the result.
+func (m *Monitor) Check() *Summary {
+	summary := &Summary{
+		Healthy:   true,
+		CheckedAt: time.Now().Unix(),
+		Channels:  make(map[string]uint64),
+	}
+	var peers []*NodeStatus
+	joined := make(map[string]uint64)
+	for _, sn := range m.conf.PeerNodes {
+		ns := m.checkPeer(sn)
+		for channelName, height := range ns.Heights {
+			joined[channelName] = height
+		}
+		peers = append(peers, ns)
+	}
+	channels := m.conf.Channels
+	if len(channels) == 0 {
+		channels = sortedKeys(joined)
+	}
+	for _, sn := range m.conf.OrdererNodes {
+		summary.Nodes = append(summary.Nodes, m.checkOrderer(sn, channels))
+	}
+	summary.Nodes = append(summary.Nodes, peers...)
+
+	for _, ns := range summary.Nodes {
+		for channelName, height := range ns.Heights {
+			if height > summary.Channels[channelName] {
+				summary.Channels[channelName] = height
+			}
+		}
+	}
+	m.discoverHeights(peers, channels, summary.Channels)
+	maxLag := m.conf.MaxLag
+	if maxLag == 0 {
+		maxLag = defaultMaxLag
+	}
+	for _, ns := range peers {
+		lag(ns, summary.Channels, maxLag)
+	}
+	for _, ns := range summary.Nodes {
+		if len(ns.Problems) != 0 {
+			summary.Healthy = false
+		}
+	}
+
+	m.alert(summary)
+	m.mutex.Lock()
+	m.summary = summary
+	m.mutex.Unlock()
+	return summary
+}
+
+func (m *Monitor) endpoint(sn *ServiceNode) *sdk.Endpoint {
+	return &sdk.Endpoint{
+		Address: sn.Endpoint,
+		TLS:     m.ca.TLSCACert(),
+		Timeout: probeTimeout,
+	}
+}
+
+func (m *Monitor) checkPeer(sn *ServiceNode) *NodeStatus {
+	ns := &NodeStatus{Org: m.conf.OrgName, ID: sn.ID, Type: Peer, Endpoint: sn.Endpoint}
+	m.checkCert(ns, sdk.PeerNode)
+	ep := m.endpoint(sn)
+	joined, err := m.client.QueryChannels(ep)
+	if err != nil {
+		fail(ns, CheckReachable, "", "unreachable: %s", err)
+		return ns
+	}
+	ns.Reachable = true
+
+	ns.Heights = make(map[string]uint64)
+	channels := m.conf.Channels
+	if len(channels) == 0 {
+		channels = joined
+	}
+	for _, channelName := range channels {
+		if !contains(joined, channelName) {
+			fail(ns, CheckHeight, channelName, "has not joined channel %s", channelName)
+			continue
+		}
+		info, err := m.client.QueryChainInfo(channelName, ep)
+		if err != nil {
+			fail(ns, CheckHeight, channelName, "ledger height of channel %s is unknown: %s", channelName, err)
+			continue
+		}
+		ns.Heights[channelName] = info.Height
+		if err := m.checkDeliver(channelName, info.Height, ep); err != nil {
+			fail(ns, CheckDeliver, channelName, "does not deliver blocks of channel %s: %s", channelName, err)
+		}
+	}
+	return ns
+}
+
+// checkDeliver receives the last block of the ledger of channelName from peer
+func (m *Monitor) checkDeliver(channelName string, height uint64, peer *sdk.Endpoint) error {
+	if height == 0 {
+		return nil
+	}
+	iter, err := m.client.GetCommittedBlocksByChannel(channelName, height-1, peer)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	errC := make(chan error, 1)
+	go func() {
+		_, err := iter.NextBlock()
+		errC <- err
+	}()
+	select {
+	case err := <-errC:
+		return err
+	case <-time.After(probeTimeout):
+		return fmt.Errorf("no block is delivered in %s", probeTimeout)
+	}
+}
+
+// checkOrderer reads the newest block of every channel from the orderer
+func (m *Monitor) checkOrderer(sn *ServiceNode, channels []string) *NodeStatus {
+	ns := &NodeStatus{Org: m.conf.OrgName, ID: sn.ID, Type: Orderer, Endpoint: sn.Endpoint}
+	m.checkCert(ns, sdk.OrdererNode)
+	ep := m.endpoint(sn)
+	ns.Heights = make(map[string]uint64)
+	for _, channelName := range channels {
+		block, err := m.client.GetNewestBlockByChannel(channelName, ep)
+		if err != nil {
+			fail(ns, CheckDeliver, channelName, "stopped serving channel %s: %s", channelName, err)
+			continue
+		}
+		ns.Reachable = true
+		ns.Heights[channelName] = block.Header.Number + 1
+	}
+	if len(channels) != 0 && !ns.Reachable {
+		fail(ns, CheckReachable, "", "serves none of the channels %v", channels)
+	}
+	return ns
+}
+
+// checkCert checks the validity of the TLS certificate of the node in the msp
+// dir of the org, the nodes deployed with certificates of elsewhere are skipped.
+func (m *Monitor) checkCert(ns *NodeStatus, nodeType sdk.NodeType) {
+	file := filepath.Join(m.ca.NodeTLSDir(ns.ID, nodeType), "server.crt")
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return
+	}
+	var cert *x509.Certificate
+	if err == nil {
+		cert, err = parseCert(data)
+	}
+	if err != nil {
+		fail(ns, CheckCert, "", "invalid TLS certificate %s: %s", file, err)
+		return
+	}
+	ns.CertNotAfter = cert.NotAfter.Unix()
+	now := time.Now()
+	switch {
+	case now.Before(cert.NotBefore):
+		fail(ns, CheckCert, "", "TLS certificate is not valid before %s", cert.NotBefore)
+	case now.After(cert.NotAfter):
+		fail(ns, CheckCert, "", "TLS certificate expired at %s", cert.NotAfter)
+	case now.Add(certWarning).After(cert.NotAfter):
+		fail(ns, CheckCert, "", "TLS certificate expires at %s", cert.NotAfter)
+	}
+}
+
+func parseCert(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data is found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// discoverHeights raises the heights of channels to the ledger heights the
+// peers of other orgs gossip, discovered through a reachable peer of the org.
+func (m *Monitor) discoverHeights(peers []*NodeStatus, channels []string, heights map[string]uint64) {
+	for _, channelName := range channels {
+		for _, ns := range peers {
+			if _, ok := ns.Heights[channelName]; !ok {
+				continue
+			}
+			msps, err := m.client.DiscoveryChannel(channelName, m.endpoint(&ServiceNode{ID: ns.ID, Endpoint: ns.Endpoint}))
+			if err != nil {
+				logger.Warning("Error discovering channel %s through %s: %s", channelName, ns.Endpoint, err)
+				continue
+			}
+			for _, conf := range msps {
+				for _, node := range conf.Nodes {
+					if node.LedgerHeight > heights[channelName] {
+						heights[channelName] = node.LedgerHeight
+					}
+				}
+			}
+			break
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]uint64) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
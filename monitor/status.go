@@ -0,0 +1,67 @@
+package monitor
+
+import (
+	"fmt"
+	"manageChain/protocols"
+)
+
+// node types
+const (
+	Peer    = "peer"
+	Orderer = "orderer"
+)
+
+// checks a problem is found by
+const (
+	CheckReachable = "reachable"
+	CheckCert      = "cert"
+	CheckHeight    = "height"
+	CheckDeliver   = "deliver"
+	CheckLag       = "lag"
+)
+
+// the health of the nodes, see protocols
+type (
+	Problem    = protocols.NodeProblem
+	NodeStatus = protocols.NodeStatus
+	Summary    = protocols.Summary
+)
+
+// key identifies the problem across checks, its message may change between them
+func key(p *Problem) string {
+	return p.Check + "/" + p.Channel
+}
+
+func fail(ns *NodeStatus, check, channelName, format string, v ...interface{}) {
+	ns.Problems = append(ns.Problems, &Problem{
+		Check:   check,
+		Channel: channelName,
+		Message: fmt.Sprintf(format, v...),
+	})
+}
+
+func merge(s *Summary, other *Summary) {
+	if other == nil {
+		return
+	}
+	s.Healthy = s.Healthy && other.Healthy
+	if other.CheckedAt > s.CheckedAt {
+		s.CheckedAt = other.CheckedAt
+	}
+	for channelName, height := range other.Channels {
+		if height > s.Channels[channelName] {
+			s.Channels[channelName] = height
+		}
+	}
+	s.Nodes = append(s.Nodes, other.Nodes...)
+}
+
+// lag flags the channels peer is more than maxLag blocks behind
+func lag(peer *NodeStatus, heights map[string]uint64, maxLag uint64) {
+	for _, channelName := range sortedKeys(peer.Heights) {
+		height, top := peer.Heights[channelName], heights[channelName]
+		if top > height+maxLag {
+			fail(peer, CheckLag, channelName, "%d blocks behind channel %s at height %d", top-height, channelName, top)
+		}
+	}
+}
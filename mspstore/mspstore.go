@@ -0,0 +1,345 @@
+// Package mspstore keeps the MSPs of the orgs added to the channels, received
+// in their identity code, by MSP ID and hash of their certificates. A bundle
+// is stored once whatever the number of AddOrg retries, and a bundle different
+// from the one of an org in the channels is refused until the org is removed
+// from all of them.
+package mspstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"manageChain/logging"
+	"manageChain/protocols"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var logger = logging.GetLogger("mspstore")
+
+const (
+	defaultDir      = "tmpMspDir"
+	defaultVersions = 3
+	indexFile       = "index.json"
+)
+
+// Bundle is a version of the MSP of an org
+type Bundle struct {
+	MSPID string `json:"mspId"`
+	Org   string `json:"org"`
+	// Hash is the SHA-256 of the certificates, the name of the directory
+	Hash     string    `json:"hash"`
+	Received time.Time `json:"received"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// Dir is the storage key of the MSP directory of the bundle
+func (b *Bundle) Dir() string {
+	return path.Join(Dir(), b.MSPID, b.Hash)
+}
+
+// LocalDir is the local MSP directory of the bundle, its checkout
+func (b *Bundle) LocalDir() (string, error) {
+	return sdk.Store().Checkout(b.Dir())
+}
+
+// index is the index.json of an MSP ID
+type index struct {
+	// Channels maps the channels the org is in to the hash of its bundle
+	Channels map[string]string `json:"channels,omitempty"`
+	Versions []*Bundle         `json:"versions"`
+}
+
+// current is the hash of the bundle of the org in the channels, empty when
+// it is in none. Put refuses other bundles, the channels share it.
+func (idx *index) current() string {
+	var channels []string
+	for channelName := range idx.Channels {
+		channels = append(channels, channelName)
+	}
+	if len(channels) == 0 {
+		return ""
+	}
+	sort.Strings(channels)
+	return idx.Channels[channels[0]]
+}
+
+func (idx *index) inUse(hash string) bool {
+	for _, h := range idx.Channels {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func (idx *index) version(hash string) *Bundle {
+	for _, b := range idx.Versions {
+		if b.Hash == hash {
+			return b
+		}
+	}
+	return nil
+}
+
+// lock takes the lock of the index of mspID against the other users of the
+// storage
+func lock(mspID string) (func(), error) {
+	return sdk.Store().Lock("mspstore/" + mspID)
+}
+
+// Dir is the directory of the store, ForeignMSPDir of app.conf
+func Dir() string {
+	return beego.AppConfig.DefaultString("ForeignMSPDir", defaultDir)
+}
+
+// Versions is the number of versions kept for an MSP ID besides the current
+// one, ForeignMSPVersions of app.conf, 3 by default.
+func Versions() int {
+	if n := beego.AppConfig.DefaultInt("ForeignMSPVersions", defaultVersions); n >= 0 {
+		return n
+	}
+	return defaultVersions
+}
+
+// bundle is the MSP of an identity code, as written by sdk.CA.MSPBytes
+type bundle struct {
+	MSPID      string
+	Org        string
+	AdminCerts map[string][]byte
+	CACerts    map[string][]byte
+	TLSCACerts map[string][]byte
+}
+
+// parse returns the bundle of data and the hash of its certificates
+func parse(data []byte) (*bundle, string, error) {
+	b := &bundle{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, "", protocols.Errorf(protocols.CodeInvalidRequest, "invalid MSP in the identity: %s", err)
+	}
+	if b.MSPID == "" || b.MSPID != filepath.Base(b.MSPID) || strings.HasPrefix(b.MSPID, ".") {
+		return nil, "", protocols.Errorf(protocols.CodeInvalidRequest, "invalid MSP ID %q in the identity", b.MSPID)
+	}
+	if len(b.AdminCerts) == 0 || len(b.CACerts) == 0 {
+		return nil, "", protocols.Errorf(protocols.CodeInvalidRequest, "the MSP of %s has no admin or CA certificate", b.MSPID)
+	}
+	// the maps are marshaled sorted, the same certificates give the same hash
+	certs, err := json.Marshal([]map[string][]byte{b.AdminCerts, b.CACerts, b.TLSCACerts})
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(certs)
+	return b, hex.EncodeToString(sum[:]), nil
+}
+
+func readIndex(mspID string) (*index, error) {
+	idx := &index{}
+	data, err := sdk.Store().Read(path.Join(Dir(), mspID, indexFile))
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+func writeIndex(mspID string, idx *index) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return sdk.Store().Write(path.Join(Dir(), mspID, indexFile), data)
+}
+
+// Put stores the MSP of an identity code unless it is stored already, and
+// returns its bundle. It fails with a conflict when the org is in the
+// channels with other certificates.
+func Put(data []byte) (*Bundle, error) {
+	b, hash, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := lock(b.MSPID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	idx, err := readIndex(b.MSPID)
+	if err != nil {
+		return nil, err
+	}
+	if current := idx.current(); current != "" && current != hash {
+		return nil, protocols.Errorf(protocols.CodeConflict, "%s is in the channels with another MSP (%.12s), received %.12s: remove the org from all of them before adding it with new certificates", b.MSPID, current, hash)
+	}
+
+	now := time.Now()
+	version := idx.version(hash)
+	if version == nil {
+		version = &Bundle{MSPID: b.MSPID, Org: b.Org, Hash: hash, Received: now}
+		if err := write(version, data); err != nil {
+			return nil, err
+		}
+		idx.Versions = append(idx.Versions, version)
+		logger.Info("Stored the MSP %.12s of %s", hash, b.MSPID)
+	}
+	version.LastUsed = now
+	if err := writeIndex(b.MSPID, prune(b.MSPID, idx)); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// write writes the certificates of the bundle, replacing what an interrupted
+// Put left in its directory. The bundle is only used once in the index.
+func write(b *Bundle, data []byte) error {
+	_, _, err := sdk.WriteMSP(b.Dir(), data)
+	return err
+}
+
+// prune removes the versions of mspID beyond Versions besides the one in
+// the channels, the least recently used first.
+func prune(mspID string, idx *index) *index {
+	sort.SliceStable(idx.Versions, func(i, j int) bool {
+		return idx.Versions[i].LastUsed.After(idx.Versions[j].LastUsed)
+	})
+	var kept []*Bundle
+	others := 0
+	for _, b := range idx.Versions {
+		if !idx.inUse(b.Hash) {
+			if others == Versions() {
+				if err := sdk.Store().Remove(b.Dir()); err != nil {
+					logger.Warning("Error removing the MSP %.12s of %s: %s", b.Hash, mspID, err)
+					kept = append(kept, b)
+					continue
+				}
+				logger.Info("Removed the MSP %.12s of %s", b.Hash, mspID)
+				continue
+			}
+			others++
+		}
+		kept = append(kept, b)
+	}
+	idx.Versions = kept
+	return idx
+}
+
+// Commit records that the bundle of hash is the MSP of mspID in channelName,
+// once a config update added it.
+func Commit(mspID string, channelName string, hash string) error {
+	unlock, err := lock(mspID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	idx, err := readIndex(mspID)
+	if err != nil {
+		return err
+	}
+	version := idx.version(hash)
+	if version == nil {
+		return protocols.Errorf(protocols.CodeNotFound, "no MSP %.12s of %s is stored", hash, mspID)
+	}
+	if idx.Channels == nil {
+		idx.Channels = make(map[string]string)
+	}
+	idx.Channels[channelName] = hash
+	version.LastUsed = time.Now()
+	return writeIndex(mspID, prune(mspID, idx))
+}
+
+// Retire records that mspID is not in channelName anymore, once a config
+// update removed it. Once it has left all its channels it can be added again
+// with other certificates.
+func Retire(mspID string, channelName string) error {
+	unlock, err := lock(mspID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	idx, err := readIndex(mspID)
+	if err != nil {
+		return err
+	}
+	if _, ok := idx.Channels[channelName]; !ok {
+		return nil
+	}
+	delete(idx.Channels, channelName)
+	return writeIndex(mspID, prune(mspID, idx))
+}
+
+// Current returns the bundle of mspID in the channels, nil when there is none
+func Current(mspID string) (*Bundle, error) {
+	idx, err := readIndex(mspID)
+	if err != nil {
+		return nil, err
+	}
+	return idx.version(idx.current()), nil
+}
+
+// List returns the bundles stored for mspID, the most recently used first
+func List(mspID string) ([]*Bundle, error) {
+	idx, err := readIndex(mspID)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Versions, nil
+}
+
+// Start removes what an interrupted Put left in the store, the versions
+// missing from the indexes.
+func Start() error {
+	keys, err := sdk.Store().List(Dir())
+	if err != nil {
+		return err
+	}
+	// the MSP IDs of the store have an index
+	for _, key := range keys {
+		rel := strings.TrimPrefix(key, Dir()+"/")
+		if mspID := path.Dir(rel); path.Base(rel) == indexFile && mspID == filepath.Base(mspID) {
+			if err := removeUnindexed(mspID, keys); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func removeUnindexed(mspID string, keys []string) error {
+	unlock, err := lock(mspID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	idx, err := readIndex(mspID)
+	if err != nil {
+		return err
+	}
+	removed := make(map[string]bool)
+	prefix := path.Join(Dir(), mspID) + "/"
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		hash := strings.Split(strings.TrimPrefix(key, prefix), "/")[0]
+		if len(hash) != sha256.Size*2 || idx.version(hash) != nil || removed[hash] {
+			continue
+		}
+		removed[hash] = true
+		logger.Info("Removing the MSP %.12s of %s missing from its index", hash, mspID)
+		if err := sdk.Store().Remove(prefix + hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
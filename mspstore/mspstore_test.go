@@ -0,0 +1,121 @@
+package mspstore
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"manageChain/protocols"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astaxie/beego"
+)
+
+func identity(t *testing.T, mspID string, cert string) []byte {
+	data, err := json.Marshal(&bundle{
+		MSPID:      mspID,
+		Org:        mspID,
+		AdminCerts: map[string][]byte{"Admin@" + mspID + "-cert.pem": []byte("admin " + cert)},
+		CACerts:    map[string][]byte{mspID + "-cert.pem": []byte("ca " + cert)},
+		TLSCACerts: map[string][]byte{mspID + "-cert.pem": []byte("tlsca " + cert)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mspstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	beego.AppConfig.Set("ForeignMSPDir", dir)
+	beego.AppConfig.Set("ForeignMSPVersions", "1")
+	defer beego.AppConfig.Set("ForeignMSPDir", "")
+	defer beego.AppConfig.Set("ForeignMSPVersions", "")
+
+	// a retried AddOrg gets the same bundle
+	first, err := Put(identity(t, "org4", "first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := Put(identity(t, "org4", "first"))
+	if err != nil || again.Hash != first.Hash {
+		t.Fatalf("unexpected bundle %+v: %v", again, err)
+	}
+	if _, err := os.Stat(filepath.Join(first.Dir(), "cacerts", "org4-cert.pem")); err != nil {
+		t.Fatal(err)
+	}
+
+	// another bundle replaces one that is not in the channels
+	second, err := Put(identity(t, "org4", "second"))
+	if err != nil || second.Hash == first.Hash {
+		t.Fatalf("unexpected bundle %+v: %v", second, err)
+	}
+	for _, channelName := range []string{"mychannel", "otherchannel"} {
+		if err := Commit("org4", channelName, second.Hash); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// and conflicts with the one in the channels until the org left all of them
+	var pe *protocols.Error
+	if _, err := Put(identity(t, "org4", "third")); !errors.As(err, &pe) || pe.Code != protocols.CodeConflict {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := Retire("org4", "mychannel"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Put(identity(t, "org4", "third")); !errors.As(err, &pe) || pe.Code != protocols.CodeConflict {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if current, err := Current("org4"); err != nil || current == nil || current.Hash != second.Hash {
+		t.Fatalf("unexpected current bundle %+v: %v", current, err)
+	}
+	if err := Retire("org4", "otherchannel"); err != nil {
+		t.Fatal(err)
+	}
+	third, err := Put(identity(t, "org4", "third"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current, err := Current("org4"); err != nil || current != nil {
+		t.Fatalf("unexpected current bundle %+v: %v", current, err)
+	}
+
+	// the least recently used versions are removed
+	versions, err := List("org4")
+	if err != nil || len(versions) != 1 || versions[0].Hash != third.Hash {
+		t.Fatalf("unexpected versions %+v: %v", versions, err)
+	}
+	for _, b := range []*Bundle{first, second} {
+		if _, err := os.Stat(b.Dir()); !os.IsNotExist(err) {
+			t.Fatalf("%s is not removed: %v", b.Dir(), err)
+		}
+	}
+
+	// Start removes what an interrupted Put left
+	orphan := filepath.Join(dir, "org4", second.Hash)
+	if err := os.MkdirAll(filepath.Join(orphan, "cacerts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(orphan, "cacerts", "org4-cert.pem"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Start(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(orphan); !os.IsNotExist(err) {
+		t.Fatalf("%s is not removed: %v", orphan, err)
+	}
+	if _, err := os.Stat(third.Dir()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Put(identity(t, "../org5", "first")); !errors.As(err, &pe) || pe.Code != protocols.CodeInvalidRequest {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"manageChain/calls"
+	"strings"
+
+	"manageChain/chaincode"
+	"manageChain/channel"
+)
+
+// Apply computes the plan of the network and runs its steps in order,
+// stopping at the first failing one; the steps run are marked done. Applying
+// a spec the network already matches runs nothing. Once the crypto material
+// and the genesis block are generated, it stops for the nodes to be started.
+func (n *Network) Apply() (*Plan, error) {
+	plan, err := n.Plan()
+	if err != nil {
+		return nil, err
+	}
+	for i, step := range plan.Steps {
+		logger.WithContext(n.ctx).Info("start %s, org:%s, channel:%s, chaincode:%s", step.Action, step.Org, step.Channel, step.Chaincode)
+		if err := n.apply(step); err != nil {
+			logger.WithContext(n.ctx).Error("Error applying %s: %s", step.Action, err)
+			return plan, fmt.Errorf("%s failed: %w", step.Action, err)
+		}
+		step.Done = true
+		if local(step) && i+1 < len(plan.Steps) && !local(plan.Steps[i+1]) {
+			plan.Warnings = append(plan.Warnings, "start the nodes with the generated crypto material and apply again")
+			return plan, nil
+		}
+	}
+	return plan, nil
+}
+
+// local reports whether step only writes local files
+func local(step *Step) bool {
+	return step.Action == GenerateCrypto || step.Action == GenGenesisBlock
+}
+
+func (n *Network) apply(step *Step) error {
+	switch step.Action {
+	case GenerateCrypto:
+		return channel.GenerateCrypto([]*channel.OrgInfo{withNodes(n.orgs[step.Org], step.Nodes)})
+	case GenGenesisBlock:
+		return n.genGenesisBlock()
+	case BootstrapPublicChain:
+		return n.bootstrapPublicChain()
+	case CreateChannel:
+		return n.createChannel(step)
+	case AddOrg:
+		return n.addOrg(step)
+	case DeleteOrg:
+		return n.deleteOrg(step)
+	case JoinChannel:
+		return n.joinChannel(step)
+	case InstallChaincode:
+		return n.installChaincode(step)
+	case InstantiateChaincode:
+		return n.instantiateChaincode(step)
+	}
+	return fmt.Errorf("unknown action %s", step.Action)
+}
+
+func (n *Network) genGenesisBlock() error {
+	var orgs []*channel.OrgInfo
+	for _, name := range n.allOrgs() {
+		org, err := n.withCA(n.orgs[name])
+		if err != nil {
+			return err
+		}
+		orgs = append(orgs, org)
+	}
+	var kafkas []string
+	if n.spec.Consensus != nil {
+		kafkas = n.spec.Consensus.Kafkas
+	}
+	_, err := channel.GenGenesisBlock(orgs, kafkas)
+	return err
+}
+
+func (n *Network) bootstrapPublicChain() error {
+	orgs, err := n.members(n.allOrgs())
+	if err != nil {
+		return err
+	}
+	c, err := n.newChannel(orgs)
+	if err != nil {
+		return err
+	}
+	pc := n.spec.PublicChain
+	return c.BootstrapPublicChain(pc.CcTarPath, pc.CcVersion, pc.Policy)
+}
+
+func (n *Network) createChannel(step *Step) error {
+	orgs, err := n.members(step.Operators)
+	if err != nil {
+		return err
+	}
+	c, err := n.newChannel(orgs)
+	if err != nil {
+		return err
+	}
+	return c.CreateChannel(step.Channel)
+}
+
+func (n *Network) addOrg(step *Step) error {
+	newOrg, err := n.members([]string{step.Org})
+	if err != nil {
+		return err
+	}
+	nc, err := n.newChannel(newOrg)
+	if err != nil {
+		return err
+	}
+	ic, err := nc.IdentityCode()
+	if err != nil {
+		return err
+	}
+	identity, err := json.Marshal(ic)
+	if err != nil {
+		return err
+	}
+
+	operators, err := n.members(step.Operators)
+	if err != nil {
+		return err
+	}
+	c, err := n.newChannel(operators)
+	if err != nil {
+		return err
+	}
+	return c.AddOrg(identity, operators, step.Channel)
+}
+
+func (n *Network) deleteOrg(step *Step) error {
+	operators, err := n.members(step.Operators)
+	if err != nil {
+		return err
+	}
+	c, err := n.newChannel(operators)
+	if err != nil {
+		return err
+	}
+	return c.DeleteOrg(step.Org, nil, step.Channel, operators)
+}
+
+func (n *Network) joinChannel(step *Step) error {
+	org, err := n.withClient(n.orgs[step.Org])
+	if err != nil {
+		return err
+	}
+	c, err := n.newChannel([]*channel.OrgInfo{withNodes(org, step.Nodes)})
+	if err != nil {
+		return err
+	}
+	if err := c.JoinChannel(step.Channel); err != nil {
+		return err
+	}
+	// joining publishes only the nodes of the step, the org publishes them all
+	c, err = n.newChannel([]*channel.OrgInfo{org})
+	if err != nil {
+		return err
+	}
+	return c.PublishChainOrgInfo(step.Channel)
+}
+
+func (n *Network) installChaincode(step *Step) error {
+	cc := n.chaincode(step.Chaincode)
+	org, err := n.withCA(n.orgs[step.Org])
+	if err != nil {
+		return err
+	}
+	c, err := chaincode.NewChaincode(org.OrgMSP, cc.TarPath, cc.Path, cc.Name, cc.Version, org.OrgCA, n.gm)
+	if err != nil {
+		return err
+	}
+	return c.WithContext(n.ctx).InstallChaincode(peerEndpoints(withNodes(org, step.Nodes), n.timeout(calls.OpInstall)))
+}
+
+func (n *Network) instantiateChaincode(step *Step) error {
+	cc := n.chaincode(step.Chaincode)
+	org, err := n.withClient(n.orgs[step.Org])
+	if err != nil {
+		return err
+	}
+	c, err := chaincode.NewChaincode(org.OrgMSP, cc.TarPath, cc.Path, cc.Name, cc.Version, org.OrgCA, n.gm)
+	if err != nil {
+		return err
+	}
+	endorsers := peerEndpoints(org, n.timeout(calls.OpInstantiate))
+	casters, err := channel.ChainOrderers(org.Client, endorsers, step.Channel, n.timeout(calls.OpBroadcast))
+	if err != nil {
+		return err
+	}
+	policy := cc.Policy
+	if policy == "" {
+		policy = n.membersPolicy(step.Channel)
+	}
+	var args [][]byte
+	for _, arg := range cc.Args {
+		args = append(args, []byte(arg))
+	}
+	return c.WithContext(n.ctx).InstantiateChaincode(endorsers, casters, step.Channel, policy, args)
+}
+
+func (n *Network) chaincode(name string) *ChaincodeSpec {
+	for _, cc := range n.spec.Chaincodes {
+		if cc.Name == name {
+			return cc
+		}
+	}
+	return nil
+}
+
+// membersPolicy is satisfied by a member of any member org of channelName
+func (n *Network) membersPolicy(channelName string) string {
+	var principals []string
+	for _, name := range n.channelMembers(channelName) {
+		principals = append(principals, fmt.Sprintf("'%s.member'", n.orgs[name].OrgMSP))
+	}
+	return fmt.Sprintf("OR(%s)", strings.Join(principals, ","))
+}
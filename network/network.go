@@ -0,0 +1,269 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"manageChain/calls"
+	"manageChain/logging"
+	"os"
+	"path"
+	"time"
+
+	"manageChain/channel"
+
+	"github.com/hyperledger/fabric/sdk"
+	"gopkg.in/yaml.v2"
+)
+
+var logger = logging.GetLogger("network")
+
+// Network reconciles a running network with its spec
+type Network struct {
+	spec         *Spec
+	orgs         map[string]*channel.OrgInfo
+	mspDir       string
+	genesisBlock string
+	gm           bool
+	state        State
+	ctx          context.Context
+}
+
+// ParseSpec parses a spec in YAML or JSON and checks it is consistent
+func ParseSpec(data []byte) (*Spec, error) {
+	spec := &Spec{}
+	if err := yaml.Unmarshal(data, spec); err != nil {
+		logger.Error("Error unmarshaling spec: %s", err)
+		return nil, err
+	}
+	if err := validate(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func validate(s *Spec) error {
+	if len(s.Orgs) == 0 {
+		return errors.New("spec has no orgs")
+	}
+	orgs := make(map[string]bool)
+	for _, org := range s.Orgs {
+		if org.Name == "" || org.MSP == "" {
+			return errors.New("name and msp of every org should not be empty")
+		}
+		if orgs[org.Name] {
+			return fmt.Errorf("org %s is declared twice", org.Name)
+		}
+		orgs[org.Name] = true
+	}
+	if s.Consensus != nil && s.Consensus.Type != "" && s.Consensus.Type != consensusType {
+		return fmt.Errorf("consensus %s is not supported, only %s is", s.Consensus.Type, consensusType)
+	}
+
+	channels := make(map[string]bool)
+	if s.PublicChain != nil {
+		channels[channel.PublicChainID] = true
+	}
+	for _, ch := range s.Channels {
+		if ch.Name == "" || ch.Name == channel.PublicChainID {
+			return fmt.Errorf("invalid channel name '%s'", ch.Name)
+		}
+		if channels[ch.Name] {
+			return fmt.Errorf("channel %s is declared twice", ch.Name)
+		}
+		channels[ch.Name] = true
+		if len(ch.Members) == 0 {
+			return fmt.Errorf("channel %s has no members", ch.Name)
+		}
+		for _, member := range ch.Members {
+			if !orgs[member] {
+				return fmt.Errorf("member %s of channel %s is not an org of the spec", member, ch.Name)
+			}
+		}
+	}
+	chaincodes := make(map[string]bool)
+	for _, cc := range s.Chaincodes {
+		if cc.Name == "" || cc.Version == "" || cc.TarPath == "" {
+			return errors.New("name, version and tarPath of every chaincode should not be empty")
+		}
+		if chaincodes[cc.Name] {
+			return fmt.Errorf("chaincode %s is declared twice", cc.Name)
+		}
+		chaincodes[cc.Name] = true
+		for _, ch := range cc.Channels {
+			if !channels[ch] {
+				return fmt.Errorf("channel %s of chaincode %s is not declared", ch, cc.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// NewNetwork reconciles the live network the orgs of spec run, with the
+// crypto material of the orgs under mspDir, making its calls with ctx.
+func NewNetwork(ctx context.Context, spec *Spec, mspDir string, gm bool) *Network {
+	n := newNetwork(spec, mspDir, gm, nil)
+	n.state = &liveState{network: n}
+	n.ctx = ctx
+	return n
+}
+
+func newNetwork(spec *Spec, mspDir string, gm bool, state State) *Network {
+	n := &Network{
+		spec:         spec,
+		orgs:         make(map[string]*channel.OrgInfo),
+		mspDir:       mspDir,
+		genesisBlock: genesisBlock,
+		gm:           gm,
+		state:        state,
+		ctx:          context.Background(),
+	}
+	for _, org := range spec.Orgs {
+		n.orgs[org.Name] = orgInfo(org)
+	}
+	return n
+}
+
+// hasCA reports whether the CA of org has been generated
+func (n *Network) hasCA(org *channel.OrgInfo) bool {
+	_, err := os.Stat(path.Join(n.mspDir, org.OrgName))
+	return err == nil
+}
+
+// withCA sets the CA of org, generating it when missing
+func (n *Network) withCA(org *channel.OrgInfo) (*channel.OrgInfo, error) {
+	if org.OrgCA != nil {
+		return org, nil
+	}
+	orgCA, err := channel.GetCA(path.Join(n.mspDir, org.OrgName), org.OrgName)
+	if err != nil {
+		logger.WithContext(n.ctx).Error("Error getting org ca: %s", err)
+		return nil, err
+	}
+	org.OrgCA = orgCA
+	return org, nil
+}
+
+// withClient sets the CA and the admin client of org, making its calls with
+// the context of n
+func (n *Network) withClient(org *channel.OrgInfo) (*channel.OrgInfo, error) {
+	if org.Client == nil {
+		if _, err := n.withCA(org); err != nil {
+			return nil, err
+		}
+		if _, err := channel.NewChannel([]*channel.OrgInfo{org}, n.gm); err != nil {
+			return nil, err
+		}
+	}
+	org.Client = org.Client.WithContext(n.ctx)
+	return org, nil
+}
+
+// newChannel returns the Channel of orgs making its calls with the context of n
+func (n *Network) newChannel(orgs []*channel.OrgInfo) (*channel.Channel, error) {
+	c, err := channel.NewChannel(orgs, n.gm)
+	if err != nil {
+		return nil, err
+	}
+	return c.WithContext(n.ctx), nil
+}
+
+// timeout returns the timeout of op for the calls of n
+func (n *Network) timeout(op string) time.Duration {
+	return calls.Timeout(n.ctx, op)
+}
+
+// members returns the named orgs, with their clients
+func (n *Network) members(names []string) ([]*channel.OrgInfo, error) {
+	var orgs []*channel.OrgInfo
+	for _, name := range names {
+		org, err := n.withClient(n.orgs[name])
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, nil
+}
+
+// allOrgs returns every org of the spec, in the order of the spec
+func (n *Network) allOrgs() []string {
+	var names []string
+	for _, org := range n.spec.Orgs {
+		names = append(names, org.Name)
+	}
+	return names
+}
+
+// channelMembers returns the names of the member orgs of a channel of the spec
+func (n *Network) channelMembers(channelName string) []string {
+	if channelName == channel.PublicChainID {
+		return n.allOrgs()
+	}
+	for _, ch := range n.spec.Channels {
+		if ch.Name == channelName {
+			return ch.Members
+		}
+	}
+	return nil
+}
+
+// withNodes copies org with only the named peers and orderers
+func withNodes(org *channel.OrgInfo, ids []string) *channel.OrgInfo {
+	selected := make(map[string]bool)
+	for _, id := range ids {
+		selected[id] = true
+	}
+	ret := *org
+	ret.PeerNodes, ret.OrdererNodes = nil, nil
+	for _, sn := range org.PeerNodes {
+		if selected[sn.ID] {
+			ret.PeerNodes = append(ret.PeerNodes, sn)
+		}
+	}
+	for _, sn := range org.OrdererNodes {
+		if selected[sn.ID] {
+			ret.OrdererNodes = append(ret.OrdererNodes, sn)
+		}
+	}
+	return &ret
+}
+
+// peerEndpoints addresses the peers of org the way the org itself reaches them
+func peerEndpoints(org *channel.OrgInfo, timeout time.Duration) []*sdk.Endpoint {
+	var endpoints []*sdk.Endpoint
+	for _, sn := range org.PeerNodes {
+		endpoints = append(endpoints, &sdk.Endpoint{
+			Address: sn.Endpoint,
+			TLS:     org.OrgCA.TLSCACert(),
+			Timeout: timeout,
+		})
+	}
+	return endpoints
+}
+
+// ordererEndpoints addresses the orderers of every org with a generated CA
+func (n *Network) ordererEndpoints() []*sdk.Endpoint {
+	var endpoints []*sdk.Endpoint
+	for _, name := range n.allOrgs() {
+		org := n.orgs[name]
+		if !n.hasCA(org) {
+			continue
+		}
+		if _, err := n.withCA(org); err != nil {
+			continue
+		}
+		for _, sn := range org.OrdererNodes {
+			address := sn.ExternalEndpoint
+			if address == "" {
+				address = sn.Endpoint
+			}
+			endpoints = append(endpoints, &sdk.Endpoint{
+				Address: address,
+				TLS:     org.OrgCA.TLSCACert(),
+				Timeout: n.timeout(calls.OpDeliver),
+			})
+		}
+	}
+	return endpoints
+}
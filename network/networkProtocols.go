@@ -0,0 +1,61 @@
+package network
+
+import (
+	"manageChain/channel"
+	"manageChain/protocols"
+)
+
+const (
+	consensusType = "kafka"
+	genesisBlock  = "orderer.block"
+)
+
+// the actions of the steps of a plan, in the order they are applied
+const (
+	GenerateCrypto       = "generateCrypto"
+	GenGenesisBlock      = "genGenesisBlock"
+	BootstrapPublicChain = "bootstrapPublicChain"
+	CreateChannel        = "createChannel"
+	AddOrg               = "addOrg"
+	DeleteOrg            = "deleteOrg"
+	JoinChannel          = "joinChannel"
+	InstallChaincode     = "installChaincode"
+	InstantiateChaincode = "instantiateChaincode"
+)
+
+// the spec and the plan of the network routes, see protocols
+type (
+	Spec            = protocols.Spec
+	OrgSpec         = protocols.OrgSpec
+	NodeSpec        = protocols.NodeSpec
+	ConsensusSpec   = protocols.ConsensusSpec
+	PublicChainSpec = protocols.PublicChainSpec
+	ChannelSpec     = protocols.ChannelSpec
+	ChaincodeSpec   = protocols.ChaincodeSpec
+	Step            = protocols.Step
+	Plan            = protocols.Plan
+)
+
+// orgInfo is the OrgInfo of an org of the spec
+func orgInfo(o *OrgSpec) *channel.OrgInfo {
+	org := &channel.OrgInfo{
+		OrgName: o.Name,
+		OrgMSP:  o.MSP,
+	}
+	for _, n := range o.Peers {
+		org.PeerNodes = append(org.PeerNodes, serviceNode(n))
+	}
+	for _, n := range o.Orderers {
+		org.OrdererNodes = append(org.OrdererNodes, serviceNode(n))
+	}
+	return org
+}
+
+func serviceNode(n *NodeSpec) *channel.ServiceNode {
+	return &channel.ServiceNode{
+		ID:               n.ID,
+		Endpoint:         n.Endpoint,
+		ExternalEndpoint: n.ExternalEndpoint,
+		Public:           n.Public,
+	}
+}
@@ -0,0 +1,351 @@
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"manageChain/channel"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// planner computes a plan, tracking the channels peers have joined and the
+// chaincodes they have installed, live or once the planned steps are applied.
+type planner struct {
+	network *Network
+	state   State
+	plan    *Plan
+	// channels created by the plan
+	created map[string]bool
+	// live channels of every peer, keyed by org and peer ID, and the
+	// channels the plan joins them to
+	joined  map[string]map[string]bool
+	planned map[string]map[string]bool
+	// live and planned chaincodes of every peer, as name:version
+	installed map[string]map[string]bool
+}
+
+// Plan computes the steps bringing the network to its spec. The nodes cannot
+// run before their crypto material and the genesis block are generated, so
+// the steps after generating them are planned for a network not running yet.
+func (n *Network) Plan() (*Plan, error) {
+	plan := &Plan{}
+	if err := n.planCrypto(plan); err != nil {
+		return nil, err
+	}
+	n.planGenesisBlock(plan)
+
+	p := &planner{
+		network:   n,
+		state:     n.state,
+		plan:      plan,
+		created:   make(map[string]bool),
+		joined:    make(map[string]map[string]bool),
+		planned:   make(map[string]map[string]bool),
+		installed: make(map[string]map[string]bool),
+	}
+	if len(plan.Steps) > 0 {
+		p.state = emptyState{}
+	}
+	if err := p.planPublicChain(); err != nil {
+		return nil, err
+	}
+	for _, ch := range n.spec.Channels {
+		if err := p.planChannel(ch); err != nil {
+			return nil, err
+		}
+	}
+	for _, cc := range n.spec.Chaincodes {
+		if err := p.planChaincode(cc); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// planCrypto generates the crypto material of the nodes missing it, the
+// material of the others is never regenerated.
+func (n *Network) planCrypto(plan *Plan) error {
+	for _, name := range n.allOrgs() {
+		org := n.orgs[name]
+		hasCA := n.hasCA(org)
+		if hasCA {
+			if _, err := n.withCA(org); err != nil {
+				return err
+			}
+		}
+		missing := func(sn *channel.ServiceNode, nodeType sdk.NodeType) bool {
+			if !hasCA {
+				return true
+			}
+			_, err := os.Stat(org.OrgCA.NodeMSPDir(sn.ID, nodeType))
+			return err != nil
+		}
+		var nodes []string
+		for _, sn := range org.OrdererNodes {
+			if missing(sn, sdk.OrdererNode) {
+				nodes = append(nodes, sn.ID)
+			}
+		}
+		for _, sn := range org.PeerNodes {
+			if missing(sn, sdk.PeerNode) {
+				nodes = append(nodes, sn.ID)
+			}
+		}
+		if len(nodes) > 0 || !hasCA {
+			plan.Steps = append(plan.Steps, &Step{Action: GenerateCrypto, Org: name, Nodes: nodes})
+		}
+	}
+	return nil
+}
+
+func (n *Network) planGenesisBlock(plan *Plan) {
+	if keys, err := sdk.Store().List(n.genesisBlock); err != nil || len(keys) == 0 {
+		plan.Steps = append(plan.Steps, &Step{Action: GenGenesisBlock})
+	}
+}
+
+func (p *planner) planPublicChain() error {
+	if p.network.spec.PublicChain == nil {
+		return nil
+	}
+	names := p.network.allOrgs()
+	orgs, exists, err := p.state.ChannelOrgs(p.network.orgs[names[0]], channel.PublicChainID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		p.plan.Steps = append(p.plan.Steps, &Step{Action: BootstrapPublicChain, Channel: channel.PublicChainID})
+		p.created[channel.PublicChainID] = true
+		for _, name := range names {
+			org := p.network.orgs[name]
+			for _, sn := range org.PeerNodes {
+				p.markJoined(p.planned, org, sn, channel.PublicChainID)
+			}
+		}
+		return nil
+	}
+
+	in := toSet(orgs)
+	var members []string
+	for _, name := range names {
+		if in[p.network.orgs[name].OrgMSP] {
+			members = append(members, name)
+			continue
+		}
+		p.warn("org %s is not a member of %s, it joins through an invitation", name, channel.PublicChainID)
+	}
+	return p.planJoins(channel.PublicChainID, members, nil)
+}
+
+func (p *planner) planChannel(ch *ChannelSpec) error {
+	creator := p.network.orgs[ch.Members[0]]
+	orgs, exists, err := p.state.ChannelOrgs(creator, ch.Name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		p.plan.Steps = append(p.plan.Steps, &Step{Action: CreateChannel, Org: creator.OrgName, Operators: ch.Members, Channel: ch.Name})
+		p.created[ch.Name] = true
+		return p.planJoins(ch.Name, ch.Members, nil)
+	}
+
+	in := toSet(orgs)
+	var operators []string
+	var added []string
+	for _, name := range ch.Members {
+		if in[p.network.orgs[name].OrgMSP] {
+			operators = append(operators, name)
+		}
+	}
+	if len(operators) == 0 {
+		return fmt.Errorf("no member of channel %s in the spec is in the channel", ch.Name)
+	}
+	for _, name := range ch.Members {
+		if in[p.network.orgs[name].OrgMSP] {
+			continue
+		}
+		p.plan.Steps = append(p.plan.Steps, &Step{Action: AddOrg, Org: name, Operators: copyStrings(operators), Channel: ch.Name})
+		operators = append(operators, name)
+		added = append(added, name)
+	}
+
+	members := make(map[string]bool)
+	for _, name := range ch.Members {
+		members[p.network.orgs[name].OrgMSP] = true
+	}
+	for _, msp := range orgs {
+		if members[msp] {
+			continue
+		}
+		if !p.network.spec.Prune {
+			p.warn("org %s of channel %s is not a member in the spec, set prune to remove it", msp, ch.Name)
+			continue
+		}
+		p.plan.Steps = append(p.plan.Steps, &Step{Action: DeleteOrg, Org: msp, Operators: copyStrings(operators), Channel: ch.Name})
+	}
+	return p.planJoins(ch.Name, ch.Members, toSet(added))
+}
+
+// planJoins joins the peers of the members not in channelName yet, the peers
+// of the added orgs and of a created channel have not joined it.
+func (p *planner) planJoins(channelName string, members []string, added map[string]bool) error {
+	for _, name := range members {
+		org := p.network.orgs[name]
+		var nodes []string
+		for _, sn := range org.PeerNodes {
+			if !p.created[channelName] && !added[name] {
+				joined, err := p.joinedBy(org, sn)
+				if err != nil {
+					return err
+				}
+				if joined[channelName] {
+					continue
+				}
+			}
+			nodes = append(nodes, sn.ID)
+			p.markJoined(p.planned, org, sn, channelName)
+		}
+		if len(nodes) > 0 {
+			p.plan.Steps = append(p.plan.Steps, &Step{Action: JoinChannel, Org: name, Nodes: nodes, Channel: channelName})
+		}
+	}
+	return nil
+}
+
+// planChaincode installs cc on the peers of the members of its channels and
+// instantiates it on every channel, instantiated versions are not upgraded.
+func (p *planner) planChaincode(cc *ChaincodeSpec) error {
+	ccID := cc.Name + ":" + cc.Version
+	install := make(map[string][]string)
+	var orgs []string
+	for _, channelName := range cc.Channels {
+		for _, name := range p.network.channelMembers(channelName) {
+			org := p.network.orgs[name]
+			for _, sn := range org.PeerNodes {
+				key := nodeKey(org, sn)
+				if !p.joined[key][channelName] && !p.planned[key][channelName] {
+					continue
+				}
+				installed, err := p.installedOn(org, sn)
+				if err != nil {
+					return err
+				}
+				if installed[ccID] {
+					continue
+				}
+				installed[ccID] = true
+				if _, ok := install[name]; !ok {
+					orgs = append(orgs, name)
+				}
+				install[name] = append(install[name], sn.ID)
+			}
+		}
+	}
+	for _, name := range orgs {
+		p.plan.Steps = append(p.plan.Steps, &Step{Action: InstallChaincode, Org: name, Nodes: install[name], Chaincode: cc.Name})
+	}
+
+	for _, channelName := range cc.Channels {
+		instantiated, err := p.instantiatedOn(channelName)
+		if err != nil {
+			return err
+		}
+		version, ok := instantiated[cc.Name]
+		if ok && version != cc.Version {
+			p.warn("chaincode %s is instantiated on %s at version %s, upgrading to %s is not supported", cc.Name, channelName, version, cc.Version)
+		}
+		if ok {
+			continue
+		}
+		members := p.network.channelMembers(channelName)
+		p.plan.Steps = append(p.plan.Steps, &Step{Action: InstantiateChaincode, Org: members[0], Channel: channelName, Chaincode: cc.Name})
+	}
+	return nil
+}
+
+// installedOn returns the chaincodes installed on a peer, querying it once
+func (p *planner) installedOn(org *channel.OrgInfo, sn *channel.ServiceNode) (map[string]bool, error) {
+	key := nodeKey(org, sn)
+	if installed, ok := p.installed[key]; ok {
+		return installed, nil
+	}
+	chaincodes, err := p.state.InstalledChaincodes(org, sn)
+	if err != nil {
+		return nil, err
+	}
+	installed := make(map[string]bool)
+	for _, cc := range chaincodes {
+		installed[cc.Name+":"+cc.Version] = true
+	}
+	p.installed[key] = installed
+	return installed, nil
+}
+
+// joinedBy returns the channels a peer has joined, querying it once
+func (p *planner) joinedBy(org *channel.OrgInfo, sn *channel.ServiceNode) (map[string]bool, error) {
+	key := nodeKey(org, sn)
+	if joined, ok := p.joined[key]; ok {
+		return joined, nil
+	}
+	channels, err := p.state.JoinedChannels(org, sn)
+	if err != nil {
+		return nil, err
+	}
+	p.joined[key] = toSet(channels)
+	return p.joined[key], nil
+}
+
+// instantiatedOn returns the versions of the chaincodes instantiated on
+// channelName, through a peer of a member that has joined it.
+func (p *planner) instantiatedOn(channelName string) (map[string]string, error) {
+	versions := make(map[string]string)
+	if p.created[channelName] {
+		return versions, nil
+	}
+	for _, name := range p.network.channelMembers(channelName) {
+		org := p.network.orgs[name]
+		for _, sn := range org.PeerNodes {
+			if !p.joined[nodeKey(org, sn)][channelName] {
+				continue
+			}
+			chaincodes, err := p.state.InstantiatedChaincodes(org, sn, channelName)
+			if err != nil {
+				return nil, err
+			}
+			for _, cc := range chaincodes {
+				versions[cc.Name] = cc.Version
+			}
+			return versions, nil
+		}
+	}
+	return versions, nil
+}
+
+func (p *planner) markJoined(joined map[string]map[string]bool, org *channel.OrgInfo, sn *channel.ServiceNode, channelName string) {
+	key := nodeKey(org, sn)
+	if joined[key] == nil {
+		joined[key] = make(map[string]bool)
+	}
+	joined[key][channelName] = true
+}
+
+func (p *planner) warn(format string, args ...interface{}) {
+	p.plan.Warnings = append(p.plan.Warnings, fmt.Sprintf(format, args...))
+}
+
+func nodeKey(org *channel.OrgInfo, sn *channel.ServiceNode) string {
+	return sn.ID + "." + org.OrgName
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func copyStrings(values []string) []string {
+	return append([]string(nil), values...)
+}
@@ -0,0 +1,171 @@
+package network
+
+import (
+	"reflect"
+	"testing"
+
+	"manageChain/channel"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+const testSpec = `
+orgs:
+- name: testorg1
+  msp: testorg1
+  peers:
+  - {id: peer0, endpoint: "172.16.93.215:56051", public: true}
+  - {id: peer1, endpoint: "172.16.93.215:56151"}
+  orderers:
+  - {id: orderer0, endpoint: "172.16.93.215:56050"}
+- name: testorg2
+  msp: testorg2
+  peers:
+  - {id: peer0, endpoint: "172.16.93.215:57051", public: true}
+  - {id: peer1, endpoint: "172.16.93.215:57151"}
+- name: testorg3
+  msp: testorg3
+  peers:
+  - {id: peer0, endpoint: "172.16.93.215:58051", public: true}
+  - {id: peer1, endpoint: "172.16.93.215:58151"}
+consensus:
+  type: kafka
+  kafkas: ["172.16.93.215:9092"]
+channels:
+- name: mychannel
+  members: [testorg1, testorg2, testorg3]
+chaincodes:
+- name: mycc
+  path: mycc
+  tarPath: ../chaincodefile/mycc.tar.gz
+  version: "1.0"
+  channels: [mychannel]
+prune: true
+`
+
+// fakeState is a network whose channels, joined peers and chaincodes are given
+type fakeState struct {
+	channels     map[string][]string
+	joined       map[string][]string
+	installed    map[string][]sdk.Chaincode
+	instantiated map[string][]sdk.Chaincode
+}
+
+func (s *fakeState) ChannelOrgs(org *channel.OrgInfo, channelName string) ([]string, bool, error) {
+	orgs, ok := s.channels[channelName]
+	return orgs, ok, nil
+}
+
+func (s *fakeState) JoinedChannels(org *channel.OrgInfo, peer *channel.ServiceNode) ([]string, error) {
+	return s.joined[peer.ID+"."+org.OrgName], nil
+}
+
+func (s *fakeState) InstalledChaincodes(org *channel.OrgInfo, peer *channel.ServiceNode) ([]sdk.Chaincode, error) {
+	return s.installed[peer.ID+"."+org.OrgName], nil
+}
+
+func (s *fakeState) InstantiatedChaincodes(org *channel.OrgInfo, peer *channel.ServiceNode, channelName string) ([]sdk.Chaincode, error) {
+	return s.instantiated[channelName], nil
+}
+
+func testNetwork(t *testing.T, mspDir string, state State) *Network {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := newNetwork(spec, mspDir, true, state)
+	n.genesisBlock = "../orderer.block"
+	return n
+}
+
+func TestParseSpec(t *testing.T) {
+	if _, err := ParseSpec([]byte(testSpec)); err != nil {
+		t.Fatal(err)
+	}
+	for _, spec := range []string{
+		`{"orgs": [{"name": "testorg1", "msp": "testorg1"}], "channels": [{"name": "mychannel", "members": ["testorg2"]}]}`,
+		`{"orgs": [{"name": "testorg1", "msp": "testorg1"}], "consensus": {"type": "solo"}}`,
+		`{"orgs": [{"name": "testorg1", "msp": "testorg1"}], "chaincodes": [{"name": "mycc", "version": "1.0", "tarPath": "mycc.tar.gz", "channels": ["mychannel"]}]}`,
+	} {
+		if _, err := ParseSpec([]byte(spec)); err == nil {
+			t.Fatalf("invalid spec is accepted: %s", spec)
+		}
+	}
+}
+
+func TestPlanNewNetwork(t *testing.T) {
+	n := testNetwork(t, "nonexistent", &fakeState{})
+	plan, err := n.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Step{
+		{Action: GenerateCrypto, Org: "testorg1", Nodes: []string{"orderer0", "peer0", "peer1"}},
+		{Action: GenerateCrypto, Org: "testorg2", Nodes: []string{"peer0", "peer1"}},
+		{Action: GenerateCrypto, Org: "testorg3", Nodes: []string{"peer0", "peer1"}},
+		{Action: CreateChannel, Org: "testorg1", Operators: []string{"testorg1", "testorg2", "testorg3"}, Channel: "mychannel"},
+		{Action: JoinChannel, Org: "testorg1", Nodes: []string{"peer0", "peer1"}, Channel: "mychannel"},
+		{Action: JoinChannel, Org: "testorg2", Nodes: []string{"peer0", "peer1"}, Channel: "mychannel"},
+		{Action: JoinChannel, Org: "testorg3", Nodes: []string{"peer0", "peer1"}, Channel: "mychannel"},
+		{Action: InstallChaincode, Org: "testorg1", Nodes: []string{"peer0", "peer1"}, Chaincode: "mycc"},
+		{Action: InstallChaincode, Org: "testorg2", Nodes: []string{"peer0", "peer1"}, Chaincode: "mycc"},
+		{Action: InstallChaincode, Org: "testorg3", Nodes: []string{"peer0", "peer1"}, Chaincode: "mycc"},
+		{Action: InstantiateChaincode, Org: "testorg1", Channel: "mychannel", Chaincode: "mycc"},
+	}
+	if !reflect.DeepEqual(plan.Steps, want) {
+		t.Fatalf("unexpected plan: %+v", plan.Steps)
+	}
+}
+
+func TestPlanLiveNetwork(t *testing.T) {
+	cc := sdk.Chaincode{Name: "mycc", Version: "1.0"}
+	state := &fakeState{
+		channels: map[string][]string{"mychannel": {"testorg1", "testorg2", "oldorg"}},
+		joined: map[string][]string{
+			"peer0.testorg1": {"mychannel"},
+			"peer1.testorg1": {"mychannel"},
+			"peer0.testorg2": {"mychannel"},
+		},
+		installed: map[string][]sdk.Chaincode{
+			"peer0.testorg1": {cc},
+			"peer1.testorg1": {cc},
+		},
+		instantiated: map[string][]sdk.Chaincode{"mychannel": {cc}},
+	}
+	n := testNetwork(t, "../msp", state)
+	plan, err := n.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Step{
+		{Action: AddOrg, Org: "testorg3", Operators: []string{"testorg1", "testorg2"}, Channel: "mychannel"},
+		{Action: DeleteOrg, Org: "oldorg", Operators: []string{"testorg1", "testorg2", "testorg3"}, Channel: "mychannel"},
+		{Action: JoinChannel, Org: "testorg2", Nodes: []string{"peer1"}, Channel: "mychannel"},
+		{Action: JoinChannel, Org: "testorg3", Nodes: []string{"peer0", "peer1"}, Channel: "mychannel"},
+		{Action: InstallChaincode, Org: "testorg2", Nodes: []string{"peer0", "peer1"}, Chaincode: "mycc"},
+		{Action: InstallChaincode, Org: "testorg3", Nodes: []string{"peer0", "peer1"}, Chaincode: "mycc"},
+	}
+	if !reflect.DeepEqual(plan.Steps, want) {
+		t.Fatalf("unexpected plan: %+v", plan.Steps)
+	}
+
+	// the network matches the spec once the steps are applied
+	state.channels["mychannel"] = []string{"testorg1", "testorg2", "testorg3"}
+	for _, node := range []string{"peer1.testorg2", "peer0.testorg3", "peer1.testorg3"} {
+		state.joined[node] = []string{"mychannel"}
+	}
+	for _, node := range []string{"peer0.testorg2", "peer1.testorg2", "peer0.testorg3", "peer1.testorg3"} {
+		state.installed[node] = []sdk.Chaincode{cc}
+	}
+	state.instantiated["mychannel"] = []sdk.Chaincode{{Name: "mycc", Version: "0.9"}}
+	plan, err = n.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Steps) != 0 {
+		t.Fatalf("unexpected steps: %+v", plan.Steps)
+	}
+	if len(plan.Warnings) != 1 {
+		t.Fatalf("the instantiated version should be reported: %v", plan.Warnings)
+	}
+}
@@ -0,0 +1,103 @@
+package network
+
+import (
+	"errors"
+	"manageChain/calls"
+	"strings"
+
+	"manageChain/channel"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// State is the live state of the network a plan is computed against
+type State interface {
+	// ChannelOrgs returns the MSP IDs of the application orgs of channelName
+	// as org sees them, or false when the channel does not exist.
+	ChannelOrgs(org *channel.OrgInfo, channelName string) ([]string, bool, error)
+	// JoinedChannels returns the channels peer of org has joined
+	JoinedChannels(org *channel.OrgInfo, peer *channel.ServiceNode) ([]string, error)
+	// InstalledChaincodes returns the chaincodes installed on peer of org
+	InstalledChaincodes(org *channel.OrgInfo, peer *channel.ServiceNode) ([]sdk.Chaincode, error)
+	// InstantiatedChaincodes returns the chaincodes instantiated on channelName, queried through peer of org
+	InstantiatedChaincodes(org *channel.OrgInfo, peer *channel.ServiceNode, channelName string) ([]sdk.Chaincode, error)
+}
+
+// emptyState is a network none of whose nodes run yet
+type emptyState struct{}
+
+func (emptyState) ChannelOrgs(*channel.OrgInfo, string) ([]string, bool, error) {
+	return nil, false, nil
+}
+
+func (emptyState) JoinedChannels(*channel.OrgInfo, *channel.ServiceNode) ([]string, error) {
+	return nil, nil
+}
+
+func (emptyState) InstalledChaincodes(*channel.OrgInfo, *channel.ServiceNode) ([]sdk.Chaincode, error) {
+	return nil, nil
+}
+
+func (emptyState) InstantiatedChaincodes(*channel.OrgInfo, *channel.ServiceNode, string) ([]sdk.Chaincode, error) {
+	return nil, nil
+}
+
+// liveState queries the nodes of the network with the admin clients of the orgs
+type liveState struct {
+	network *Network
+}
+
+func (s *liveState) ChannelOrgs(org *channel.OrgInfo, channelName string) ([]string, bool, error) {
+	org, err := s.network.withClient(org)
+	if err != nil {
+		return nil, false, err
+	}
+	casters := s.network.ordererEndpoints()
+	if len(casters) == 0 {
+		return nil, false, errors.New("no orderers can be found")
+	}
+	var block *cb.Block
+	for _, caster := range calls.Order(org.Client.Context(), casters) {
+		block, err = org.Client.GetConfigBlockByChannel(channelName, caster)
+		if err == nil {
+			break
+		}
+		if strings.Contains(err.Error(), cb.Status_NOT_FOUND.String()) {
+			return nil, false, nil
+		}
+		logger.Error("Error getting config block of %s from %s: %s", channelName, caster.Address, err)
+	}
+	if block == nil {
+		return nil, false, errors.New("failed getting config block after try all orderers")
+	}
+	orgs, err := sdk.ApplicationOrgs(block)
+	if err != nil {
+		return nil, false, err
+	}
+	return orgs, true, nil
+}
+
+func (s *liveState) JoinedChannels(org *channel.OrgInfo, peer *channel.ServiceNode) ([]string, error) {
+	org, err := s.network.withClient(org)
+	if err != nil {
+		return nil, err
+	}
+	return org.Client.QueryChannels(peerEndpoints(withNodes(org, []string{peer.ID}), s.network.timeout(calls.OpEndorse))[0])
+}
+
+func (s *liveState) InstalledChaincodes(org *channel.OrgInfo, peer *channel.ServiceNode) ([]sdk.Chaincode, error) {
+	org, err := s.network.withClient(org)
+	if err != nil {
+		return nil, err
+	}
+	return org.Client.QueryInstalledChaincodes(peerEndpoints(withNodes(org, []string{peer.ID}), s.network.timeout(calls.OpEndorse))[0])
+}
+
+func (s *liveState) InstantiatedChaincodes(org *channel.OrgInfo, peer *channel.ServiceNode, channelName string) ([]sdk.Chaincode, error) {
+	org, err := s.network.withClient(org)
+	if err != nil {
+		return nil, err
+	}
+	return org.Client.QueryInstantiatedChaincodes(channelName, peerEndpoints(withNodes(org, []string{peer.ID}), s.network.timeout(calls.OpEndorse))[0])
+}
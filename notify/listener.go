@@ -0,0 +1,216 @@
+package notify
+
+import (
+	"encoding/json"
+	"manageChain/channel"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+const (
+	deliverTimeout = 5 * time.Second
+	retryInterval  = 10 * time.Second
+)
+
+// Listener receives the blocks of the public chain from the peers of an org,
+// and notifies the org of the governance events in them.
+type Listener struct {
+	conf      *OrgConfig
+	client    *sdk.Client
+	peers     []*sdk.Endpoint
+	notifiers []Notifier
+
+	// next is the number of the block to receive, 0 before any block is received
+	next  uint64
+	stopC chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewListener ...
+func NewListener(conf *OrgConfig) (*Listener, error) {
+	mspDir := beego.AppConfig.String("MSPDir")
+	gm, _ := beego.AppConfig.Bool("GM")
+
+	orgCA, err := channel.GetCA(path.Join(mspDir, conf.OrgName), conf.OrgName)
+	if err != nil {
+		logger.Error("Error getting org ca: %s", err)
+		return nil, err
+	}
+	client, err := sdk.NewClient(orgCA.AdminCommonName(), conf.OrgMSP, orgCA.AdminMSPDir(), gm)
+	if err != nil {
+		logger.Error("Error creating client for org: %s", err)
+		return nil, err
+	}
+
+	var peers []*sdk.Endpoint
+	for _, sn := range conf.PeerNodes {
+		peers = append(peers, &sdk.Endpoint{
+			Address: sn.Endpoint,
+			TLS:     orgCA.TLSCACert(),
+			Timeout: deliverTimeout,
+		})
+	}
+
+	var notifiers []Notifier
+	if len(conf.Adapters) != 0 {
+		an, err := newAdapterNotifier(conf.Adapters)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, an)
+	}
+	for _, url := range conf.Webhooks {
+		notifiers = append(notifiers, newWebhookNotifier(url))
+	}
+
+	return &Listener{
+		conf:      conf,
+		client:    client,
+		peers:     peers,
+		notifiers: notifiers,
+		stopC:     make(chan struct{}),
+	}, nil
+}
+
+// Start ...
+func (l *Listener) Start() {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		for {
+			for _, peer := range l.peers {
+				l.listen(peer)
+				if l.stopped() {
+					return
+				}
+			}
+			select {
+			case <-l.stopC:
+				return
+			case <-time.After(retryInterval):
+			}
+		}
+	}()
+}
+
+// Stop ...
+func (l *Listener) Stop() {
+	close(l.stopC)
+	l.wg.Wait()
+	for _, n := range l.notifiers {
+		n.Close()
+	}
+}
+
+func (l *Listener) stopped() bool {
+	select {
+	case <-l.stopC:
+		return true
+	default:
+		return false
+	}
+}
+
+// listen receives blocks from peer until it fails or the listener is stopped
+func (l *Listener) listen(peer *sdk.Endpoint) {
+	var iter *sdk.BlockIterator
+	var err error
+	if l.next == 0 {
+		iter, err = l.client.GetNewCommittedBlocksByChannel(channel.PublicChainID, peer)
+	} else {
+		iter, err = l.client.GetCommittedBlocksByChannel(channel.PublicChainID, l.next, peer)
+	}
+	if err != nil {
+		logger.Error("Error requesting blocks from %s: %s", peer.Address, err)
+		return
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-l.stopC:
+			iter.Close()
+		case <-done:
+			iter.Close()
+		}
+	}()
+
+	for {
+		block, err := iter.NextBlock()
+		if err != nil {
+			if err != sdk.ErrClosed {
+				logger.Error("Error receiving blocks from %s: %s", peer.Address, err)
+			}
+			return
+		}
+		for _, event := range governanceEvents(block) {
+			l.notify(event)
+		}
+		l.next = block.Header.Number + 1
+	}
+}
+
+func (l *Listener) notify(event *Event) {
+	if !l.conf.wants(event) {
+		return
+	}
+	for _, n := range l.notifiers {
+		if err := n.Notify(event); err != nil {
+			logger.Error("Error notifying %s of %s: %s", l.conf.OrgName, event.Type, err)
+		}
+	}
+}
+
+// governanceEvents extracts the events the public chaincode set in the valid transactions of block
+func governanceEvents(block *cb.Block) []*Event {
+	var events []*Event
+	var flags util.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	for i, data := range block.Data.Data {
+		if i < len(flags) && !flags.IsValid(i) {
+			continue
+		}
+		env, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil || payload.Header == nil {
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil || cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		action, err := utils.GetActionFromEnvelope(data)
+		if err != nil || len(action.Events) == 0 {
+			continue
+		}
+		ccEvent, err := utils.GetChaincodeEvents(action.Events)
+		if err != nil || ccEvent.ChaincodeId != channel.PublicCCName {
+			continue
+		}
+
+		event := &Event{}
+		if err := json.Unmarshal(ccEvent.Payload, event); err != nil {
+			logger.Warning("invalid payload of event %s: %s", ccEvent.EventName, err)
+			continue
+		}
+		event.Type = ccEvent.EventName
+		event.TxID = chdr.TxId
+		event.BlockNumber = block.Header.Number
+		events = append(events, event)
+	}
+	return events
+}
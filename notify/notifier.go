@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	logs "gglogs"
+	"manageChain/alerting"
+	"net/http"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// adapterNotifier writes events through the gglogs adapters, e.g. smtp or slack
+type adapterNotifier struct {
+	bl *logs.BeeLogger
+}
+
+func newAdapterNotifier(adapters map[string]string) (*adapterNotifier, error) {
+	bl, err := alerting.NewLogger(adapters)
+	if err != nil {
+		return nil, err
+	}
+	return &adapterNotifier{bl: bl}, nil
+}
+
+func (an *adapterNotifier) Notify(event *Event) error {
+	an.bl.Notice("%s", event)
+	return nil
+}
+
+func (an *adapterNotifier) Close() {
+	an.bl.Close()
+}
+
+// webhookNotifier posts events as json to an url
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookNotifier(url string) *webhookNotifier {
+	return &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (wn *webhookNotifier) Notify(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := wn.client.Post(wn.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s responded %s", wn.url, resp.Status)
+	}
+	return nil
+}
+
+func (wn *webhookNotifier) Close() {}
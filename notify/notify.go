@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"fmt"
+	"manageChain/alerting"
+	"manageChain/logging"
+	"sync"
+
+	"github.com/astaxie/beego"
+)
+
+var logger = logging.GetLogger("notify")
+
+// governance events emitted by the public chaincode
+const (
+	InvitationCreated = "InvitationCreated"
+	VoteCast          = "VoteCast"
+	ProposalDecided   = "ProposalDecided"
+	OrgInfoChanged    = "OrgInfoChanged"
+	RemovalProposed   = "RemovalProposed"
+)
+
+// Event is a governance event of the public chain
+type Event struct {
+	Type        string `json:"type"`
+	ChainId     string `json:"chainId"`
+	Orgname     string `json:"orgname,omitempty"`
+	Inviter     string `json:"inviter,omitempty"`
+	Invitee     string `json:"invitee,omitempty"`
+	Proposer    string `json:"proposer,omitempty"`
+	Target      string `json:"target,omitempty"`
+	Signer      string `json:"signer,omitempty"`
+	Accepted    string `json:"accepted,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Time        int64  `json:"time"`
+	TxID        string `json:"txId"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+func (e *Event) String() string {
+	switch e.Type {
+	case InvitationCreated:
+		return fmt.Sprintf("[%s] %s invited %s into chain %s", e.Type, e.Inviter, e.Invitee, e.ChainId)
+	case RemovalProposed:
+		return fmt.Sprintf("[%s] %s proposed removing %s from chain %s", e.Type, e.Proposer, e.Target, e.ChainId)
+	case VoteCast:
+		if e.Target != "" {
+			return fmt.Sprintf("[%s] %s voted %s on removing %s from chain %s", e.Type, e.Signer, e.Accepted, e.Target, e.ChainId)
+		}
+		return fmt.Sprintf("[%s] %s voted %s on inviting %s into chain %s by %s", e.Type, e.Signer, e.Accepted, e.Invitee, e.ChainId, e.Inviter)
+	case ProposalDecided:
+		if e.Target != "" {
+			return fmt.Sprintf("[%s] removing %s from chain %s by %s is %s", e.Type, e.Target, e.ChainId, e.Proposer, e.Status)
+		}
+		return fmt.Sprintf("[%s] inviting %s into chain %s by %s is %s", e.Type, e.Invitee, e.ChainId, e.Inviter, e.Status)
+	case OrgInfoChanged:
+		return fmt.Sprintf("[%s] info of %s in chain %s changed", e.Type, e.Orgname, e.ChainId)
+	}
+	return fmt.Sprintf("[%s] chain %s, tx %s", e.Type, e.ChainId, e.TxID)
+}
+
+// Notifier delivers events to the members of an org
+type Notifier interface {
+	Notify(event *Event) error
+	Close()
+}
+
+// ServiceNode is a peer the listener receives blocks from
+type ServiceNode = alerting.ServiceNode
+
+// OrgConfig configures the notifications of one org, sent through Adapters
+// and posted to Webhooks. Events selects the event types to notify, all of
+// them when empty.
+type OrgConfig struct {
+	alerting.OrgConfig
+	Events   []string
+	Webhooks []string
+}
+
+func (oc *OrgConfig) wants(event *Event) bool {
+	if len(oc.Events) == 0 {
+		return true
+	}
+	for _, t := range oc.Events {
+		if t == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	listenersLock sync.Mutex
+	listeners     []*Listener
+)
+
+// Start listens to the public chain for every org configured in the file given by
+// NotifyConfig of app.conf, it does nothing when NotifyConfig is not set.
+func Start() error {
+	file := beego.AppConfig.String("NotifyConfig")
+	if file == "" {
+		return nil
+	}
+	var configs []*OrgConfig
+	if err := alerting.LoadConfig(file, &configs); err != nil {
+		logger.Error("Error loading notify config %s: %s", file, err)
+		return err
+	}
+
+	listenersLock.Lock()
+	defer listenersLock.Unlock()
+	for _, conf := range configs {
+		l, err := NewListener(conf)
+		if err != nil {
+			logger.Error("Error creating listener of %s: %s", conf.OrgName, err)
+			return err
+		}
+		l.Start()
+		listeners = append(listeners, l)
+	}
+	return nil
+}
+
+// Stop stops all listeners started by Start
+func Stop() {
+	listenersLock.Lock()
+	defer listenersLock.Unlock()
+	for _, l := range listeners {
+		l.Stop()
+	}
+	listeners = nil
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan *Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		event := &Event{}
+		if err := json.Unmarshal(data, event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- event
+	}))
+	defer srv.Close()
+
+	event := &Event{Type: InvitationCreated, ChainId: "channel1", Inviter: "testorg1", Invitee: "testorg3"}
+	if err := newWebhookNotifier(srv.URL).Notify(event); err != nil {
+		t.Fatal(err)
+	}
+	got := <-received
+	if got.Type != event.Type || got.Invitee != event.Invitee {
+		t.Fatalf("unexpected event: %+v", got)
+	}
+	t.Log(got)
+}
+
+func TestOrgConfigWants(t *testing.T) {
+	conf := &OrgConfig{Events: []string{VoteCast}}
+	if conf.wants(&Event{Type: InvitationCreated}) {
+		t.Fatal("InvitationCreated should be filtered")
+	}
+	if !conf.wants(&Event{Type: VoteCast}) {
+		t.Fatal("VoteCast should be notified")
+	}
+	if !(&OrgConfig{}).wants(&Event{Type: OrgInfoChanged}) {
+		t.Fatal("all events should be notified without filter")
+	}
+}
@@ -0,0 +1,509 @@
+package openapi
+
+import (
+	"manageChain/protocols"
+	"sort"
+)
+
+// helpers building the schemas
+
+func str(description string) *Schema {
+	return &Schema{Type: "string", Description: description}
+}
+
+func nonEmpty(description string) *Schema {
+	return &Schema{Type: "string", Description: description, MinLength: 1}
+}
+
+func pattern(description string, p string) *Schema {
+	return &Schema{Type: "string", Description: description, Pattern: p}
+}
+
+func enum(description string, values ...string) *Schema {
+	return &Schema{Type: "string", Description: description, Enum: values}
+}
+
+func base64Bytes(description string) *Schema {
+	return &Schema{Type: "string", Format: "byte", Description: description}
+}
+
+func boolean(description string) *Schema {
+	return &Schema{Type: "boolean", Description: description}
+}
+
+func integer(description string, format string, minimum int64) *Schema {
+	return &Schema{Type: "integer", Format: format, Description: description, Minimum: &minimum}
+}
+
+// list is an optional array, null when a Go client leaves it nil
+func list(items *Schema, description string) *Schema {
+	return &Schema{Type: "array", Description: description, Items: items, Nullable: true}
+}
+
+// nonEmptyList is a required array with at least one item
+func nonEmptyList(items *Schema, description string) *Schema {
+	return &Schema{Type: "array", Description: description, Items: items, MinItems: 1}
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: refPrefix + name}
+}
+
+var closed = false
+
+// object is an object without other properties than props
+func object(description string, props map[string]*Schema, required ...string) *Schema {
+	sort.Strings(required)
+	return &Schema{
+		Type:                 "object",
+		Description:          description,
+		Properties:           props,
+		Required:             required,
+		AdditionalProperties: &closed,
+	}
+}
+
+func nullable(s *Schema) *Schema {
+	s.Nullable = true
+	return s
+}
+
+// reserved is a field clients marshal as null, the server sets it
+func reserved(description string) *Schema {
+	return nullable(object(description+", set by the server, null in requests", nil))
+}
+
+const (
+	// channelNamePattern is the names fabric allows for channels
+	channelNamePattern = `^[a-z][a-z0-9.-]*$`
+	addressPattern     = `^[^\s/:]+:[0-9]{1,5}$`
+	// optionalAddressPattern is addressPattern or empty
+	optionalAddressPattern = `^([^\s/:]+:[0-9]{1,5})?$`
+)
+
+func channelName() *Schema {
+	s := pattern("name of the channel", channelNamePattern)
+	s.MaxLength = 249
+	return s
+}
+
+var schemas = map[string]*Schema{
+	"ErrorMessage": object("an error, code tells its kind and fields the invalid fields of a request", map[string]*Schema{
+		"code": enum("kind of the error, returned with the HTTP status: invalid_request 400, permission_denied and policy_not_satisfied 403, not_found 404, tx_invalid and conflict 409, internal 500, endorsement_failed and orderer_rejected 502, unavailable 503, timeout 504",
+			protocols.CodeInvalidRequest, protocols.CodeNotFound, protocols.CodePermissionDenied, protocols.CodePolicyNotSatisfied,
+			protocols.CodeEndorsementFailed, protocols.CodeOrdererRejected, protocols.CodeTimeout, protocols.CodeTxInvalid,
+			protocols.CodeConflict, protocols.CodeUnavailable, protocols.CodeInternal),
+		"message":        str("description of the error"),
+		"fields":         list(ref("FieldError"), "invalid fields of the request"),
+		"ordererStatus":  str("status an orderer rejected the transaction with, e.g. BAD_REQUEST"),
+		"endorserStatus": integer("status of the proposal response of a peer", "int32", 0),
+		"validationCode": str("why the transaction is invalid, e.g. MVCC_READ_CONFLICT"),
+		"txId":           str("the invalid transaction"),
+	}, "code", "message"),
+	"FieldError": object("an invalid field of a request", map[string]*Schema{
+		"field":   str("path of the field in the request, e.g. Orgs[0].OrgName"),
+		"code":    enum("why the field is invalid", CodeRequired, CodeUnknownField, CodeInvalidType, CodeInvalidValue),
+		"message": str("description of the error"),
+	}, "field", "code", "message"),
+
+	"ServiceNode": object("a peer or orderer of an org", map[string]*Schema{
+		"ID":               str("name of the node"),
+		"Endpoint":         pattern("host:port the node listens on", addressPattern),
+		"ExternalEndpoint": pattern("host:port the other orgs reach the node on, Endpoint when empty", optionalAddressPattern),
+		"Public":           boolean("whether the node is published to the public chain"),
+	}, "Endpoint"),
+	"OrgInfo": object("an org and its nodes, its crypto material being under MSPDir/OrgName", map[string]*Schema{
+		"OrgName":      nonEmpty("name of the org"),
+		"MspID":        str("MSP ID of the org"),
+		"OrgMSP":       str("MSP ID of the org"),
+		"OrgCA":        reserved("CA of the org"),
+		"Client":       reserved("sdk client of the org"),
+		"PeerNodes":    list(ref("ServiceNode"), "peers of the org"),
+		"OrdererNodes": list(ref("ServiceNode"), "orderers of the org"),
+	}, "OrgName"),
+	"Images": nullable(object("docker images of the nodes, the defaults when empty", map[string]*Schema{
+		"Peer":      str(""),
+		"Orderer":   str(""),
+		"CCEnv":     str(""),
+		"BaseOS":    str(""),
+		"Kafka":     str(""),
+		"ZooKeeper": str(""),
+		"CouchDB":   str(""),
+	})),
+	"K8sOptions": nullable(object("how the nodes are deployed to kubernetes", map[string]*Schema{
+		"Namespace":    str("namespace of the manifests"),
+		"ServiceType":  enum("type of the Services exposing ExternalEndpoint", "", "ClusterIP", "NodePort", "LoadBalancer"),
+		"StorageClass": str("storage class of the volumes"),
+		"StorageSize":  str("size of the volumes, 10Gi by default"),
+	})),
+	"InvitationFilter": nullable(object("selects invitations, zero values select everything", map[string]*Schema{
+		"status":    str(""),
+		"inviter":   str(""),
+		"invitee":   str(""),
+		"startTime": integer("", "int64", 0),
+		"endTime":   integer("", "int64", 0),
+	})),
+
+	"GenCryptoRequest": object("", map[string]*Schema{
+		"Orgs": nonEmptyList(ref("OrgInfo"), "orgs to generate the crypto material of"),
+	}, "Orgs"),
+	"GenGenesisBlockRequest": object("", map[string]*Schema{
+		"Orgs":   nonEmptyList(ref("OrgInfo"), "orgs of the system channel"),
+		"Kafkas": list(pattern("", addressPattern), "kafka brokers"),
+	}, "Orgs"),
+	"ComposeRequest": object("", map[string]*Schema{
+		"Orgs":         nonEmptyList(ref("OrgInfo"), "orgs to deploy"),
+		"PerOrg":       boolean("whether a compose file is generated per org"),
+		"OutputDir":    str("directory of the generated files, relative to DeployDir"),
+		"GenesisBlock": str("genesis block of the orderers"),
+		"Kafkas":       list(pattern("", addressPattern), "kafka brokers to run"),
+		"ZooKeepers":   list(pattern("", addressPattern), "zookeepers to run"),
+		"CouchDB":      boolean("whether the peers use CouchDB"),
+		"Images":       ref("Images"),
+	}, "Orgs"),
+	"NodeConfigRequest": object("", map[string]*Schema{
+		"Orgs":         nonEmptyList(ref("OrgInfo"), "orgs to configure the nodes of"),
+		"OutputDir":    str("directory of the generated files, relative to DeployDir"),
+		"GenesisBlock": str("genesis block of the orderers"),
+		"Kafkas":       list(pattern("", addressPattern), "kafka brokers"),
+		"CouchDB":      boolean("whether the peers use CouchDB"),
+		"Images":       ref("Images"),
+	}, "Orgs"),
+	"ManifestRequest": object("", map[string]*Schema{
+		"Orgs":         nonEmptyList(ref("OrgInfo"), "orgs to deploy"),
+		"OutputDir":    str("directory of the generated files, relative to DeployDir"),
+		"Tarball":      boolean("whether the manifests are returned as a tarball"),
+		"GenesisBlock": str("genesis block of the orderers"),
+		"CouchDB":      boolean("whether the peers use CouchDB"),
+		"Images":       ref("Images"),
+		"K8sOptions":   ref("K8sOptions"),
+	}, "Orgs"),
+
+	"NodeSpec": object("", map[string]*Schema{
+		"id":               str("name of the node"),
+		"endpoint":         pattern("host:port the node listens on", addressPattern),
+		"externalEndpoint": pattern("host:port the other orgs reach the node on", optionalAddressPattern),
+		"public":           boolean("whether the node is published to the public chain"),
+	}, "endpoint"),
+	"OrgSpec": object("", map[string]*Schema{
+		"name":     nonEmpty("name of the org"),
+		"msp":      nonEmpty("MSP ID of the org"),
+		"peers":    list(ref("NodeSpec"), ""),
+		"orderers": list(ref("NodeSpec"), ""),
+	}, "name", "msp"),
+	"Spec": object("the declared state of a network, in YAML or JSON", map[string]*Schema{
+		"orgs": nonEmptyList(ref("OrgSpec"), ""),
+		"consensus": nullable(object("", map[string]*Schema{
+			"type":   enum("only kafka is supported", "", "kafka"),
+			"kafkas": list(pattern("", addressPattern), "kafka brokers"),
+		})),
+		"publicChain": nullable(object("bootstraps the public chain with every org, empty fields fall back to the defaults of /channel/bootstrap", map[string]*Schema{
+			"ccTarPath": str(""),
+			"ccVersion": str(""),
+			"policy":    str(""),
+		})),
+		"channels": list(object("", map[string]*Schema{
+			"name":    channelName(),
+			"members": nonEmptyList(nonEmpty(""), "names of the orgs of the channel, the first one creates it"),
+		}, "name", "members"), ""),
+		"chaincodes": list(object("", map[string]*Schema{
+			"name":     nonEmpty(""),
+			"path":     str(""),
+			"tarPath":  nonEmpty(""),
+			"version":  nonEmpty(""),
+			"channels": list(channelName(), ""),
+			"policy":   str("any member of the channel by default"),
+			"args":     list(str(""), ""),
+		}, "name", "tarPath", "version"), ""),
+		"prune": boolean("removes the orgs of a channel that are not its members"),
+	}, "orgs"),
+
+	"IdentityRequest": object("", map[string]*Schema{
+		"Orgs": nonEmptyList(ref("OrgInfo"), "the org to generate the identity code of, first"),
+	}, "Orgs"),
+	"AddOrgRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "members of the channel signing the update, the first one sends it"),
+		"Identity":    base64Bytes("identity code of the new org, as returned by /channel/identity"),
+		"ChannelName": channelName(),
+	}, "Orgs", "Identity", "ChannelName"),
+	"DeleteOrgRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "members of the channel signing the update, the first one sends it"),
+		"DelOrg":      nonEmpty("MSP ID of the removed org"),
+		"DelOrderers": list(pattern("", addressPattern), "orderers of the removed org"),
+		"ChannelName": channelName(),
+	}, "Orgs", "DelOrg", "ChannelName"),
+	"NewCreateChannelRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "members of the channel, the first one creates it"),
+		"ChannelName": channelName(),
+	}, "Orgs", "ChannelName"),
+	"JoinChannelRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "orgs joining their peers"),
+		"ChannelName": channelName(),
+	}, "Orgs", "ChannelName"),
+	"BootstrapRequest": object("", map[string]*Schema{
+		"Orgs":      nonEmptyList(ref("OrgInfo"), "founding orgs of the network"),
+		"CcTarPath": str("package of the public chaincode, the bundled one by default"),
+		"CcVersion": str("version of the public chaincode"),
+		"Policy":    str("endorsement policy of the public chaincode, every founder by default"),
+	}, "Orgs"),
+	"UpdateChainOrgInfoRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "orgs publishing their nodes"),
+		"ChannelName": channelName(),
+	}, "Orgs", "ChannelName"),
+	"ProposeRemovalRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "the proposing org first"),
+		"ChannelName": channelName(),
+		"Target":      nonEmpty("MSP ID of the org to remove"),
+		"DelOrderers": list(pattern("", addressPattern), "orderers of the removed org"),
+		"Reason":      str(""),
+	}, "Orgs", "ChannelName", "Target"),
+	"VoteRemovalRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "the voting orgs"),
+		"ChannelName": channelName(),
+		"Target":      nonEmpty("MSP ID of the org to remove"),
+		"Accept":      boolean(""),
+	}, "Orgs", "ChannelName", "Target"),
+	"RemovalRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), ""),
+		"ChannelName": channelName(),
+		"Target":      nonEmpty("MSP ID of the org to remove"),
+	}, "Orgs", "ChannelName", "Target"),
+	"QueryPageRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "the querying org first"),
+		"ChannelName": channelName(),
+		"PageSize":    integer("20 by default", "int32", 0),
+		"Bookmark":    str("bookmark of the previous page"),
+		"Filter":      ref("InvitationFilter"),
+	}, "Orgs", "ChannelName"),
+
+	"InstallChaincodeRequest": object("", map[string]*Schema{
+		"Org":       nonEmpty("name of the org"),
+		"CcTarPath": nonEmpty("package of the chaincode"),
+		"CcPath":    nonEmpty("import path of the chaincode"),
+		"CcName":    nonEmpty(""),
+		"CcVersion": nonEmpty(""),
+		"PeerNodes": nonEmptyList(ref("ServiceNode"), "peers to install on"),
+	}, "Org", "CcTarPath", "CcPath", "CcName", "CcVersion", "PeerNodes"),
+	"InstantiateChaincodeRequest": object("", map[string]*Schema{
+		"Org":          nonEmpty("name of the org"),
+		"ChannelName":  channelName(),
+		"CcName":       nonEmpty(""),
+		"CcVersion":    nonEmpty(""),
+		"Policy":       str("endorsement policy"),
+		"Args":         list(base64Bytes(""), "arguments of Init"),
+		"PeerNodes":    nonEmptyList(ref("ServiceNode"), "endorsers"),
+		"OrdererNodes": list(ref("ServiceNode"), "orderers, those published to the public chain when empty"),
+	}, "Org", "ChannelName", "CcName", "CcVersion", "PeerNodes"),
+	"InvokeRequest": object("", map[string]*Schema{
+		"Org":           nonEmpty("name of the org"),
+		"ChannelName":   channelName(),
+		"CcName":        nonEmpty(""),
+		"Args":          list(base64Bytes(""), "function and arguments"),
+		"PeerNodes":     nonEmptyList(ref("ServiceNode"), "endorsers"),
+		"OrdererNodes":  list(ref("ServiceNode"), "orderers, those published to the public chain when empty"),
+		"EndorsingOrgs": list(str(""), "other orgs of the channel endorsing too, through the peers they published to the public chain"),
+	}, "Org", "ChannelName", "CcName", "PeerNodes"),
+
+	"InventoryRequest": object("", map[string]*Schema{
+		"Orgs": nonEmptyList(ref("OrgInfo"), "orgs whose peers are listed"),
+	}, "Orgs"),
+	"ChannelRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "the org whose peers are read first"),
+		"ChannelName": channelName(),
+	}, "Orgs", "ChannelName"),
+	"LedgerRequest": object("", map[string]*Schema{
+		"Orgs":        nonEmptyList(ref("OrgInfo"), "the org whose peers are read first"),
+		"ChannelName": channelName(),
+		"Number":      nullable(integer("block to read by /ledger/block, the last one when null", "int64", 0)),
+		"TxID":        str("transaction to read by /ledger/tx"),
+	}, "Orgs", "ChannelName"),
+	"Job": object("an operation run after the response to its request, until status is succeeded or failed", map[string]*Schema{
+		"id":        str(""),
+		"operation": str("route of the request"),
+		"requestId": str("correlation ID of the request"),
+		"status":    enum("", "running", "succeeded", "failed"),
+		"created":   &Schema{Type: "string", Format: "date-time"},
+		"finished":  &Schema{Type: "string", Format: "date-time"},
+		"result":    &Schema{Description: "response of the operation when it succeeded"},
+		"error":     ref("ErrorMessage"),
+	}, "id", "operation", "status", "created"),
+
+	"AuditQuery": object("selects audit records, zero values select everything", map[string]*Schema{
+		"FromSeq":   integer("", "int64", 0),
+		"ToSeq":     integer("", "int64", 0),
+		"Operation": str("route of the request"),
+		"Caller":    str("subject of the client certificate"),
+		"Outcome":   enum("", "", "success", "failure"),
+		"TxID":      str(""),
+		"Limit":     integer("maximum number of records, the last ones are returned", "int64", 0),
+	}),
+}
+
+// route is a documented operation
+type route struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+	// request is the schema of the request body, none when empty
+	request   string
+	mediaType string
+	// response is the media type of a successful response, JSON when empty
+	response string
+	// async tells whether the operation can run in a job with async=true
+	async  bool
+	params []*Parameter
+}
+
+var asyncParam = &Parameter{
+	Name:        "async",
+	In:          "query",
+	Description: "runs the operation in a job, returned with 202, that GET /jobs/{id} polls",
+	Schema:      boolean(""),
+}
+
+// callTags are the tags of the operations calling the nodes, whose timeouts,
+// retries and failover order the callParams override
+var callTags = map[string]bool{"network": true, "channel": true, "public": true, "chaincode": true, "ledger": true}
+
+var callParams = []*Parameter{
+	{Name: "timeouts", In: "query", Description: "overrides Timeouts of app.conf, comma separated op=duration pairs, e.g. install=2m,waittx=30s, op being one of endorse, broadcast, deliver, waittx, createchannel, joinchannel, install and instantiate", Schema: str("")},
+	{Name: "retries", In: "query", Description: "overrides Retries of app.conf, the times the nodes are tried again after all of them timed out or were unavailable", Schema: integer("", "int32", 0)},
+	{Name: "retryBackoff", In: "query", Description: "overrides RetryBackoff of app.conf, the delay before trying the nodes again, e.g. 2s", Schema: str("")},
+	{Name: "failover", In: "query", Description: "overrides Failover of app.conf, the order the nodes are tried in", Schema: enum("", "given", "random", "roundrobin")},
+}
+
+var routes = []*route{
+	{method: "GET", path: "/", tag: "console", summary: "Serves the management console", response: HTML},
+	{method: "POST", path: "/gencrypto", tag: "crypto", summary: "Generates the crypto material of orgs under MSPDir", request: "GenCryptoRequest"},
+	{method: "POST", path: "/gengenesisblock", tag: "crypto", summary: "Generates the genesis block of the system channel", request: "GenGenesisBlockRequest"},
+	{method: "POST", path: "/gencompose", tag: "deploy", summary: "Generates docker-compose files running the nodes of orgs", request: "ComposeRequest"},
+	{method: "POST", path: "/genmanifests", tag: "deploy", summary: "Generates kubernetes manifests running the nodes of orgs", request: "ManifestRequest"},
+	{method: "POST", path: "/gennodeconfig", tag: "deploy", summary: "Generates the configuration bundles of the nodes of orgs", request: "NodeConfigRequest"},
+	{method: "POST", path: "/network/plan", tag: "network", summary: "Returns the steps bringing the network to a spec", request: "Spec", mediaType: YAML},
+	{method: "POST", path: "/network/apply", tag: "network", summary: "Runs the steps bringing the network to a spec", request: "Spec", mediaType: YAML, async: true},
+	{method: "GET", path: "/health", tag: "operations", summary: "Returns the last check of the monitored nodes, 503 when any is unhealthy"},
+	{method: "GET", path: "/metrics", tag: "operations", summary: "Serves the metrics in the Prometheus text format", response: Text},
+	{method: "GET", path: "/ha", tag: "operations", summary: "Returns whether the replica is the leader running the mutating operations, and the leader"},
+	{method: "GET", path: "/openapi.json", tag: "operations", summary: "Serves this document"},
+	{method: "POST", path: "/audit/query", tag: "audit", summary: "Returns the audit records matching a query", request: "AuditQuery"},
+	{method: "GET", path: "/audit/export", tag: "audit", summary: "Serves the audit log as it is stored", response: NDJSON},
+	{method: "GET", path: "/audit/verify", tag: "audit", summary: "Verifies that no audit record is missing or modified"},
+	{method: "GET", path: "/orgs", tag: "channel", summary: "Returns the orgs under MSPDir with the expiry of their certificates"},
+	{method: "POST", path: "/channel/list", tag: "channel", summary: "Returns the channels the peers of the first org have joined with their members", request: "InventoryRequest"},
+	{method: "POST", path: "/channel/config", tag: "channel", summary: "Returns the current config of a channel", request: "ChannelRequest"},
+	{method: "POST", path: "/channel/identity", tag: "channel", summary: "Returns the identity code an org joins channels with", request: "IdentityRequest"},
+	{method: "POST", path: "/channel/addorg", tag: "channel", summary: "Adds an org to a channel", request: "AddOrgRequest", async: true},
+	{method: "POST", path: "/channel/deleteorg", tag: "channel", summary: "Removes an org from a channel", request: "DeleteOrgRequest", async: true},
+	{method: "POST", path: "/channel/create", tag: "channel", summary: "Creates a channel", request: "NewCreateChannelRequest", async: true},
+	{method: "POST", path: "/channel/join", tag: "channel", summary: "Joins the peers of orgs to a channel", request: "JoinChannelRequest", async: true},
+	{method: "POST", path: "/channel/bootstrap", tag: "channel", summary: "Creates the public chain and deploys the public chaincode", request: "BootstrapRequest", async: true},
+	{method: "POST", path: "/channel/orginfo", tag: "channel", summary: "Publishes the nodes of orgs to the public chain", request: "UpdateChainOrgInfoRequest", async: true},
+	{method: "POST", path: "/channel/removal/propose", tag: "channel", summary: "Starts a vote on removing an org from a channel", request: "ProposeRemovalRequest", async: true},
+	{method: "POST", path: "/channel/removal/vote", tag: "channel", summary: "Votes on a removal, executing it once approved", request: "VoteRemovalRequest", async: true},
+	{method: "POST", path: "/channel/removal/get", tag: "channel", summary: "Returns a removal proposal with its votes", request: "RemovalRequest"},
+	{method: "POST", path: "/channel/removal/list", tag: "channel", summary: "Returns the removals proposed of the orgs of a channel", request: "ChannelRequest"},
+	{method: "POST", path: "/channel/removal/execute", tag: "channel", summary: "Applies an approved removal or retries a failed one", request: "RemovalRequest", async: true},
+	{method: "POST", path: "/public/orginfo", tag: "public", summary: "Returns a page of the orgs of the public chain", request: "QueryPageRequest"},
+	{method: "POST", path: "/public/orgname", tag: "public", summary: "Returns a page of the names of the orgs of the public chain", request: "QueryPageRequest"},
+	{method: "POST", path: "/public/invitation", tag: "public", summary: "Returns a page of the invitations of the public chain", request: "QueryPageRequest"},
+	{method: "POST", path: "/chaincode/install", tag: "chaincode", summary: "Installs a chaincode on peers", request: "InstallChaincodeRequest", async: true},
+	{method: "POST", path: "/chaincode/instantiate", tag: "chaincode", summary: "Instantiates a chaincode on a channel", request: "InstantiateChaincodeRequest", async: true},
+	{method: "POST", path: "/chaincode/invoke", tag: "chaincode", summary: "Invokes a chaincode", request: "InvokeRequest", async: true},
+	{method: "POST", path: "/chaincode/list", tag: "chaincode", summary: "Returns the chaincodes installed on the peers of orgs and instantiated on their channels", request: "InventoryRequest"},
+	{method: "POST", path: "/ledger/info", tag: "ledger", summary: "Returns the height of the ledger of a channel", request: "LedgerRequest"},
+	{method: "POST", path: "/ledger/block", tag: "ledger", summary: "Returns a block of a channel with its transactions", request: "LedgerRequest"},
+	{method: "POST", path: "/ledger/tx", tag: "ledger", summary: "Returns a transaction of a channel with its validation code", request: "LedgerRequest"},
+	{method: "GET", path: "/jobs", tag: "jobs", summary: "Returns the jobs, the most recent first", params: []*Parameter{
+		{Name: "status", In: "query", Description: "returns only the jobs of status", Schema: enum("", "running", "succeeded", "failed")},
+	}},
+	{method: "GET", path: "/jobs/{id}", tag: "jobs", summary: "Returns a job with its outcome once it is finished", params: []*Parameter{
+		{Name: "id", In: "path", Required: true, Schema: str("")},
+	}},
+}
+
+// optionalBodies are the requests whose body may be empty
+var optionalBodies = map[string]bool{
+	"AuditQuery": true,
+}
+
+func errorResponse(description string) *Response {
+	return &Response{
+		Description: description,
+		Content:     map[string]*MediaType{JSON: {Schema: ref("ErrorMessage")}},
+	}
+}
+
+func (r *route) operation() *Operation {
+	response := r.response
+	if response == "" {
+		response = JSON
+	}
+	op := &Operation{
+		OperationID: r.method + r.path,
+		Summary:     r.summary,
+		Tags:        []string{r.tag},
+		Parameters:  r.params,
+		Responses: map[string]*Response{
+			"200":     {Description: "OK", Content: map[string]*MediaType{response: {Schema: &Schema{}}}},
+			"default": errorResponse("the request failed, code tells why and the HTTP status follows it"),
+		},
+	}
+	if r.request != "" {
+		mediaType := r.mediaType
+		if mediaType == "" {
+			mediaType = JSON
+		}
+		content := map[string]*MediaType{mediaType: {Schema: ref(r.request)}}
+		if mediaType == YAML {
+			content[JSON] = &MediaType{Schema: ref(r.request)}
+		}
+		op.RequestBody = &RequestBody{Required: !optionalBodies[r.request], Content: content}
+		op.Responses["400"] = errorResponse("the request does not match its schema, fields tells the invalid fields")
+	}
+	if callTags[r.tag] && r.request != "" {
+		op.Parameters = append(op.Parameters, callParams...)
+	}
+	if r.async {
+		op.Parameters = append(op.Parameters, asyncParam)
+		op.Responses["202"] = &Response{Description: "the job running the operation", Content: map[string]*MediaType{JSON: {Schema: ref("Job")}}}
+	}
+	return op
+}
+
+var document = newDocument()
+
+func newDocument() *Document {
+	d := &Document{
+		OpenAPI: "3.0.3",
+		Info: &Info{
+			Title:       "manageChain",
+			Description: "Manages the orgs, channels and chaincodes of a Hyperledger Fabric network",
+			Version:     "1.0",
+		},
+		Paths:      make(map[string]*PathItem),
+		Components: &Components{Schemas: schemas},
+	}
+	for _, r := range routes {
+		item, ok := d.Paths[r.path]
+		if !ok {
+			item = &PathItem{}
+			d.Paths[r.path] = item
+		}
+		switch r.method {
+		case "GET":
+			item.Get = r.operation()
+		case "POST":
+			item.Post = r.operation()
+		}
+	}
+	for _, s := range schemas {
+		compilePatterns(s)
+	}
+	return d
+}
+
+// Get returns the document of the REST API
+func Get() *Document {
+	return document
+}
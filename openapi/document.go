@@ -0,0 +1,207 @@
+// Package openapi describes the REST API in an OpenAPI 3 document, and
+// validates the requests against it before they are routed.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"manageChain/protocols"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Document is an OpenAPI 3.0 document
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       *Info                `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components"`
+}
+
+// Info ...
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem are the operations of a path by their method
+type PathItem struct {
+	Get  *Operation `json:"get,omitempty"`
+	Post *Operation `json:"post,omitempty"`
+}
+
+// Operation ...
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a parameter of the path or the query
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody ...
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response ...
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType ...
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components ...
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// media types of the requests and responses
+const (
+	JSON   = "application/json"
+	YAML   = "application/x-yaml"
+	Text   = "text/plain"
+	HTML   = "text/html"
+	NDJSON = "application/x-ndjson"
+)
+
+// ValidateRequest validates the body of a request to the route pattern
+// against the document, returning the error to respond with, nil when the
+// request is valid or the route is not documented.
+func ValidateRequest(method string, pattern string, body []byte) *protocols.ErrorMessage {
+	op := document.Operation(method, pattern)
+	if op == nil {
+		return nil
+	}
+	fields, err := document.ValidateBody(op, body)
+	if err != nil {
+		return &protocols.ErrorMessage{Code: protocols.CodeInvalidRequest, Message: err.Error()}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		name := f.Field
+		if name == "" {
+			name = "request body"
+		}
+		msgs[i] = name + " " + f.Message
+	}
+	return &protocols.ErrorMessage{
+		Code:    protocols.CodeInvalidRequest,
+		Message: "invalid request: " + strings.Join(msgs, "; "),
+		Fields:  fields,
+	}
+}
+
+// Path returns the OpenAPI path of a beego route pattern, its parameters
+// being templated, e.g. /jobs/{id} for /jobs/:id.
+func Path(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Operation returns the operation of method on path, nil if not documented.
+// path is an OpenAPI path or a beego route pattern.
+func (d *Document) Operation(method string, path string) *Operation {
+	path = Path(path)
+	item, ok := d.Paths[path]
+	if !ok {
+		item, ok = d.Paths[strings.TrimSuffix(path, "/")]
+	}
+	if !ok {
+		return nil
+	}
+	switch strings.ToUpper(method) {
+	case "GET":
+		return item.Get
+	case "POST":
+		return item.Post
+	}
+	return nil
+}
+
+// ValidateBody validates the body of a request to op, the returned error
+// tells a body that cannot be parsed, the field errors the fields that do
+// not match the schema.
+func (d *Document) ValidateBody(op *Operation, body []byte) ([]*protocols.FieldError, error) {
+	if op.RequestBody == nil {
+		return nil, nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return nil, errors.New("request body is required")
+		}
+		return nil, nil
+	}
+	var value interface{}
+	var schema *Schema
+	if media, ok := op.RequestBody.Content[JSON]; ok {
+		schema = media.Schema
+	}
+	if media, ok := op.RequestBody.Content[YAML]; ok {
+		// JSON is YAML, both are read as YAML
+		schema = media.Schema
+		var raw interface{}
+		if err := yaml.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("request body is not valid YAML or JSON: %s", err)
+		}
+		var err error
+		if body, err = json.Marshal(jsonValue(raw)); err != nil {
+			return nil, err
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("request body is not valid JSON: %s", err)
+	}
+	if decoder.More() {
+		return nil, errors.New("request body is not valid JSON: data after the top-level value")
+	}
+	v := &validator{schemas: d.Components.Schemas}
+	v.validate(schema, value, "")
+	return v.errs, nil
+}
+
+// jsonValue converts the maps YAML decodes to maps of strings
+func jsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			obj[fmt.Sprint(k)] = jsonValue(v)
+		}
+		return obj
+	case []interface{}:
+		for i, v := range value {
+			value[i] = jsonValue(v)
+		}
+	}
+	return value
+}
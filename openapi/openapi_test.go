@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"encoding/json"
+	"manageChain/chaincode"
+	"manageChain/channel"
+	"manageChain/protocols"
+	"reflect"
+	"testing"
+)
+
+func validate(t *testing.T, path string, body string) []string {
+	op := Get().Operation("POST", path)
+	if op == nil {
+		t.Fatalf("%s is not documented", path)
+	}
+	fields, err := Get().ValidateBody(op, []byte(body))
+	if err != nil {
+		t.Fatalf("%s: %s", body, err)
+	}
+	errs := []string{}
+	for _, f := range fields {
+		errs = append(errs, f.Field+" "+f.Code)
+	}
+	return errs
+}
+
+func marshal(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+var testOrg = &channel.OrgInfo{
+	OrgName:   "testorg1",
+	MspID:     "testorg1",
+	PeerNodes: []*channel.ServiceNode{{ID: "peer0", Endpoint: "172.16.93.215:56051", Public: true}},
+}
+
+// the requests Go clients marshal from the request structs are valid
+func TestGoRequests(t *testing.T) {
+	for path, req := range map[string]interface{}{
+		"/gencrypto":         &channel.GenCryptoRequest{Orgs: []*channel.OrgInfo{testOrg}},
+		"/gengenesisblock":   &channel.GenGenesisBlockRequest{Orgs: []*channel.OrgInfo{testOrg}, Kafkas: []string{"kafka0:9092"}},
+		"/channel/addorg":    &channel.AddOrgRequest{Orgs: []*channel.OrgInfo{testOrg}, Identity: []byte("identity"), ChannelName: "mychannel"},
+		"/channel/deleteorg": &channel.DeleteOrgRequest{Orgs: []*channel.OrgInfo{testOrg}, DelOrg: "testorg2", ChannelName: "mychannel"},
+		"/ledger/block":      &channel.LedgerRequest{Orgs: []*channel.OrgInfo{testOrg}, ChannelName: "mychannel"},
+		"/channel/config":    &channel.ChannelRequest{Orgs: []*channel.OrgInfo{testOrg}, ChannelName: "mychannel"},
+		"/chaincode/list":    &channel.InventoryRequest{Orgs: []*channel.OrgInfo{testOrg}},
+		"/public/invitation": &channel.QueryPageRequest{Orgs: []*channel.OrgInfo{testOrg}, ChannelName: "publicchain", Filter: &channel.InvitationFilter{Status: "Accept"}},
+		"/chaincode/instantiate": &chaincode.InstantiateChaincodeRequest{
+			Org:         "testorg1",
+			ChannelName: "mychannel",
+			CcName:      "mycc",
+			CcVersion:   "1.0",
+			Args:        [][]byte{[]byte("init"), []byte("a")},
+			PeerNodes:   []*chaincode.ServiceNode{{Endpoint: "172.16.93.215:56051"}},
+		},
+	} {
+		if errs := validate(t, path, marshal(t, req)); len(errs) != 0 {
+			t.Fatalf("valid request to %s is refused: %v", path, errs)
+		}
+	}
+}
+
+func TestInvalidRequests(t *testing.T) {
+	for _, c := range []struct {
+		path string
+		body string
+		errs []string
+	}{
+		{"/gencrypto", `{"Orgs": []}`, []string{"Orgs invalid_value"}},
+		{"/gencrypto", `{}`, []string{"Orgs required"}},
+		{"/gencrypto", `[]`, []string{" invalid_type"}},
+		{"/gencrypto", `{"Orgs": [{"OrgName": "", "Peers": []}]}`, []string{"Orgs[0].OrgName required", "Orgs[0].Peers unknown_field"}},
+		{"/gengenesisblock", `{"Orgs": [{"OrgName": "org1"}], "Kafkas": ["kafka0"]}`, []string{"Kafkas[0] invalid_value"}},
+		{"/channel/create", `{"Orgs": [{"OrgName": "org1"}], "ChannelName": "MyChannel"}`, []string{"ChannelName invalid_value"}},
+		{"/channel/addorg", `{"Orgs": [{"OrgName": "org1"}], "Identity": "not base64!", "ChannelName": "mychannel"}`, []string{"Identity invalid_value"}},
+		{"/channel/removal/vote", `{"Orgs": [{"OrgName": "org1"}], "ChannelName": "mychannel", "Target": "org2", "Accept": "yes"}`, []string{"Accept invalid_type"}},
+		{"/public/orginfo", `{"Orgs": [{"OrgName": "org1"}], "ChannelName": "publicchain", "PageSize": -1}`, []string{"PageSize invalid_value"}},
+		{"/chaincode/invoke", `{"Org": "org1", "ChannelName": "mychannel", "CcName": "mycc", "PeerNodes": [{"ID": "peer0"}]}`, []string{"PeerNodes[0].Endpoint required"}},
+		{"/network/plan", "orgs:\n- name: org1\n  msp: org1\nchannels:\n- name: mychannel\n  members: []\n", []string{"channels[0].members invalid_value"}},
+	} {
+		if errs := validate(t, c.path, c.body); !reflect.DeepEqual(errs, c.errs) {
+			t.Fatalf("%s %s: expected %v, got %v", c.path, c.body, c.errs, errs)
+		}
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	if msg := ValidateRequest("POST", "/network/plan", []byte("orgs:\n- name: org1\n  msp: org1\n")); msg != nil {
+		t.Fatalf("a valid YAML spec is refused: %s", msg.Message)
+	}
+	if msg := ValidateRequest("POST", "/audit/query", nil); msg != nil {
+		t.Fatalf("an optional body is refused: %s", msg.Message)
+	}
+	if Get().Operation("GET", "/jobs/:id") == nil {
+		t.Fatal("the path of a route pattern with parameters is not found")
+	}
+	if msg := ValidateRequest("GET", "/unknown", nil); msg != nil {
+		t.Fatalf("an undocumented route is refused: %s", msg.Message)
+	}
+	for _, body := range []string{"", `{"Orgs": [`, `{} {}`} {
+		msg := ValidateRequest("POST", "/channel/create", []byte(body))
+		if msg == nil || msg.Code != protocols.CodeInvalidRequest || len(msg.Fields) != 0 {
+			t.Fatalf("body %q: unexpected error %+v", body, msg)
+		}
+	}
+	msg := ValidateRequest("POST", "/channel/create", []byte(`{"ChannelName": "mychannel", "Org": "org1"}`))
+	if msg == nil || len(msg.Fields) != 2 || msg.Message != "invalid request: Orgs is required; Org is not a field of the request" {
+		t.Fatalf("unexpected error %+v", msg)
+	}
+}
@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"manageChain/protocols"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// codes of the FieldErrors
+const (
+	CodeRequired     = "required"
+	CodeUnknownField = "unknown_field"
+	CodeInvalidType  = "invalid_type"
+	CodeInvalidValue = "invalid_value"
+)
+
+// Schema is the subset of the OpenAPI 3.0 schema object the requests are
+// described with. Objects do not allow properties they do not declare.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            int                `json:"minLength,omitempty"`
+	MaxLength            int                `json:"maxLength,omitempty"`
+	Minimum              *int64             `json:"minimum,omitempty"`
+	MinItems             int                `json:"minItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+const refPrefix = "#/components/schemas/"
+
+var patterns = make(map[string]*regexp.Regexp)
+
+// validator validates values against the schemas of a document
+type validator struct {
+	schemas map[string]*Schema
+	errs    []*protocols.FieldError
+}
+
+func (v *validator) fail(path string, code string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &protocols.FieldError{Field: path, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) resolve(s *Schema) *Schema {
+	for s.Ref != "" {
+		s = v.schemas[strings.TrimPrefix(s.Ref, refPrefix)]
+	}
+	return s
+}
+
+// validate validates value, decoded from JSON with numbers as json.Number
+func (v *validator) validate(s *Schema, value interface{}, path string) {
+	s = v.resolve(s)
+	if value == nil {
+		if !s.Nullable {
+			v.fail(path, CodeInvalidType, "should not be null")
+		}
+		return
+	}
+	switch s.Type {
+	case "object":
+		v.validateObject(s, value, path)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			v.fail(path, CodeInvalidType, "should be an array")
+			return
+		}
+		if len(items) < s.MinItems {
+			v.fail(path, CodeInvalidValue, "should have at least %d items", s.MinItems)
+		}
+		for i, item := range items {
+			v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			v.fail(path, CodeInvalidType, "should be a string")
+			return
+		}
+		v.validateString(s, str, path)
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			v.fail(path, CodeInvalidType, "should be an integer")
+			return
+		}
+		i, err := n.Int64()
+		if err != nil {
+			v.fail(path, CodeInvalidType, "should be an integer")
+			return
+		}
+		if s.Minimum != nil && i < *s.Minimum {
+			v.fail(path, CodeInvalidValue, "should be at least %d", *s.Minimum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(path, CodeInvalidType, "should be a boolean")
+		}
+	}
+}
+
+func (v *validator) validateObject(s *Schema, value interface{}, path string) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		v.fail(path, CodeInvalidType, "should be an object")
+		return
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	for _, name := range s.Required {
+		if obj[name] == nil {
+			v.fail(prefix+name, CodeRequired, "is required")
+		}
+	}
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				v.fail(prefix+name, CodeUnknownField, "is not a field of the request")
+			}
+			continue
+		}
+		if obj[name] == nil && contains(s.Required, name) {
+			continue
+		}
+		v.validate(prop, obj[name], prefix+name)
+	}
+}
+
+func (v *validator) validateString(s *Schema, str string, path string) {
+	if len(s.Enum) != 0 && !contains(s.Enum, str) {
+		v.fail(path, CodeInvalidValue, "should be one of %s", strings.Join(quote(s.Enum), ", "))
+		return
+	}
+	length := utf8.RuneCountInString(str)
+	if length < s.MinLength {
+		if s.MinLength == 1 {
+			v.fail(path, CodeRequired, "should not be empty")
+		} else {
+			v.fail(path, CodeInvalidValue, "should have at least %d characters", s.MinLength)
+		}
+		return
+	}
+	if s.MaxLength != 0 && length > s.MaxLength {
+		v.fail(path, CodeInvalidValue, "should have at most %d characters", s.MaxLength)
+		return
+	}
+	if s.Format == "byte" {
+		if _, err := base64.StdEncoding.DecodeString(str); err != nil {
+			v.fail(path, CodeInvalidValue, "should be base64 encoded")
+			return
+		}
+	}
+	if s.Pattern != "" && !patterns[s.Pattern].MatchString(str) {
+		v.fail(path, CodeInvalidValue, "should match %s", s.Pattern)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func quote(list []string) []string {
+	quoted := make([]string, len(list))
+	for i, s := range list {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return quoted
+}
+
+// compilePatterns compiles the patterns of the schemas, they are fixed so
+// an invalid one panics.
+func compilePatterns(s *Schema) {
+	if s == nil {
+		return
+	}
+	if s.Pattern != "" && patterns[s.Pattern] == nil {
+		patterns[s.Pattern] = regexp.MustCompile(s.Pattern)
+	}
+	compilePatterns(s.Items)
+	for _, prop := range s.Properties {
+		compilePatterns(prop)
+	}
+}
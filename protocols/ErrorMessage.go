@@ -1,7 +1,27 @@
 package protocols
 
-// ErrorMessage uses for describe the error message and give it to the front end
+// RequestIDHeader carries the correlation ID of a request
+const RequestIDHeader = "X-Request-ID"
+
+// ErrorMessage uses for describe the error message and give it to the front end.
+// Code is one of the Code constants, the other fields tell more about some of them.
 type ErrorMessage struct {
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Fields  []*FieldError `json:"fields,omitempty"`
+	// OrdererStatus is the status an orderer rejected a transaction with, e.g. BAD_REQUEST
+	OrdererStatus string `json:"ordererStatus,omitempty"`
+	// EndorserStatus is the status of the proposal response of a peer
+	EndorserStatus int32 `json:"endorserStatus,omitempty"`
+	// ValidationCode is why a transaction is invalid, e.g. MVCC_READ_CONFLICT
+	ValidationCode string `json:"validationCode,omitempty"`
+	TxID           string `json:"txId,omitempty"`
+}
+
+// FieldError is why a field of a request is invalid, Field being its path
+// in the request, e.g. Orgs[0].OrgName.
+type FieldError struct {
+	Field   string `json:"field"`
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
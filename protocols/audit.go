@@ -0,0 +1,63 @@
+package protocols
+
+import "fmt"
+
+// AuditConfigBlock is a config block a request resulted in
+type AuditConfigBlock struct {
+	Channel string
+	TxID    string
+	Number  uint64
+}
+
+// AuditRecord is an administrative request. Hash chains it to the previous record,
+// it is the sha256 of the record with an empty Hash, PrevHash included.
+type AuditRecord struct {
+	Seq        uint64
+	Time       string
+	RequestID  string
+	Operation  string
+	Method     string
+	Caller     string
+	RemoteAddr string
+	// RequestDigest is the sha256 of the request body
+	RequestDigest string
+	// Signers are the MSPs whose identities signed the transactions
+	Signers      []string
+	TxIDs        []string
+	ConfigBlocks []*AuditConfigBlock
+	Status       int
+	Outcome      string
+	Error        string
+	PrevHash     string
+	Hash         string
+}
+
+// AuditQuery selects records, its zero values select everything
+type AuditQuery struct {
+	FromSeq   uint64
+	ToSeq     uint64
+	Operation string
+	Caller    string
+	Outcome   string
+	TxID      string
+	// Limit is the maximum number of records, the last ones are returned
+	Limit int
+}
+
+// AuditProblem is why a line of the store does not verify
+type AuditProblem struct {
+	Line    int
+	Seq     uint64
+	Message string
+}
+
+func (p *AuditProblem) String() string {
+	return fmt.Sprintf("line %d (record %d): %s", p.Line, p.Seq, p.Message)
+}
+
+// AuditVerification is the result of verifying the records of a store
+type AuditVerification struct {
+	LastSeq  uint64
+	LastHash string
+	Problems []*AuditProblem
+}
@@ -0,0 +1,348 @@
+package protocols
+
+import "time"
+
+// ServiceNode is a peer or orderer of an org
+type ServiceNode struct {
+	ID               string
+	Endpoint         string
+	ExternalEndpoint string
+	Public           bool
+}
+
+// OrgInfo is an org and its nodes as the requests carry it, the server loads
+// the crypto material of the org from its MSPDir.
+type OrgInfo struct {
+	OrgName      string
+	MspID        string
+	OrgMSP       string
+	PeerNodes    []*ServiceNode
+	OrdererNodes []*ServiceNode
+}
+
+type GenCryptoRequest struct {
+	Orgs []*OrgInfo
+}
+
+type GenGenesisBlockRequest struct {
+	Orgs   []*OrgInfo
+	Kafkas []string
+}
+
+type NewCreateChannelRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+}
+
+type BootstrapRequest struct {
+	Orgs      []*OrgInfo
+	CcTarPath string
+	CcVersion string
+	Policy    string
+}
+
+type JoinChannelRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+}
+
+type UpdateChainOrgInfoRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+}
+
+type IdentityRequest struct {
+	Orgs []*OrgInfo
+}
+
+type AddOrgRequest struct {
+	Orgs        []*OrgInfo
+	Identity    []byte
+	ChannelName string
+}
+
+type DeleteOrgRequest struct {
+	Orgs        []*OrgInfo
+	DelOrg      string
+	DelOrderers []string
+	ChannelName string
+}
+
+type ProposeRemovalRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	Target      string
+	DelOrderers []string
+	Reason      string
+}
+
+type VoteRemovalRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	Target      string
+	Accept      bool
+}
+
+type RemovalRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	Target      string
+}
+
+// LedgerRequest reads the ledger of a channel through the peers of the first org
+type LedgerRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	// Number is the block to read, the last one when it is not given
+	Number *uint64
+	TxID   string
+}
+
+// InventoryRequest lists the channels and chaincodes of the peers of orgs
+type InventoryRequest struct {
+	Orgs []*OrgInfo
+}
+
+// ChannelRequest reads the config or the removals of a channel through the
+// peers of the first org
+type ChannelRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+}
+
+type QueryPageRequest struct {
+	Orgs        []*OrgInfo
+	ChannelName string
+	PageSize    int32
+	Bookmark    string
+	Filter      *InvitationFilter
+}
+
+// Endpoint is a node an org publishes to the public chain
+type Endpoint struct {
+	Address  string
+	Override string
+	TLS      []byte
+	Timeout  time.Duration
+}
+
+// IdentityCode is what an org joins channels with, its MSP and its nodes
+type IdentityCode struct {
+	Org          string
+	OrgMSP       []byte
+	Orderers     []string
+	Anchors      []string
+	ChainOrgInfo *ChainOrgInfo
+}
+
+type ChainOrgInfo struct {
+	Peers       []*Endpoint
+	Orderers    []*Endpoint
+	OrgName     string
+	ChannelName string
+}
+
+// InvitationFilter selects invitations, empty fields and zero times match everything
+type InvitationFilter struct {
+	Status    string `json:"status"`
+	Inviter   string `json:"inviter"`
+	Invitee   string `json:"invitee"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+}
+
+type PublicOrgInfo struct {
+	ChainId string `json:"chainId"`
+	Orgname string `json:"orgname"`
+	Info    string `json:"info"`
+}
+
+type Invitation struct {
+	ChainId    string `json:"chainId"`
+	Inviter    string `json:"inviter"`
+	Invitee    string `json:"invitee"`
+	Status     string `json:"status"`
+	InviteTime int64  `json:"inviteTime"`
+	RawData    string `json:"rawdata"`
+}
+
+// pages returned by the public chaincode, an empty Bookmark means the last page
+type OrgInfoPage struct {
+	Records             []*PublicOrgInfo `json:"records"`
+	FetchedRecordsCount int32            `json:"fetchedRecordsCount"`
+	Bookmark            string           `json:"bookmark"`
+}
+
+type OrgnamePage struct {
+	Records             []string `json:"records"`
+	FetchedRecordsCount int32    `json:"fetchedRecordsCount"`
+	Bookmark            string   `json:"bookmark"`
+}
+
+type InvitationPage struct {
+	Records             []*Invitation `json:"records"`
+	FetchedRecordsCount int32         `json:"fetchedRecordsCount"`
+	Bookmark            string        `json:"bookmark"`
+}
+
+type RemovalProposal struct {
+	ChainId     string         `json:"chainId"`
+	Proposer    string         `json:"proposer"`
+	Target      string         `json:"target"`
+	Reason      string         `json:"reason"`
+	Status      string         `json:"status"`
+	Threshold   int            `json:"threshold"`
+	Voters      []string       `json:"voters"`
+	ProposeTime int64          `json:"proposeTime"`
+	DecideTime  int64          `json:"decideTime"`
+	RawData     string         `json:"rawdata"`
+	Result      string         `json:"result"`
+	Votes       []*RemovalVote `json:"votes,omitempty"`
+}
+
+type RemovalVote struct {
+	ChainId   string `json:"chainId"`
+	Target    string `json:"target"`
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
+	Accepted  string `json:"accepted"`
+	SignTime  int64  `json:"signTime"`
+}
+
+// RemovalResult is the outcome of removing an org, recorded in the public chain
+type RemovalResult struct {
+	SystemTxID  string `json:"systemTxId"`
+	ChannelTxID string `json:"channelTxId"`
+	Error       string `json:"error,omitempty"`
+}
+
+// LedgerInfo is the height of the ledger of a channel on a peer
+type LedgerInfo struct {
+	ChannelName       string `json:"channelName"`
+	Peer              string `json:"peer"`
+	Height            uint64 `json:"height"`
+	CurrentBlockHash  string `json:"currentBlockHash"`
+	PreviousBlockHash string `json:"previousBlockHash"`
+}
+
+// BlockInfo is a block of the ledger of a channel, the hashes in hex
+type BlockInfo struct {
+	Number       uint64    `json:"number"`
+	Hash         string    `json:"hash"`
+	PreviousHash string    `json:"previousHash"`
+	DataHash     string    `json:"dataHash"`
+	Txs          []*TxInfo `json:"txs"`
+}
+
+// TxInfo is a transaction of the ledger of a channel
+type TxInfo struct {
+	TxID      string    `json:"txId"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	// Creator is the MSP of the creator, Signers also those of the endorsers
+	// and of the signatures of a config update
+	Creator        string   `json:"creator"`
+	Signers        []string `json:"signers"`
+	ValidationCode string   `json:"validationCode"`
+}
+
+// ChannelInfo is a channel a peer has joined with the orgs of its config
+type ChannelInfo struct {
+	ChannelName string   `json:"channelName"`
+	Peer        string   `json:"peer"`
+	Height      uint64   `json:"height"`
+	Members     []string `json:"members"`
+}
+
+// Chaincode is a chaincode installed on a peer or instantiated on a channel
+type Chaincode struct {
+	Name    string
+	Version string
+}
+
+// PeerChaincodes are the chaincodes installed on a peer and those
+// instantiated on the channels it has joined
+type PeerChaincodes struct {
+	Org          string                 `json:"org"`
+	Peer         string                 `json:"peer"`
+	Installed    []Chaincode            `json:"installed"`
+	Instantiated map[string][]Chaincode `json:"instantiated"`
+	// Error tells why the peer could not be queried
+	Error string `json:"error,omitempty"`
+}
+
+// ConfigGroup is a group of the config of a channel, the values decoded
+type ConfigGroup struct {
+	Version   uint64                   `json:"version"`
+	ModPolicy string                   `json:"modPolicy"`
+	Groups    map[string]*ConfigGroup  `json:"groups"`
+	Values    map[string]*ConfigValue  `json:"values"`
+	Policies  map[string]*ConfigPolicy `json:"policies"`
+}
+
+// ConfigValue is a value of a config group, Value holding the decoded
+// message, or the bytes in base64 when the key is unknown
+type ConfigValue struct {
+	Version   uint64      `json:"version"`
+	ModPolicy string      `json:"modPolicy"`
+	Value     interface{} `json:"value"`
+}
+
+// ConfigPolicy is a policy of a config group, Rule e.g. "MAJORITY Admins"
+// or "OutOf(1, 'org1.admin')"
+type ConfigPolicy struct {
+	Version   uint64 `json:"version"`
+	ModPolicy string `json:"modPolicy"`
+	Type      string `json:"type"`
+	Rule      string `json:"rule"`
+}
+
+// OrgCerts are the certificates in the msp dir of an org
+type OrgCerts struct {
+	OrgName string      `json:"orgName"`
+	Certs   []*CertInfo `json:"certs"`
+	// NotAfter is the earliest expiry of the certificates
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// CertInfo is a certificate of an org, Kind is one of ca, tlsca, admin,
+// peer, peer-tls, orderer and orderer-tls
+type CertInfo struct {
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+type InstallChaincodeRequest struct {
+	Org       string
+	CcTarPath string
+	CcPath    string
+	CcName    string
+	CcVersion string
+	PeerNodes []*ServiceNode
+}
+
+type InstantiateChaincodeRequest struct {
+	Org          string
+	ChannelName  string
+	CcName       string
+	CcVersion    string
+	Policy       string
+	Args         [][]byte
+	PeerNodes    []*ServiceNode
+	OrdererNodes []*ServiceNode
+}
+
+type InvokeRequest struct {
+	Org          string
+	ChannelName  string
+	CcName       string
+	Args         [][]byte
+	PeerNodes    []*ServiceNode
+	OrdererNodes []*ServiceNode
+	// EndorsingOrgs are the other orgs of the channel whose endorsements the
+	// policy needs, one of the peers they published endorses for each
+	EndorsingOrgs []string
+}
@@ -0,0 +1,53 @@
+package protocols
+
+// Images are the docker images of the nodes
+type Images struct {
+	Peer      string
+	Orderer   string
+	CCEnv     string
+	BaseOS    string
+	Kafka     string
+	ZooKeeper string
+	CouchDB   string
+}
+
+// K8sOptions selects how the nodes are deployed to kubernetes.
+// ServiceType is the type of the Services exposing ExternalEndpoint:
+// ClusterIP, NodePort on the port of ExternalEndpoint, in 30000-32767, or
+// LoadBalancer.
+type K8sOptions struct {
+	Namespace    string
+	ServiceType  string
+	StorageClass string
+	StorageSize  string
+}
+
+type ComposeRequest struct {
+	Orgs         []*OrgInfo
+	PerOrg       bool
+	OutputDir    string
+	GenesisBlock string
+	Kafkas       []string
+	ZooKeepers   []string
+	CouchDB      bool
+	Images       *Images
+}
+
+type NodeConfigRequest struct {
+	Orgs         []*OrgInfo
+	OutputDir    string
+	GenesisBlock string
+	Kafkas       []string
+	CouchDB      bool
+	Images       *Images
+}
+
+type ManifestRequest struct {
+	Orgs         []*OrgInfo
+	OutputDir    string
+	Tarball      bool
+	GenesisBlock string
+	CouchDB      bool
+	Images       *Images
+	K8sOptions   *K8sOptions
+}
@@ -0,0 +1,60 @@
+package protocols
+
+import (
+	"errors"
+	"fmt"
+)
+
+// codes of the ErrorMessages
+const (
+	// CodeInvalidRequest is a request that is malformed or inconsistent
+	CodeInvalidRequest = "invalid_request"
+	// CodeNotFound is a request referring to an org, node, channel or
+	// removal that does not exist
+	CodeNotFound = "not_found"
+	// CodePermissionDenied is a transaction its creator may not send
+	CodePermissionDenied = "permission_denied"
+	// CodePolicyNotSatisfied is a transaction whose signatures or
+	// endorsements do not satisfy a policy
+	CodePolicyNotSatisfied = "policy_not_satisfied"
+	// CodeEndorsementFailed is a proposal a peer did not endorse
+	CodeEndorsementFailed = "endorsement_failed"
+	// CodeOrdererRejected is a transaction an orderer rejected
+	CodeOrdererRejected = "orderer_rejected"
+	// CodeTimeout is a node that did not answer in time
+	CodeTimeout = "timeout"
+	// CodeTxInvalid is a transaction committed as invalid
+	CodeTxInvalid = "tx_invalid"
+	// CodeConflict is a request contradicting the state of the channels,
+	// e.g. another MSP for an org in them
+	CodeConflict = "conflict"
+	// CodeUnavailable is a request the server cannot serve for now
+	CodeUnavailable = "unavailable"
+	// CodeInternal is any other error
+	CodeInternal = "internal"
+)
+
+// Error is an error of a request with its code, for the errors the code
+// cannot be told from their type.
+type Error struct {
+	Code    string
+	Message string
+	// Err is the error it wraps, if any
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap ...
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf returns an Error of code formatted like fmt.Errorf, wrapping the
+// error of a %w verb.
+func Errorf(code string, format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	return &Error{Code: code, Message: err.Error(), Err: errors.Unwrap(err)}
+}
@@ -0,0 +1,15 @@
+package protocols
+
+import "time"
+
+// HAStatus is the role of a replica and the leader it knows of
+type HAStatus struct {
+	Enabled bool   `json:"enabled"`
+	ID      string `json:"id"`
+	Leader  bool   `json:"leader"`
+	// LeaderID and LeaderURL are the leader, empty while none is elected
+	LeaderID  string     `json:"leaderId,omitempty"`
+	LeaderURL string     `json:"leaderUrl,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	Heartbeat *time.Time `json:"heartbeat,omitempty"`
+}
@@ -0,0 +1,36 @@
+package protocols
+
+import "fmt"
+
+// NodeProblem is a failed check of a node, Channel is empty for the checks of the node itself
+type NodeProblem struct {
+	Check   string `json:"check"`
+	Channel string `json:"channel,omitempty"`
+	Message string `json:"message"`
+}
+
+// NodeStatus is the result of probing a peer or an orderer.
+// Heights maps the channels of the node to the height of its ledger.
+type NodeStatus struct {
+	Org          string            `json:"org"`
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Endpoint     string            `json:"endpoint"`
+	Reachable    bool              `json:"reachable"`
+	CertNotAfter int64             `json:"certNotAfter,omitempty"`
+	Heights      map[string]uint64 `json:"heights,omitempty"`
+	Problems     []*NodeProblem    `json:"problems,omitempty"`
+}
+
+func (ns *NodeStatus) String() string {
+	return fmt.Sprintf("%s %s of %s at %s", ns.Type, ns.ID, ns.Org, ns.Endpoint)
+}
+
+// Summary is the health of the monitored nodes, Channels maps the channels
+// to the highest height any of their peers or orderers reached.
+type Summary struct {
+	Healthy   bool              `json:"healthy"`
+	CheckedAt int64             `json:"checkedAt,omitempty"`
+	Channels  map[string]uint64 `json:"channels"`
+	Nodes     []*NodeStatus     `json:"nodes"`
+}
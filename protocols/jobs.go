@@ -0,0 +1,32 @@
+package protocols
+
+import "time"
+
+// statuses of a Job
+const (
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	// JobInterrupted is the status of the jobs the server stopped while they
+	// were running, their operation may be partially applied
+	JobInterrupted = "interrupted"
+)
+
+// Job is an operation running after the response to the request of it
+type Job struct {
+	ID        string `json:"id"`
+	Operation string `json:"operation"`
+	// RequestID is the correlation ID of the request, the logs and the audit
+	// record of the job carry it
+	RequestID string        `json:"requestId"`
+	Status    string        `json:"status"`
+	Created   time.Time     `json:"created"`
+	Finished  *time.Time    `json:"finished,omitempty"`
+	Result    interface{}   `json:"result,omitempty"`
+	Error     *ErrorMessage `json:"error,omitempty"`
+}
+
+// Done tells whether the job is finished
+func (j *Job) Done() bool {
+	return j.Status != JobRunning
+}
@@ -0,0 +1,78 @@
+package protocols
+
+// Spec is the declared state of a network, given in YAML or JSON
+type Spec struct {
+	Orgs        []*OrgSpec       `yaml:"orgs" json:"orgs"`
+	Consensus   *ConsensusSpec   `yaml:"consensus" json:"consensus"`
+	PublicChain *PublicChainSpec `yaml:"publicChain" json:"publicChain"`
+	Channels    []*ChannelSpec   `yaml:"channels" json:"channels"`
+	Chaincodes  []*ChaincodeSpec `yaml:"chaincodes" json:"chaincodes"`
+	// Prune removes the orgs of a channel that are not its members
+	Prune bool `yaml:"prune" json:"prune"`
+}
+
+type OrgSpec struct {
+	Name     string      `yaml:"name" json:"name"`
+	MSP      string      `yaml:"msp" json:"msp"`
+	Peers    []*NodeSpec `yaml:"peers" json:"peers"`
+	Orderers []*NodeSpec `yaml:"orderers" json:"orderers"`
+}
+
+type NodeSpec struct {
+	ID               string `yaml:"id" json:"id"`
+	Endpoint         string `yaml:"endpoint" json:"endpoint"`
+	ExternalEndpoint string `yaml:"externalEndpoint" json:"externalEndpoint"`
+	Public           bool   `yaml:"public" json:"public"`
+}
+
+// ConsensusSpec ... only kafka is supported
+type ConsensusSpec struct {
+	Type   string   `yaml:"type" json:"type"`
+	Kafkas []string `yaml:"kafkas" json:"kafkas"`
+}
+
+// PublicChainSpec bootstraps the public chain with every org of the spec,
+// empty fields fall back to the defaults of /channel/bootstrap.
+type PublicChainSpec struct {
+	CcTarPath string `yaml:"ccTarPath" json:"ccTarPath"`
+	CcVersion string `yaml:"ccVersion" json:"ccVersion"`
+	Policy    string `yaml:"policy" json:"policy"`
+}
+
+// ChannelSpec ... Members are the names of the orgs of the channel, the first one creates it
+type ChannelSpec struct {
+	Name    string   `yaml:"name" json:"name"`
+	Members []string `yaml:"members" json:"members"`
+}
+
+// ChaincodeSpec is installed on the peers of the members of Channels and
+// instantiated on every channel, Policy defaults to any member of the channel.
+type ChaincodeSpec struct {
+	Name     string   `yaml:"name" json:"name"`
+	Path     string   `yaml:"path" json:"path"`
+	TarPath  string   `yaml:"tarPath" json:"tarPath"`
+	Version  string   `yaml:"version" json:"version"`
+	Channels []string `yaml:"channels" json:"channels"`
+	Policy   string   `yaml:"policy" json:"policy"`
+	Args     []string `yaml:"args" json:"args"`
+}
+
+// Step is one operation of a plan. Org is the org it applies to, the MSP ID
+// of the removed org for deleteOrg; Nodes are the nodes of Org it applies to;
+// Operators are the members of Channel signing an addOrg or deleteOrg.
+type Step struct {
+	Action    string   `json:"action"`
+	Org       string   `json:"org,omitempty"`
+	Nodes     []string `json:"nodes,omitempty"`
+	Operators []string `json:"operators,omitempty"`
+	Channel   string   `json:"channel,omitempty"`
+	Chaincode string   `json:"chaincode,omitempty"`
+	Done      bool     `json:"done"`
+}
+
+// Plan is the steps bringing the network to its spec, Warnings are the
+// differences it does not reconcile.
+type Plan struct {
+	Steps    []*Step  `json:"steps"`
+	Warnings []string `json:"warnings,omitempty"`
+}
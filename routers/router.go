@@ -1,33 +1,104 @@
 package routers
 
 import (
+	"manageChain/audit"
 	"manageChain/controllers"
+	"manageChain/ha"
+	"manageChain/logging"
+	"manageChain/metrics"
+	"manageChain/server"
 
 	"github.com/astaxie/beego"
 )
 
+// auditedRoutes change the crypto material, the consortium, the channels or
+// the chaincodes, or write the deployments of the nodes with their keys, they
+// are recorded in the audit log and drained on shutdown.
+var auditedRoutes = []string{
+	"/gencrypto",
+	"/gengenesisblock",
+	"/gencompose",
+	"/genmanifests",
+	"/gennodeconfig",
+	"/network/apply",
+	"/channel/addorg",
+	"/channel/deleteorg",
+	"/channel/create",
+	"/channel/join",
+	"/channel/bootstrap",
+	"/channel/orginfo",
+	"/channel/removal/propose",
+	"/channel/removal/vote",
+	"/channel/removal/execute",
+	"/chaincode/install",
+	"/chaincode/instantiate",
+	"/chaincode/invoke",
+}
+
 func init() {
-	// beego.InsertFilter("*", beego.BeforeRouter, cors.Allow(&cors.Options{
-	// 	// AllowAllOrigins:  true,
-	// 	AllowMethods:     []string{"POST", "GET"},
-	// 	AllowHeaders:     []string{"Origin", "Authorization", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers", "Content-Type", "x-requested-with", "no-referrer-when-downgrade"},
-	// 	ExposeHeaders:    []string{"Content-Length", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers", "Content-Type", "Access-Control-Allow-Origin"},
-	// 	AllowCredentials: true,
-	// 	AllowOrigins:     beego.AppConfig.Strings("Allowip"),
-	// }))
+	// the preflight requests end in the CORS filter, before any other
+	beego.InsertFilter("*", beego.BeforeRouter, server.CORS)
+	// the leader runs the mutating operations, keeps the jobs and monitors
+	// the nodes, the other replicas forward them before serving anything
+	for _, pattern := range append(auditedRoutes, "/jobs", "/jobs/*", "/health") {
+		beego.InsertFilter(pattern, beego.BeforeRouter, ha.Forward)
+	}
+	beego.InsertFilter("*", beego.BeforeRouter, logging.StartRequest)
+	beego.InsertFilter("*", beego.BeforeRouter, metrics.StartRequest)
+	beego.InsertFilter("*", beego.FinishRouter, metrics.ObserveRequest, false)
+	for _, pattern := range auditedRoutes {
+		beego.InsertFilter(pattern, beego.BeforeRouter, server.StartOperation)
+		beego.InsertFilter(pattern, beego.BeforeRouter, audit.StartRequest)
+		beego.InsertFilter(pattern, beego.FinishRouter, audit.FinishRequest, false)
+		beego.InsertFilter(pattern, beego.FinishRouter, server.FinishOperation, false)
+	}
 
 	beego.Router("/", &controllers.MainController{})
 	beego.Router("/gencrypto", &controllers.ChannelController{}, "post:GenCrypto")
 	beego.Router("/gengenesisblock", &controllers.ChannelController{}, "post:GenGenesisBlock")
+	beego.Router("/gencompose", &controllers.DeployController{}, "post:GenCompose")
+	beego.Router("/genmanifests", &controllers.DeployController{}, "post:GenManifests")
+	beego.Router("/gennodeconfig", &controllers.DeployController{}, "post:GenNodeConfig")
+	beego.Router("/network/plan", &controllers.NetworkController{}, "post:Plan")
+	beego.Router("/network/apply", &controllers.NetworkController{}, "post:Apply")
+	beego.Router("/health", &controllers.HealthController{}, "get:Health")
+	beego.Router("/metrics", &controllers.MetricsController{}, "get:Metrics")
+	beego.Router("/ha", &controllers.HAController{}, "get:Status")
+	beego.Router("/openapi.json", &controllers.OpenAPIController{}, "get:Document")
+	beego.Router("/audit/query", &controllers.AuditController{}, "post:Query")
+	beego.Router("/audit/export", &controllers.AuditController{}, "get:Export")
+	beego.Router("/audit/verify", &controllers.AuditController{}, "get:Verify")
 	// beego.Router("/genchannelconfig", &controllers.ChannelController{}, "post:GenChannelConfig")
+	beego.Router("/orgs", &controllers.ChannelController{}, "get:Orgs")
+	beego.Router("/channel/list", &controllers.ChannelController{}, "post:Channels")
+	beego.Router("/channel/config", &controllers.ChannelController{}, "post:Config")
 	beego.Router("/channel/identity", &controllers.ChannelController{}, "post:Identity")
 	beego.Router("/channel/addorg", &controllers.ChannelController{}, "post:AddOrg")
 	beego.Router("/channel/deleteorg", &controllers.ChannelController{}, "post:DeleteOrg")
 	beego.Router("/channel/create", &controllers.ChannelController{}, "post:CreateChannel")
 	beego.Router("/channel/join", &controllers.ChannelController{}, "post:JoinChannel")
+	beego.Router("/channel/bootstrap", &controllers.ChannelController{}, "post:BootstrapPublicChain")
+	beego.Router("/channel/orginfo", &controllers.ChannelController{}, "post:UpdateChainOrgInfo")
+	beego.Router("/channel/removal/propose", &controllers.ChannelController{}, "post:ProposeRemoval")
+	beego.Router("/channel/removal/vote", &controllers.ChannelController{}, "post:VoteRemoval")
+	beego.Router("/channel/removal/get", &controllers.ChannelController{}, "post:GetRemoval")
+	beego.Router("/channel/removal/list", &controllers.ChannelController{}, "post:Removals")
+	beego.Router("/channel/removal/execute", &controllers.ChannelController{}, "post:ExecuteRemoval")
+
+	beego.Router("/public/orginfo", &controllers.PublicController{}, "post:QueryOrgInfo")
+	beego.Router("/public/orgname", &controllers.PublicController{}, "post:QueryOrgname")
+	beego.Router("/public/invitation", &controllers.PublicController{}, "post:QueryInvitation")
 
 	beego.Router("/chaincode/install", &controllers.ChaincodeController{}, "post:InstallChaincode")
 	beego.Router("/chaincode/instantiate", &controllers.ChaincodeController{}, "post:InstantiateChaincode")
-	beego.Router("/chaincode/invoke ", &controllers.ChaincodeController{}, "post:Invoke")
+	beego.Router("/chaincode/invoke", &controllers.ChaincodeController{}, "post:Invoke")
+	beego.Router("/chaincode/list", &controllers.ChaincodeController{}, "post:Chaincodes")
+
+	beego.Router("/ledger/info", &controllers.LedgerController{}, "post:Info")
+	beego.Router("/ledger/block", &controllers.LedgerController{}, "post:Block")
+	beego.Router("/ledger/tx", &controllers.LedgerController{}, "post:Tx")
+
+	beego.Router("/jobs", &controllers.JobsController{}, "get:List")
+	beego.Router("/jobs/:id", &controllers.JobsController{}, "get:Job")
 
 }
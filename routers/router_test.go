@@ -0,0 +1,111 @@
+package routers
+
+import (
+	"encoding/json"
+	"manageChain/openapi"
+	"manageChain/protocols"
+	"manageChain/server"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/astaxie/beego"
+)
+
+// TestRoutesDocumented checks that every route is in the OpenAPI document
+func TestRoutesDocumented(t *testing.T) {
+	for method, routes := range beego.PrintTree()["Data"].(map[string]interface{}) {
+		for _, route := range *routes.(*[][]string) {
+			// a controller routed without methods, /, is listed under every
+			// method but only serves GET
+			if method != "GET" && !strings.Contains(route[1], method+":") {
+				continue
+			}
+			if openapi.Get().Operation(method, route[0]) == nil {
+				t.Errorf("%s %s is not documented", method, route[0])
+			}
+		}
+	}
+}
+
+func TestInvalidRequest(t *testing.T) {
+	// as copyrequestbody of app.conf
+	beego.BConfig.CopyRequestBody = true
+	r, _ := http.NewRequest("POST", "/channel/identity", strings.NewReader(`{"Orgs": []}`))
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+	if w.Code != 400 {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+	}
+	msg := &protocols.ErrorMessage{}
+	if err := json.Unmarshal(w.Body.Bytes(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Code != protocols.CodeInvalidRequest || len(msg.Fields) != 1 || msg.Fields[0].Field != "Orgs" {
+		t.Fatalf("unexpected error %+v", msg)
+	}
+
+	r, _ = http.NewRequest("GET", "/openapi.json", nil)
+	w = httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+	doc := &openapi.Document{}
+	if err := json.Unmarshal(w.Body.Bytes(), doc); err != nil || w.Code != 200 {
+		t.Fatalf("unexpected document %d: %s", w.Code, w.Body.String())
+	}
+	if doc.Operation("POST", "/channel/create") == nil {
+		t.Fatal("/channel/create is not documented")
+	}
+}
+
+func TestConsole(t *testing.T) {
+	beego.BConfig.WebConfig.ViewsPath = "../views"
+	if err := beego.AddViewPath(beego.BConfig.WebConfig.ViewsPath); err != nil {
+		t.Fatal(err)
+	}
+	beego.SetStaticPath("/static", "../static")
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+	if w.Code != 200 || !strings.Contains(w.Body.String(), `<script src="/static/js/console.js">`) {
+		t.Fatalf("unexpected console %d: %s", w.Code, w.Body.String())
+	}
+	for _, asset := range []string{"/static/js/console.js", "/static/css/console.css"} {
+		r, _ = http.NewRequest("GET", asset, nil)
+		w = httptest.NewRecorder()
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		if w.Code != 200 {
+			t.Fatalf("%s is not served: %d", asset, w.Code)
+		}
+	}
+}
+
+func TestCORS(t *testing.T) {
+	beego.AppConfig.Set("CORSAllowOrigins", "https://console.example.com")
+	defer func() {
+		beego.AppConfig.Set("CORSAllowOrigins", "")
+		server.Setup()
+	}()
+	if err := server.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the preflight request of a route ends before the route
+	r, _ := http.NewRequest("OPTIONS", "/channel/create", nil)
+	r.Header.Set("Origin", "https://console.example.com")
+	r.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+	if w.Code != 204 || w.Header().Get("Access-Control-Allow-Origin") != "https://console.example.com" {
+		t.Fatalf("unexpected preflight response %d %v", w.Code, w.Header())
+	}
+
+	r, _ = http.NewRequest("GET", "/openapi.json", nil)
+	r.Header.Set("Origin", "https://console.example.com")
+	w = httptest.NewRecorder()
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+	if w.Code != 200 || w.Header().Get("Access-Control-Expose-Headers") != "X-Request-ID" || w.Header().Get("X-Request-ID") == "" {
+		t.Fatalf("unexpected response %d %v", w.Code, w.Header())
+	}
+}
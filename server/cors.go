@@ -0,0 +1,118 @@
+package server
+
+import (
+	"manageChain/logging"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+)
+
+// CORSPolicy tells the browsers which other origins may call the REST API
+type CORSPolicy struct {
+	// AllowOrigins are the allowed origins, * for all of them, none when empty
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	// MaxAge is the seconds the browsers may cache a preflight response
+	MaxAge int
+}
+
+// defaults of the CORS keys of app.conf
+var (
+	defaultAllowMethods  = []string{"GET", "POST"}
+	defaultAllowHeaders  = []string{"Content-Type", "Authorization", logging.RequestIDHeader}
+	defaultExposeHeaders = []string{logging.RequestIDHeader}
+)
+
+// loadCORS reads CORSAllowOrigins, CORSAllowMethods, CORSAllowHeaders,
+// CORSExposeHeaders, CORSAllowCredentials and CORSMaxAge of app.conf.
+func loadCORS() (*CORSPolicy, error) {
+	p := &CORSPolicy{
+		AllowOrigins:  fields(beego.AppConfig.String("CORSAllowOrigins"), nil),
+		AllowMethods:  fields(beego.AppConfig.String("CORSAllowMethods"), defaultAllowMethods),
+		AllowHeaders:  fields(beego.AppConfig.String("CORSAllowHeaders"), defaultAllowHeaders),
+		ExposeHeaders: fields(beego.AppConfig.String("CORSExposeHeaders"), defaultExposeHeaders),
+	}
+	var err error
+	if p.AllowCredentials, err = beego.AppConfig.Bool("CORSAllowCredentials"); err != nil && beego.AppConfig.String("CORSAllowCredentials") != "" {
+		return nil, err
+	}
+	if p.MaxAge, err = beego.AppConfig.Int("CORSMaxAge"); err != nil && beego.AppConfig.String("CORSMaxAge") != "" {
+		return nil, err
+	}
+	return p, nil
+}
+
+// fields splits a comma separated list, def when it is empty
+func fields(s string, def []string) []string {
+	var ret []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			ret = append(ret, f)
+		}
+	}
+	if len(ret) == 0 {
+		return def
+	}
+	return ret
+}
+
+// allows tells whether origin may call the REST API
+func (p *CORSPolicy) allows(origin string) bool {
+	for _, o := range p.AllowOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS is a beego.BeforeRouter filter applying the CORS policy of app.conf
+// to the requests of other origins, it answers their preflight requests.
+func CORS(ctx *context.Context) {
+	Current().CORS.apply(ctx)
+}
+
+func (p *CORSPolicy) apply(ctx *context.Context) {
+	origin := ctx.Input.Header("Origin")
+	if origin == "" {
+		return
+	}
+	ctx.Output.Header("Vary", "Origin")
+	preflight := ctx.Input.Method() == http.MethodOptions && ctx.Input.Header("Access-Control-Request-Method") != ""
+	if !p.allows(origin) {
+		if preflight {
+			ctx.Output.SetStatus(http.StatusForbidden)
+			ctx.Output.Body(nil)
+		}
+		return
+	}
+
+	// with credentials the browsers need the origin itself, not *
+	if p.AllowCredentials || !p.allows("*") {
+		ctx.Output.Header("Access-Control-Allow-Origin", origin)
+	} else {
+		ctx.Output.Header("Access-Control-Allow-Origin", "*")
+	}
+	if p.AllowCredentials {
+		ctx.Output.Header("Access-Control-Allow-Credentials", "true")
+	}
+	if !preflight {
+		if len(p.ExposeHeaders) != 0 {
+			ctx.Output.Header("Access-Control-Expose-Headers", strings.Join(p.ExposeHeaders, ", "))
+		}
+		return
+	}
+	ctx.Output.Header("Access-Control-Allow-Methods", strings.Join(p.AllowMethods, ", "))
+	ctx.Output.Header("Access-Control-Allow-Headers", strings.Join(p.AllowHeaders, ", "))
+	if p.MaxAge > 0 {
+		ctx.Output.Header("Access-Control-Max-Age", strconv.Itoa(p.MaxAge))
+	}
+	ctx.Output.SetStatus(http.StatusNoContent)
+	ctx.Output.Body(nil)
+}
@@ -0,0 +1,223 @@
+// Package server runs the listeners of the REST API: HTTPS with optional
+// client certificate authentication, the CORS policy of the browsers, the
+// reload of app.conf and of the certificates on SIGHUP, the requests and
+// jobs in flight going on with the configuration they started with, and the
+// graceful shutdown draining them on SIGINT or SIGTERM.
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"manageChain/logging"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/astaxie/beego"
+)
+
+var logger = logging.GetLogger("server")
+
+// client authentications of HTTPSClientAuth
+const (
+	// ClientAuthNone does not ask the clients for a certificate
+	ClientAuthNone = "none"
+	// ClientAuthRequest verifies the certificate of the clients presenting one
+	ClientAuthRequest = "request"
+	// ClientAuthRequire refuses the clients without a valid certificate
+	ClientAuthRequire = "require"
+)
+
+// Config is the configuration of the listeners reloaded on SIGHUP
+type Config struct {
+	// TLS is the configuration of the HTTPS listener, nil when disabled
+	TLS  *tls.Config
+	CORS *CORSPolicy
+}
+
+var (
+	lock    sync.RWMutex
+	current = &Config{CORS: &CORSPolicy{}}
+)
+
+// Current returns the configuration of the listeners
+func Current() *Config {
+	lock.RLock()
+	defer lock.RUnlock()
+	return current
+}
+
+// Setup loads the configuration of the listeners from app.conf, the one in
+// use being kept when it is not valid.
+func Setup() error {
+	conf, err := Load()
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	current = conf
+	lock.Unlock()
+	return nil
+}
+
+// Load reads the configuration of the listeners from app.conf: the
+// certificate of HTTPSCertFile and HTTPSKeyFile when EnableHTTPS, the client
+// CAs of HTTPSClientCAFile with HTTPSClientAuth, and the CORS keys.
+func Load() (*Config, error) {
+	cors, err := loadCORS()
+	if err != nil {
+		return nil, err
+	}
+	conf := &Config{CORS: cors}
+	if !beego.BConfig.Listen.EnableHTTPS {
+		return conf, nil
+	}
+	if conf.TLS, err = loadTLS(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func loadTLS() (*tls.Config, error) {
+	certFile, keyFile := beego.BConfig.Listen.HTTPSCertFile, beego.BConfig.Listen.HTTPSKeyFile
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("EnableHTTPS needs HTTPSCertFile and HTTPSKeyFile")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the certificate of %s: %s", certFile, err)
+	}
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	auth := strings.ToLower(beego.AppConfig.DefaultString("HTTPSClientAuth", ClientAuthNone))
+	switch auth {
+	case ClientAuthNone:
+		return conf, nil
+	case ClientAuthRequest:
+		conf.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("unknown HTTPSClientAuth %s, none, request or require", auth)
+	}
+	caFile := beego.AppConfig.String("HTTPSClientCAFile")
+	if caFile == "" {
+		return nil, fmt.Errorf("HTTPSClientAuth %s needs HTTPSClientCAFile", auth)
+	}
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	conf.ClientCAs = x509.NewCertPool()
+	if !conf.ClientCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in %s", caFile)
+	}
+	return conf, nil
+}
+
+// tlsConfig is the configuration of the HTTPS listener, each handshake
+// taking the certificates and client CAs loaded last.
+func tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			conf := Current().TLS
+			if conf == nil {
+				return nil, errors.New("HTTPS is not configured")
+			}
+			return conf, nil
+		},
+	}
+}
+
+// Run serves the REST API until it fails or is shut down, HTTP on httpport
+// when EnableHTTP and HTTPS on HTTPSPort when EnableHTTPS.
+func Run() {
+	if err := Setup(); err != nil {
+		logger.Error("Error setting up the listeners: %s", err)
+		os.Exit(1)
+	}
+	if beego.BConfig.Listen.EnableHTTPS {
+		// the certificate files are only read by Setup, so that they are
+		// reloaded with it
+		beego.BeeApp.Server.TLSConfig = tlsConfig()
+	}
+	beego.Run()
+	// beego.Run returns once Shutdown stopped the listeners
+	if Draining() {
+		<-done
+		if shutdownErr != nil {
+			os.Exit(1)
+		}
+	}
+}
+
+// reloaders reload the configuration of the other packages on SIGHUP
+var (
+	reloadersLock sync.Mutex
+	reloaders     []func() error
+)
+
+// OnReload adds f to the functions called once app.conf is reloaded
+func OnReload(f func() error) {
+	reloadersLock.Lock()
+	defer reloadersLock.Unlock()
+	reloaders = append(reloaders, f)
+}
+
+// ConfigPath is the app.conf beego loaded at start
+func ConfigPath() string {
+	path := filepath.Join("conf", "app.conf")
+	if _, err := os.Stat(path); err != nil {
+		path = filepath.Join(beego.AppPath, "conf", "app.conf")
+	}
+	return path
+}
+
+// Reload reloads app.conf, the configuration of the listeners and of the
+// packages registered with OnReload. The addresses and ports the listeners
+// are bound to are only changed by a restart.
+func Reload() error {
+	if err := beego.LoadAppConfig("ini", ConfigPath()); err != nil {
+		return err
+	}
+	if err := Setup(); err != nil {
+		return err
+	}
+	reloadersLock.Lock()
+	defer reloadersLock.Unlock()
+	var errs []string
+	for _, f := range reloaders {
+		if err := f(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) != 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// WatchReload reloads on every SIGHUP
+func WatchReload() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for range signals {
+			if err := Reload(); err != nil {
+				logger.Error("Error reloading %s: %s", ConfigPath(), err)
+				continue
+			}
+			logger.Info("Reloaded %s", ConfigPath())
+		}
+	}()
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+)
+
+// writeCert writes a self-signed certificate of cn and its key under dir
+func writeCert(t *testing.T, dir string, cn string) (certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile = filepath.Join(dir, cn+".crt"), filepath.Join(dir, cn+".key")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// configure sets the HTTPS keys of app.conf, restored when the test ends
+func configure(t *testing.T, certFile string, keyFile string, clientAuth string, clientCAFile string) {
+	listen := beego.BConfig.Listen
+	t.Cleanup(func() {
+		beego.BConfig.Listen = listen
+		beego.AppConfig.Set("HTTPSClientAuth", "")
+		beego.AppConfig.Set("HTTPSClientCAFile", "")
+	})
+	beego.BConfig.Listen.EnableHTTPS = true
+	beego.BConfig.Listen.HTTPSCertFile = certFile
+	beego.BConfig.Listen.HTTPSKeyFile = keyFile
+	beego.AppConfig.Set("HTTPSClientAuth", clientAuth)
+	beego.AppConfig.Set("HTTPSClientCAFile", clientCAFile)
+}
+
+func TestReloadCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCert(t, dir, "first")
+	clientCert, clientKey := writeCert(t, dir, "admin")
+	configure(t, certFile, keyFile, ClientAuthRequire, clientCert)
+	if err := Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	s.TLS = tlsConfig()
+	s.StartTLS()
+	defer s.Close()
+
+	pair, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := func(certificates []tls.Certificate) (string, error) {
+		var cn string
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			Certificates:       certificates,
+			VerifyConnection: func(cs tls.ConnectionState) error {
+				cn = cs.PeerCertificates[0].Subject.CommonName
+				return nil
+			},
+		}}}
+		resp, err := client.Get(s.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != "admin" {
+			t.Fatalf("unexpected client %s", body)
+		}
+		return cn, nil
+	}
+
+	if cn, err := served([]tls.Certificate{pair}); err != nil || cn != "first" {
+		t.Fatalf("served %s: %v", cn, err)
+	}
+	// the clients without a certificate are refused
+	if _, err := served(nil); err == nil {
+		t.Fatal("a client without certificate is served")
+	}
+
+	// the connections after a reload get the new certificate
+	certFile, keyFile = writeCert(t, dir, "second")
+	configure(t, certFile, keyFile, ClientAuthRequire, clientCert)
+	if err := Setup(); err != nil {
+		t.Fatal(err)
+	}
+	if cn, err := served([]tls.Certificate{pair}); err != nil || cn != "second" {
+		t.Fatalf("served %s: %v", cn, err)
+	}
+
+	// an invalid configuration leaves the one in use
+	configure(t, filepath.Join(dir, "missing.crt"), keyFile, ClientAuthRequire, clientCert)
+	if err := Setup(); err == nil {
+		t.Fatal("a missing certificate is loaded")
+	}
+	configure(t, certFile, keyFile, ClientAuthRequire, "")
+	if err := Setup(); err == nil {
+		t.Fatal("client authentication is loaded without client CAs")
+	}
+	if cn, err := served([]tls.Certificate{pair}); err != nil || cn != "second" {
+		t.Fatalf("served %s: %v", cn, err)
+	}
+}
+
+func preflight(p *CORSPolicy, origin string) *httptest.ResponseRecorder {
+	r, _ := http.NewRequest("OPTIONS", "/channel/create", nil)
+	r.Header.Set("Origin", origin)
+	r.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	ctx := context.NewContext()
+	ctx.Reset(w, r)
+	p.apply(ctx)
+	return w
+}
+
+func TestCORS(t *testing.T) {
+	for key, value := range map[string]string{
+		"CORSAllowOrigins":     "https://console.example.com, https://ops.example.com",
+		"CORSAllowCredentials": "true",
+		"CORSMaxAge":           "600",
+	} {
+		beego.AppConfig.Set(key, value)
+		defer beego.AppConfig.Set(key, "")
+	}
+	p, err := loadCORS()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := preflight(p, "https://ops.example.com")
+	if w.Code != http.StatusNoContent ||
+		w.Header().Get("Access-Control-Allow-Origin") != "https://ops.example.com" ||
+		w.Header().Get("Access-Control-Allow-Credentials") != "true" ||
+		w.Header().Get("Access-Control-Allow-Methods") != "GET, POST" ||
+		w.Header().Get("Access-Control-Max-Age") != "600" {
+		t.Fatalf("unexpected preflight response %d %v", w.Code, w.Header())
+	}
+	if w := preflight(p, "https://evil.example.com"); w.Code != http.StatusForbidden || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("unexpected preflight response %d %v", w.Code, w.Header())
+	}
+
+	// the other origins are not allowed by default
+	beego.AppConfig.Set("CORSAllowOrigins", "")
+	if p, err = loadCORS(); err != nil || len(p.AllowOrigins) != 0 {
+		t.Fatalf("unexpected policy %+v: %v", p, err)
+	}
+	beego.AppConfig.Set("CORSMaxAge", "ten minutes")
+	if _, err := loadCORS(); err == nil {
+		t.Fatal("an invalid CORSMaxAge is loaded")
+	}
+}
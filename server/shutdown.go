@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"manageChain/jobs"
+	"manageChain/protocols"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/astaxie/beego"
+	beecontext "github.com/astaxie/beego/context"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+const (
+	defaultShutdownTimeout = time.Minute
+	// abortGrace is the wait for the operations to fail once aborted, and
+	// for the reads in progress once the operations are drained
+	abortGrace = 5 * time.Second
+)
+
+// ShutdownTimeout is the time the operations in progress are given to
+// finish on shutdown, ShutdownTimeout of app.conf, a minute by default.
+func ShutdownTimeout() time.Duration {
+	if d, err := time.ParseDuration(beego.AppConfig.String("ShutdownTimeout")); err == nil && d > 0 {
+		return d
+	}
+	return defaultShutdownTimeout
+}
+
+var (
+	draining int32
+
+	// operations is the number of mutating requests in progress, idle is
+	// closed when it is 0
+	operationsLock sync.Mutex
+	operations     int
+	idle           = closedChan()
+
+	// done is closed once the shutdown is over, with its error
+	done        = make(chan struct{})
+	shutdownErr error
+)
+
+func closedChan() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}
+
+// Draining tells whether the server is shutting down
+func Draining() bool {
+	return atomic.LoadInt32(&draining) == 1
+}
+
+const operationKey = "serverOperation"
+
+// StartOperation is a beego.BeforeRouter filter of the mutating routes, it
+// refuses them once the server is shutting down and counts the others until
+// FinishOperation.
+func StartOperation(ctx *beecontext.Context) {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+	if Draining() {
+		ctx.Output.SetStatus(503)
+		ctx.Output.JSON(&protocols.ErrorMessage{Code: protocols.CodeUnavailable, Message: "the server is shutting down"}, false, false)
+		return
+	}
+	if operations == 0 {
+		idle = make(chan struct{})
+	}
+	operations++
+	ctx.Input.SetData(operationKey, true)
+}
+
+// FinishOperation is a beego.FinishRouter filter ending the count of a
+// mutating request, a job it started is waited for by jobs.Wait.
+func FinishOperation(ctx *beecontext.Context) {
+	if started, _ := ctx.Input.GetData(operationKey).(bool); !started {
+		return
+	}
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+	if operations--; operations == 0 {
+		close(idle)
+	}
+}
+
+// drain waits for the mutating requests and the jobs in progress, the
+// broadcasts and the waits for transactions they make, until ctx is done.
+func drain(ctx context.Context) error {
+	operationsLock.Lock()
+	c := idle
+	operationsLock.Unlock()
+	select {
+	case <-c:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return jobs.Wait(ctx)
+}
+
+var (
+	shutdownersLock sync.Mutex
+	shutdowners     []func() error
+)
+
+// OnShutdown adds f to the functions called once the operations are drained,
+// in the order they are added, to stop the other packages.
+func OnShutdown(f func() error) {
+	shutdownersLock.Lock()
+	defer shutdownersLock.Unlock()
+	shutdowners = append(shutdowners, f)
+}
+
+// Shutdown refuses the mutating requests from now on and waits up to timeout
+// for the ones in progress and the jobs, aborting them after it. It then
+// stops the listeners, calls the functions of OnShutdown and closes the
+// connections to the nodes. It returns an error when it had to abort.
+func Shutdown(timeout time.Duration) error {
+	operationsLock.Lock()
+	atomic.StoreInt32(&draining, 1)
+	operationsLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	logger.Info("Draining the operations in progress for up to %s", timeout)
+	var errs []string
+	if err := drain(ctx); err != nil {
+		logger.Warning("Aborting the operations still in progress after %s", timeout)
+		errs = append(errs, "operations aborted after "+timeout.String())
+		jobs.Cancel()
+	}
+
+	// the aborted requests fail with their connection
+	grace, cancelGrace := context.WithTimeout(context.Background(), abortGrace)
+	defer cancelGrace()
+	if err := beego.BeeApp.Server.Shutdown(grace); err != nil {
+		beego.BeeApp.Server.Close()
+	}
+	jobs.Wait(grace)
+
+	shutdownersLock.Lock()
+	for _, f := range shutdowners {
+		if err := f(); err != nil {
+			logger.Error("Error shutting down: %s", err)
+			errs = append(errs, err.Error())
+		}
+	}
+	shutdownersLock.Unlock()
+
+	iterators, conns := sdk.CloseAll()
+	logger.Info("Shut down, closed %d block iterators and %d connections", iterators, conns)
+	if len(errs) != 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// WatchShutdown shuts down with ShutdownTimeout on SIGINT or SIGTERM, a
+// second one exits at once.
+func WatchShutdown() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		logger.Info("Shutting down on %s", sig)
+		go func() {
+			sig := <-signals
+			logger.Error("Exiting on %s without waiting for the shutdown", sig)
+			os.Exit(1)
+		}()
+		shutdownErr = Shutdown(ShutdownTimeout())
+		close(done)
+	}()
+}
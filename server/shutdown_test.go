@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"manageChain/jobs"
+	"manageChain/protocols"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	beecontext "github.com/astaxie/beego/context"
+)
+
+func newContext(method string, path string) (*beecontext.Context, *httptest.ResponseRecorder) {
+	r, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	ctx := beecontext.NewContext()
+	ctx.Reset(w, r)
+	return ctx, w
+}
+
+func TestShutdown(t *testing.T) {
+	// a mutating request in progress is drained
+	ctx, _ := newContext("POST", "/channel/addorg")
+	StartOperation(ctx)
+	timeout, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := drain(timeout); err != context.DeadlineExceeded {
+		t.Fatalf("draining a request in progress: %v", err)
+	}
+	FinishOperation(ctx)
+	if err := drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a job going past the timeout is aborted
+	job := jobs.Submit("/channel/addorg", "", func() (interface{}, *protocols.ErrorMessage) {
+		<-jobs.Context().Done()
+		return nil, &protocols.ErrorMessage{Code: protocols.CodeUnavailable, Message: jobs.Context().Err().Error()}
+	})
+	var stopped bool
+	OnShutdown(func() error {
+		stopped = true
+		return nil
+	})
+	if err := Shutdown(20 * time.Millisecond); err == nil {
+		t.Fatal("the shutdown did not abort the job")
+	}
+	if j := jobs.Get(job.ID); j.Status != jobs.Failed || !stopped {
+		t.Fatalf("unexpected job %+v after the shutdown", j)
+	}
+
+	// the mutating requests are refused from then on
+	ctx, w := newContext("POST", "/channel/create")
+	StartOperation(ctx)
+	if w.Code != 503 || !Draining() {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	FinishOperation(ctx)
+}
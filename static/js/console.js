@@ -0,0 +1,594 @@
+// The management console: a page per tab, each one reading the REST API of
+// the server with the orgs of the workspace, kept in the local storage.
+(function () {
+  "use strict";
+
+  var workspaceKey = "manageChain.orgs";
+  var pollInterval = 3000;
+  var current = "";
+  var pollTimer = null;
+
+  function $(id) {
+    return document.getElementById(id);
+  }
+
+  // el creates an element with attributes and children, strings becoming text
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (name) {
+      if (name === "onclick") {
+        node.addEventListener("click", attrs[name]);
+      } else {
+        node.setAttribute(name, attrs[name]);
+      }
+    });
+    [].concat(children === undefined ? [] : children).forEach(function (child) {
+      if (child === null || child === undefined) {
+        return;
+      }
+      node.appendChild(typeof child === "object" ? child : document.createTextNode(String(child)));
+    });
+    return node;
+  }
+
+  function link(text, onclick) {
+    return el("a", { "class": "link", onclick: onclick }, text);
+  }
+
+  function show(id, node) {
+    var view = $(id);
+    view.innerHTML = "";
+    view.appendChild(node);
+  }
+
+  // table renders rows with columns of a title and the cell of a row
+  function table(columns, rows, empty) {
+    if (!rows || rows.length === 0) {
+      return el("p", {}, empty || "nothing to show");
+    }
+    var head = el("tr", {}, columns.map(function (col) {
+      return el("th", {}, col.title);
+    }));
+    var body = rows.map(function (row) {
+      return el("tr", {}, columns.map(function (col) {
+        return el("td", col.mono ? { "class": "mono" } : {}, col.cell(row));
+      }));
+    });
+    return el("table", {}, [el("thead", {}, head), el("tbody", {}, body)]);
+  }
+
+  function time(value) {
+    if (!value) {
+      return "";
+    }
+    var date = typeof value === "number" ? new Date(value * 1000) : new Date(value);
+    return isNaN(date.getTime()) || date.getFullYear() <= 1 ? "" : date.toLocaleString();
+  }
+
+  // expiry renders the expiry of a certificate, warning when it is within days
+  function expiry(value, days) {
+    var date = typeof value === "number" ? new Date(value * 1000) : new Date(value);
+    var left = (date.getTime() - Date.now()) / 86400000;
+    var cls = left < 0 ? "bad" : left < days ? "warn" : "ok";
+    return el("span", { "class": cls }, date.toLocaleDateString() + (left < 0 ? " (expired)" : " (" + Math.floor(left) + " days)"));
+  }
+
+  function status(message, error) {
+    var bar = $("status");
+    bar.textContent = message || "";
+    bar.className = error ? "error" : "";
+  }
+
+  // api sends a request and resolves to the decoded response, rejecting with
+  // the ErrorMessage of a failed one unless its status is accepted
+  function api(method, path, body, accept) {
+    var init = { method: method, headers: {} };
+    if (body !== undefined) {
+      init.headers["Content-Type"] = "application/json";
+      init.body = JSON.stringify(body);
+    }
+    return fetch(path, init).then(function (resp) {
+      return resp.text().then(function (text) {
+        var data = text ? JSON.parse(text) : null;
+        if (resp.ok || (accept || []).indexOf(resp.status) >= 0) {
+          return data;
+        }
+        var msg = data && data.message ? data.code + ": " + data.message : resp.status + " " + resp.statusText;
+        throw new Error(msg);
+      });
+    });
+  }
+
+  function fail(err) {
+    status(err.message, true);
+  }
+
+  function orgs() {
+    try {
+      var value = JSON.parse(localStorage.getItem(workspaceKey) || "[]");
+      return Array.isArray(value) ? value : [];
+    } catch (e) {
+      return [];
+    }
+  }
+
+  // withOrgs calls f with the orgs of the workspace, asking for them when there are none
+  function withOrgs(f) {
+    var list = orgs();
+    if (list.length === 0) {
+      status("Set the orgs of the workspace first", true);
+      location.hash = "#workspace";
+      return Promise.resolve();
+    }
+    return f(list);
+  }
+
+  function selectedChannel(id) {
+    var name = $(id).value;
+    if (!name) {
+      status("Load the channels first", true);
+    }
+    return name;
+  }
+
+  // jobStarted reports a job and switches to the jobs once it is submitted
+  function jobStarted(job) {
+    status("Started job " + job.id + " of " + job.operation);
+    location.hash = "#jobs";
+  }
+
+  // topology shows the monitored nodes by org, the nodes of the workspace not
+  // monitored included
+  function topology() {
+    return api("GET", "/health", undefined, [503]).then(function (health) {
+      var nodes = (health && health.nodes) || [];
+      var known = {};
+      nodes.forEach(function (n) {
+        known[n.endpoint] = true;
+      });
+      orgs().forEach(function (org) {
+        [["peer", org.PeerNodes], ["orderer", org.OrdererNodes]].forEach(function (pair) {
+          (pair[1] || []).forEach(function (n) {
+            if (!known[n.Endpoint]) {
+              nodes.push({ org: org.OrgName, id: n.ID, type: pair[0], endpoint: n.Endpoint, unmonitored: true });
+            }
+          });
+        });
+      });
+      $("health").textContent = health && health.checkedAt
+        ? (health.healthy ? "healthy" : "unhealthy") + ", checked at " + time(health.checkedAt)
+        : "no nodes are monitored";
+      $("health").className = health && health.healthy ? "ok" : "bad";
+
+      var byOrg = {};
+      nodes.forEach(function (n) {
+        (byOrg[n.org] = byOrg[n.org] || []).push(n);
+      });
+      var view = el("div", {}, Object.keys(byOrg).sort().map(function (org) {
+        return el("div", { "class": "org" }, [
+          el("h2", {}, org),
+          table([
+            { title: "Type", cell: function (n) { return n.type; } },
+            { title: "ID", cell: function (n) { return n.id; } },
+            { title: "Endpoint", cell: function (n) { return n.endpoint; }, mono: true },
+            {
+              title: "State", cell: function (n) {
+                if (n.unmonitored) {
+                  return el("span", { "class": "warn" }, "not monitored");
+                }
+                return el("span", { "class": n.reachable ? "ok" : "bad" }, n.reachable ? "reachable" : "unreachable");
+              }
+            },
+            { title: "TLS certificate", cell: function (n) { return n.certNotAfter ? expiry(n.certNotAfter, 30) : ""; } },
+            {
+              title: "Heights", cell: function (n) {
+                return Object.keys(n.heights || {}).sort().map(function (ch) {
+                  return ch + ": " + n.heights[ch];
+                }).join(", ");
+              }
+            },
+            {
+              title: "Problems", cell: function (n) {
+                return el("div", {}, (n.problems || []).map(function (p) {
+                  return el("div", { "class": "bad" }, (p.channel ? p.channel + ": " : "") + p.message);
+                }));
+              }
+            }
+          ], byOrg[org])
+        ]);
+      }));
+      show("topology-view", nodes.length ? view : el("p", {}, "Set MonitorConfig on the server or the orgs of the workspace"));
+    });
+  }
+
+  function orgList() {
+    var days = parseInt($("orgs-days").value, 10) || 0;
+    return api("GET", "/orgs").then(function (list) {
+      show("orgs-view", el("div", {}, (list || []).map(function (org) {
+        return el("div", { "class": "org" }, [
+          el("h2", {}, [org.orgName + " ", expiry(org.notAfter, days)]),
+          table([
+            { title: "Kind", cell: function (c) { return c.kind; } },
+            { title: "Name", cell: function (c) { return c.name; } },
+            { title: "Subject", cell: function (c) { return c.subject; }, mono: true },
+            { title: "Not before", cell: function (c) { return time(c.notBefore); } },
+            { title: "Not after", cell: function (c) { return expiry(c.notAfter, days); } }
+          ], org.certs)
+        ]);
+      })));
+    });
+  }
+
+  // fillChannels sets the channels of the selects, keeping their selection
+  function fillChannels(list) {
+    [].forEach.call(document.querySelectorAll(".channel-select"), function (select) {
+      var selected = select.value;
+      select.innerHTML = "";
+      list.forEach(function (ch) {
+        select.appendChild(el("option", { value: ch.channelName }, ch.channelName));
+      });
+      if (selected) {
+        select.value = selected;
+      }
+    });
+  }
+
+  function loadChannels() {
+    return withOrgs(function (list) {
+      return api("POST", "/channel/list", { Orgs: list }).then(function (channels) {
+        channels = channels || [];
+        fillChannels(channels);
+        return channels;
+      });
+    });
+  }
+
+  function channels() {
+    return loadChannels().then(function (list) {
+      if (!list) {
+        return;
+      }
+      show("channels-view", table([
+        { title: "Channel", cell: function (ch) { return ch.channelName; } },
+        { title: "Height", cell: function (ch) { return ch.height; } },
+        { title: "Members", cell: function (ch) { return ch.members.join(", "); } },
+        { title: "Read through", cell: function (ch) { return ch.peer; }, mono: true },
+        {
+          title: "", cell: function (ch) {
+            return el("span", {}, [
+              link("config", function () { config(ch.channelName); }), " ",
+              link("blocks", function () {
+                $("explorer-channel").value = ch.channelName;
+                location.hash = "#explorer";
+              })
+            ]);
+          }
+        }
+      ], list, "the peers have joined no channels"));
+    });
+  }
+
+  // tree renders a config group as nested details, the values as JSON
+  function tree(name, group, open) {
+    var children = [];
+    Object.keys(group.values).sort().forEach(function (key) {
+      var value = group.values[key];
+      children.push(el("details", {}, [
+        el("summary", {}, "value " + key + " (version " + value.version + ", mod policy " + value.modPolicy + ")"),
+        el("pre", {}, JSON.stringify(value.value, null, 2))
+      ]));
+    });
+    Object.keys(group.policies).sort().forEach(function (key) {
+      var policy = group.policies[key];
+      children.push(el("div", {}, "policy " + key + ": " + policy.type + " " + policy.rule));
+    });
+    Object.keys(group.groups).sort().forEach(function (key) {
+      children.push(tree(key, group.groups[key], false));
+    });
+    var attrs = open ? { open: "" } : {};
+    return el("details", attrs, [
+      el("summary", {}, name + " (version " + group.version + ", mod policy " + group.modPolicy + ")")
+    ].concat(children));
+  }
+
+  function config(channelName) {
+    return withOrgs(function (list) {
+      return api("POST", "/channel/config", { Orgs: list, ChannelName: channelName }).then(function (group) {
+        $("config-title").textContent = "Config of " + channelName;
+        show("config-view", tree("Channel", group, true));
+      });
+    }).catch(fail);
+  }
+
+  function vote(channelName, target, accept) {
+    return withOrgs(function (list) {
+      var body = { Orgs: list, ChannelName: channelName, Target: target, Accept: accept };
+      return api("POST", "/channel/removal/vote?async=true", body).then(jobStarted);
+    }).catch(fail);
+  }
+
+  function execute(channelName, target) {
+    return withOrgs(function (list) {
+      var body = { Orgs: list, ChannelName: channelName, Target: target };
+      return api("POST", "/channel/removal/execute?async=true", body).then(jobStarted);
+    }).catch(fail);
+  }
+
+  function governance() {
+    var channelName = $("governance-channel").value;
+    if (!channelName) {
+      return loadChannels().then(function (list) {
+        if (list && list.length) {
+          return governance();
+        }
+      });
+    }
+    var all = $("governance-all").checked;
+    return withOrgs(function (list) {
+      return api("POST", "/channel/removal/list", { Orgs: list, ChannelName: channelName }).then(function (proposals) {
+        proposals = (proposals || []).filter(function (p) {
+          return all || p.status === "init" || p.status === "approved";
+        });
+        show("governance-view", table([
+          { title: "Remove", cell: function (p) { return p.target; } },
+          { title: "Proposer", cell: function (p) { return p.proposer; } },
+          { title: "Reason", cell: function (p) { return p.reason; } },
+          { title: "Proposed", cell: function (p) { return time(p.proposeTime); } },
+          { title: "Status", cell: function (p) { return p.status; } },
+          {
+            title: "Votes", cell: function (p) {
+              return (p.votes || []).length + " of " + p.threshold + " needed: " + (p.votes || []).map(function (v) {
+                return v.signer + " " + v.accepted;
+              }).join(", ");
+            }
+          },
+          {
+            title: "", cell: function (p) {
+              if (p.status === "init") {
+                return el("span", {}, [
+                  el("button", { onclick: function () { vote(channelName, p.target, true); } }, "Approve"), " ",
+                  el("button", { onclick: function () { vote(channelName, p.target, false); } }, "Reject")
+                ]);
+              }
+              if (p.status === "approved") {
+                return el("button", { onclick: function () { execute(channelName, p.target); } }, "Execute");
+              }
+              return "";
+            }
+          }
+        ], proposals, "no removals are pending on " + channelName));
+      });
+    });
+  }
+
+  function chaincodeList(list) {
+    return (list || []).map(function (cc) {
+      return cc.Name + ":" + cc.Version;
+    }).join(", ");
+  }
+
+  function chaincodes() {
+    return withOrgs(function (list) {
+      return api("POST", "/chaincode/list", { Orgs: list }).then(function (inventory) {
+        show("chaincodes-view", table([
+          { title: "Org", cell: function (p) { return p.org; } },
+          { title: "Peer", cell: function (p) { return p.peer; }, mono: true },
+          { title: "Installed", cell: function (p) { return chaincodeList(p.installed); } },
+          {
+            title: "Instantiated", cell: function (p) {
+              return el("div", {}, Object.keys(p.instantiated || {}).sort().map(function (ch) {
+                return el("div", {}, ch + ": " + chaincodeList(p.instantiated[ch]));
+              }));
+            }
+          },
+          { title: "Error", cell: function (p) { return el("span", { "class": "bad" }, p.error || ""); } }
+        ], inventory, "the orgs have no peers"));
+      });
+    });
+  }
+
+  function ledger(path, extra) {
+    var channelName = selectedChannel("explorer-channel");
+    if (!channelName) {
+      return loadChannels().then(function () {
+        return null;
+      });
+    }
+    return withOrgs(function (list) {
+      var body = { Orgs: list, ChannelName: channelName };
+      Object.keys(extra || {}).forEach(function (key) {
+        body[key] = extra[key];
+      });
+      return api("POST", path, body);
+    });
+  }
+
+  function txTable(txs) {
+    return table([
+      { title: "Transaction", cell: function (tx) { return link(tx.txId || "(none)", function () { transaction(tx.txId); }); }, mono: true },
+      { title: "Type", cell: function (tx) { return tx.type; } },
+      { title: "Time", cell: function (tx) { return time(tx.timestamp); } },
+      { title: "Creator", cell: function (tx) { return tx.creator; } },
+      { title: "Signers", cell: function (tx) { return (tx.signers || []).join(", "); } },
+      {
+        title: "Validation", cell: function (tx) {
+          return el("span", { "class": tx.validationCode === "VALID" ? "ok" : "bad" }, tx.validationCode);
+        }
+      }
+    ], txs, "the block has no transactions");
+  }
+
+  function block(number) {
+    return ledger("/ledger/block", { Number: number }).then(function (b) {
+      if (!b) {
+        return;
+      }
+      $("explorer-block").value = b.number;
+      show("explorer-view", el("div", {}, [
+        el("h2", {}, [
+          "Block " + b.number + " ",
+          b.number > 0 ? link("previous", function () { block(b.number - 1).catch(fail); }) : null, " ",
+          link("next", function () { block(b.number + 1).catch(fail); })
+        ]),
+        table([
+          { title: "Hash", cell: function (x) { return x.hash; }, mono: true },
+          { title: "Previous hash", cell: function (x) { return x.previousHash; }, mono: true },
+          { title: "Data hash", cell: function (x) { return x.dataHash; }, mono: true }
+        ], [b]),
+        txTable(b.txs)
+      ]));
+    });
+  }
+
+  function transaction(txID) {
+    if (!txID) {
+      return Promise.resolve();
+    }
+    return ledger("/ledger/tx", { TxID: txID }).then(function (tx) {
+      if (tx) {
+        $("explorer-tx").value = txID;
+        show("explorer-view", el("div", {}, [el("h2", {}, "Transaction"), txTable([tx])]));
+      }
+    }).catch(fail);
+  }
+
+  function explorer() {
+    if (!$("explorer-channel").value) {
+      return loadChannels().then(function (list) {
+        if (list && list.length) {
+          return explorer();
+        }
+      });
+    }
+    return ledger("/ledger/info").then(function (info) {
+      if (!info) {
+        return;
+      }
+      show("explorer-info", el("p", {}, "Height " + info.height + " on " + info.peer + ", current block " + info.currentBlockHash));
+      return block(null);
+    });
+  }
+
+  function jobList() {
+    var query = $("jobs-status").value ? "?status=" + $("jobs-status").value : "";
+    return api("GET", "/jobs" + query).then(function (list) {
+      show("jobs-view", table([
+        { title: "ID", cell: function (j) { return j.id; }, mono: true },
+        { title: "Operation", cell: function (j) { return j.operation; } },
+        {
+          title: "Status", cell: function (j) {
+            var cls = j.status === "succeeded" ? "ok" : j.status === "failed" ? "bad" : "warn";
+            return el("span", { "class": cls }, j.status);
+          }
+        },
+        { title: "Created", cell: function (j) { return time(j.created); } },
+        { title: "Finished", cell: function (j) { return time(j.finished); } },
+        {
+          title: "Outcome", cell: function (j) {
+            if (j.error) {
+              return el("span", { "class": "bad" }, j.error.code + ": " + j.error.message);
+            }
+            return j.result === undefined ? "" : el("pre", {}, JSON.stringify(j.result, null, 2));
+          }
+        },
+        { title: "Request", cell: function (j) { return j.requestId; }, mono: true }
+      ], list, "no jobs"));
+    });
+  }
+
+  function workspace() {
+    $("workspace-orgs").value = JSON.stringify(orgs(), null, 2);
+    return Promise.resolve();
+  }
+
+  function save() {
+    var value;
+    try {
+      value = JSON.parse($("workspace-orgs").value || "[]");
+    } catch (e) {
+      status("The orgs are not valid JSON: " + e.message, true);
+      return Promise.resolve();
+    }
+    if (!Array.isArray(value)) {
+      status("The orgs must be an array of OrgInfo", true);
+      return Promise.resolve();
+    }
+    localStorage.setItem(workspaceKey, JSON.stringify(value));
+    status("Saved " + value.length + " orgs");
+    return Promise.resolve();
+  }
+
+  var pages = {
+    topology: topology,
+    orgs: orgList,
+    channels: channels,
+    governance: governance,
+    chaincodes: chaincodes,
+    explorer: explorer,
+    jobs: jobList,
+    workspace: workspace
+  };
+
+  var actions = {
+    topology: topology,
+    orgs: orgList,
+    channels: channels,
+    governance: governance,
+    chaincodes: chaincodes,
+    explorer: explorer,
+    jobs: jobList,
+    save: save,
+    block: function () {
+      var number = parseInt($("explorer-block").value, 10);
+      return isNaN(number) ? explorer() : block(number);
+    },
+    tx: function () {
+      return transaction($("explorer-tx").value.trim());
+    }
+  };
+
+  function run(f) {
+    status("Loading...");
+    return Promise.resolve().then(f).then(function () {
+      if ($("status").textContent === "Loading...") {
+        status("");
+      }
+    }).catch(fail);
+  }
+
+  function poll() {
+    clearInterval(pollTimer);
+    pollTimer = null;
+    if (current === "jobs" && $("jobs-poll").checked) {
+      pollTimer = setInterval(function () {
+        jobList().catch(fail);
+      }, pollInterval);
+    }
+  }
+
+  function navigate() {
+    var name = location.hash.replace("#", "");
+    if (!pages[name]) {
+      name = orgs().length ? "topology" : "workspace";
+    }
+    current = name;
+    [].forEach.call(document.querySelectorAll("main section"), function (section) {
+      section.className = section.id === name ? "active" : "";
+    });
+    [].forEach.call(document.querySelectorAll("#tabs a"), function (a) {
+      a.className = a.getAttribute("href") === "#" + name ? "active" : "";
+    });
+    poll();
+    run(pages[name]);
+  }
+
+  document.addEventListener("click", function (event) {
+    var action = event.target.getAttribute && event.target.getAttribute("data-action");
+    if (action && actions[action]) {
+      run(actions[action]);
+    }
+  });
+  $("jobs-poll").addEventListener("change", poll);
+  window.addEventListener("hashchange", navigate);
+  navigate();
+})();
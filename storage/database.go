@@ -0,0 +1,272 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+// lockRetry is the wait before trying again a lock held by another
+const lockRetry = 100 * time.Millisecond
+
+// keyStore holds the values of the keys and the leases of the locks of a
+// Database, in the process or in the replica serving it
+type keyStore interface {
+	// get returns the value of k, an error satisfying os.IsNotExist when
+	// there is none
+	get(k string) ([]byte, error)
+	put(k string, value []byte) error
+	// remove removes prefix and the keys under it
+	remove(prefix string) error
+	// values returns the values of prefix and of the keys under it
+	values(prefix string) (map[string][]byte, error)
+	// replace makes values the keys of prefix and under it
+	replace(prefix string, values map[string][]byte) error
+	// acquire takes the lease of name for owner for ttl, or renews it when
+	// owner holds it, and tells whether owner holds it
+	acquire(name string, owner string, ttl time.Duration) (bool, error)
+	// release releases the lease of name unless another took it after it
+	// expired
+	release(name string, owner string) error
+	close() error
+}
+
+// Database is an sdk.Storage in a database, the LevelDB the process holds
+// open or the one another replica serves. The checkouts are files under
+// CacheDir, local to each process.
+type Database struct {
+	CacheDir string
+	// LockTTL is the time a lock is held without being renewed, its holder
+	// renews it every third of it until it releases it
+	LockTTL time.Duration
+
+	store keyStore
+	// owner identifies the locks of the process
+	owner string
+}
+
+func newDatabase(store keyStore, cacheDir string) *Database {
+	host, _ := os.Hostname()
+	return &Database{
+		CacheDir: cacheDir,
+		LockTTL:  defaultLockTTL,
+		store:    store,
+		owner:    fmt.Sprintf("%s/%d", host, os.Getpid()),
+	}
+}
+
+// Close closes the database, or the connections to the replica serving it
+func (s *Database) Close() error {
+	return s.store.close()
+}
+
+// key is the database key of a storage key, the absolute paths being taken
+// relative to /
+func key(k string) string {
+	k = strings.TrimPrefix(path.Clean(filepath.ToSlash(k)), "/")
+	if k == "." {
+		return ""
+	}
+	return k
+}
+
+// under tells whether k is prefix or a key under it
+func under(k string, prefix string) bool {
+	return prefix == "" || k == prefix || strings.HasPrefix(k, prefix+"/")
+}
+
+// Read ...
+func (s *Database) Read(k string) ([]byte, error) {
+	value, err := s.store.get(key(k))
+	if os.IsNotExist(err) {
+		return nil, &os.PathError{Op: "read", Path: k, Err: os.ErrNotExist}
+	}
+	return value, err
+}
+
+// Write ...
+func (s *Database) Write(k string, value []byte) error {
+	return s.store.put(key(k), value)
+}
+
+// Remove ...
+func (s *Database) Remove(k string) error {
+	return s.store.remove(key(k))
+}
+
+// List returns the keys joined to prefix as given, like the FileStorage
+func (s *Database) List(prefix string) ([]string, error) {
+	values, err := s.store.values(key(prefix))
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for k := range values {
+		keys = append(keys, path.Join(filepath.ToSlash(prefix), strings.TrimPrefix(k, key(prefix))))
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Lock waits for the lease of name and renews it until it is released
+func (s *Database) Lock(name string) (func(), error) {
+	for {
+		unlock, err := s.TryLock(name)
+		if err != sdk.ErrLocked {
+			return unlock, err
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// TryLock takes the lease of name unless another holds it, and renews it
+// until it is released
+func (s *Database) TryLock(name string) (func(), error) {
+	unlock, _, err := s.TryLease(name)
+	return unlock, err
+}
+
+// TryLease is TryLock, lost being closed once a renewal of the lease failed,
+// another may hold it then
+func (s *Database) TryLease(name string) (unlock func(), lost <-chan struct{}, err error) {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	// the locks of the process exclude each other too
+	owner := s.owner + "/" + hex.EncodeToString(suffix)
+	acquired, err := s.store.acquire(name, owner, s.LockTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !acquired {
+		return nil, nil, sdk.ErrLocked
+	}
+
+	stop, lostc := make(chan struct{}), make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.LockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if held, err := s.store.acquire(name, owner, s.LockTTL); err != nil || !held {
+					logger.Error("Lost the lock of %s: %v", name, err)
+					close(lostc)
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			if err := s.store.release(name, owner); err != nil {
+				logger.Error("Error releasing the lock of %s: %s", name, err)
+			}
+		})
+	}, lostc, nil
+}
+
+// local is the file of the checkout of a database key
+func (s *Database) local(k string) string {
+	return filepath.Join(s.CacheDir, filepath.FromSlash(k))
+}
+
+// Checkout writes the values of the keys under prefix to their files, and
+// removes the files of the keys removed from the database since the last one.
+func (s *Database) Checkout(prefix string) (string, error) {
+	p := key(prefix)
+	values, err := s.store.values(p)
+	if err != nil {
+		return "", err
+	}
+	root := s.local(p)
+	if err := s.sync(root, values); err != nil {
+		return "", err
+	}
+	return root, nil
+}
+
+// sync makes the files under root the values
+func (s *Database) sync(root string, values map[string][]byte) error {
+	for k, value := range values {
+		file := s.local(k)
+		if current, err := ioutil.ReadFile(file); err == nil && bytes.Equal(current, value) {
+			continue
+		}
+		if err := writeFile(file, value); err != nil {
+			return err
+		}
+	}
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		if _, ok := values[s.key(file)]; !ok {
+			return os.Remove(file)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// key is the database key of a file of the checkouts
+func (s *Database) key(file string) string {
+	rel, _ := filepath.Rel(s.CacheDir, file)
+	return key(rel)
+}
+
+// writeFile writes value aside then renames it, the private keys being only
+// readable by the process
+func writeFile(file string, value []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	mode := os.FileMode(0644)
+	if strings.HasSuffix(file, "_sk") {
+		mode = 0600
+	}
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, value, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// Commit writes the files of the checkout of prefix to the database, and
+// removes the keys of the files removed.
+func (s *Database) Commit(prefix string) error {
+	p := key(prefix)
+	files := make(map[string][]byte)
+	err := filepath.Walk(s.local(p), func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || strings.HasSuffix(file, ".tmp") {
+			return err
+		}
+		value, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		files[s.key(file)] = value
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.store.replace(p, files)
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// prefixes of the database keys
+const (
+	valuePrefix = "v/"
+	lockPrefix  = "l/"
+)
+
+// levelStore is a keyStore in a LevelDB database the process holds open, a
+// single process can open it.
+type levelStore struct {
+	db *leveldb.DB
+	// leases makes reading and writing a lease one step
+	leases sync.Mutex
+}
+
+// OpenLevelDB opens the LevelDB database in dir, checked out under cacheDir.
+// It stays open until Close, the other replicas reach it through Serve.
+func OpenLevelDB(dir string, cacheDir string) (*Database, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err == syscall.EWOULDBLOCK {
+		return nil, fmt.Errorf("%s is open in another process, reach it through the storage that process serves", dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %s", dir, err)
+	}
+	return newDatabase(&levelStore{db: db}, cacheDir), nil
+}
+
+func (s *levelStore) get(k string) ([]byte, error) {
+	value, err := s.db.Get([]byte(valuePrefix+k), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, os.ErrNotExist
+	}
+	return value, err
+}
+
+func (s *levelStore) put(k string, value []byte) error {
+	return s.db.Put([]byte(valuePrefix+k), value, nil)
+}
+
+func (s *levelStore) remove(prefix string) error {
+	batch := new(leveldb.Batch)
+	err := s.each(prefix, func(k string, value []byte) {
+		batch.Delete([]byte(valuePrefix + k))
+	})
+	if err != nil {
+		return err
+	}
+	return s.db.Write(batch, nil)
+}
+
+func (s *levelStore) values(prefix string) (map[string][]byte, error) {
+	values := make(map[string][]byte)
+	err := s.each(prefix, func(k string, value []byte) {
+		values[k] = append([]byte(nil), value...)
+	})
+	return values, err
+}
+
+// replace writes in one batch the values changed and removes the keys
+// missing
+func (s *levelStore) replace(prefix string, values map[string][]byte) error {
+	batch := new(leveldb.Batch)
+	unchanged := make(map[string]bool)
+	err := s.each(prefix, func(k string, value []byte) {
+		if v, ok := values[k]; !ok {
+			batch.Delete([]byte(valuePrefix + k))
+		} else if bytes.Equal(v, value) {
+			unchanged[k] = true
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for k, value := range values {
+		if !unchanged[k] {
+			batch.Put([]byte(valuePrefix+k), value)
+		}
+	}
+	return s.db.Write(batch, nil)
+}
+
+// each calls f with the keys under prefix and their values
+func (s *levelStore) each(prefix string, f func(k string, value []byte)) error {
+	iter := s.db.NewIterator(util.BytesPrefix([]byte(valuePrefix+prefix)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		if k := strings.TrimPrefix(string(iter.Key()), valuePrefix); under(k, prefix) {
+			f(k, iter.Value())
+		}
+	}
+	return iter.Error()
+}
+
+// lease is the value of a lock
+type lease struct {
+	Owner   string
+	Expires time.Time
+}
+
+func (s *levelStore) acquire(name string, owner string, ttl time.Duration) (bool, error) {
+	s.leases.Lock()
+	defer s.leases.Unlock()
+	k := []byte(lockPrefix + name)
+	data, err := s.db.Get(k, nil)
+	if err != nil && err != leveldb.ErrNotFound {
+		return false, err
+	}
+	if err == nil {
+		l := &lease{}
+		if err := json.Unmarshal(data, l); err != nil {
+			return false, err
+		}
+		if l.Owner != owner && time.Now().Before(l.Expires) {
+			return false, nil
+		}
+	}
+	if data, err = json.Marshal(&lease{Owner: owner, Expires: time.Now().Add(ttl)}); err != nil {
+		return false, err
+	}
+	return true, s.db.Put(k, data, nil)
+}
+
+func (s *levelStore) release(name string, owner string) error {
+	s.leases.Lock()
+	defer s.leases.Unlock()
+	k := []byte(lockPrefix + name)
+	data, err := s.db.Get(k, nil)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	l := &lease{}
+	if err := json.Unmarshal(data, l); err != nil || l.Owner != owner {
+		return err
+	}
+	return s.db.Delete(k, nil)
+}
+
+func (s *levelStore) close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,247 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego"
+)
+
+// paths of the storage server
+const (
+	valuePath  = "/value"
+	valuesPath = "/values"
+	leasePath  = "/lease"
+)
+
+// Handler serves the keys and the leases of s to the replicas reaching it
+// with Dial
+func Handler(s *Database) http.Handler {
+	return &handler{store: s.store}
+}
+
+type handler struct {
+	store keyStore
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var (
+		value []byte
+		err   error
+	)
+	switch r.Method + " " + r.URL.Path {
+	case "GET " + valuePath:
+		value, err = h.store.get(q.Get("key"))
+	case "PUT " + valuePath:
+		if value, err = ioutil.ReadAll(r.Body); err == nil {
+			value, err = nil, h.store.put(q.Get("key"), value)
+		}
+	case "DELETE " + valuePath:
+		err = h.store.remove(q.Get("prefix"))
+	case "GET " + valuesPath:
+		var values map[string][]byte
+		if values, err = h.store.values(q.Get("prefix")); err == nil {
+			value, err = json.Marshal(values)
+		}
+	case "PUT " + valuesPath:
+		values := make(map[string][]byte)
+		if err = json.NewDecoder(r.Body).Decode(&values); err == nil {
+			err = h.store.replace(q.Get("prefix"), values)
+		}
+	case "POST " + leasePath:
+		var (
+			ttl      time.Duration
+			acquired bool
+		)
+		if ttl, err = time.ParseDuration(q.Get("ttl")); err == nil {
+			if acquired, err = h.store.acquire(q.Get("name"), q.Get("owner"), ttl); err == nil && !acquired {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+	case "DELETE " + leasePath:
+		err = h.store.release(q.Get("name"), q.Get("owner"))
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if os.IsNotExist(err) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		logger.Error("Error serving %s %s of the storage: %s", r.Method, r.URL, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(value)
+}
+
+// remoteStore is a keyStore served by another replica
+type remoteStore struct {
+	url    string
+	client *http.Client
+}
+
+// callTimeout bounds the requests to the replica serving the storage, a
+// renewal hanging past the TTL would hide the loss of a lease
+const callTimeout = 5 * time.Second
+
+// Dial returns the storage the replica at rawurl serves, checked out under
+// cacheDir
+func Dial(rawurl string, cacheDir string, transport http.RoundTripper) *Database {
+	client := &http.Client{Transport: transport, Timeout: callTimeout}
+	return newDatabase(&remoteStore{url: strings.TrimSuffix(rawurl, "/"), client: client}, cacheDir)
+}
+
+// call sends a request to the replica serving the storage, a status other
+// than 200 failing but 404 and 409, which the callers tell apart
+func (s *remoteStore) call(method string, p string, query url.Values, body []byte) ([]byte, int, error) {
+	req, err := http.NewRequest(method, s.url+p+"?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reaching the storage at %s: %s", s.url, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNotFound, http.StatusConflict:
+		return data, resp.StatusCode, nil
+	}
+	return nil, resp.StatusCode, fmt.Errorf("error from the storage at %s: %s %s", s.url, resp.Status, bytes.TrimSpace(data))
+}
+
+func (s *remoteStore) get(k string) ([]byte, error) {
+	data, status, err := s.call("GET", valuePath, url.Values{"key": {k}}, nil)
+	if err == nil && status == http.StatusNotFound {
+		return nil, os.ErrNotExist
+	}
+	return data, err
+}
+
+func (s *remoteStore) put(k string, value []byte) error {
+	_, _, err := s.call("PUT", valuePath, url.Values{"key": {k}}, value)
+	return err
+}
+
+func (s *remoteStore) remove(prefix string) error {
+	_, _, err := s.call("DELETE", valuePath, url.Values{"prefix": {prefix}}, nil)
+	return err
+}
+
+func (s *remoteStore) values(prefix string) (map[string][]byte, error) {
+	data, _, err := s.call("GET", valuesPath, url.Values{"prefix": {prefix}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string][]byte)
+	return values, json.Unmarshal(data, &values)
+}
+
+func (s *remoteStore) replace(prefix string, values map[string][]byte) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.call("PUT", valuesPath, url.Values{"prefix": {prefix}}, data)
+	return err
+}
+
+func (s *remoteStore) acquire(name string, owner string, ttl time.Duration) (bool, error) {
+	_, status, err := s.call("POST", leasePath, url.Values{"name": {name}, "owner": {owner}, "ttl": {ttl.String()}}, nil)
+	return err == nil && status == http.StatusOK, err
+}
+
+func (s *remoteStore) release(name string, owner string) error {
+	_, _, err := s.call("DELETE", leasePath, url.Values{"name": {name}, "owner": {owner}}, nil)
+	return err
+}
+
+func (s *remoteStore) close() error {
+	if t, ok := s.client.Transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+// tlsConfig is the mutual TLS of the storage server and of its clients: the
+// certificate of StorageTLSCertFile and StorageTLSKeyFile, the peer's being
+// verified with the CAs of StorageTLSCAFile. The storage holding the private
+// keys of the orgs, it is never served or reached without it.
+func tlsConfig() (*tls.Config, error) {
+	caFile := beego.AppConfig.String("StorageTLSCAFile")
+	certFile := beego.AppConfig.String("StorageTLSCertFile")
+	keyFile := beego.AppConfig.String("StorageTLSKeyFile")
+	if caFile == "" || certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("sharing the storage needs StorageTLSCAFile, StorageTLSCertFile and StorageTLSKeyFile")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	cas := x509.NewCertPool()
+	if !cas.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in %s", caFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      cas,
+		ClientCAs:    cas,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+// serve serves s at addr over mutual TLS until Stop
+func serve(s *Database, addr string) error {
+	conf, err := tlsConfig()
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: Handler(s), TLSConfig: conf}
+	server = srv
+	go func() {
+		if err := srv.ServeTLS(ln, "", ""); err != http.ErrServerClosed {
+			logger.Error("Error serving the storage at %s: %s", addr, err)
+		}
+	}()
+	logger.Info("Serving the storage to the other replicas at %s", addr)
+	return nil
+}
+
+// shutdown stops serving the storage
+func shutdown() error {
+	if server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := server.Shutdown(ctx)
+	server = nil
+	return err
+}
@@ -0,0 +1,151 @@
+// Package storage sets the storage of the crypto material, of the MSPs of
+// the orgs added to the channels and of the genesis block from app.conf:
+// files under a directory, or an embedded database one replica of
+// manageChain holds open and serves to the others.
+package storage
+
+import (
+	"fmt"
+	"io"
+	"manageChain/logging"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/hyperledger/fabric/sdk"
+)
+
+var logger = logging.GetLogger("storage")
+
+// backends of Storage
+const (
+	// FileBackend keeps the keys in files under StorageDir
+	FileBackend = "file"
+	// LevelDBBackend keeps the keys in the LevelDB database of StorageDir,
+	// checked out under StorageCacheDir
+	LevelDBBackend = "leveldb"
+	// RemoteBackend reaches the database another replica serves at
+	// StorageURL, checked out under StorageCacheDir
+	RemoteBackend = "remote"
+)
+
+const (
+	defaultFileDir    = "."
+	defaultLevelDBDir = "state.db"
+	defaultCacheDir   = "storage-cache"
+	defaultLockTTL    = 30 * time.Second
+)
+
+var (
+	// server serves the database of the process to the other replicas
+	server *http.Server
+	// opened is the storage Setup opened
+	opened sdk.Storage
+)
+
+// Open returns the storage of app.conf: Storage is the backend, file by
+// default, StorageDir its directory, StorageURL the replica serving the
+// database of remote. The locks of the databases expire after
+// StorageLockTTL unless their holder renews them.
+func Open() (sdk.Storage, error) {
+	backend := beego.AppConfig.DefaultString("Storage", FileBackend)
+	if backend == FileBackend {
+		return sdk.NewFileStorage(beego.AppConfig.DefaultString("StorageDir", defaultFileDir)), nil
+	}
+	ttl := defaultLockTTL
+	if s := beego.AppConfig.String("StorageLockTTL"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid StorageLockTTL %s", s)
+		}
+		ttl = d
+	}
+	cacheDir := beego.AppConfig.DefaultString("StorageCacheDir", defaultCacheDir)
+	var db *Database
+	switch backend {
+	case LevelDBBackend:
+		var err error
+		if db, err = OpenLevelDB(beego.AppConfig.DefaultString("StorageDir", defaultLevelDBDir), cacheDir); err != nil {
+			return nil, err
+		}
+	case RemoteBackend:
+		rawurl := beego.AppConfig.String("StorageURL")
+		if u, err := url.Parse(rawurl); err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("the remote storage needs the https URL of the replica serving it in StorageURL, got %q", rawurl)
+		}
+		conf, err := tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		db = Dial(rawurl, cacheDir, &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: conf})
+	default:
+		return nil, fmt.Errorf("unknown Storage %s, file, leveldb or remote", backend)
+	}
+	db.LockTTL = ttl
+	return db, nil
+}
+
+// Setup sets the storage of app.conf as the one of the sdk. The process
+// holding the leveldb database serves it at StorageListen when set.
+func Setup() error {
+	s, err := Open()
+	if err != nil {
+		return err
+	}
+	if listen := beego.AppConfig.String("StorageListen"); listen != "" {
+		db, ok := s.(*Database)
+		if !ok || beego.AppConfig.String("Storage") != LevelDBBackend {
+			Close(s)
+			return fmt.Errorf("StorageListen serves the leveldb storage only")
+		}
+		if err := serve(db, listen); err != nil {
+			db.Close()
+			return err
+		}
+	}
+	sdk.SetStorage(s)
+	opened = s
+	logger.Info("Using the %s storage", beego.AppConfig.DefaultString("Storage", FileBackend))
+	return nil
+}
+
+// Stop stops serving the storage and closes it
+func Stop() error {
+	if err := shutdown(); err != nil {
+		logger.Error("Error stopping the storage server: %s", err)
+	}
+	if opened == nil {
+		return nil
+	}
+	err := Close(opened)
+	opened = nil
+	return err
+}
+
+// Close closes s when it holds a database or connections
+func Close(s sdk.Storage) error {
+	if c, ok := s.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Copy copies the keys under prefix from src to dst, e.g. the files of
+// MSPDir into a database. It returns the number of keys copied.
+func Copy(dst sdk.Storage, src sdk.Storage, prefix string) (int, error) {
+	keys, err := src.List(prefix)
+	if err != nil {
+		return 0, err
+	}
+	for i, key := range keys {
+		value, err := src.Read(key)
+		if err != nil {
+			return i, err
+		}
+		if err := dst.Write(key, value); err != nil {
+			return i, err
+		}
+	}
+	return len(keys), nil
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/sdk"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// openLevelDB opens the database of dir, checked out under cacheDir
+func openLevelDB(t *testing.T, dir string, cacheDir string) *Database {
+	db, err := OpenLevelDB(filepath.Join(dir, "state.db"), filepath.Join(dir, cacheDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// replicas returns the database of dir and another replica reaching it
+// through the server of the first
+func replicas(t *testing.T, dir string) (*Database, *Database) {
+	first := openLevelDB(t, dir, "first")
+	srv := httptest.NewServer(Handler(first))
+	t.Cleanup(srv.Close)
+	second := Dial(srv.URL, filepath.Join(dir, "second"), http.DefaultTransport)
+	return first, second
+}
+
+func TestLevelDB(t *testing.T) {
+	dir := tempDir(t)
+	db := openLevelDB(t, dir, "cache")
+	// a single process holds the database
+	if _, err := OpenLevelDB(filepath.Join(dir, "state.db"), filepath.Join(dir, "other")); err == nil {
+		t.Fatal("the database is opened twice")
+	}
+	for _, key := range []string{"msp/org1/ca/ca-cert.pem", "msp/org1/msp/cacerts/ca-cert.pem", "msp/org10/ca/ca-cert.pem"} {
+		if err := db.Write(key, []byte(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if keys, err := db.List("msp/org1"); err != nil || !reflect.DeepEqual(keys, []string{"msp/org1/ca/ca-cert.pem", "msp/org1/msp/cacerts/ca-cert.pem"}) {
+		t.Fatalf("unexpected keys %v: %v", keys, err)
+	}
+	if _, err := db.Read("msp/org2/ca/ca-cert.pem"); !os.IsNotExist(err) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the checkout is committed back with its changes
+	local, err := db.Checkout("msp/org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(local, "msp")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(local, "ca", "priv_sk"), []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Commit("msp/org1"); err != nil {
+		t.Fatal(err)
+	}
+	if keys, err := db.List("msp"); err != nil || !reflect.DeepEqual(keys, []string{"msp/org1/ca/ca-cert.pem", "msp/org1/ca/priv_sk", "msp/org10/ca/ca-cert.pem"}) {
+		t.Fatalf("unexpected keys %v: %v", keys, err)
+	}
+
+	// and gets the changes of the others
+	if err := db.Remove("msp/org1/ca/priv_sk"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Checkout("msp/org1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(local, "ca", "priv_sk")); !os.IsNotExist(err) {
+		t.Fatalf("the removed key is checked out: %v", err)
+	}
+}
+
+func TestLevelDBLock(t *testing.T) {
+	// two processes sharing the database
+	first, second := replicas(t, tempDir(t))
+	second.owner = "other"
+	first.LockTTL, second.LockTTL = 300*time.Millisecond, 300*time.Millisecond
+
+	var (
+		lock    sync.Mutex
+		holders int
+		wg      sync.WaitGroup
+	)
+	for _, db := range []*Database{first, second, first, second} {
+		wg.Add(1)
+		go func(db *Database) {
+			defer wg.Done()
+			unlock, err := db.Lock("ca/msp/org1")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			lock.Lock()
+			holders++
+			if holders != 1 {
+				t.Error("the lock is held twice")
+			}
+			lock.Unlock()
+			// held past its TTL, renewed meanwhile
+			time.Sleep(400 * time.Millisecond)
+			lock.Lock()
+			holders--
+			lock.Unlock()
+			unlock()
+		}(db)
+	}
+	wg.Wait()
+}
+
+func TestLeaseLost(t *testing.T) {
+	db := openLevelDB(t, tempDir(t), "cache")
+	db.LockTTL = 300 * time.Millisecond
+	unlock, lost, err := db.TryLease("ha/leader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	// another took the lease the holder failed to renew in time
+	data, err := json.Marshal(&lease{Owner: "other", Expires: time.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.store.(*levelStore).db.Put([]byte(lockPrefix+"ha/leader"), data, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("the lease is not lost")
+	}
+}
+
+func TestCA(t *testing.T) {
+	dir := tempDir(t)
+	first, second := replicas(t, dir)
+	defer sdk.SetStorage(sdk.Store())
+
+	sdk.SetStorage(first)
+	ca, err := sdk.OpenCA("msp/org1", "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ca.GenerateMSP([]*sdk.CertConfig{{CN: "peer0", NodeType: sdk.PeerNode}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// another replica gets the same CA and its nodes
+	sdk.SetStorage(second)
+	other, err := sdk.OpenCA("msp/org1", "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(other.TLSCACert()) != string(ca.TLSCACert()) {
+		t.Fatal("another CA is created")
+	}
+	if _, err := os.Stat(other.NodeMSPDir("peer0", sdk.PeerNode)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(other.MSPDir(), filepath.Join(dir, "second")) {
+		t.Fatalf("unexpected checkout %s", other.MSPDir())
+	}
+	if err := other.GenerateMSP([]*sdk.CertConfig{{CN: "peer1", NodeType: sdk.PeerNode}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the serial numbers of the admin and of the peers
+	serials, err := second.Read("msp/org1/serials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(serials)), "\n"); len(lines) != 3 {
+		t.Fatalf("unexpected serials %s", serials)
+	}
+}
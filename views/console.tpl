@@ -0,0 +1,112 @@
+<!DOCTYPE html>
+
+<html>
+<head>
+  <title>{{.AppName}} console</title>
+  <meta http-equiv="Content-Type" content="text/html; charset=utf-8">
+  <link rel="stylesheet" href="/static/css/console.css">
+</head>
+
+<body>
+  <header>
+    <h1>{{.AppName}}</h1>
+    <nav id="tabs">
+      <a href="#topology">Topology</a>
+      <a href="#orgs">Orgs</a>
+      <a href="#channels">Channels</a>
+      <a href="#governance">Governance</a>
+      <a href="#chaincodes">Chaincodes</a>
+      <a href="#explorer">Explorer</a>
+      <a href="#jobs">Jobs</a>
+      <a href="#workspace">Workspace</a>
+    </nav>
+    <a class="api" href="/openapi.json">API</a>
+  </header>
+
+  <div id="status"></div>
+
+  <main>
+    <section id="topology">
+      <div class="toolbar">
+        <button data-action="topology">Refresh</button>
+        <span id="health"></span>
+      </div>
+      <div id="topology-view"></div>
+    </section>
+
+    <section id="orgs">
+      <div class="toolbar">
+        <button data-action="orgs">Refresh</button>
+        <label>Expiring within <input id="orgs-days" type="number" min="0" value="30"> days</label>
+      </div>
+      <div id="orgs-view"></div>
+    </section>
+
+    <section id="channels">
+      <div class="toolbar">
+        <button data-action="channels">Refresh</button>
+      </div>
+      <div id="channels-view"></div>
+      <h2 id="config-title"></h2>
+      <div id="config-view" class="tree"></div>
+    </section>
+
+    <section id="governance">
+      <div class="toolbar">
+        <select id="governance-channel" class="channel-select"></select>
+        <button data-action="governance">Refresh</button>
+        <label><input id="governance-all" type="checkbox"> show decided proposals</label>
+      </div>
+      <div id="governance-view"></div>
+    </section>
+
+    <section id="chaincodes">
+      <div class="toolbar">
+        <button data-action="chaincodes">Refresh</button>
+      </div>
+      <div id="chaincodes-view"></div>
+    </section>
+
+    <section id="explorer">
+      <div class="toolbar">
+        <select id="explorer-channel" class="channel-select"></select>
+        <button data-action="explorer">Latest block</button>
+        <label>Block <input id="explorer-block" type="number" min="0"></label>
+        <button data-action="block">Show</button>
+        <label>Transaction <input id="explorer-tx" size="40"></label>
+        <button data-action="tx">Show</button>
+      </div>
+      <div id="explorer-info"></div>
+      <div id="explorer-view"></div>
+    </section>
+
+    <section id="jobs">
+      <div class="toolbar">
+        <select id="jobs-status">
+          <option value="">all</option>
+          <option value="running">running</option>
+          <option value="succeeded">succeeded</option>
+          <option value="failed">failed</option>
+        </select>
+        <button data-action="jobs">Refresh</button>
+        <label><input id="jobs-poll" type="checkbox" checked> poll every 3 seconds</label>
+      </div>
+      <div id="jobs-view"></div>
+    </section>
+
+    <section id="workspace">
+      <p>
+        The orgs the console sends its requests on behalf of, the first one being read
+        through. They are an array of OrgInfo as in the requests of the API, kept in this
+        browser only, their crypto material being under MSPDir of the server.
+      </p>
+      <textarea id="workspace-orgs" rows="20" spellcheck="false"></textarea>
+      <div class="toolbar">
+        <button data-action="save">Save</button>
+      </div>
+    </section>
+  </main>
+
+  <script src="/static/js/console.js"></script>
+</body>
+</html>
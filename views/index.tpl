@@ -1,95 +0,0 @@
-<!DOCTYPE html>
-
-<html>
-<head>
-  <title>Beego</title>
-  <meta http-equiv="Content-Type" content="text/html; charset=utf-8">
-  <link rel="shortcut icon" href="data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAEAAAABACAYAAACqaXHeAAAAGXRFWHRTb2Z0d2FyZQBBZG9iZSBJbWFnZVJlYWR5ccllPAAAAyRpVFh0WE1MOmNvbS5hZG9iZS54bXAAAAAAADw/eHBhY2tldCBiZWdpbj0i77u/IiBpZD0iVzVNME1wQ2VoaUh6cmVTek5UY3prYzlkIj8+IDx4OnhtcG1ldGEgeG1sbnM6eD0iYWRvYmU6bnM6bWV0YS8iIHg6eG1wdGs9IkFkb2JlIFhNUCBDb3JlIDUuMy1jMDExIDY2LjE0NTY2MSwgMjAxMi8wMi8wNi0xNDo1NjoyNyAgICAgICAgIj4gPHJkZjpSREYgeG1sbnM6cmRmPSJodHRwOi8vd3d3LnczLm9yZy8xOTk5LzAyLzIyLXJkZi1zeW50YXgtbnMjIj4gPHJkZjpEZXNjcmlwdGlvbiByZGY6YWJvdXQ9IiIgeG1sbnM6eG1wPSJodHRwOi8vbnMuYWRvYmUuY29tL3hhcC8xLjAvIiB4bWxuczp4bXBNTT0iaHR0cDovL25zLmFkb2JlLmNvbS94YXAvMS4wL21tLyIgeG1sbnM6c3RSZWY9Imh0dHA6Ly9ucy5hZG9iZS5jb20veGFwLzEuMC9zVHlwZS9SZXNvdXJjZVJlZiMiIHhtcDpDcmVhdG9yVG9vbD0iQWRvYmUgUGhvdG9zaG9wIENTNiAoTWFjaW50b3NoKSIgeG1wTU06SW5zdGFuY2VJRD0ieG1wLmlpZDpFNTdGOTA3OTczQTgxMUU0QTlEQUM0NEYyRkI1NEQxQiIgeG1wTU06RG9jdW1lbnRJRD0ieG1wLmRpZDpFNTdGOTA3QTczQTgxMUU0QTlEQUM0NEYyRkI1NEQxQiI+IDx4bXBNTTpEZXJpdmVkRnJvbSBzdFJlZjppbnN0YW5jZUlEPSJ4bXAuaWlkOkU1N0Y5MDc3NzNBODExRTRBOURBQzQ0RjJGQjU0RDFCIiBzdFJlZjpkb2N1bWVudElEPSJ4bXAuZGlkOkU1N0Y5MDc4NzNBODExRTRBOURBQzQ0RjJGQjU0RDFCIi8+IDwvcmRmOkRlc2NyaXB0aW9uPiA8L3JkZjpSREY+IDwveDp4bXBtZXRhPiA8P3hwYWNrZXQgZW5kPSJyIj8+lsWdMwAAIvtJREFUeNrke3d81PX9//PzuX3JXXYu+7InmzCDQCDsISAbLFppa798URHroE4eddRW21rR/iy2Vm0drbL3hoBASMII2Xtfkkvucnv/Xu9PSAyKCv2p//w+eVxyufG59/s1nuP9/hzn8/nw//Mh9nq9cLvdP/oHO51OyGQyiMVisCTwPA+LxSL8z3HcjzIGkUgE8fnz57F27VqwQPyYh1arFT355JM/bygvnWOz2nxjpuZa3nr77UeOHzvWIWYDo0D80MfGjRshtlqtqKur+9ErYNXq1blqueytL/buhJIqoaO6EslRUZHdQ4e+WlBYeLq7u9vyQ49Br9ezyuOFUvi+Drlcjueee+7u4cOHZ3zb665evVrR0Nx8IiQwEH4+N9ztTdAVnpu6at7s/cOHDcsIDQnhf4wW+N4/ZNq0aaOWL1/+AeFKxLe9bv++fU1bnn1uqTIh9VRAWhbEmiho4hOxe89epKSkfHJg795ff5+J+UYQ/L5PaDabA1paWlQEbM7vem1DfX33vuMnVrzzzjuPV5VdF3l8nDfcZJpBuJRcVVdXcqef7efvjw8//OdPg4ODAjb8z/+8X1JSov/RA2AwGLwM1f1pMLdzXC4u1k0YP35zHxv7cN+aNa+HqVRJVEGqOyHoe3/ykwlZKUkvNl4uyG0TiZGXM36OXCa971JhUeu3ve97bwFGZU6nA1Kp5Lbf4/F4iIU8AhO5fT6xJiREkZiYMP52yTAmNlYaEx2z7NLJk7lFx47i4rEjUPmrZqxctXru91YB1I88DfQ7uZJlXyKR0uu/+dRiqRQcOAmdz0fnpXn6XC5nX8fIFApYbFbo2todtzs2TVhYjFoi+t+E5FTnlbLyF6oqqwxxXpGh+PK7//reAjB58uTshx56aOWDDz74nE6nM31bAJiQuZWYiYiMlGVmZGRNGpO9WSXCuK4unScsLEpkdHsPvvbGnx612uxOtUoNr9uF3p4eoTK+6+AJKFetXPlSa22NJFSrferqnj2vtLe2oq619bbmddstQOXJLVq0aFNOTs7473gd7Hb714QVC8j4MWNjnn34obOR3a2rU0y6pCkKX2poW02Su6Jkw4JZs/4YEhaGWK3WxaqnranRo4mIQCDRJMfz3ObHNs+Nj4+/Be3KUFl2PZmDr6G0rGxP+21O/I4DQBPgenq6QQhv/7bXSSQSypxbkLqDD6WfH+bOmvHrsr3/kadJfXDanahq6Ya+qxe8zQybxfzLP/35z8ckUvHSwuJCKBSKZadOnjz0523bXooIC42WiCWvP71ly4ktTz2Vt3TZsoiMjAxh7D9Zs3ZNVEhwemVtTf6HH3xw/QejQZfL1V5XW1cyb+7cvLa2ti8aGxtvaSBUKhWpK5FQBYMP5jcMLc2jkyU+BAQFQZOWCmlDM3buPQaT2YJOUy+OvrNtmorzIMLlhOF8ftzHVwvj2k2WmQtHD99QdeaEuuLUsbTJs+dNHLpokZP0xvYtW7ZsaqipjQvLyvSbMXe+fM/hYz+cDvjiiy/q33vvvfd/+9tXX/7i/PnfUwBuiQM9PT1OJq0ZG9z0QaTtg6Oi7Ib2OozQhMGrVKDbYAbn8yA+LBAzR2RCq1IgQCaFiaqHQx+LuNR+cHi8aocmELxYBH3lZemxYwekovDYR5YvWBju5ZDS2N5qqO3seOsHFUKspyOjotTlFeWu2traW+p0pVLJLViwIJ2Cg46ODstXZacmLl5x5OQJiC6Xw1tUipbODmRnZSA4PgkepR95khr4dXUhLsCPes7dxyhSDn4UDp6XC+eJVEqQFKxCja4HZodndX51HeLGjW+tbmiq/a/k8Lp16/DBBx/gdtYFRo0a7VarVTNJ5HSePXv26i0CIN26detO6n81ydnepqamE729vb7+Crjv/p+qHU53bmPRJV4doEbY6PGYtOanmLhkGUZOmoyokWNQbzChvaYGIQoJvD6vMC5SVvBQAvpvZKARGeAPrVoBKedDeYtOFZ+WOSNvxgx/Q0/PZQq+6zaZ7c6E0J///MYZh9Wqnz9//sOZWVnKmyguIoJ76qmnfqNWqxlAYeLEievo/kCFORwOVFVWxJrqq/jYKA0SZi/C6sefRtrw4ZDesL5RkZFY8ov/gVebjHaz7YaW4G7cMPCXBcRJOOEgwZVJ7ZQEL0qO7s9SSiS/jYyOTvtBWKA/ix16/X6L2Tz6/nXr5rEFDeb+SBvc9eijj76el5f3K3KB4uSkJJw4ftxMmRgQM/csXTais7pykZ++jQscng2jRIaz+flfbyM6Z9rEyWi3OsCkRP/02X2ebiIaAwNZ8g1CWzlIKwQRnuTEROHC5x9DyfN/yxoyNIU9RwkQbt+2wHJHXoCMDggIX1+zevWvCOQe37Zt23yr1coNzcpcFBkdo0pISBBWdkiaIiAwMGLTo48++OstW/7CylitUq1Cd2d0YFQ0nIFh2P7O/0FyfAIm5UwSJtcvoNgRFBUFCyeC0+MD04lU8fScDB63B+29VhgpCGIKIGeykoa0UzU4IBPxGBMVgYoO3fCRaWk7Y2JjNvzu1VfXeF0uftmKFc/1GAw9+q4uy1db/Y7NUExMjDwtPd1ZVVWVPX369Gw/4nfi7JvMD6sUqgQ/Yo4J9IF/GTV6tNra1RkrETmgHj4KDnqPydCNuXM3oD85g7PktNngpcn7qN85UV8JmDwu1NjdiJ44DbOn5wl029LQiKITR6Gjm5/bgUCqxsywAFxrqc/kVUE739j6QoDXYcPoBO3secufcNY0NT3x4ksvf0q6+78PAItgUlISR66PaQOEkXr7qgwetOY3/J577kkkdacZqglb5Th/Ah6OxyQCvIgIDXIm5nztfSaiz9KiQgSQOZJJqNRJP7BAlOutGLbuZ5g+Zz495oKM/ERYRCSGjBmDmIwsfPiHV+GhwIVSIuL85RB16wL8nWaqIjf8OD7q9IfvIXP2nBc1mvDPm5ub3f+1G2QCh01u6NChKCwsFNpisNztd4TV1dWg9hhOej7tQv4Za2ttJYIo8+1tHZQ9f0yfNl3Aj8HvM+i7cWj/AVSfPQNtcCApSp/A/a1mBzRj70IeTf6tbW9i5fIVqCGmEDJILTd5xixMWrIKrS4PbA4nAik4yaFB6HHYoQgMgohwQEl6o+mLs7HTJkx85P/JDptMJofRaGCrNoJOP3jw4IDsZVqBgA9FRUUC6jPKsjudd7ldLn9DWzOCVBSA+ipcLrhwkxU295rQRqrw8K5dOPvB3zGE+CXET0KV4REGqHO4MGzqVPrfi5NHj0HXrmPBHTiHg0p6+LBhCIyJR4fFBsJHlJGeCI6LQV7uBMREa2Aj/ODsFllkRPiKBQsWav+rFpBSZDdt2vQQ2V0F6/uxY8fijT+9Iej/0aNHC4uM9fX1uHL1CoKDg1mrMCPzZPzU3Gm1+z6HIikOakK097dtg7/cD2HhGtipR0k9ouRSAarzT2BiiBLxocFURX2VZbO7IKHPYhTJAHbEyBEIDQtHenr6l1mkCTNGio6OQWVNKRkSf4gZblisuF5eheZaCj6ZJpvNjpxxY7JHT546cs+e3Q0DAbjdJfHZs2eFUdaXEdoLi3VsklqtFm+/9TZyp+UKr2GZYcCYmJgoUNDlK1e4Q0cOj0slievxujAiLgqVF6/izd9uxeScyTQAHrV1VTA31iFHGwkDmaRzVA3DNaGQUrDcBG4ihR94iZQmyuFXTz4JtmzOgj7gM6gCPYRHUlKTPNtnoNaJ8lejtaML3Q43gohKJBQln0yNg8dOfLLn4KFDAxXAAIj1IkWXYwsU/TTRv1rcvzBJk45+4IH1H4WHhmaGh4cPWo2JQRTRlp1EiTY2TjgXYwQW1FryBOGU5T27d0ETGQIR8beazNDKsUNQ1dUJ97XzdH4Z+O5uqGUSXO3qwV8OniShAzy5IA8ZkWHwlyqA7l60kc0Npc9V3MCNAYlOLaSj52xmE1rrG6ASUaA8NFk6h8XmhCiUF4RWS2cnNOMmISFjiOGdv79nGwhAN334M888s3LDhg1bS0tLvSRdBefGIkwZ9FIvW2gynq6urgCNRpM+hMCvH7TMJhMqKysxNXeqAIZU7gI9MXbooXY4+vlnlDkxMjMyoWuqRDeVYIhMDhnvwYioSCGjZrdXyLgqOAhepwce4nNKI+pMNnRZmxBM44gg7Di7dyeSUtNIbiv6mIO1B6F+O/kOK43jWlkFqi4XYUR4ID1HusNPgc6GJuRX2qAJCUEs0e+E+QsLf/fa67+/SQewiVZUVHQSmBVRMNzMy7NeYROivvKLjIxcdO7cOcRo42AmdGfZZQDI8ODi+fNC348lKoqgHt2xc6ewtsey3m0wIkTKY92i6fjdP3ehpKMHpY0dGBkfCyVJXJebgJMokSeiTwkJQmlnD6QBKkzISIGMUCxARq+hNJZ16KENCoD5+hX8a9sbmHbPMgQEBMJGY+nUtaOzpRUXCy9h384dSJNLoKBWY1UsomTIqOIQHom7VqzA0Ozsgq3PP7/q6NGjNTetcxw/fpyt5d+y5xnH79q1awtRzrNmi1nm9XjR1tYGf7UKYaFhRGltGDlyJNP9NKgA1NfW4j+U9ThtPDNG+OBv26FV8JBTjzp7DPATSRCsViJZE0z97SF+9wlV4KCerRAHIPquqXDZLDAwJjl9AkZ6TyA9PycrGUFSEa7puklFhlNZh6OH0L6ttRl1tXUkMNuQShWUqtGAYi5UB1NY+VV1SJ0xBxs3P4biwkubli9f/sfB83v66af7MID1+a3W3zqpb1asWPHS9u3b053NznvH3zVeqIDDhw9jF2U7NS1VoLv+logn4Js6NVdwl7Ekh1OyhuLo3l1YmRSFCaOHostsQY/JTMjvhtJf1ieAaLicl8SKXIqskaMREhqK4KBAbKXP9p0+jllD0xEs46myiAo1QcQKdrTVl8FltUNDgCtX8hAnxBGryAnoIPQ+U45eCq6XKk1GVeEhfAoKIPt5C4v/nTRIlhahoaEmkr5oaGjArFmzcP/995NPl1L5dwmcX1FZgdmzZjP5K9ATCyYTKosXL6EIA+cO7kJaRDichC1yhRwmajFdV7cwWh8NnE3eYW1F/qcfQps5hASRHq6aSszOTIPGT0bv63O3jN8tPhfCA/2QEc0Yw4bSpnZYiDkYFfpuGEaWEGalHTRBGbEBtW7d5zt37maPs6A/8cQT60aNGpVN1fuf7wwAqw6iNZ71fGtrm6AEWXkHEMXZCYRSkpJRR9z/ySefCOrPz9+PBQwhBDwnT56AmCbYYPVg767jSHGx/XgOCspOKAGll+4bSO528l64/aXoPH8FDaQAwynTOVFhxN1yEll2YdCM+lotDhR1GCCnzMbKzUIGTTaHMHlvPzLecI/UVfAR/YVSG3d399QSOM987bXX/lFXX4ex48YOI0Y6cuzYsWrxnVhhBo4mU6+g/AwEcv3Iz7QAgSVYlTB5PG78OOGxasIENZX3IkrdVCNljwZsJqmaQPJ2KE2OMBB26jwLSVgrUVYHHKhpMqArqAO12h6ExkdCExZMSfAJy9/N+nbCm2yMSE/B+5/tQVlFLVLDQqn0RQId+jBQAsRkHkhlKtIIbly4cD6X6Hk6a8sxBNiU1N0bN25cM3nyZPNtSWFGiyzajIKuX7+Oy5cvUzCsAvCxwLDnmBLLysoCW7puJWQ+nX8GsdpYOM7kY3FXLyYEqhFPAklJNCehG0dB4Xw8FBSFUALHOIkc2RIFFkv8MMfggraoFg2HL6Agvxj1eiM4EkIyotTr18sRHxeBxXNnUEXYcK6qAWZiLpHoxlQ4wjTW3wSyFhJRxZeLQeaHZ6s/ubm5KC4uPrp+/fqfUKLMTLB9ZwWwfiaudzO9wFxZRXk5GQ4HDCRfkxKThBZhbMHolAUnJiaWAEeFHXv3wlVQiEWVrUgnje6l5zVkX9XUSgq2LyRU7M3enD0mpewlSknWchKkWp24dLUZtfVt6B6WgpjkOJRX1eNw/iVSmOVIDAiClQDubE0DJhC9qqV9KtBLHqKGRJU6OhrpqamIi4vFeKpKp9OFa9euVZLeMX6rF6D+5R782c+mqQMDV10puea5eu1aTlhYqCs8LJxjE2VWmDEE0/CnT59mBknE3sPoLykxhgZ3GQYCseSWNqqaAFyXi6GyehFKuj6cso4b6vIb12koLjJ6XbpUjlCxBIWE+IXnSlDX1onoIal4dfvH0KplSCacYdhQ0iJGcXMLJiTGC/zfbHXAHa2lSokn0FWQT8kmHPOH0cg2TTj+awsi/fKXJC1PPTKSPPzv6bHJZGuNy5Yua963d+8bRHtnSAlKWKlTS/ho8jbmDPVkYf9340P/PnLk+LgeCooUlYgL7ELupmFIDF5I+t8IPfG0/oIO1aTpgxrM0Pa6EMzWAZk+xw3e+rKDBx5hv0OpvyfIlFC5eBTU61BnsaOHI7GlDBOssJeymhoeBIfOA6PVBgMBZeiocaRFtOhsa4WX2pcBt0NYn5AjLS01PDs7m7t06ZKPtTZ34MABzJ8/H4SQ82wO+/Ixo7N/ciY//7ORQ4e2VNfUbHd6PHcR0PG7dux46/z58wOu6cUXX5oglSqG7zv0H+/iRbMes3Q0pGgUOiyYH4uwmMC+QDP68pLs9jLEF6PXxKG92IDmj8oQcbIVaR4xJDJRH3cLN9+gAHCE5H3/83QuB9HaZa8d5zxO/FvkhDtQhRlD0uEnPM8x241GwgT/jCGISs0k+m6Eh9SmjNopKCgYk3JyCEh5GIy9TPm+S0GpWLp06UmOqEB04MD+NYsWLSaKqL9w+tSpZ+mxw4wzly5Z8npCkHqTi1FfXMK/3v373zdHRWgC8vJmvNKtb5oi8hqDtBEmJKaqkRglQkgEGXmTkT7YS0BE0/C4BlBZmBZRHtUkaAwo+bgWzjevYKzFBz/Wu24BvwZ0fh+V+WDhfQLFBVBLWOjeFQK2o8Qse2VejB2VTpXgRxqCMk/U2EL1F0qGzG63CpiUSv1vJUt8990LEUtWma0m7927DyWlJZg/b75nyJAhj3JU6uMJ5P5+5syZq2+++eZGQvgONoiXX3nlFyqP+y/ZEf5oam5Ckd6BsdPy9CdPnRVdvFQYGBZswsKcEPx0bRa4yHjiM0p4VyN8TiNN3iVkhchLuA3ubfaLpwl7KRAFn9VD99wFTLPz8GfBcd8cAPbbRpNupwkHURUEkm+op6AWU2Z3+6ywpCdgBAFjbUsH2glrIpMSEULZZujOUbZtFJi7Fy4U1i3YQXNEWVkZY4Mzv/jFL+7Ly8trFa1du5bftGnTMWqBP7a3tw/s5mjjtHlBSnlefKAEPcT5+aU1eGd/gbJBpJXbA9Ng8EtDeYcfmutaEKc0IyhYCs5B6s7jFEqWu5F4QepyN9+oRMB5HIgapUE9YUr56UYk+8QQCfjEDQJHtkHGgRQDdFQiMhYEwgSTr4/zrzlsCIsIQ6/FDD48WthzZBdmSAg4G4lxkpOTBepjFr2kpARnz55FanLS2Wefe34lmaJGCoxbXFtb27hv377GrwLxzOnTGrp0za7aljbJwWu1KOS1iJi5ABL/IFJYYtIAcmHVZee1Anzx6kH8eo0Bd00IBe8S44YuG+DlW2w1C1KNJyE1eWUiPqaiO/NZHXKVfoPAkBsoG6YVwilAreQ0I2hySvo/iqohgVRgZ3ePgCGJKUmkICNRVl6Gjo5OgR1YAFg1sEUaxlajRo5of+XlV5YePHSovV/c8ezXra7Gun/9+o+vlVY8vONiteOKLAvROWsgDwmHm2RsMJu8z0m9bIY8JhOtCWvx4k4FSq9bwSlFwqS/xDWhBvr+9t8XdD39JRRWyl0YuyYdBRFitNkdA3LWN/BzIwiE+KxDGgQbLYaUZHSyh0CtywgTKUkxJYWtUFVWVKDXaEQEOUMmxxkWlNNjTLQRY/3r6PHj7be1KJqTk6Mgj59Y0NTGe4PSkZCixfjseIQFy+F2MC9uhIN0rIMmIQvSoEk9Cy/+24TWDhc48vJcv9NjZc/uE5iRiKD/pYKr6XuOY8vMSB4eAPXcJFwg7LAJup676acPEXg46Tw6lxOdBIQeelU0oY3KaIHN5WZqj8xaPSy9vYIMl1HZs8UZRvElJddA5sf0+Y4df2WLNTcFgA3iVlZ408Ob1vXwtscsw0wSve4zNF29Sn2uQkJCCJmLXrhIvnq4vgpnVlUWmYACzxRs+1cTbGYPq6+BKgAFxMPLoTNYaYpewT8MVAO9VC7xIHu2FmVqHm0UUCs3OPd9f8X0WADpBjtNqJfO4SWjI6NKCLe7CeyswkJMIGGAmDCFrVaziTMD19LaKtwnJ/vGyZMnK7+2N8jU3MMPPzzryKFD76anpgWwB5nn37ljZ8DphotQZIdBlNSM6qKPcLWggoBKATHp8gHJwt1Adq8DqtgRONCUhf0niUjErK3YwqUUze1uHDlqxKm9wBcXTPSw+GYVSFlNS5fBlx2CahI2rhsUOHhDlP1mAMgmzbHtIvrLFlT93UxueBEYEsouucN4Qnwb2WRm1MRUce0tLbCYLdWfff75R1QlX1v95Vl0CCiC2trbf3r3miXvTZ45Zdhzv34mZfbCmY+0eLsQSDo/PD0SnqAqlF++iIKrjTRA0Y0trZt3bXkxD3vEVPzjjBjNDWRgCJHZZAoKjAjHSIxMnExB9KKpjdJOAqV/05PkIlQBEmjHhhOXO9HlcsA0SBkKnoQt0JDDs3Neln8qOzfhkAdKekZO1XDx9CmBdJmoY5unzPbqSZl2kYehsm/454cf3vLyGZ4tXf/hD3/4uEuv33vqav6i5LwhhT1Oy2/PnT2rEUl58A4vjGQ4eI0PVnMNPGRnvb6+Vde+m+/GHj6bhwd+wRpUetKw67ROeIFY7EaIigwQydmeXj2MXW4UFXcRk0j6Kkh4H0sooXpmOFlhGTqpp400SR/fV10M/Mqp7ytcJGlZzxGe+Pi+K9HsRI/tzJvQbcqUqWSRy9llc8hII2AtKBAqITw8XPxNF24KGMA0cUe77uVxaWNc+S3F4sPygsUfVRzkXC02NO0vh73bBt5fDKe7FxyBCMdzXyae475Ue8IuLrVD+EjsviyhcxrBK2SEwCRgdE3QG3oRGhKG0msOGhjlVNS3593b64S52w6NNgCWSAVaKdNdFBWS/4Q1fZOvpKqwM9SgxzmKCE8M4CY6bCFN0UUYFpOQiHZdOwGzAw888AD5k43QU/aZcTMajZ7BW3g3BYCt/zNk3Hdg/8Wx6aOOKuo4WMQeqOfFQTEhHJIwuWCDPRKKOmcTAE9AZu4rPrZ/7YCqQBEYjobeGJwtMbML+aBWS2EmVeaiQKuVcthNYjQ0mYU2YIFzugnddVao/ST0XgkxAQ8rnaeOqq2Y+rlaQH2fsILM8cQkfJ9o6qFxFpHsyFuyFNNyp+AktUGAOkDY02CrUlOnTkVNbQ1cHnfU+vXrU7/xAgmmA8gnu198+aV7J8ePOtV9sBZKsqLKqABwGSq4/XkCJSfhmo2i7+mb8QDRD7r1L6MRFjiUUbhQTqlycVAEyYmqzPBTqqGgtlJKg5CfbyBBSOlliyMEip1dNhiJJSRyDl4KioHarJxaoZF6y9O/fuBjO8UeiFilURscs/UicMJ4LF+2jEo+w23uNbl7zb2orKoSmG38+PEYP24Cmhoa00kU3UuKkP/WK0RKy0r1CXHxn64dtQBVH1yCqNuNALE/ITyEXVqvxAk2HCZJxcLVoPj6TegDwg51GOo6nejuJHsaQtQU5kK3QU/ITD6AMtrU1IXqmk6BEaR0bqPZisvlbbC76b0in6AFPGx3irLO8+yqEKlwY7tL7DhlN6I5PQVL1t6LYKI9hUKxKyQ0dFdTUzNI0jPlZ9+/f38+JfekTqcrddjtm0eMHJH4nZfI/O6137+VFJfywtTIsWjYX4nuK+1CIMSevjVnqkABkb0+382ZH3TfQ+jFS/zRYVahq8dB3AxMypWjpvsKThVWwGDrQGqmHFW1nWhttAqTZnsOLrZhZSAn6cLA5TGDwAbs6w1sufuQTY9rQ1KwjPo8NiKSrULZL14s+IfH432vuLjYfppMj16vb33sscfuWr58WS75gNkGo/E6OcDfPPLIIwvZ2iDTCMJ3lb4aALbx8dSWp55fMfueF+anTIbhSgtsTSa4qk3w9LBscMJ6G/gbnTCYCW/ofjZ4likzubxemxsOow3BKh6rl0chNl2PhCwRAlVkY10+FJW04nJJM1xMUDlpkka7sCzmvcEuwt4Bu1yOzttLlfOp04CmaTlYtWkzMgnps7OzbTt2fH7f1q0v7Dl06NDesePGXTl08CCrCGlcXFwk2z4rKipq+vTTT+dPmzYtbsmSe96+fv362czMzDB2HcMtL5Njmx1Xrlw5uXj23b4Qr3/utWvFZC/9IHYmweciEeS2UeWKqNclfbZXcHFfmljmBl2mbigsVZiSTtqdaFRCFjhcqyKg42B1mCmITiFQLsIUtlbHk4JrqzCDO9WOEMEr9JUU43Z2XUCly4rj5EECFy/AigfWC2t9NLmdZOZeIDf7bzZRxmjpaWkN8dr4e+vr69Xr7lunpLntY2Ni30EiN/g38gZlpBS1n3zyyZ6MjAz7N14nyBRiYVHhqfvX3aeMD4zKKTlfAd4vkQaqhN3SC6fZCLe1lyjZKzgvEVNmrPdZqVL2LT3tiPbWYlSsmVrCJXB9t96K5hYzHE73DUtEOML3fUPMbifZuqce2ma7sHfIyl1M5dVD9vqkz46akZkY9+DPMHfxEjJQ8lPvv//+r7bS8de//vU6m3z/To/T6TQQ4sedP39+aHJKsob4/xS1hWCAurq62JpA1bvvvvsZBcQ+ffr0b18VZhufpWVl/37m6Wd+rgnUBB44dxV1plqIFVECfLDr+m1GPVWCFHJVMPUVOXeFP6G6HBJzO9KCzMIke4wudHTp4aE694o5gaYGupsYg5P5oeVoC4KvGaASvkcIYUm7lvOgSqtBQN40rF+8GJFR0V1RkZFnN27c+POPPvqo41Zjpn7vfvqZp+/fvHkzTxlfMWzYsHuUSmXx4CtK7uhK0fLy8taoqKjilatWzclIilEq7Dq019fDYDRTNRAyE5h4WRlTNdjM3XBYjDD3dCHYWYZ5Q3sgk7nIMTJU5wXhw/PcoGv96X6vD+1HdZAdaUaU1SssdlRxLlyPJQ+yZB6m/PKXWLZ6tYcMzx9379797IYNG3534cIFy7dd1NHR0eGpqqraPSQzMy02Lu6Xc+bMcVEwznw1CGyvgK0JYubMmd/55QTS2Bkr6Rg6JGtdY2OTdveBI7hwvR7dojDwAWGkaRSQiBQCBXqMjZibVIW8EYQnVMI+35cLHKxPGW7IZBK49W40fNQEnG+Gk9itVUZ0GB2OpAXzMGrWbMQmJHS0NDUdeXf79j/QBArZNch3chDaK99+++1/kB5QkB2e/9X3s93h2w6A4Gop4xMmTNQsXrz4aQrEQmKMuNPni1HZoIPObCEDowZns2NIuA4rpkugZrqD8bhY1H8dnXCJq8vuQWuLG1dPNUBSaYU8PBRcWjyyps/AuNzpKC29vtPn9ekPHzz41o4dO4pJxv7XX3AOCAjgSQuISRI7v1o1dxyAwcfjjz8+6je/efGfVRXl4Y2N9cGNrc1oaNahqbKcJtgNdYAXUr6v3+3ULh5SdQz13W4XJGoNVIEJNLhQxCfEQJue0aONiTXv3rPraZW/yvDSSy/tq66u9uAHPoTrA5gRutPJs+PVV18tOnz48CRCYOkTT1A0ho+ePXGcPJ2ZDn13D6G6gyjOJWyoGul/htRsbzEoKAhx8QnQdepOKeQyu5+/f8vW559/rlOnc14rKenAj3iwDROO7eO/9957uNOv0bNyYqDCMswWHhctWpRCfbaIJu6x223C83205Br4djhrIalMxvv7+VnpM7cTVzv7L6xkSVAolQI+/FjHlClT8H8FGADjk8Qr5h5T8wAAAABJRU5ErkJggg==" type="image/x-icon" />
-
-  <style type="text/css">
-    *,body {
-      margin: 0px;
-      padding: 0px;
-    }
-
-    body {
-      margin: 0px;
-      font-family: "Helvetica Neue", Helvetica, Arial, sans-serif;
-      font-size: 14px;
-      line-height: 20px;
-      background-color: #fff;
-    }
-
-    header,
-    footer {
-      width: 960px;
-      margin-left: auto;
-      margin-right: auto;
-    }
-
-    .logo {
-      background-image: url('data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAMgAAADICAYAAACtWK6eAAAgAElEQVR42uy9d5Rd13Xm+TvnxhfrVS5UIRSIQEIAQUpMIKlEylGWZblbTi27veyWJXcvjZqW7TVrrPG0Q0vyTKtHtnuWou0ZS1Y7yDOyksdjK9CkJSYxEwCRc6hcr+qFG8+ZP859r14lEAAhEKBqr0VW4b1X995379ln7/3tb+8Na7Ima7Ima7Ima7Ima7Ima7Ima7Ima7Ima7Im17qItVvwyss3vvGNHq31/QCPfPMfAajPVEmTmN71G6kMDjE4OMju3bsBgi1btnx17a5dHbHXbsE1IduFEF9YbcsSYtGLp4E1BVlTkFe/NJvNIlB55plnBqanpgCIwwiANIlJ44QkioiaTcJGg/m5OdBafupTn1qfHeL8e9/73mTtTq4pyKtStNbvFkJ8rK+nh69+4a/RCE499d1FHvDc2Fls3wHL5cGvFXA9b/g1d911KvvAhsyirMn3SOTaLbhGlKXj/wvqoVnh5TVZsyDfV1aEKGhy+sghANZVisviDyGAJEIEMQrNd7/8RQDKg0OP/ca7f1n99C/8W3buvgUhxGfylcrvrd3VNQV51YgQAq01aZyAANu3MpMhltkXoRVaKaKg2Xp9uBW/Z8fpXrujawryPZGpqak9Qohf11pP9vb2/vurdNq/B05Pz8xs33H7nR8COPXovwBQci1sKRBSIiwLpEY7AqElPZZvAvrZaWIBjzz0zxw4dGjtIa4pyPd0J1+vtX5nFvReFQXJ5/MHgYOf/OQn9/SPGGDqYGxAqYKVhYiZhREIdBYx5i0H0FSDBlprTp84ztjsLOi1YGVNQa6w7N37whuB1x0/fvy1URS9UpdxXmv9hwCbd+1+AGD27EnmgyZplKBUhCUEtrQW/VEUKzSa2XPnSMYnSDWve+tb3/rA9m3b+NDv/m7rY39cqFTU2lJfU5DLlZ8E8cD8/Bz797+4NCl3VeRXf/VXjwO/BvDXn//8AwAP/+MsM0FEFATEYYAjLXKOw7JMotYcO3OC6UadSm/fG3sHh97Yel0IkQD/B7CmIGsKcnkShiEASZJiWdY1cEX6UQGsG9mwq7unt9is1QiaDSzAzjwolblStuMggDnbxZqbx3Y94jgmSRI0BiFbkzUFeVly9uw5hBBEUUQul3vFF9XPvOvn7wZoNBqPCCH2TE1NMTc3S9BoMD81idLQSM019vX1IaXkmWee4fz58xw+fJjnn3+e2WqVUKk2QrYmawry8vbsjkX0SrhYq4AGCZDRSFrXJFiWTDTXK4UQsnXtesG9um5oKM1m09Zaty1gp/T29iZrCvIKyYkTJwAolUoMDw9fS0p734UUuVOUUn/Z19f3zlOnTtFsNnn66acf1Vq/wSQZxfWiJN8SQuxZYaM4DWxeU5BX0Hp0Wo1rxYLk8/mLXtif+tSn6LQeWutXdNe9zOdgCyHsFZ7BK7pG14L0MEQIQbFYpFgsXp9KXq8D4KYpFd9jy9atu55++ulHtNYJcN+lKNvVkCNHjuwSQnwmTVP+5rN/hlKKP/if/6ddWmuIIkgSkBLlugjBwO9/8H98JFOin/hfPvy/ja8pyFUUlQWzwDWCYl2GgqQpQggkGkdKCrlcEdgjhEiu0SC9CCxyp86cOknQaCCiCJkkaMtCeR6247hbd+7ck0Vg7poFucoyPz/fjkFc17k+FcRx0IDteuR9H9dxaDQa1+z1fuUrX2m7gycPHUQrjZeE5GwBwkE4FikQqhQdKQ7sPwDAu/7dr/ynJ574qZoQ4s9vv/32Z9YU5CpIrVZDCEEcR9i2fd0qCIDlOuQ8D8e2CYLgmomnlspDDz3U/j09fwoN3NBbIec6YBk+TZym6DAiSTXHjx1HAI7jvDuDrr8NrCnI1XKxWrvZ9epitRVBm++j0pQ4jq+56/yXf/n2dmD3E489tv2hB78JGsJmBFozl4+I0gV3MNWaSIGWFpu2bDHPSquvaq2DDNlac7GuhrT4V0ppHMe9Lr+DlLIFBZFGMXEYUa/XMuW5phT5rVrrj40MD6Nq5vrmp+ZMZsdysT23jSoKKZGOTS7v8eYf+EEAjh0/8e9/6Id/5KpWUH7fK0hr981yBte3ggAa3YZ6M525ZqSZxUVRGCKFqZcsdVdAg2Vb40AkOi5aIBZbyFeAU/Z9ryCe5yGEwPM8fN+/Lr9DPp83v1iS+SBkPgwIgrDtOl4r8q1/+Bpaa8KgST7nIqXklx/43ZZr+xM/9mM/9uhKf/dXX/v7V27zYU1eFdK2GOKavsi2Vbhe5JqwIFNTU58QQrxNa/2Z3t7eq1pT3WLzXo2gdu/eFx4DMbz/6aeZPH+eRtCg3qwvVNhq6O/pB2DLrpvJl0porX/39a+/908udNyuri4AfMdFhAEEAfF87ZpZZEePHv0E8LZv/sM/FP/hS3+H43lU1m8CRFSv13eUy+UIGF9TkNWlT2u9HrjqNdVX2QUZBtabWKHjGoQ2+rFi0zheMr3fip+kyI6s9TUVfGit+4QQ66WUxHGEsG2EZZbeww8/fPrjH/94xDUq14SCtJJanue9tdlsrtdaH8zn8x+8npWl0WjsEUL8em1ujr/44z8C4KG//oseAciggR3HFHVKQS1mgciGSVw+efQwkdJEWv3ib777l+/V8O2P/smf/eFK5yoUjA45fg5slxiYnJleifz7isgzz5iUxelTp1BRQqk3z333LXAxP/7xj6+5WBeSOI7RWuO67nat9Xbg0VfiOq4kitVR497GWs8c2E8aRwwV8xQ8B0cIbGuxPQmaDQRw7th55pohhcHBW/3e3lszi7CigjiOixBg2TZaShTQCJrXjL9/7tw5AOaqVXSa4jouo6OjV/yev2oVpLVzJ0nSjgmulrQShUkSEwTBFTvus48ZHQ+bTeLqtPGvCj5SuZQ8B9eykAKslluUpkZB4hgN9OY9io7FfFBn5kyAnctt/eAHP/iA1rr24Q9/+E8WxyBlY0nyeTzbRmpNbXr6kq9537697waKWuu/37lz18ErdS/OnjIlBbX5Kq7vEkfBWeBvMPUqak1BLlKSJLnqHKI0W5hxnNBsXrlzP/JPX0cLjU4SomkTf27uK+PKxTumQKB1Cspcx3hkwIKhooGc950ZZ3x8isrI+luBWzFdVxYpSE+PCd3KxSK+7WApTXXsPEIL9CX4WFrr/wSszzLVV0xBjh560XzXNMXPezQbtZN33HHHr62hWBf9YFRbQa42bt+ilwgh2tbk5cgTTzwxCgw9//A/b588fRqhUrpcc5ulVggtSBQoDalWJEqjlUJnihHFRlGkzAJvS+J5LkorpqamsCzLPXLkyB4hBCdPniRJEs6fP98jpWRufp5UK+I0odZoIITg333gN/b84gd+PVlwx5wW52w6azvUlvHxCeMKnjm9/Vd+5Vf2vPmNb+Qdb3sbQK3Q3f3CZaB2rwPc//rh3++pzc3h2jZe3uN6kmtCQVp0jziOieOrW7qQz+cRQmDbNmF4RcCU/yiEeKCnv4/D3/4mviW5Z8OQcbeCJqSK+UARJJpqGDEThKhUETeilklBayjkXSxL4ubzDHge4/UmTz74IP39/QNCiEc646bvPPook1MTnDx8hHoUEtdg34mjWFLajus+vJKfL4T4W+CnOl/76lfNVIVms/mhToYBWj8K3H0ZcdiXgPWDPQOMHztFef0Im7bdwPXUbPiay6RfbQvSuXiuxLnbNI8OusTi4wqDLgkQmZUQQiyGeAULXUn0EuqIUu2cTZokJGmKSlNUqowl1iamEdnfpkmCXsEyKqXkG153q2tJi5HBPnO+FnFTqTbpMclioq997WtuhjQihKC3twfXddFaJzt37lIXiu/I6C+I1v0WawpyKdLVVQFgYmKC8fGrmy8aGjK7e7FYZG5u7mUf7+mHHzZrfG6aG7rLWAKi0PTSzeXKCCGJ+3xs12awWKSr0sXU1DQPfuPhRcepKY1QgrHJKcZnp5mqNxk/P8nUcZt//WNvBaDbVoYKobNIQ0iEJbFSG3/MuI6//bM/DUBVpyRomrEiSBVK63doxHwnhnbsuWddDZw5fZK5uSp7v/0wf/tnf8bA8PCd7/iFX5hf5Sv/JvDHK72x/zsGqBg7dYr5uVle0/da7nrTW8yb//kP1hTkcnfg65U02LlryoxWsTgVIRYsSGa9pJBIIdGLPtlp1VjU40pr3e76kRpqYru9j7BAtPqTZpYkiVufTUjRxIkiThTC0Izc1tkWrFVGmdeaVKUkSYJKU6m1dldx1eSFYsvF5ENDrLyeWhFdKwryQeC/nj975ueCeu39pXJ5V6PReASIgLdcjZrqMAyZnJy8wv5be6kYtGn9ALZjs2FgALtQwLIktm3TU8wxsfsmAD7zl19qu2YAjmVyJet7erh5dAu2Jdk83ANAv+VhCYFlgZQwF0RMNwNcKenLGQKjyIrA0gzPakYJYZKgtDZFSWgMLiCY9c3iHVzXT9LbRb5YolipoFTIP/3Zp9HAZGjcO6tUQdg2977+3v+4b98LP6M139y5c9ei5O7Thw8DMBMEaMelt7+fXTfeuBakX6qMjIwcBPib//75PVqDRLRqqiOuEqGy1ZPpZe9uQnTQPAzJSmjzuuO7OI5DqVjAKxXbCcQw9CmWCqBhdr6+2P0s+NiWg+84DHV1YduS9d0mcz4g81gIbEcgpWa6EWAJgWNZdPseIJC2beKg7HiBExHFCYlWREmC0lBtmNxTJA265noOypL4OY+c79JsBpw+ZcowTjdCQKMKXWA53Hb7baMgRoXQy+o0qvW6qdZMFQiB67rXXWOMa8rFEpaNwGSOWrXi32uZzhJqvu9fEZh3yw5jCWZPHuPY4X24UrLRLbVNigZqzQZ1UiZn6pydrFKv1zn84lEAbho1Xd4r+Ry2bdGV8yh5Hp7rUs4XsSR0Z4VdjjBOnFYJqVK4QtObc5FC4lnmM0Iu3l+k7eJJ28wkcU3wXHZzAKyTqXHn0hStNbbjYLsuUc6hz9mERrNuvo4GTs3WCZKQvf/yMGMHD5Gk6e5f/Tf/5mN+Ps8bfvRHAfjyX/1VJYoibFvQ1dvN2MTYQwK+qOH8moJchsjMHVBAvV6/KuesVqvt1qNXouR2/WbT4yytz1ENIzwpQTiLULNGGJCkCSfGxnnu8FnCRoPJE2cAGF03YI7T203OdejyPYreQu5Aak1BK2OdhIlb4ihFqRRXalzfMUZXrNyAwrFX/46um3mFQmfMSXOOFBjsKqO1pmvObFzN+nHm4oQjTz/DE3N1egYHtm+/5ebtnWhgs1qlUavhV0rkukqcOHn8qXyl8odrFuQyxfNM9tiy7CuCKF0KLJskCc1m82Ufz+R0BPUgZHKuhmfbTHabHEfPfB3btpibnSPQmrnJOUSjgZtE9JZMzGBnCziJE5pKIy2H2DLxQ6I1Qmn8wFDZfWkhBLiWwJayg8WrQV962KbSlRFYhUBgAu5CdpqRSpnufI6cZdPlu+RLedwoRGrFib0mp1idmaHZbNI9PERXX+91CbpcUwrS3d2N1pCmCWfOnLkq52xRTYIgWNYT9nKkVjMZ7MmZOV48M4Hn2gz29iA05M6O4UjJyfNTzNbrCAQ24DgWoxuM5Zir1tAI5oKIVGmqwkVYPlGqqKca0gRrfALQrHN9bCEY6euiXMhBEkOaZLHPpSc9V/v6Go2dac6AbX72bt6AkpLpuXmq9RqNWDHTqBElCQ/+9ZMAHB+fJlaKW9/wBm7ctWtNQV6utFwcrdUVWawXkqmpqQHA/chHPlK8kvwv1zW+v5/LUap04ViSZlb+GsUpWBpLCjzbJlWKRKkMScrgYd9YkpwrUQhkVwVZLBIrjZ0piI6M+ykTo9yRgmYUY2uF04aLXxps0FxMyk50fFAvtitaYAlwpIVnCXwbLKHbLqFtSRSaNIkJgwDHcYpPPPHEeiC64447xtcU5BJl48YNaK2Znp7mueeeXZRBvuKAgBBf0lrvectb3sLevXup1WqMj4+/7BzMrl07EUIwMNBP/8gI1elp/ssHftPQ+e/YRd5z2LFukJ1Dg5ycnebQ1DhBmjAxqdDAnp98FwA7br+NYlcXpVKpjfxorUnTlJMnTwLw4Je/RNBosPf5p6kdPsbm3l52DA2CVggdLGppYtIiegUYukVo7EDfMiROwLJZojp73wqbCGAo57K+VOhwV+H2DYPGxXr4EebCkNNHDzA9Pc5td+x5N/BuTDnD3WsKcg3Laop3pegmS12U5fvy4snoutXB48JKfREn7zhetu4XNn+xsgnRneMVVv6sXvb+wjXrZfPdV7ig1hGus5kl11TK+uzZs0Wg8q1vfet1586d+5KUkp/6qZ86nbW1+YmRkZGnrtS5PvvZzz4C7BkdHWV4eJgXX3yRL3/5y5RKpeQDH/hAC4a8a2Rk5OylHLfRaFSEEMV9zzz7tma9/oladZY/+5CZF/iv77qFvOtydHKKmXqT/NAIhQ2b6e0f4L63GvpIqWKSgLbrIKU02fbOtj6ZFQFTa6K15snHH+fs2TNM7X+Bsacep5zzue2GkQw1CBF6ddL7SxcdChYVza+otGLRx5VjXKwD5yaIk5THjp/nzMw8ynNROY9KT0903w/98LhlWbz//e9vdzURQjx1rSnINWVBhoeHa0Dtox/96PqOXWZ9y72/kudqxR1CCHK5HJ7ntWgudsc5LzlJmc/nZ4HZ73zzW5Myo5LYtmxDrK5tkShFM45xEVheDieXp9RjUJ5cLveSlsm2TR6jYJo64JdKuIUiOA7NJMZPl84yXF0N9EsowEtbywWcq+Wqtc6ccxxcKRFKkcQxQRxTb9SwbcftuMd8L57vq9rF6uuutH3hEydOXDFu1t/+7d/awOeEELZlWdvBjDHr7u6mUqlQLpfxfZ+DBy+/Vmh6evrfAj/+yMMPr//aF7+IjeK2baZ15sTEFJNaUxoZpbi9wsYbd7Dtdbfh+X47uL8Y96OTlwWwdetWRkZGeCEOmTx+FEtozs7OIYRgXSlvln2SrNDIQa/iSIgl77/Ue2KxC5YYBK3sOCjbZvtQHz2lAtPNJuONGiKs8fWv/B2WtOju7l5kIdcU5GJ2Yd9vB5izs7NX8tBSCPGvADeXy2HbNvl8Ht/38TwPz/OwbbudXb/M+OO1Qoh3pirl+WefpeA57LnrFjQwduwYaZLQX6yQH97IutHNbLlEblKLzNn5s7fXWJ+zA/14pS5kGlGbM00b1nWVzAaTZceXYlft5b7MgOhVfDG9zKUSSy1KahIqnmWh0fQW8ziug7QhSEMaYcTRgwcQUvLcs88iLeuajUuuSQUZXDfcDv6OnTCIzT333PNzWus9WuuHpJSX5Kt+7nOfGxZC/HQQBHaxWJQAg4OD5PN5SqVSu7Nif5bMaqFElyNPPv4YAOePHmV9ziPn2OjU7KiidwCpYd327QzesJVyTy/VahXLsi6Zo7SooCmTQleF4S1bieZmmTp/ppMT3BF3i2U7v1g46DJLsUhvVnh/mUp1nCNWCqXNBOEkSshLm3XFEoEXk6QmS3/swH4QglJ3z8+95z3v2QM89OlPf/qpNQW5gKzftAkhBGma8p1HHyNNU/L5/PuzBfFrwKXewI3Ax1r+u4GUN9Ld3d3elXO+x/DQEGEY8th3n7xsROufv/6PZjuuzrAtnzPxRxqCBjm0CSyb0VtuZfuum6lWq0xPT+N53mWT+DrLA7p6+xjdeTMzZ09z8OEHO6KLJf1NVkiAiAu9t1LC5ELHyN4PlUIpU++fRgkFx6E3nydIE3zbRaH55tNPooHXvv5N78+Z9kWX83y/72DeGlnrnzRN71RKyersLLmL7J27d+8L24GeU6dOc+TIEdI03ZXL5RBCUKlU2iW2nbuxbTsUi0Usy7qsxhFnzpzZBRQ//UcfG5qenCQfhVTyPlIKgijNdvgucFwmJqfQBw4wNTXFuXPnqFQqlEqG0Nj6ebGK0WlBLNvGy/k4nkeavaeURgtoVZws7li3AlC1EmS8QmNvxeKQRgiQSwyM0pq0VdCS+V9KK9AaxxJoLegpFgyTul6npgXStje/5z3v2aO1nv7MZz5zcE1BVpAtW7a8ANz9W7/1Wy4wD7hPPPYohVz+YnGWD4F4Z+uZpmlKb28vUkruvvvuZUGh1ppSucyOXTczPT3NsROfvhwM/DNCiD053+eZRx9jx7o+7n/tDqI45dkTZ0HDm991F365iy9+8Yvs37+fw4cP89xzz7Fz504+9anPAHDvvReXP1sJuMiXygxu2EQaJwSuhwSCKEUg8AVYnXdvJUXRK4Ufwqx+Z/HmFEUJHeM8sKXAcxYTIcO0SZqmpBm5MlUpcZwigD7fRSN4041b0ELw2N4DVOcbbLh51/vzXeX3A8tq5tcU5AKLQCtzgwH7k5/8pOt5HqUM5mz1s2pmeQGttexMTEkp2/+t5jotGoOQvdbf3+8+/vjjrhAiueOOOy7IhW9T5bXZSUW2w+pWHbYw521XHHYoqbEEWaxwCSjW0mD9An+xOIbQmlYGXawSSyxtTqSXJDYVixOEGmMxloYqK7VT1UvAM6E1MpsJAuYeCZC///u/7wL89m//dpR9bxdMKUQURThSGpImqEKlknzfKchNN93U/v0b3/gGU1NT7Nix4yNbtmz50IX+7tmnnrIdx2F4ZIS3v/3tOI5DX1/fqjtvlr9gdHSUYrHYduXe+5737M8+/3PA/3Ohc37hC18AoHr6JK/p7aK/kGc6USjpULnNWIV9Bw8hLItiucSOXTtJVMr4+Dg9PT2XFe8shXsty8LzPBzfB89DCZhrNBFC4JRySNuCNIaMw7WotBdA+O3XOpUkiGKOnxszrmQUoDXkiyWsDjc1DSKS6jxSCPpKJpfjeDZgKiIde4lyZP8oKPPCPTduQSF48fQ5ntq7n+GtW96xcdOmeUwfsC3Z99yP6dvVMVVLk7njb/q+U5DOHVYp1cog2xdaTC3+VqtDR8uCXGwuZQk65Ga/vyRQ38pug+7YqBcjTYuvS7ZjiIVs9JWBOpeCuFq3lvvyHIZe8a87rYVoo7sajdLZ9+iwGK1zKKURcqk1Ww0uXmyu2vchq4lH61bNfGcCcem/O1///nOxWv1bAYaHh3Ech8HBQQYGBigWi6xbt24RobGrqwspJa7rYFs2juvi+/4lTY+ybZubb74ZgGPHjuE4Fzf59rlHvgPABhdu3LAOS8P8xAR2Ls/m15tcx5npWVKlePTb/8KhQwdwvRybNm3ihhtuYPfuXRe0cBcjSimiKDKlwx3OkxACkgihM0KiECu7UraBrKbDiCBVzDcDZhtNrFyB0s2vQwjB219/L0JIunt6Ft2bZqPB9MQkcRRyMGtWffT5p0iiiLBWJ41iCjmfrkIetFrg1mfK4ugENGwf6GJTd57pNOb/+8qXsW174D3vec8jAL/x3vcOCGCiXqcRRfgC8kKQJMmud9x/3yMIwb0//CMIKflXP/7jDA0OorX+o0J391+9KhWktbhbv3cm9Hzfp1AoLEJ0+vr6MgVxL7s6UErZRpKazSZhGF6U+zPXSmj2liiWCqRhTBw0EU5KvlA0lmWmitaamZlpzp07S//AEN09fe18zMuV1mahVrreVteSVbMYoKW5jzGaUKfUk4RqGOJ5OcqlLsNSHt2ClJLe3l4cx2k/n1qthiyWCJtNOHIMATSVaQwRJ6Z3l681QshlGAAYlA2g6DkUPYfaXI3J8XFcz3PJZqofPnCANEkYq9WoRRF5AUUJtuMWyz29e1b8zkL89avWgnTOLR8eHm53QRwbGzPdQHp6lnzefdmzBqWUDAyY4qXTp09fsC7lyJEjEvhfAfsjH3hg49TYGLneEl2+R10r5moxLilupshbtmxBA5s2bWZ6eobdt9zC3ffcy9Dg0Mu6T62WrY1ajZmJcWrTU8hGAyHA7620IdhOx2lpmyFTKx+hNYw1Q2bjhO7hDewe3UKuWOKGXbeAEPT29rYTq52bUKFQQIhB0jThnjeZcGB4aIAkiXnhiccZP3OWZmOeY+Pn8SybdaVipzFb+F1KEBLfdRjMuyAEB5991oAmlsQC8qUcWuRwAKeVmFQxAjj6hEnUfqlRJ18qsWnL1p88c+bMJuDpkZGRz76qFMSYcJ0hSn3kcjnm5+eZnp6mr6+Pcrm8ahzxUrv+ap+RUrYV7/nnn3+peeNSCPEAYJd8n6kgxBdQdD3iOCIkBaGwfRO09lUqWJbF0Lph+s+cZceOnbzl/vvJ5XKrImsX+h6t91vxWdBsMD8zQ6M6iwhCU47r2AYhIlnBqRKLooRGGKM0TDZDJpOUvr5Btu15PcVikZtuuumC12JZVnvGY3+/2WAGN2wgTVMmZ6rMRzFTp5uMTU1T9D2G2jUutBG8rN0kwrbwHIc+3yVKU/YdOmQUbusNeI5DV8HHd+02GpeqlEbDWPrTe19Aozl77jw4Lu/8+Xe9EXhjBhu/uhSkc/fO5fJIaZGmqWlsphTj4+NYltXmIl0pafUKnpqaotlscv/999+bJQ8P5vP551qfm5meWvD/0xRbgi0ErhDYSNAStECkZr/O5/PYts3u3bspFIvs2LGDUqncJipeqnSWC0dRRLU6y+TkOPPVWcOiFQLXkggBYmlmL9OPzlemanWiVFFet55Cscy6DZvo6TEtRlvnMs00NPl8YVHj75Xg81anmNEbbsC1bY57Ls25KjYwVquBgMFS2ehFGyZX6FTgCKj4HkmqGO0xI+YqvodjW9iAShKU0iQGNciSlCbxCBCgUGnE+VMnefbpy0/MX9MKEoZR++a3TLtt21iWRZIk7N27F8/zuOeee67YOZVS7YYRhw4dYn5+nl/6pV96AHgAM8Cm3bb/6KGDtCrx0ijAtSQ5KckLSU1YaGUhlI0MUwSavt5eHNflXe961yVZu9Wk1cdrZmaGer3GyRMn2LdvH7o2T8l2TZMF10IKQRSaHljLEx4LgfuBcxPUo32UO40AACAASURBVIT73/JjbL71taxbN8zmzaPt4F9rzZkzp9FaMzq6uU3NX60bZsvCv/EHfpA0injk4YeoBxHzc1WeeepxpBD8YHePiUvi0FxHqtCpIi8ko+UuhIBtGSXIzUp5q80GQRgTJimNMMG2BKWci0AwuG4AEJydmiYIAp77zsM89fzTl40PXvMwr14CJdq2TS6Xw7KsdoKwM3G20k622iJcBL9mrkqSJB2QrXG54njl4TpRFHccCyxLtJN+Wi8kBrME5yWjuC9V9diKPaIoMv+FIWkYIpIEx7baLUVbiiEWf/n2JSXKsHxt38e1UlzPw3U9bNtqd3yZnp5Ga83Y2Bhaa7q7e0jT1ORdXgrpa9exOOQKRZI4xnbNgk6UQgqN1C0EeHHZ74IeC6LWLJe0VcsPlm0hpUBpk4yNU5XFXALbsgzVJUlxHMf/0uc+v16g1dt/4efPvioUxOxai1+74YYt9PR0c/DgQb7yla9QKpW49dZbjQmuVC6YLV9pobWUpNFoMDExQa1Wa3dUcRyHXC7H/v37V9wl9z/3LK0sc8GxyPdWKOQ8tBbESUy1Nk9Ta05MmQLF16gUZwUFvVA+Zln5rtYEmYU7cvw4cZJw9OhRZmZmOL9/HyefeILegs+btm5EAHOBaQbnC40lsoy4zhrKOS5Jojh0fhyhYevr3wyOy+vuuZcbsiStyGpyfud3fodUpXzhb76AUor3ve99jI6O8qY3vYnbb7/9gt8j1ppIKUa3bucdhQpnz5/l3PwsAjh15gxCw7pyAd+xFxIl7STogo7tHTtPpFLSUKFSRV9/H0MbBwnjhInpGVAwNj4BWrO5v5e843J+bo6ps2cZ3nbj20C/TZvE44ZXjQVZvIbESsk8VmmqfMF/L/37zvdX++wypWv9ffu6VrpmLurYL6UoS62HWPW7LI4vltaHL2K8ty1LR0NtVrmn4mKe1Ut/jyVzR5Ydu02yX1rouPT6EMuegW5/P7HouKt+p+tdQTpHIaxfv55czqerq0xXVxcDAwNs3boVrTV/9VcmD/QzP/Mz5PN5HMe56DzI/Pw8jUaD+fl5zpw5Q7PZpFqtArBnj4HW9+7bx6OPPbbsb08dXiCbbhAhviNwMxaYIyVl10VrxVPf+iYAb7nvPsjnL+teBEFAtVpFJSlTp4yH8PyzTxHFEc9/59uMnTrJxu4i9960Ht+yqHi26Y6VjV5YsSWEMC7OwekZAH78jjvJdVXo6u9vW9X5+XnOnTvHkcNHALjttbeZvE91jqNHj7at90WBLiolSAIKhRw/8sa3oNKU//5/fZpUpRSLJRAuNglW1qROC0iU4njVdHOcDEJSpXjLna/lhuF1SEtiSZNBUUqhteafvvkYWkOj2SQKA1w0AwWPaG6Gb2XP4VWjIK1YoD0HvIN0aFkWjuOQpmmbnt66SRfy45cS/FpNEFr/dY6Baw2LiaOIer2+LIRI4nhhV3L04sLTzA9WQBQGtIiIS+OlZZahw1ItHe6TZsNyWlO44jAkikLCRp2wVkeXPHzXxpMSmblT8oKbpu6IQcDxPDzfb28uLbSwdV8AfNc3fRaz3mVL+xmv9D1WsiY5P2cG9GhlKPntuKODAZAVQCZKAQKVxVOe61LM5xasRGZhlMqeK2Sf1dmICUGqlIHsX00K0tnAulwu093dTT7bgXt7e7nrrruo1+ttouBzzz2H7/ts2rSJrq4uHMfBy5CP1sOq1UzbzomJCaJs4QdBQL1eZ3x8nDAMqdXNZ267/TZ8z+f82BhT09Ns37btre/7D/9hPXDwne985wenZhfao24bKFN0bWwJaRrjWpL+rhJBqnjqwF4Tszz/LF2VCkNDwxRKJRMHLKXeZwux1miQpClxIyAOQlSaEkUxcRxx8MXnAXjmoW8RNJsM2jA6uo6BcoF+10OgieNwCVy1DK5DxxEkKTpLyA4NDFDu7iGX3bMWehWFIcH8nKHN95pcx80338zAwADDw8MXdLU6a2+khDiJEJagd2iINE3J5btI05R6EJBGESXXpujaZuE7Ap1CvWGeR1d3H1gWxa4yXjFHEMY0G4ZaU52eQaOphyFaQzmXx+roQxwLaNbnKJZKPc1m8wvZy+/N5XLT13WQ3lrcvu9TLBbbNzuXyzEyMsLc3BzNZrOdYXddl97eXvL5/IoBewsanZ2dpdEICIIGQRDQbDap1WqEUUgcxSCMEhYLRfys40k+n98ObBdCPArQCML2ApSiC1fayGxqrSUFRd+HKGJmahKtNZNj54mCkK5S2SxC20ZkHUraFi2KDE2j0SBOU5qz80RZR3WEIAoDpibHQcO5E8cJ6nU2bx1lpKuLrpxH3rayMW3RooBkeeM4bZrxagXZNZQKBbpKpXbXlDYKlySkUbTI7gwODLJh44ZFFJlOdnGnkrQsPkKgVYqQklyxaHJHro9MU6LEwLx5S6C1hZACLHPNURwhEJRzeYRt4/getmtDnBKlmihOzdgIrYmSrPZeSIRl0cmOjKMQIUp5rfU7s+v7NeD6U5AzZ84MAz+dpqn99a9/XWqtsSwLy7IWBXm2beO6bnsXO3bsGFprKpVKW4lafK3Wox0bG0drTa1WJ45j6vUGzWaD6lyV02fMiIvRzaPm5lhmofT19bFx40bCIODxxx+nVCoNnTlz5oE4iuxf+LG3Sa0VUuk2nSPJFrNrW3ipjasNk/a5J5/Gz+expMy6yTvYjmvcp2zSb4vEVw8CUqWImk3iJKReqzF27hxJFHL2sMksj3aX0aU8g+UCXTkPv6Ng6aUax6VaEySaKNUUfK/tYlmui8zus+u6lMtlevv62Lx1GwjoHxpGCMHGTRtZv379IgXpdF9XdeqEQCKwpU2qBaW8Z1zp+SaJSlDCRdgL5YkCgZ9tivXaPEjJ+fOTWKkibIY0603iKKI6Y7hwvb4PaHwhcLVxtbQGKXT763dWk16vLtZGIcTHpJR4nodSCsdxFuHtrVjE8zw2ZyMHvvjFL9JoNOjv70cIQaGwnAR4/PiJRRalVps3bUcnxjly5Ai5XI773nyfqaHIzjc0NITjOBw+fJjHHnuMm266aVQI8THLsqAZopIEobSxHpgu7EiB7zqkSpPLxqI9+tBDIC1K5TKpUni2h+/kUCiiJFxeASsgSU1eZnziHE9991FUHHN+n+mefv/2UXzbYaCUo5AF5Su6VSt4WLHSzIWKFE2lYNxW1/dxO2gvuVyuTe95zS23tO8FwI033nhR7tWKMQgCR9pIJJWij1Ip9ZoiTiO0zIEjF+I5Ab5r+nydr86QoDlxqkB9ro6OI3QYkMQJs7PGHR8umuSkI0BoRZqaJivSkmBn586e68WkAq5JBWllsrXWFItFWhZkJZFSti2GSs0E2KmpKfL5POVymTgLpM3utlB12ArG5+fnqdfrqFTRVe5qt/5pgQRaazzPo1AotCkhURRx6tQpVJqCFAhLIqQZoNmiSwgNlhDYlqS7YALKsTAkRTA1Ps7ZcplSoURXudtQQbI/TVpdE+MIpRXNep0waDI9MY4KmpCmDJaNVcy7Lp5loYAgXQiWhdZIudAIfPUsZWZiLKeNai1NzLaIiZs2bWrHfsCl0WNaYxm0XtZyFdnKfYhl4AEKhBbks4FBVpyCAp2Y6bsySYzl1uDZVluhOstrlNYkGrSU+Lk80rEjsqYQ2QSz609Bvvvd77YX/1133YUQgnw+v6LGe57H3Xebir3/9kf/jenJaR781oPYjs3w8DCbNm1qU+A7kZWpqSmiKCIMQ+I4pru7m1tvvRXbttutgBqNBlpr+vv7GRwcZGpqqg0//+mf/qlZQL6N1BYi5yE8DyE0RBHSkpmyCe69cRNo+Ow/P0YtCPn6175mGLLbtrFz9248x2EkW3izNdO5/cTYGYIw4NThw4ydOklOSnosSd51+NHd20EI8paHAM4HNSaDhdkmvmUxlM1aicImK2dOsnspBSJXXkCRVognhoaGeN/73nfRmf5ln0sSdBxnvbmWXIpfAqVAWga1al2r0uhQIQVs6ukFATPNMSKdks7NUw9DcpZNXtq4UlDONo0l/ewIVEotSXHLRYY2jQKMCyHuvi5drH37XpCAfOaZ52zVURF4oYRgp8/7UlT3lSDf1Y6/FGpt/WwF/lnV24WTkx2z0luwr5TS7JdZ0bpWrfnmmmWp4458hVh6/BZkLARaLa4BUUpfINna2cR95ebTK8HhS+/Nyykp0Ks9l86tXy+ZMd95zSs8M7FKQrPTXl1OB8drzYK8H/gvW7du4eDBQwgh2hbgQtKCcm993a1Uq1UOHzzM9PQ0XZUKiVaQKqKmqVhLMjRmYGAA13Xp7u6mXC4vonaEYdh2I0wsU8RxHG666SaUUoyNneeL/7epw+krdyOEIElVm35tBi0qdBRgI9gxaKzDr/7AG0iVYu/5U0zUZmke/S5PHnsS1/I4WjK+vczOnc85eFKyFdjav8BWtizJ+cxaPHnoEFGc8Pjho5ydnsGSEkdaDHd38QtvuBMB3DS4mOmcJjFJmuBbgqG8RaDgxRljGYNmEze3kGi90MZ0KVKt16nX68xOTRNPzZA6DnavQ6pS5manUCql6PlYtoMrHHSw0AFSA3NtNNMcr7uQN6zdNG3D4ou9RtMtQwtNtdbg/HydHZu38faf/lkA/vNH//frVUFEdk0vTRO56KBQL/Nsl1mP1RaCWNJJsLO+fZGvvgQkEqtMppFSoJEsaoiuNVortDK17DpLiqGXdDzUHR1CtF7okqIX+k8JpUlFZk20RndyXzqutzV1twUAL9Scr255X5bFWMEV02LpA1ra3k5kC30x1UR3jlFoIxoLytRmqYiO1zqe33VpQY4cOfIh4P5jx44NHT9+nEpXmTfde2978PxL3fzOfAnA7lt349gOURLTqNcplYrcvOvmZb5160ZHHRh/C94EU1GYpinT04Y67fs5+vr6UGnKupGNoDWTx4+hgTS+CbTAsmwc187yBzFmyKaxCt2uoUXcsWmUuGPRGOZpFihn7tF0FBNrzZEz5zg9MUmxUGBocMBAyQ0TyH/7wGHqQcC5+QbzUWICVASRtHjk+DmEgFwxv2hhlKVFQXgd9y8hnTbUlZPHj1OYrbJ+/XrK5fKKzekuRWnCet3Q8cfOMzs7QxJF2F0l0iRl4tQp0jTl3JFTKJWye7Cbsu9iCdF2TVtSzBKZU3MNgjjBtqeYbNTozuXp7+hI2Xk1R8+dpRGGeOs2MrBtBxtuuXVRl5zrSkGEENu11nvSNGVmZgbPdenJOiBejCylO7SmMs3OztJsNBCIZXBvq74hjuNF9HatdVspgyAgjmNmZmao1WpZJ/gKrufh+wYOjaPIbHJaZdBshmh1xBJam+M7ma67np+hN6zazKSOsSZRmlBtNhCOQ5qN0gyy7ztTr1NrNmnEqVG4zBQ0k5TZZoBA0ExVR4scyNs2WLJjNUkTQGculvT8RRSfl7LaF3wuaWqSllFIFDRNzzDbJk0VUdAkTRVho4lKFRYa15JtG9EyDEKYIjSAOEmJ4oRGFCEcKLheBv9lDVY7DGUjjpkPAxzHwS134ZfLl9XedZmCNBqNtwP3xXFMrVbLfO6xNvza2rE7F2ULCmxRQgD6+/vbuYtWks91XZRSbTLg4cOHieOYgwcP7tZa4zgOd911F8ViESeX6/BCVkdL4jhm//79WY4tQQhBV1dXu6mA8dst9u/fjxCCLVu2mJgh4xEt5W8JIYizBfPi888TNBtZUjFianyMo0ePIqVk165daK2ZOmnyKuPVGbQKcfv6KLvdxr2xTG5CJPGiGnCRZhnsFcSyjfU6PzXDbDOkmUKh3E2uUMB1nEWeR293F7l8jsZ0laC5QC0RUuJmDS+mGoZ/FAYhOlWMA44WVAo5Rgf7AIv+klk4+59+Cun5lAsFisViOwN+cc3pFp5Vi84zfupkB1dOYFkWtnSoV+s88cQTaKXJuRKtBFY7BlzqghmrDNDdlSeXJFQbDSbnqzTLEWmsQAhklgaoZ8pd2XQDva7D9tfdwaabdlIul5/RWv85pq3t5SuIEOI+rfUDq/mQK/1+MRnUC+0+k5OT1Ot1tm3bxs6dO7FtG/sicfYkSTh27Fj791bupNWYugXlHjt2DCklm7LG2CsR7VrfqUXMO3H4EPNzC3ywIEmoJwl9fX380A/9EFprniiVUFoxXZsjjuoMFQskupLlF2yzI7YDyWwRKGUw/hVQnWwtMDk7z/hcDeV6+IUSXs5v52dany+XS7hRxNn5OixSEIHjGYChmtFh6tV5kjgxndaThA39PWwc7gch6c4Shcde3E8sJLfefjtJsgnbti+pvmaZgoyNESfRwkKXNq7jEUemGlQAnm3mSgu5OE7qvCktTlW55OMlCWPVGabnaqhEY2Mstu26aA1TGZXozt276eruZsctt7LrltcCHJZS/uHLtiCtG5KmaRvJ6Jx61OmWdLbSbPudGQozNTWFzCgVLf5T629aC3BmdgaljEtTKBRwHKe9e1/Mg1BJQhyGbQtXLBbJ5/NYloVSilKpRD6fp1arUa1WEUIwMzsDCPL5XHt3XPod2jFNmiJUyo2jG+itlJmp1ZioVnH9HGOnTpp7kSqUVsQKYgWNMGG+EWBbFrkswYVoJTnTRYtgpVi+dSV516Gc85lPFEEYEklBM1qc1yr6Pp5tU/Z9gjDG91wKnk+lkCfvGmujTRtPbMdGSEmqQ1QCQZwwPWcWciVnciaiWQcNYyeOk/N8Kn299A+ta1vhlaSTCd3yLGpz1Sy2snCkS6oSFJrJ6SlmZuYYP3euTUQd8UxpsCXECjNKaCeAAWxpoyyDcKRKoaSF8M1z9PIFELCxYjanLdtvpNLTQz5feEYIcVhr/e3Lcf+XKUhnr6PWwl7aXqd1I5IkJkkSkiRhft4EZNWq4cScOnWKJDHzzicmJqjX60xMTCClbO/uA0ODWLbNjhtvYnBwEN/3aTQabZr5hSRNEqJmk/rcHM9kjcr27NmDmzWLi6KIjRs3sm3bNsbHx5mcNITBI5m12XHjTfgFv21JWudL03SBRRxHkES8/d47uPfWXRw7fYr9Rw4zPl3lSw89jNZQzxS6HoNEMjnXJC+qlHyf9d1eBqtkQbFurlp41Ho5ydSmr1Qk53gcnZhiojqHjiPTBqnD7Ax1d4OGmVoDWwqGenoYHRzEtSS9pVyb/KgBL+cjpCBMFXEYUW00OXLqHJ5r88abtgPw/LMvQBzz3D8/yKEX9vGa227jjje/GSFEu2PJCi45YRjSbDZNWa5SNDPYuOh3IW1JI6yjVcyBI4f41sPfpjk/z5mzZxECbr/5RqQ0vDWxbBpPy0sw99i3PQzdTBIrjXJdRHcPtuPQ1dePAG55/esRUnL//ffT29uL1vrPe3t7/5DLlJWC9BlMWeJFIRZ6SWnkCjmKSrlcLraC38WolIHhwjCk0Wi04xRDIqwvojQsxeWTJKFeq7X/rvV6Z61I63XLsigUTJv9iekpQJOmyTLlWGpFVQYqBWFIrdFAaU2hWCAXxhnD11gZ4zYZuFZpTaoUqVLEynQyt7OiHrEK5WORq5XRMWxL4tgSz7HwHRtbSpLUxFjS6UC8hMaxbTzXxbakSTzK1eIFsShpmGbtQlvn9jIWL2lCGgUEjTpzs7PG1WohxhmFpfXAkzgmiWPSJDHnVqp9zGZgWNZztSphFFKbrxEHASqO8Wy7Xb8vLoj6L/5n572ybLs9vq5QKIAQ+NmYC2Bcax1dasxxMS7W7wG/d6UQqkaj8TEhxAPPPvssn/vc55Z9Y4HmySefNAhKtriLxSK7du1CSsktt+xGSsm6dcP4vvHDbdvmxMmTfPWrX0Up1bZwuVwO13UZGRlpJwB932dkZIRf/MVfJElTPvwHH0GplNOnTmNZFgMDA/T29pKmKXEcY1lW+3hVJFUFX3/kuxw8cIi77rmTn/yZn+XMufPMNg169tSf/J8muZiTuL7LnOszKVxqUUIgUmwp2dJvqBI6XNzXUCzKz2T+RRSgEWwZqCCkxXBPiWo94OzsHN89fhrLcRnaudv8fVBFK8VN27YY8KRWJ5ibR9kWKmeUSLpuh1JoLGnj2R4JMB4E5JXbzo3ce8NmlNY8feo05w8f4PzhA/zLgw9S6e3h9fffb1y/nj6jpEECSpOkMalOSVVKmsQolTI/ZVysp/c+SxjFPPbYY5w5c4a02SCt1SgXCuzZttW4utJ4C3JJpl+sMLPEs82eYGVKNbRhI3fe/5ZsvexGCBgd3dTaLH+it7f30Ze7fq8KzPvSAZ5ekQayGjnhQp99qYrCC1vBC4xFWIaurHQgsaI11UKvvi0ueu0l/C/NosTfitPJV6hZ10sO0EbU9Eo2bYF+Ijq/+Ir3VK+Y/NNi5esw74nFSrCIDrLQqKuzXP1CvbBXo9Nc0dT191IajUYFKNZqtbelafqJer3O5z//eQBu2rHDuEKZ6zU7O8vU1BRBEHD69OkM+zb+54aNG/H9HJY0o5WbzSZTU1O4rsttt5k66cHBQSzL4oYbbqC7u3vZjHGAibNn0Vrzl1/4AmfPnWNgYKBdqdif1WK3ZN++fcRxzP/7lb/j0IH93LLlBu7Z9RqajTonDh5EINh1g0HFdCMEpYiTlEQttJ6xLMnGbPZhXzmHlAKS2BQrXYiglD3xINFEiWbeyXEmX6FYLvOu9/0PAHz+L/6Cer3GPffcw4YNGzhy5Aj7XthLbW6WF7+b1WZnAXM74G0G6CBksKvE7g1DOJZk25AZDaHDAA3EQpIimKwFnJuro4UksbPKzIwUWBcuKYIgaBCFAUGjyfTUFGmqGJuYBKA2P4tSiq5CDs916MnlGCgWsaWk4NoIBBXPzTLous24XpQf6vg53gxQCp46cZrz1XnufvOb+eGf/Ekcx2lPDrvjjjtam9rd14UFac0NbzQak614oBUbuI6D67rteCEITGKn1edJa029UUejmZufJ0piLCGxhEHHOunoQLupXOc5lkopo8+LLMHYWYe+VJkKhYIBIZRiPgiYqzeYm5sjbDZJohCBoKuQN9nrxMC5WmsSlYIWWU10RzHUIi+6A7JZkYlutlgJWEJi2xZeLoefz1PJXEDH97HimEK5i66eXoqTU7iFAk4UkupWzyvVppGIDGI2dBaNY0tsaXUQHQ1FxbEdHClxpcBKFYlOCYIIECiRgoC68EiERdisEQVNGo0mc7OzJEoxOzOTJVrroBUl38HCxrEsfNdBItpDhsQSJsyiYbosmZ3YsmxycY+Czv4CL7c38yuWSc/KVH+q2Wz2bdu27RMA586dQynFxo2bqFS6GB0dZefOnYRh2E7EHTlyBK01zz7/HPPz81lQmFCpVNi8eXNboYB2HfrSWoXOJJebN2PcXv/GNzI/P8/zLzzPvv37KJfKVKtVpJTtjOu5c+dIkoS+/kF27bqVqFnnn55+jt68zw9u2wwIRnLGhw6y4qg4jkli45OHiSFIzlZNfUvZdbAsiSvU4nFoq/t+xErTTBVBFBHV54g7qgbL5TKWZZHL5XAch82bN9PX10ejXmPz6Ca0Unz6o3+AVorBQgHXsujv76Hb86jkPdZ3lU2QnCwpi0hjUIJu3yE/1I1SEGTo+0w2sXc6CIiVIk4iEp0Q2oJKxWw+G3NuBs8mGVDjYFsWnm3jCoElBYVVqvou1DguTiHJeGTSlliWwJYLSUYA53pVkFwudxr4269//evre3t7PwFw4sQJwjBkZGQ9UlpUKhVGRkaI47jNBQrDEKUUjzz6KJPjE+0+tC1IutXZJEPMXnL0gcwezKZNm4iiiAMHDjA5MUkURss6ltdqNeI4Jl8o0j8gOH3qBEfOn0f19jDUY0x6OWt2ZjsSJSCNHNI4JU4TGqGBw+fn5ozrEqdopbFt04VxaTak3dSto3mVybEokjQljSPSjmYMvm9oIY7jYNs23d3d9Pf302w2sR1D6UgSk7NyKZAXkv5CnnXlIgXPoex7gMq6rmS7c3t2nCJnOxR9P6PUGFqjqmfdHFVMlKSkKiHRGl+C7WXomu924mZooRF6YYybLQSOJVeJJ1ZvHJdqSFVGd5cmIWqxMDxUZLw2AVdMSa46F6s1WqCVZ2lBvI1GnUZjYR5HV1cXWmu2bNkCwJ133snU1BTnz51jcnKS7u5uFJoojjl8+HDGcTIKMrppE5VKpT1DRAixTGlaiNfg4OD/T92bBkuWnnV+v/c9W+53X+rWXt1V3epVvQhaLVYhMTaLhWcEBtsMge0JBmNPDDi8AR88BPMBMEvgCXCgsQOMJ5AljRgxEgSgBQlEq6XultTqtbpr66pbVXdfcjvr+/jDe865mXnz3qqWuqWejKi+t/NmnpN5zvu8z/Z//n9OnT5Fr9vjpZdewvM87r333rLxODjj7ruardUVqpUqNzYty8ep43a4SqdJfjcF7Sq0tqVRIwI5+XJQ8XCUskQm+vYSQt/XNBwhzYR0Z5cQxeXLl21elrOnF56kCDfCfp9XXnwRYwx110O0w9FWg1YQMN+oMlEN8LQiy/Y+87jzG2MgS4aS5LqbB0bNmi0T543SzBjiHLbTyz1Hu90fz6BfGA771bAOI47LTEZmBNEa5XqgNUYPf3A10A74D9JAjh49OtSItGwibSoVqxXe7/eHNAWLn6DodDo8++xXefXVV3E8FyNCksS89JLFYu12O2XItXTkCDMzM2XTcdRACjKH48eP0+12eeGFF3jmmWdKtpTBpH9paYlWq4XOUpZfvUBNKy6vbKGAd508aZPxrIcymcUN5X2KamAv72Sj+nVfr4oLFaC/2yPe2kbFaYk9e/zxx6lUKvuI8nrdDs8+8xRiDBN+ACKcmZ5kplajXvGoBp7tAyXxobUaEUOWmj0wINAK7IpsNaqg93vqzBhWc7K616KULBsD5xm7I+zN0pR2oRhigsxMWhqI9gLEcbEOZSAscxyUU7DEnwAAIABJREFU1l83Ifi33EBG9ckLCEqSJCRJMgRdH8R4FXxYRX7hOi71ep0sTfc6/SJkacrm5oaN35MErTWe55UVqmIhjZZ2C1LswY6x7/tl30XlTaiJ6Wl0mrC+s2nHctMUrbA3zkCGwZjkQFiGYGeqDySmzsFYOaLCJqLKJvw138FxYPemhaffvHQB3/fRXmApbsSgxLBy4wbh9jYgTOaMJb7j4pTTkNltLKC9WRIjEOXCn06OuPXROcWRZQ0RBIO9DnGU5Oex/8o58eLIo2wrapxqycBcTYlysJ6q0BHRjs4LMu5gcv6F/LvdskF44cKFh5VS/srKCr1ej+npaRasbFvn6NGjz31LDKSY9x7sMRTd8CAIqNVqpf7gYN39jjvOoJTi8uVLOXCtyT13WXz/uTvPAnaWvdfr87nPfo5+1OfokaOcOnWqBBcCZbg0iA/Lq22cPn16yICLqcN6vYHv+xw7dZrHvud7uX71Kh/8vz6AVop3P3QPjlJMonCBThLSS5KBPXcvkNjd2cEYIdrtkMXjjShtWWOve4KroFKrEAQVHE9zaqFJnBm++qcfRIBXP/UX1kj9Gonjo5IQJ+qTRCFb12+igO+++w60UsxUa/iOCxKTxOGBWLChh+OC4xMlCa+17XprtmooBUcdl4rjEccWAJmJlW1L05SNGxZqEidgRNmcSx0QS6qDcWmljfrWyMMotnmPdlAVF79Sp15tluw2IpI+8cQT3/me97zndiWhPwYcO6Cg9E7GR8LfnMdhtJRv9vlGmdO/0WbiGLbq8gYL7BOqGT1Oqcwrsse3ObJ4hn7K/m7kPt3acVN8eZosMvhnlQ/tqT3yERSHXZKyVTHasB2oOsmQGxj8zvl3HaFi3XcthprHcnv34etYP7c63rdsYKqY21BK0e/36ff7BEGFubkIzzu4TOu6LkEQkKYp165dw3Xd0tt8x3d8x1D16fr161ZQRoRPf/rTaK356Z/+aRzHoZkzCJ4/f56vfvWraK2566678DyPO++8E6UUH//ExwnDkMmJySGxmDBJWDh7F4jwex/7axDhHa7LhIJqaKjEZq+PIdBUFvYe5NvkESNUBvZMI4rQ2NBv17tuG3wqIwZ2jSESQTxN1vDQ2mFiwoI91XQbHMVcs4UfBDQqPlPNKu5kndbpI0NGlkQRxsTjt20Z7uR7no/WDtd2Ory6sU43inn5xjoooeJa0omj9SYV1+GOIzMcm5ug1w1ZXtshE0M7d46FNvpgn+OAJvzBVsjedHqWRZgspTbRxAsqVOt+2Q8JchaXwkA2NjaeAB67cuUKN27cYGtrqxyLKDByn/jEJ9Bac/bsWWZmZkjT9HeWlpZ+/i1hIAcbDrfcGQ4ifr5dD3IQgfTteLrbmnkZCRnkEIDiSIo6soDkQPiIGoW3MDpKPJhaH4LTkFukIGNkcYURSM2+zzCmt6HUfjkDGe8hR/uogzP0osZDiQabhbe8hwfc53H39ltqIL7vEwQB/X7fNtjSlPEKxkKv1ytnDorcodfrDbEtFnlFgfw9ceIES0tLxHFcwt0/9OEP2fg471EU3feJiYnScxRTka1mC8/1MMbQ7XY5cuQIJ0+eZP3aMtc/93coge9KPBSwEAm+gDYOWnR5xxWg8wV0zNE4CuZ8l/oA7bqgyjmQ7RwJm+Wro51l9HI1JfqaDGG1azvVm2u7GKXoaCEGdlp1NqZbOL5LMD+BQnFs1krX1QLXTiQOlHDTJB6YdNxbkVnec9jsdrl08zpT01P81I//MEopjkzPoBT8zRNPsbm9y9M3Vvjb869Q8Twmqnb+PajnyyoRG3bJANmCDNv+4HNqwGDUPhIJoRsrwljjzzSp1Bs065M0my3ba2rvIiLu5z//+b/9xV/8RX7zt37zvrI35lkK1fvvv58sy7h+3RY5vvu7v5tKpUKSJL/U7/c/Ddx8SxjIoNUPKrXKAKvYqNWPShMURjIIDxkcny3I4lzXLecVjDHcvHnTLvhOlzRNWVhYYGpqCs/zyrn1wugK4ykgLa7r0mg0CGtVqgJahEWx8+cTGXgyLKs8uh9NKJvIT2lNvWBhHBkSckY2iIqGbrFYMkhR7Ka2WejGmiwvf0ZiP6O4DrrikTQqoLB0RCgIFErpAU94MIDSFF38LKUfhUwq4eiReUvDlEtW+199AdPpshNFrG1t06pWCTwfrRWVYiwyGaEElpESr+y3EBmDvpHcS2VGkRoIlGN5jV03H8dNy3skIo8VKIgojJienqbValGtVmk0GmVjFWB6eroInc8fhNv6lhjIQSpLdggrJgz7rK6uDP19d7dDlqV0u73bVpYqNAeVUuWQ1sMPPYwgfOUrX6G92yaOY9rtNrVajV6vN5TvFG631bJKtOHONs//3eeQrS3uyyI0cH+tbkdHcwrMHWPoGLE6Hvmw0mhopAzoA+pH9ZGNwcOhNeBtDNB08zhbQ4ZiM9OEYogiQ7jZIXM0YdfmGxe327ZvMTeF63u0GnXqtaoFU7re2LHfIV6ikWtddQ0KxXc8cJZu/xjPvTLJhavT7LQ7PH99Bc9xOCu2EjdZraGVzlnkZYwsFvtqv+NA3Ht9M0uiUatVaU5PE0Uxr776Ct1up+wNFd7/zjvuxHEcjhw5UvIjVKtVsiwre2tJkvxKtVrdAp59y/RBDvcsdkFHUWR7GQNbze5ulyxLh8Q0b5V7FF6mWOSD4dOrr7xKr9sjSe1wVq/fK8eFR6tc9Xqder3O+qULXHnxeVphyDuMpdm5s9JCD+ZHSUqcGdI4JsmlDEaTLGUObM1RHVk51UHi3jyen88bdLMKMuyEWyjCVpKyFnVJUKTbPQS4srsBSrGbJHiVCtk8aMfD9TQV39/v6conxudNFccGZQ+dPZF3rjXGCzh/5SoXnnuZwHGYq9km7ES9iXI0kpiiPDZE6lbM2KqB/ERkxGAGPpSdYI2pVCpMTEyQpgnXrl1lY2ODJ554AqUU733ve9Fac+zYMer1OqdOnWJpaYl+v8/W1hbGmBL5e/HixQ983/d937W3VKNwcP67aA4WzTytNcZYAurdAbIE+9o0d6GmDKEKDNI4WszRsm5x3gKOUYzmVqtVgorttr/22mtDTclWq2VdsqMtBqrbxd3YopYZllQ+Sx0n9iYGPmiFD9SyjMgYIuSQnFhuUcIZHdBWDE52WO9ib2BDaQIRlHZBKZKi5Kqgkxgb/+/0oJ/QQSFhhBt41FoNlFZUG/b71kZgGoVZRlHM1deW0Vpx/4mFXL1qD7IzPdFiolGn4jq4SrGWgzOb9Tqu41B3rTRdQQJXJtpKDigOjEJ57S+psv86vS7u1jZpkiJYlp3p6WmUUszO2oGuZrNZstNXKpVyJFgp1VNK/Xm+VnpvuU56HMflAu7mlJQFYYPruiU+a3t7e+h9hXjO4EIvJgzHhVijRlOQQRTQ+GazSZZlTE9PMzExwerqKp///Oetl5loIQjv/p53U6lUWF2+Sre9jVpdpXrhEjNuhW+bPooSwevuohBUNQDPoxXG6DilYzLCQ0ZGb28U5+CYRIBGnu02lQsO9BEWFSRiWMtSC13v2kbold46oRJWKitEvoPXrNFYXMD1HI6dO2GPV63hllyLqgwDO+0OX3jiSzha8553vQNX63K3n52ZAcen2+syUbVaH8/lGori2lGGM3NNgmqAJGIT90O+phqdjlJ74pyxowi1ZmVtnZ1+TLPVZLI7ieu4nDt3DqUU586dK3kPPM9jZmaGyclJNjc3uXjxIkqpzZ/5mZ/50bcs1GTcDHgxQ67znb6Y0xjqMBezFgM65gUtze3o4g0m+4MNqWIO3vd9fM/uwMVrCkIK0+8j3R5OnFBVLoF29hCjOSp1kCRZKUEDXr7rFz7TwaJPh/LTA72KOsCj7L1jdKkprChTlnsXlCLId/sgLxwkojAG3FQgr/rFOTVQ4gUYLThao7SFlQSeh+M6hFFcFiysopbFZPXDiN1OhzCMLSuidqjlaN5SyiFNCRMHx6iSBG6w+D0qZDwa9hUz7kYEg+AHPtWa9fye6w0BNYuIopgzGlwT/0GQVxf0O4OVq8XFRWZnZ0mikF57hywz7HZ7Qwt6bW0NgOvXr7O5uUmlUmFxcXHoAuRwg305xOBrrly5UnoysGDFu+++m5Ub1/FNTBQnfP7prxRYHXzfJ/3MpzCXLnGGgHsbizRdh2qlgHZP5zCIFBPGOFlGXUHdcZh3qgjCTg4KXNIuLnYAilGdjFs2KG7VYRMCyJuRmtmqDwLzmeWLOpZEhJKx1ldsdxW9nR4bN3dJNDz/6mVbCHn0QdzAY3F+hka9xuzcHI83W9zc2OYjn/0Cruvy2soNHMdhsjGN57h8+u+e5pOfswrAM7VJXEfz+F13AvDxLzxDP4pY6UxTrVc4NTnFudlZOy4cRyMJem40e6oMeMqCH1/btlORG2FEL0n4/kce4b77HyAMw1LAtTCA48ePoZTi1KnTVKtV+v0+29vbRFF0oITGW8pAbplcy61ff5A0wWEVs9Hq1PDxbqNhOAIJl9ttto2E0/LGXsnbawCqURRKXlEqCZ73+7DyGh/SVN2Pyh2XcxUz/YfJUhyAjhk4wYj0+deF1v163vNNN5BPfvKT5e+XLl0ijEJu3LyBox2iKCIMLVdrP68oddrtoS+WZRnGGF5+6WWefOJJtKOZnrG7+NLSEo7jcPz4cVqtFkEQlLvItWvXEBGe/dqziAgPPvggrWaL6elpoqhPagRVbeA4MfOTtgrz5B/9IVkY8aOVSe5xpzhqDGckRjl19OJxu2sdPWEhJSs3kDjCXFvGrK3lo7I265hygjLp3QN1qDdxAwKd2BU2mT/XcgLEETZMxrab0TaK60aRActte31f+/tnSBE25map1mssHpvnjjuOMe04bL397YDwa//3vwXg5MwUVc/l0mtXifo7TDWa3JGTzLl5j+e9j9yPMcJLr91kfaPLa72I9vYW9UrA3ScWrQFE4xdtL83YTRISI7zctWuhNT1PXQxz8wvMz8+zvLzM6uoqk5OTvPOd70Qpxdmz58rxBqXsiMTW1iadTmeIAPEtayBhGJYLPhkgniu620VDL8wNpJszhI/LJUxqrJJTDukOwxDHcUq+q0EQYNFlL0jvXMcKgA5Ta6pSVxsg7nRI+iGO06TiKCqAX+y+Oq/4FBggz4MsK4mrh6hs1MEe6Q3pKx3imYrPoPPBCj8Xt/SVzVM04OQxfhpGJAhJGONoB0kzPG2n/6q+hwF2tzcxIkwHPpnnEscJYgSN2CnBgUfV9zFi8xWTGdLMECUpfjE2rAaug+x3Hqmx8mmxkZLpX0QN8asNTpMO5iB7eabJm4jm67q2b6iBdLe3tYJ/A7hPP/ssu+02V65eZWX1JkmSEvX6XLhwoTKV7/hvf/DtKKXyjmYFz/NL0Rqdk631cgK5TqdNmqb0+yFhGNrfe32MGHbatqz42qUrpIkdKDLGlB7E9/1S2PPxxx9HoZiZmcH3fba3t1leXubmzZt8+ctfRtKU3UsXADg128CRGgZYJaSNcFUMjg6pd1YBRfXLVkW41W7jpSmVXo9KzoYmOcZbZ9+E5uttvq6pNBXt0QC0FlIEk3fmRVdIgc5Oj7Dd52oUsbGxDrUak3feiTGG9ZdetQR5/R6eq5mu+Nx1fAHf8Sxby2DlUeyw1bmFWZampljr7LK8s0nXCAubXVAw32wNffpeHBMlCX1RbGof7brcc/YeAF7Myc6LNVKEfgUX2iA6Y7AAZF+vh2QuviUGouyG9P6Djlsk5tY7CKdOnsL3PI4etRN7tVq9bOgV5diiMbixsZ73R3Zz6Wbb+EmzjMuv2SRzZ3uHKIy4uXqTbq9LEARUKpWSQE5rzYnjJ4YgLmEYsrm5yerqKsvLy1a1aN3S1twzWyFwNPSFXpbQAzYAV6VMxT0Qob7Ss7tbFOOL4BZ9nqKkJHlZ6c0LqHg97E2B0gT5gFJfQSaGVmp90JRyMUrRj0JSk7Gbxmz3OwQz0yw9XCfLDP1uz3pnEhxXM1OdZXaiiaRiyYkHHn4+4z7TqNNSDu00orOWoVRKt2CBmHByMRxBoUjFCpKGaELHwXU95nK09pXr14njuGRyHzSCogG8PwfV5UBV0TT+phnIU0899TDwXZtbW/zdE3/Pr/8fv+u+/Z57NCh2Oh2SLMXzXI4uHcX3fJoWC9N5/qUX/vUbEWGMTRyV8Nhjj/1AtVY9t76+zo0bN3Bdl+XlZbTWXLhwwTYOZRdI6Xd2SPpdWpVdHrm7hlYw+XbLVXtXaw5Pa2YSqBoQlWB0ZCcH8/ub3LA3a/NSG91L2YoFN83wjFCLMzQwm1/mQOe5h3mzCclubTQeMCEW2zWXY6c6JiNFmNWamoaOKHb7KclOl+XnX8GI0KxXLRzfRIRxQpwKSapRxuAU4WfBqZtLNSgsi0vL91manMTViq1+38Jd8k/ajRMyY9jq9NjuhdRn5zj7trehcrbNoqxvsgyVE5dLHkYbYwjDcCwSvJgQ7XQ63xIP8l3Ab5PXQowRPvTRj4LAsZMnqFYrnDl1hmNHjzIzM1M0c7Z/7Md+7OffzOXx67/x68dAnXvyySf51Kc+SRiGpa7Hc889h9aaJLuE0KMVaOq+Yrrm8sBDDTzf5dx9NgGfDe5A55dIAZnTIXV2CcOI5aurNqe57lrerjTGbEDcyYj7hmpomOmmeEpTq7iIgOfY66RlkAdKHVjwVQNNu3GB+iDxgRxYFh5/Dg+YwmK7nHz+ZjvsE4vB8x0Sx2ElSen0U+Jol6tPPYsA08eOIMD2Rp9+ktBPhDTTaAEHM/Qp0rypoZWVxZ6qBJyenSNMIq5urtlZfhG0UvTCiDDNWNvtsrbT5czSCR74tm8nyzKuXr1qPZLnWbZ6MZgsyfm9bG+tnxvcaLWqVqtRq9XY2trKX/MmepAvfelLDwP+F7/4RZaXl7l48eLpVqtFr9ejXm+AwJnTp23c7brPCaoz+oFFZPXNjseLgatarVoyfpCTpbmmjxbN9JSP6yom6g6NqkM90My2fFxXU6/lxHZ+OjRy6egUR2cohFbLviZNqpZS984JZC6h346JewluL0WtRyCw085Dj8RKEdSxifJB1J77E3l5AzzKwblLsQiaWpPmhHdihABFQytCpfBz8oV2FCPKNlGzNCVJU8IkwVPgO/mQ8Qgkt4C3u1pRcRTKODSDAKXy4SVjiEWIRdCVClXlUW02cyh6OtRktsWXgSuUN493d21/rd1u78tBtNaW+sjmLv6XvvSlx/II5JlHH300fiM9yMeUUuUM79NPP02SJExMTPDII4+gleK7Hn+82O/+SX1q6gt8Cx4zeRHg9OnTdDodVm7c4OVnv4IoxWR0Fa0V73v8DuYXa0zPVWhO+wckvbt7swsKyCLIbGPqRE73IzJv//YfnwEF7W3odITuRo+VV9Yx3ZSvfPhl282+1IbE8IByWRBLeOYO0zbvS7n3d9rVbRqMOiB530+gPZEjAd7uVQDhuSRiJUnxXIfJiks3y8h6MQb42uoKBkWn3yU2GZs7O9zwXZqVCvWpvKic82ypIciAouE6NDxNajymqq6Vbuh2UUqxFqf0RWieOM7MzBwnT53iyJGjRFHIxYu2aBLFMVEck+UTgQVae3d3l2ee+XKOfjADVSwrMlSv1+h2u0xPT+O67jzwRP6pjjOiZHDbBrK8vKwB3e12+X8/+Cc5l9cevWMOLDRKKTOaDww25V544Xktgr506RKf+cxnqFarvO997ytelz766KOva/H3ej2tlNLr6+ucP3+efr/Ppz/9aQCurjxFZvILqFSuP255ci3/r1Wa1Tpn2hgtMY6svYOagfuaVTIktpqzeKg9en8nRzVpZf/lQFYZPIYav4gPC51el9eQMS08ddA59pN2D353jQyFbnLAhRoKIyW/3mOcouQKU9oMV6FEzFiBo/LYA43Pg2fLh58ffG2n03F/7ud+zj179iyzs7PMz89x9Kjd/++99970Vh7kHyql/qS8dwr+6q/+ylVK8cADD/Doo48iIr/7oQ996H8sv+SwkaT5F/k1pfjn4y6+iJy+lQWPefwajD9e8fiVf/lfac/VzE8GLM1Uuf90hf/+gz9i58FPVG1jz7RBMiBGpWIh5a5vL2hR9uz3c72OlHwfGLNHx0P/36y5NFsOHA2446FziEDyY5Z95fMfe46wn/DEX14lvrDLUiic6kOgFEsHSs7l380Mlotln4e4VeMxHcwP8glYL/deo+896QccQdjOUtaTlD4O4lvK1jSNMMDfapdNZdjY2qHT7rI4Pclkq44SoY7ajyRQtqjnYsU9t3uWIPBC1xJm/+h//U9YPH6cV199latXr3Lz5k0+9rF/RxRFPPGE3fBPnznN1NQkq+sbdPsha2trXL9+HcdxWFu34kxzU8Pir9fzn3Ga0u3beZJPfepTxfp7Zf/GAMAXgO+8nRDLPahVX4AEf/M3fzO9jWO4t1uFuo3KlXv45851x3OPp7AE0k7exNKO2ps/KNbaaNFHDtrRbyewGfAIlloR7eR7rlYobaXGTKlPLuypyMgtuhq3qk6Nghr3l9lv3cGXIerOPYLpvBQre83HIcT0YM4xCAs5gPBln/PWexr0g42+cSKro/P3g99P8us5CghloGk88D73Vut+3wteeOH5fwb8xHPPfW36a197Dtd1efv9VrDl2rVr79ve3l4d8Bg3D7rMn/jEJz4A3Pfxf/uRE9cuX6ZVDTg72SBOuvzr3/otAP7xf/uzH3vhhedjEX7p3nvv/fSYMM8FPgX4f/3Xf836+jq/+iu/fKLX3bHJYdjHUcLxSTs78v3vO0cQuEzPNanWfDwnwXdiXC1MVKP87lg+kSxKkSwBEiDci40AL/cWquy+CnuoJYvz3qdJUhhdZpNOIUEpm6QXnepH3nMGI4qNu5fobYZsfOkmX/z0a9TijO1VG7ffk+sF7uU9dgIRGcA1DVWv1FDGIgfkHw425wkVRCo3ghHDb4o9dk2EilhNwKrWdI3QT+105J2uHbLaEIdthFUFWw7EWpUjs309gtZOBW1gI47YjHpov4J/7CQChJcuIwhPfOlLNF58ie3tbdqdDkFg58iNMZw4aYez3v3ud1va11zjcHZ2lunpaebn5/nhH/5hAKYmJ8eWe8MwYntnh06nw3PPPQdYBSwRoVqt5nJtcP36jVvmIKeBx5Ik5fr161SrVe6//34A3va2tz3znve853ZDovtE5LF2u83Vy5c5OjvF3dNNlGRcy7llgYfzXWX6IKSEUuoxESnjj/WNNa69dqFcIL4LZybtfMLxhTrVWsDc4gT1ZhVMH6QHJkWydrk7Wm7mQn5M9u82eni3Gw1oRj1fueMVByoDZFPuwAK0ZqdQ2iFNNe5swua1NjtVhwShm+uFDAIACzyhksP8mDBuBl7tv5A5X5VgDpTTK3IMC8v3gYp2yCTDz9OHmthv1ETsNKMyGFV4DfuhR4NRJdbzRJmhE6e4jiGoWFyUyRET29s7hGFEN9c8FDFUqhWQvJehYGZ6hlqtxuamxVa5roULTU9Pc/bsWcZFO4MN4aKnUuQ0WZZhxMKVfN8vp1nHGsjy8vI/U0qdXl6+9l2dTodKpcJ73/tegG3gX+Qn2z7MImxCLr8GuH/2/33oRHt3l6nA4T9616NM1WvcsTBLL4p45eoyAH/5F3+Bcj3e+fjjP7WxsfGugoJ0ZWWFj3zkI/ze7/2eDgJLcHtt+QphFFL3O9x1pkYlcJlqBQS+4rEH7cWeX/TxXI1X9RHXB9FAgKQxUjAZ5otXicbBsYtYGYbnLAaGPUWwFX512wHXgcXZsI8oRb0Gge8g75in0vSIb3S59sGXAZjctDfomO9R1RrRxQRewXrOwAo8jNJnrzs9kKpa5hWBGKEtwy3+Bs6QLIOdtbdAgEa+kIOcPvUIMAG0w5itMEa5Hp1OD60UU7nueglBSmPiOKGdprRxaPhVZo8dQ0SY296ykgknTlCtVtnZ3aXb6dBqtSw5t8nYzPVGbq6u4Loe29vb9Pt9zpw5wz333MPExMSh2L84jrl27RpPPvkkWZZx/Ljtc93/wP2W2MNx/x/gy+MiI3dgF/yJwnNsbGwyPz/PQw89BNB5HSqhGtQ/B3GvXrzIlQsX+MHveSePP3gvVc9hqlah3484vTQHAv/u839PL054+JFHfmjcwYwx/Nmf/RkighfYOPWhe1qcPFZnshlwYrFJEGje8agtL0pOQiAVF1wfES/3AhHG7OYHzYdn8n1SDcwiqH0BtV1exgKrymBLjRiLGon/90IhGf5vHFn8VsVHOQ7+PVNM3jnN+vlNXvjLS4Di6k1bRp73XKoF04caDoeUuZ2sSIZMowjLvPxfZoRwBMCXKT0SsIEv1kBqec7pZTZ7mlWaBLiaJPhpCJU+vVz+2pvQQxcnzlI6cUTXQE80FS9gYm6uVFAWERYWF2jUGwRBwI7vMzMzw6lTp0izDJN7go3NTQRodzpEYcjU1BQPPvjgvqnSQc8RxzGdTofr16/zxBNP4Pt+GRG95/veU5CY/3ut9UcOTUYGpvS+opR6deAk67eboF68eGmgg1llYX6eiWaDqudg0pSbq2vESULV9wBhutmgmqRceOUVy3mVZkRxxvbODpevbyAi9DM3R+NaTYiVbYO3GtPaNfT7UK043HHazlTPLPg4Wtl+BaCUB9oDpVG5hJiJd/OSdYaQDUTtaiDBHiE5Q0BlY0MXdRvAwX2vMSlg0Cg8V1OfdDj2+JKFrFy3HLhriSFKhYajqeal4cHYRY34KnVL4OLwa1ylaEgOCM0PHOafKdB28q9gQXVQVFCIUnj5VapohaOgZTTT4uAa2O6GeK5Dlp/JKZInpUBblHUUx/Ry+bxi3YnslX8LxVrHcWi32/bvOS9YwYk2MTGBPzvL5OTkgWpixXq+efMmV69eLWHxlUohEeloAAAgAElEQVSFM2fOFEb08TwBvXbLKlWBU5mdnf2jb/u2b/u6dKU/85nPlLHf/OwsS3NznFhcZKpa4ebaGl9+8Tm00szmJAF3Hj1ClGX85Z9/gtWtbXqx0IkMolzEaxZoGnt7kxyz81rGS+tt6hpm3S2adY8TR+zxHpuu4wQKlexaTiavhQQ27ncqLUAwPTsXYgbXPvmYbGEXI51gfZtow9utQUkO7/ddFz9wqJwIePwXHgUUv/+kvVf+xR6NfsrZSsAxzwWjUNloy3A/h+NwaCUHhmIVpVh0PFIRLueUpJu5R5l3XHxt2Vd0lgMZHetB6iqxnXZXkWk4pjyMAxtGeHllk2rgc+aO44CiRooSK1cg2iVOI3a7XVLg/PnzY3OGRqNBs9ksw6LBx87WNlmW8bbH7+b48eMsLS0NjSwMlbXzkYevfOUrfPazn6XVanHy5EkajUaROgD8rFLq9hqFSqlV4JqIfN260kMkbllGqhSZsYmQbdqVJX2LvMhSktSQGbufiqNRvoPSHlTGozN1JUUHGaKEVAmJctjq2ANv7UQEgaYauHiuRmmDNlne70j38SyN7royPFk+3P07sIw6sgjVAZrxZQohQzlBztJmRXUQ6lN2Q0i9PqGGCDsToZHyZsnYn6P/dxvSsIXiU9kryUurObOilr0yr5N/VF1cPytChYPCVxpXsNU7k5XFj6LcWrJiK3C0g+t5pcxdMe+TpFb6wvVcnJzWqJgTKtZVUQYu4CODZeHRkm+v1yNN01La2/M96vU61UolFZGb+Rq45ZBIaSBnzpx53zcK8VhYWCh3g+Xz54l6PY5PuByZdOj026i8wtHOp8iev3qdXpywvCt0oibBkZO0Tt6N49Wozp899Fxx2OXyzhokIf/LH1lEyyN/c5HAMfzou09y75kpmlMR03NdkBQyO5no6wN2dhkXWg0aysjv5c/h9wjj+YVLkxp8fWogi3GVw2ReqflP/8X3AvDh//VT3Dy/QTfKiCKhpTXH/Vz7PBlnquq2OiX7FoCCU/nE44tZRAqsJCmuCBPaZVa7OMCUWJ2QWddFgH4Sk4lw1PGYcwJeTUJeaq+B77O2Zms5E9MTuI4mi7eIwx6eX2F6cYljx4/xUz/1U2RZxgc+8AGMMSWY9G13v42l00ssLy9boSTHKXmslpaWSqm5ZrNJrVYb0nMZwkR97GNWm90YpudmuevOO/mRH/hBgJta6+NvCljx9WPkRncztW9TG4VaKJHD+2MDvx/KMSWH5QZjyIxHpcoP+rnXeRr7GnVYP/yWx8wbWgfgqEQNzIjv64HLbWRHtxEIjmOrvEVQqQ7JutQ+//b6gZe3YtA8ePnJ6+3zvrkG8o9//MfL33/pf/tFzl+/yInFKkdm6iRRQqNSY7cf8a8+bufSV6aPkXk1Zt/xbmanTqO0Y7XnlCZzhqEXcQ6VqFcdqoFD4C/QajxAlmVs3PMgAOefewITRrz8R0/hdp/nXY/M8oPfvUiz6fHgg5PD2Kf9NTO0ysZwtQ00Acc9Nwa/pQ7rgY/lg8ugb5k7jp60oeUj/8W9bK2F3Pyri/zpF5Y57fh8T76bL90Song7Ben9H/5Oba/5dZPSVoYM6GPHc+cstpBKaH/qPO33leBpw4KneYgaxnVY27RV0jOTdXC8EtPl+7b5V6vXynPOzc2VfQmlFJ1el0tXLrOxsUEURaXCF1DmHHNzc0xMTOybMRcRnnnmy4gIV69eZW1tjXe841HuuecetNYfB3729c7evqEGUsSVdrlZ8uM0y6zQo9nrPrf7tmsci8IohQ5qeLVWed/MWCnfHDekbLPHdV2CSoUsMzjVRh5DV8lEEXYMbEfstmPCMCUIhukSCp30oaUy6hWGt/UDylP7KUsOlG8YaMTtJ4KSksDZtVIiBHWfSmQwvqYvhhAhE3WIId5OPe1wk/LU4HZh/2XK/itsSY2B1ai8Ux9oZfPOLBtA8QxcI7Wnb14YRJFHlN/LiFWsyuH1gxi/omI1mH+MPqJ8WjVNs5K9v1arIqLC2uTktW+pB7F2IT+BUtx/39v/5R133HXO293gqy9cpJtkrPRiwkxI5u2M8cK5d6P9Crp5hD7DqkyjO/3MTA3Pc3EdhesoskxYWdtEjNDrWNHI+omzYIS4Vcd0tzkvy/zhZy5yYqHK3GILBRxf9C2BQRLaLHOg/DlK/1M6DhmN6UZAR68DtjXqwPadI7J9kDvvniJOFP3zG2xc3qYbCk9v9amiWHT8sSfVFIQT+/Vjb4ecLhO7nOta4yhN3xi2kwhfKxz8PFG2OYhLhktuPUZoojlKQN/Asx27AfYSQ6agn0I/EbJsD63reb5V4a3XMcawtrZmZSo2NjBicLRmenq6pJgtSsAFz0C9Xsf3/X26L88+9zVEDAtzMyzMTnPyxAnm5xfeGiFWbXLSAB8B+MhHPvI/AFx79hlurl9hJ8m40ktIcZG6nUqrz9yB49cIlbIVlNHtduDmVqs+1cDdgyhlCd2uRdumeZfcb83kwD+XNAzZXI24eek5ugls76RopTh+1LfOKD1gWHwo4R5QkdkHiZfh/x+HEbxVvDXuHFkMCqbnpjG4NOZqeBMBiUq4uR5RVxrRsr9OsM973Q4LsIzsbva6e7mCaEhGJCnGOPSLQlRRUUJb2HtesPJRtJSdLOznIp6xEbTJJawz26MtPILj6NxQvJKPOYos1CQMQ5q5TuWgQnHhPTzP26fsW3ybldUVjDGcOXaMarXCRGuCWk6m/S03kJHHnyilvnB1ZfW7rm/tPtwTl22pIE5A86TVIXcqVp21XvFQrkMcpUT9ZGjIZmqqgVKQJRndNMOkKSZLSVIhTi0yM8u/hqR2xsE4ATrQUF9Cpu9jR2V89otbaK04fsTH0VCr2GF+K8Uqh2BFFGMrprf6eXsV1gPPoVSGozUTxxosPrxI/8ouG9d2CdFs5tXJaWXBMoWh6m9w0l3n1zwTRaKstIKIIkPoZpaku6rd3JhMKfJTeEKroS5MRravEkYxmdhyvpEUpSSni3Vyby3U63VEhE6nw87ODlmWkRmD0roUXC3KvQXheMHjXIRqSZKUzO1aWR2U6dlZJpotHMf5HPBMASV5yxjI+9///t8FeOSRR34beDhx64TVCXSlwZFz3zb02omJKpWqz+52n+1weFc/ujCFUorrN1aJ4oS43yONIoxyybw8SVNufiHtdua5FRy3Cq1TCDU2+2t87JN/iePADzw+g+MqgiWN62uI07xP8tZ6ONqAkzF95wQnA5drT13nxb+7RB3FWp5nzih3gOv2G38Ux0qBKP+pcmh+J2epqOqcIQQhG2gjOErRch1ckzGbE711+yHaGOI0RsTKZdvF7Qzc+wlEhJ2dHVZWVkrvoJVifn6eLMtYXbVT2pVKhUqlUnIpF+RwRVPRGIOTe9jFI0vMzs4iIn9aq9V+5y3nQT7xiU/cJyKN//03fmNxbX0NU7HeA8dH8gTMCyxWql4PqNUDTGIIe2meqFn9jTixO1eWz0Dbvp2DUrrMkQ37FV8N2A66V0FlNZJgAqPhykoHRysmZqo4npPvOMVQ0uspcBw0+qpuUWK9zZqjZJCB72vqDY9q08dpVVAGdrsmr+zJPg/wRskWF+iPbCRpCsUSMJiBFLwsOYv1YgEFqVtKlhaKublgqTHEcczm5kYprSdiBYcky/ByuYIgCMqZo0FG/3ENQmMMnU6nJCMXkTJf+YY3jTdxE/wA8NjS0iJf/epTBHMerRNL4FeQ0DrnyYVJHM/h3NlF5maa3Fxpc7W+QxLHbGxZQrbNrU1A6LZ3yZIE5VRxvHr54QX26XCk+WJ0ak2atRZpOM120gUM/+qj/x4w/OLMg9xxok7V01QqKWQZxObgCtZtGokRB3AOeHU8kiccco6kByjm56tMzLcgEZ59+Bg6zPjKE3Zm4YTnUxtovlQE6vLGeBSlbJpW5h75YfuxrfPaCfW9yRSNwhMbOh3XASjF+W6XNMzzBx1gjCKLYzY2NvjsZ/8GESnh5WF7F+KIydYRFo4eZXZ2lnq9ThzHJQzKdT2rOTiy+Pv9Pi+//DIiUsroLSwssLi4+HXx8e6vnb5Bj36/T6/X8/v9vp9lWQlEUzkBkuTFQzEGjBnQyt4rB2rFCHXkoG6dGtqHZRAWMvRvTApgB8UxohDRZEbIMjN0DhGVx8ZquDtHPvAw+PtQmVbdwnNwwOtv9xyyN+eu1d53z6/bUCWnVEP/hvzH0NC3YngaTw6ohJWgwz3+nb27lJfRZcBL783tq7L8PjpzMzzGvf98Y6YEy31SRGJuA07yzfQgrlJqC3A/+tGPui+88AI7szG1HzhJlmRs9v4KnVQwz+azGf734wRVpiZapIlDvR7w0MNH2drusP73FkS8tbphx8TdKuJVDsmE95eRMnK12KCOd+qdYAzPf+kKiOEPP/Ias9WQf/CueR5/+0z+Pq/c6fda43JA13uEut23ClNqzFoz4cghRgU0DzlH1ROqLhw/Xufb33uacCfkbz5/EYBHU5e60kw5DlXlEAKhsjd14huwkWpO6JYqe7xYKdbN4XmaMUKS2tV4NG843tjcJVKQzkyRVQN2dnZZubnKkaNLJWCwUIZ6+umn2V1JCGo1FhcWmZycpFarDZG9WWpar0zOe70eu7u7rKyucmN1BaUU73rMklifP3/+excWFr5YcCO8lUIsX0TcQgRHkAFa8xxDm+8iMhSWy8BGr/ZvZoMTEXmjT9SY3kS+25bN7iI8EJWrFWkQscWrzOxJ5w3MbRT9EKUYip9Hd3QRdUAqMvh+GdMHGd4VDzvHkAPUedw9+I3loDnDw4njhv+2H3ypBndtuVWpWA2T1w28SanR+rgMgUbHIXFH+XUHtd9Ho4vB5lI5j64USZKkWuv4G97x30jL+MxnPlP+fiFaZrm6RXWpRfP0pF2ImcJEhq0n/xqA4PwMjlvnMn3WN+Y4fmyGidYC1UrAXedOIyI8ubo50BNRYxvZ4+7ZXmPccuxYG9HU77U719cu/Rvcfpv61BZZFjI3W+eeuxbsuZJBD8LeTS1XjUa84tLZWHhzIyNOhNWNTbZ2d2jWApbmWmhHMb9gq20SFSyNxaD5Ieco11EKhDQm4K77JmhvBnzuiEUOXLoBQSbco6GiBpegRQDbG6xeNzldVVua1BhNxzgohNHWZOqNRoFWQFApoZ73J5TKLE1pFhOlQLXKzNQMCydOcPfdd2OM4cMf/nCZhM/OzuL7vu2HdLtsbm6W0nmjxgIWCXz9+g3WN9bpdbpopTh39uzXpST1TTGQzc3N8gv0TEioUyoVcOpeefeyfkqarluIc3cH46T0u11UtU4ct/KGkKZRr2HEDN/EQkf7Fojz0QS6ZPtT4DTnAKGTuJiusNVO2dqJqNUD0DoP7NV4GMfgeYrdXBw7NZcYokhodxI2N0NMCtPNDNdTKKdAzWvbc8k92qHnKHZgBMHgulBruGSJC4FdgH0yUiNkIw1JKb2uDEs6H3CxRjvtWlm/4mBn2XVenZJRcjBngAss22PY0gOHV3klLDUGHI1XqRBUKmUHfX19vZTlK2Y7iugjSZKyBzLukWUZYRgRR7GtcGpNvV7f54HeUAPp9Xr/iVLqeyXLwOoCbtWnpn7ldg72zDPPlL/HOiGYCpCKSzSAXhVXUT1tZ4i7q+ch86lszIL22ahVuNpskmVCP7STZKqgjlTDnmE0YpBBj6JGQw/BowAiuoAmnboPCY5wZXsZ59lV7ur0ufNkB61gomUXvUnGF5hMBr0d6+JfuLCMCOyuuGSxJktr6KzOVjti5bUtqjWXmaXpvMrUz0MOGf6wsr9bX4yhizGQgofQqgsqhYm3zQCwce0GKs1o4zIle5UzTxRyyyn6wcHgEXRzZsM7VzQVnW9QmSl8H+XcggyLSxVjWpV8xqUwMIkMGQavqvByIdZr166RZRmXL18myzIeffhhJlotgmoVPwiGBqYKqMlo0h6FIbtbG/S7HWq1GkopY4z5n13XTYHX3iwP8r0MErPZiavbMpCXX355zwW3UvxmgPiOTXmLbcrVVI/aUtz25atIBP3de3GDGXY366ystMvtxzLuadQt+wscrpyKwZUsf86zf2yeQSpL3Njo0ru2jOfG7G73cBzF5Ew+zZjISPJg9b3TFLody4rytRdXEYF4rYEkDtONeSaqU2y3N3hl+QaNlsd3/4C9wRW6IwR0arg6o2Rf4q6MgEntiGxVMBk0Tk+ggC1vGROl9CzJ0Ej+ooYlzA6tq6kx7RrBQeNrTTrQSDXKjt7uH//dC7m8PKfSaS6dloBoQYmFlogxrK6ukqaWOccYwx1nTnP61Ck6vT79KGJ9fZ2VtRUc7bCQY6lG2TuTOKbT3iUMw2IuxPzBH/zB7/zyL/9y+qaHWBubm7z84ouISO2P//iP358//ec/+ZM/2RvjdaaBd4dR6L7/J/6RFoHMBQk0OPYiua5LrVJBMkPcttBu3UgxnpBJm7i/Ra9bY3e3nzf6bJyQ2frw6xrqG1fgKo+Th2oqqOE4LpHbYpcW632Hi9d6BL7m2PHG/o03LxMr7ZBEKcsrlourYnLSgflFXOXTrLSo+g1wDFfXm/iOw41r9vs2jvtoFyRLy0KFUkPJzQEmn/+uHbQrTEzYjGDLVRhtexJhluIoVarc9nIPOrGvJ3PrweAkL6dEYgiNIRJTds1lFBbD/umaIgfwsHIKWnugjXW9cUzYbnP+/HlEhFPHj2GM0Gg28YOAmtK4vk+3280pS4V+v1+GVFmWEcdWiSyMIuIkITO2MfhmPNxxFQSA86+8wm//7u9SrVanf+RHfuTD+Y5+HOiNec854MOe57GVbpGajLTuQy2w8bJArVLhxJEjpGnC9V7PQrsXd8mSjNhcx+wImRJiNYFBykYUuMNl1X277a3chx1ASpQ71M12WzZM6eweY9Np42y1+eSTy0w0XN759tnxC8hxUJ5Hbzfh6edvgMCR7B0oUXz7A/cz1WqWb7t4rcXVtV2UzvjKF68BwqlT9+PWPKTbAWNL9DZokZGAZz85HVojvocjmuMnJ0DBlYpLFqZ0s5SdzFBRmrpSJMB6fi8nlDOykA+eVi8MIFRCrGA3TdnJUiIRksJlyGiWt3863nPtYq2mMUoE1/HQnoMyMarTYXtnh+fPn0cpxfv+wXvRWnPkyBLNySkKJoKivCsibO9sl895nk+73cEYw267TTeMSiKHb4qBiMgWcC3LsprrutOO47DbtZQ5r26/tvQD/9OP8Z1nHuHE1BIK1fnP/7P/cnv/5FZe2nUsalO7No8wucxvoXutHIU2GkkyxKQ504gZuXW32FVvCxJy8HNKe2i3gtF9wjgjiDVJrpTkerkO4QDDYvElnTwTVaZodtm/pVlGZuxPlS+cMMeXGSMMrjM1GmoNhV1q7MdWgOuqAgCFOBpjLBraDH5CNTw+cDtXsKBDTUVIcmK4TA4f4x2lCbLpvX0uEyErjV/lvZKUzJgSMhLkyl8FbCRN0zJBL54rKll2TiTb068fGLR6IytXhxpIrVb7FeBXfuEXfuH9d9xxx4ejJOL//ODvgYLFB88+6freYGXod4CfJ7aoIMnSsvzqTVVwphwmZ6dpTjVBoB9HZElKr58gApW5OghEFzYJt3o4QQsktGVU8fLFme9RBSerDNTCC5r0key27GnIXl1eyt7JcJOkNn8GZ2KR7tbzfOrFZ5huePzDV9dRSnHXfcdwPQfiHmQxCoOQ4bsJR6crtj8YN+1PleFmEZdX1ri2uU2/F9KqOoRxyt//nSVH+6EfzvAdQWcjdaX885SDXEOfcaD6IILrKM4dryAoPnekhqlotlZi3G7KpHZxlTPkHYyXR4cJe9dyrIkIO8qGZzfTmK7J6BtFV7DMiVU3LxzIwAx/0V8CnYKX7Hk/UGwmMV0xxGoK5dfYbnfZub5CUKuxcPYsjuPw+Pd+H1pb/cAoirh06RLXc6m1WrVGkiS89OJLKKW4ce01mo0G3c4uflBhe3vbcnV5HpOTk/sI1N/0Mu/eyQbJngeHhQ4SuFaHbuRDO5qog+ssI8Mw+35Xo++UXFpAhptbImMaa2PmJUTtj9DU+B10UPti9EhySNNu6E0DUePQlOOocPi+wUW1rxYl+zuP+woMjAXijAddjtOOH2whjv+m6kBVkn3rZ+w6G//865o/f7M9SPH4mZ/5GQDWNtb4s3/65wAsZIJ4ik+/+vcYyUh3+W8e/5Efev8/+rl/6j908gxGhFcvX0GMYequ03iqxk57nfblDSTQZC1LAhfnOLOK59qou9lDkj6Z2sZ02uB4VKpTlixsn8y94tDqJaNctjLSSR7uN7h+Hd+r0gkXWfdOE2bCxz5nqS7/u+PTtBpebmy+TUAV+J5mcbYConjp6RUE2Jw/gnEc1re6rNxYwVEOjUrNUtjk53rymSvU6i733bvA/PwkxH2IO3teUfYqUImRIUPQYhlIlKNpLc4BUD82g2mEdDpbmF4fhdAoICH5OXfyJklLZOhmq4Gwaif/n5eiPolAR7LyupftlD4H3QUKjETJaquHb89ut8NOFrPd7bO222GpXud973wMpTU17aBQfP6LX2R9c5NqrUZQrXDu3Dne+z3fQxTHfOGppwD46le/ShzHPPzwwxzJhT1HjemNNpQDDaSYL++FPSTbYydRQJhGRGmETryGxm8kacr2zk4ZQ0oxhGTIFVHBaINJh2vuUmCXtLE0ujmQUWkZVifaN010QBVr0NkNVmiVjLqtkT1PI8ohwyXF0AuzvAdhpcj2eNT3Tu9oG1dnOVdt+ZWNIUsztGvHX7XaQ9tGUYp2Le+UUnp4t5fRkvKw55MBUIfSVj5XuRrlaETZVN8AZkQqoPj4MqYlWJQEiiJukqN00/K50ZKh7EvNZay5DA+vGzGYzJAZU471+jmcvSjeR1FEv9/HC3wCwPdt2BRFUalgW5BLjxfUkdv2Nm+IgSilvgD86O5ue/a973r37wM8ufwckYmZf/QoraVF+ts9eutdwrjPU+sWVOjN1hGE/vUO0XpoaSONwZkI8E5ZiWev5e7r5ymBNEsIwx7ay6jWDpEak0P7X7dyxkP326DIlEJVpqjNP4AjfZ65YqEwNzf6xEnKxKRPUHEoKsXadWhOtBCBTrSFGGj3d3BciNN4CH2htcvigtVtvPzyLtoxnD2dsrg4GhIN17EdJYhSbO/EtNsJ1ZrL9KwV/2kEFrRZOxKQ1aF/QeiZlEA5Q2VdpaCfz2k0HIaIqVMgMkIiwuW8YtSWLB+EOuxCFqbmjqU5EhShsc+uZxkdMWwnKW0FtVaLu48cpznR5Ob16yitubm5idaKJE1RSvH2Bx7koYcfotlo4leruEHAww8/XDaitdYlBEVrXfJitdvtb26ItbS0dA34yK/+6q8eu+PEmd8H+OTlJ+hEXWZrxwmONIiChESnZJ2E9s1ty+NatyW+rJ2QkZCl+SBMJnjzdUQr3JY/dq0bY0izBEc7Q0w742Law5T6DsEP7ntOUBgUyq3g1Rcg6XDjhq2adPsJFV/REA/l7oVmWmmCSoAxQpxazxklIVFayeXf9ryT1ppGYwpE2N7YRCQlCs1Ic24/OV2RBMdRRqeTlzwzA0rhB9amvIaDLx5tFxIMoShi5e6FlmJnOpRS+xD2RmwpNxZhx9hSczzEYDau3jWcnKkhC9nzLIVH6onQFaFvMmKjaQQVi7cKfDqdDkopumHfIiVyr7CwsMC999w7lHAX4VQQBKWnSdOUer1Oq9Uiy7J9sgXfNCzWI488Uv4+8Zk/xfQi2hd2SELBaWhaU5NEQUxnIcrziuGVmO3GmG6CrrjQS8DVmJGKnJR4PYMxKVrM0BLf66XJwIsZYWMf7Z7fwruovRgcARyXoNZCYk0vtrPQV2/26XQj6hMB9aZrSadNihZFrQLG7J0lzYQktfRGiC1pRklMZoRGxW4IGxsZaZrQ7YR0dvt4OiVwc0BTlo0np8t/pJnQ6aRWy6KWz4DXPDDQ8x1CJ0cqK4USwcnhHrFkVptDawRFmoc5ocB2ZsOpSMxI4q1vo4yed9RHigcGYc21R9rWiq6B6sQUtWaDUydPcffd9xBGfZZvXENrxerqKlpbfY5ms4nrOKRRZLU8BiAmAA888AD9fp/z58+zurbKdDpNEAT8/+2deYxd133fP79zt3ffNvsMxRluGu2iFMmWIqpyIltWagRWrbSym8QpHCSwssBF66AuCjgoUqdomjYI4vyVAC5QGEVbJxGaxiViJ6CdxpYqWrYWxFpoiZtFcrgMZ3v7u8s5/ePc9+a+N28oMhJpK+ERCGI0l+/dd9855/c7v993SdKERrP5g1kg73//+/v53dRvl0kbLdZfXqVzYoMb7plj7817aLXbXIxaYAzhjlIfI2cMpEtN9HJ2wqvH4Dtod6iWkkn8G2NIdYoy6UCVzAyWpIZx7pdTlNk2W+hxRpTrEVYmSNs+FzszIMIbp9aoFg27dk8xO+PaSZykOEaoFN0+Q9eAFZGINGkGRtRG040jDMJEKcQYONJK6MYx9bU2tdUG5bIiGHctzinVo8Xpsp/jWLNRS3Fdw/i0vbBS9nFFuBg4pK7K0FcKMQbP2JJ7N01AhHYqpEpop7Y73tKaZZ2iDbSHOEUizmXm8lsVKlOBc759vRVHaIliz/Q049Mz3Ln/Lh566CHOnlvi5ddeREQ4c+YMIkK1WqVUKuEpRdRu43jelgVy4MAB62t47BhnTp/BaEO1WiWOY2qN+lvA8q/SAslYWYeztOs9xWLRfz0+T6vVJKq3aa42idKEsGAthLtxOzN8yVKYQDCl3Nt40kfpbjbrDOIaRKUoEsSkWw7So7Rot1sPQ3SS7ZEpMiILE+l/MfW2wZiUKAGt1aZFgihQLnnFnlSnJFkFyelxqbPSbXaeR5SLSEonstHA9T2q4mQ3Eg2GPuVkKDJItFn3ncEAACAASURBVAFtedtaYftEgGssuVf1NhljUycxkGRJUpwhgltGE6eGttZ0taZjLCTeDNki9IoDW1NUtVkRzNIfM7SItQOJGDYyb/OgaBVLpqanmd6xg+rYGK7rZgJwHoJQq9X6C8R1XeLERoMgDAlKg3I9bgZ0nJyc5IadNxAWQ5rNJkmaEkdW/v+Tv/CLB375k59MgJfn5+cbV32BFIvFC8CDAIcOHToFLPz2H/xnznz7e1zQbZpRl+JEkVt//CYM8NJrrw6+wKxn/wzkXcnA9JUwxR0zOGkbN23garU1guQahSbnBGlGYKYGil+y2bwaqORInuLZs2YTRCmCiQkQeOnkEr7EPHi/cFPqoXSMMgKug5TGUNoAZzBiudpKuQhCKSgSpzHtqIsIlH3JKjdVUhVz+mxMZDa4+ZZJZuerGCLbIM1/Fr8IjktkWjSjLl7igNa4vmfds4CyMTha42YRXmubigHEWa1VpSkihrXUqtu3MXQZBkwObzNJrlgo/f8GOi9m828EYk/ojCnqScLzZ2yZfO6996OCgA/+xIe4+Zab+6oklXKVasli2F566SUAdu/ezcTEBKsbG3z3yBFmZ2fZPzGxpbJqjOHhhx/m7rvv5rvf/S7PPvssSilc10VEXN/3v5kVmR7sbexXdYEMjUhEIhBXKUcJYku6OV55vvu9tRE3XKyRLaVNMduE79xrijHb11nMKCTdZQhbSe52cpTqvqiiMYPFpjwDsk+UM3lb2IHPK7J5sO3xNQZYccPidFvQU4MU735fsxedtvhcsY1i5DZl2W0TqE2XrJE1j1wxRQ99745SFo09gp+xnW3B5TQDe9CSvMKJNgaVFXp6aijXJMUaGrcbY9g7uet/7nl04Z8cefN1nv/LF2nuKGOm7Y3fffttIHDi1Gla7Q5ap6R5PrNhELk+sHEZTNds7vq5GXM5bhfb58mXlIbPHrpCedb5SpetOv7J1dcRnXBqqcOeyQbVMcP4pJ/pcNn7m9zZwRhYr9dYX0+Ymx5nZmGGWr1Oe+nM5r0buGF2B3Gqef2Vk7z43DqtRzS7943jkFDZ0gDtglEUPKESBLS7KWcutPG8iLGT1p2p5UZEhQQdKiR0kUhBe/CIVpdhjNJWeTlj4m2faq/XISbO9jSbDbiZxXbigHbhvIHvrFiXqpNjNjX6jX/2s4yPW6uCHtq22+1SKpX48Ic/TJokfO7f/VvSNKEQWNs1gI2NDUSEkydP4rouCwsLA/e0b9++PkBxamqKpbNn+dKXvoRSiq985SvvaC/kihbIo48+GgH80i/9ku7vWnpzZfdy055KBQNQge0aSjJwIJA8n/sKYYlvDVaUt74yz3smz+fOsa9laxAcsN7pGcds+fw9W7vNCMI256H87ebvZ9tj8jAsQy7viWxCXOSyn2QvS+glXiKANpsucdk9KOX0xd2GJ61SCq1ULoKPZgFuh68aiCJDvuo/cMrtpz9t+VR//hcHqa2v0DUxS39yBBEoxPbQqYKUkoLC2DhBKaTVbrOc+dLJAJ7IIL5BCgZjYuKkiVFef/L4Q1+c7fJeHdyNsSdpnJIFXcuqTQEurNY4fjphlykxMVnN+NddxMC+m2/AYPibb2xQX1tnshwQ+uN0PLWZ7mS3227UieKENI4xxnDxYpPv/s0ZKkXF/kWLv3GzkrD1g9vceLTRdJOIKBW++71TthHXTEhTQ6yzN1FgvKwaFl/BtiLuZW8xvftJsk3gDAnLWnPKpHw77RKUSnz0Yx8HYH5+njAM+0DEUqlEpVJBa/3yhQsXnux2u/7cjvmvGXCPHz/O2to6e/fuZefOncRxzNLSEkopWi3LsLhw4QJaa8rlSp+7Xi5XqFarTE1Z1uZffPUvAPjFX/yFL7zyyssN4PfvvHP/l67pAtm3z3aGZ2dmKQchdCE+1wSBdq0NSii4Lq4oAtclDMJ+p3TwoctmgcSxgCMjKUZ0n2UnQ80L2eK/d6mv1IxoHY406BjcIV13Uy1QhChKaHUjoiREevyK7PwQluzETtM2UTdG6xTHsZitAWUQJNMVjq1FhQhxlNCod3CMi8nEH8RRoxuamZqhMYZGyx7o49RY+7oelkus87XoXKNvOzq6GTpIbHc8GfX/zeY9dYA6hppJqZFSUoYbdloHk55MaJxtCD3eOdD4lV/5lcP33nuv7wcFLSK0Wm0cp9bvkPdE5USEZtbjWFtby0hTmjAsZJUtp++Xbozh3Jlz2ZyS/Vmg+6NrHkGALwLPzEzPfuDnfvYTHzl19jRn/uiLADSOWkXEdNcYTsElqm1Q99vEJDgymDQZP8MjZaShLbv5JUP/lXlfXDrVsssuxsFgcLJF0HIqGOOw1nZZWdfMzpj+wugZce7cYcGMu292qE5pztdPcfrwOVzlEPqFgXnZ7GzQiSLGZgyzRZ/qJMRxh1ZbkVnHs29vsLlIRRjQ9jM5FLFAUg/QqSBNUM3Y2lobd6QV3BZruBEoZbOdQ5aYLV3YU0S0RXNEJ3zfpHgzMxy48w4KxSI37rUusnHG2eiJTk9Pz7Bz5ybIMGemycsvv8yJEyeYnJxkYWGBNE1ZW1tDRFjLfNKB38z4SqPRFSLu/rv2/ydAvfDCi7zyyqs/mBQrDMODAMeOHePWW277yMtHXuG//ekfA4bamzX74AsFVNHQxsI2nJLCm1YDW5kWByPWRMeMqC7JqFbUyF1uO/+Boe1OZNut0RghFRcjBpUpmHekiBFFvZOw3jB0ohyIMFsg05MhgjC3y6FQTXnx+fMceW2D2YkJ9i/ePPBVtrtNOlGXnRMBk7M+YQHSpEunK5xftq+9d37anoMcByTrcmzjQ6JbAUks0AbpJIiSrOkCI5ixlwVdM5e5GZ0jZkM0x3XCGzrlxvExHnzgAL7vszA/3z9sJ8ZQKISEYYHx8XHm5ub66fMDDzzQf71vfvObnDx5krvvvjtTK+mwvr4+/LZf+NSnPrWtCc7v/M7vuCLyHwH1/PPPU6vV3nY16+3K/hw1xjwlIpM/es99jxhj+Mb3vmWFahoRpAbxFeIqdFeT1LPavJ/pJhWMVcJJsTJ+enMbcxzppxH5ZN7kDpaXLxxn+gQqkyNh5HkYA1yRrH/iKh+tNEkS0+3ExHGanVMEet4Uqa3uTE74FAoOE5M+larCqIRTF1atZYBvUwTHhQCHsOARhh6eq0E0WkMn0wWu1236FIz7OC7EqSbR1hLAaJteOq59jlpFaCUo0XgiOFuA/XneiNlm6xAG7aMZkYdlvzeGU8Y2NM8qTQ1DYW6OmybG2bXvRqanpnBdN6ela6dXpVKhXC7jed7rxpi/AXoe0Br4X5nQ4COFQmFyfX2do0ePbpaKHYf5bMG9Zb5gFTGeMsa4CwsLj8RxPFkoFO595JFHPrpr1y7e9773AXTm5+cPXpMFsri4eBA4+LWvfe3AP/+FX302TVNe+MyLpFrTOd8kdsGdDFBjPsSQNKw6oDsZZp1WgziQdEF3BIuOsBPcC2we2mqP4kLLUM9kRHGs/3de5ypXsRkgIZmBPkJvWviqDI5Pu11nrdak06n0u8riB/bKzgZguHFfBZTL8sU6q+s1VlY6HH75aE7Rx3Dr3pBSyWdyvMjUhE+adEmSNqk2rDe6YODUUg0MzHgl/NClFWlaUUQ3SpHYrs3Az+weVJNYaTxlvUQ8ZKDn0cc+Gkb2MjZTK+lfbEbXrfqz+f8lNVLgdd+lgfDA3Xdz5/0/ytTUFLfeeqvlitcsRbtaraKUYufOG5iengb4c6XUr/Ve/YknnkiAn80wf89OTEwcOHHyJEtLS4xPTHDjvn2EYcjDDz88WKHbZnzmM5/Rvdc7dOjQsyJy4PTp059YXl7+RK6Sdho4eK0iSL+BmL0xUxOTC1obzrOG0QmkBhOnNvw72ReR2R/oWCNaLN8kzbidorYcws3Ajs/bEo7bmkhI7vdDHfp+A6zH99js0unsMyiTey+jCXyHStmj20kohkn/34Pg+w6uawWo0YNC2SZbzEmSWRt0Y4wo4tj6jud1oXtIApMYTGJ5N72nJiMlR+QSy2P7jcZkaOcUC1HRGCLHntPCSgXluoSlEkEQ9GEg+TJrTwhORFaxYh9r291D3iwnThKSOCaO4wFNrCtMly4YY067rjsehmHZcZxeNUw9/fQzCwD79+/HtxTy1WKx2LpqC+SDH/zgC8Cu73znO+4XfvcP24D7b/79Zzly9HU6rQ4dL8Gt+PjzFioQr7UtQnUtk/ipO+iu4JUUfqWC65b6DkT9eT+KZXsFwnGS66r3D6x5TeBhDJLZnCUbbY1nUurZGSSOElaXN0BgZjwz8elGYODAPRMcuGeCpbN1nnvhLBrDSjsDe1YnUFnjrtO2/iWIl+3NCQY4fs7m3cfPr28FZQZ2cRjHHv4bJ5fpthImGoYCDgFqayKVh9FvV5aS0RWuWKCr4Gwa8yedFQzQ2rMbI/Azn/h5bthpbQomJiaIooiNjQ0cx2FmZhaAu+7a35vgvxyG4VOXmkOf+9xvAsIXv/hfOXjwIOPj46RpSrVapdu9condRx999HGA48eP/x7w6bNnz/LVr34V3/d33nHHnaf6KAi72XyMzDrwakWQbSABg40uGYFHH2g+5Uu/eWq1XH4Ta/vi/db33HbOiIy4JteIEy4Nrc+ukT7sSwbjlQw2CM0A5XZE53JrVzAHuJRLPiIZeWDfKttgRhb5zDa+6aO54qPu5XLhJJfyQu+Vyd8p+IjI5UO+3+kFkgD7RIR/+eS/+DOMec+XD36Zv/7mN2hvdFh58zTKd5i4d5BPrBs+uukQJy4tR6OiFsmJ10GgGFq6pSoWbWWnJwRnFEkGe7gc4bh+HjNMg8uiiELwEDSK7sCve4s9x82INavLzX4/D4Sxso/nOlbGy4XJuSLvfe8NRHHKS0fOZ9fWtt1MLvlBhqD+nY79fWtpnW4jYqYRU04cPNEg0WVUpUb/3vN8ROBMGlMzKcfjiNdabVSpxOx734tSin/85JMox2FsfBzXdfv2aWEYMj8/j+d53Hnnnb3P9oAxZglYvYxN9XHAn5iY/I2f/umf/uTp06d57rnnqFarHDliFTs/9KF/+K3V1VVtjPnVqampg5e5WX8O+N3ez3Nzczt93/uWLYjUtxiBXtUFct999/UOQTzzzDORMUIxCHGNQhIwUZrl2vm8G3v20AqjLQ9ca4NOYztRdGqhCjklkz68YTv/8iHhuK3XGEZjPGREh2QwsplM8bGn7dXjf+Q3XFGCUoIfWEi8oySPSx6dAY0IA0Mkw01Vw2y716lGJzorRlhqbV6YZVjUbTR2YPPeMvFIUoyl5BpNw2g8DKWMQ14dH+8TnJRSxHHc54i7rttv2mWRY0kpdVne5O9730MXAD772c82PK/UbzBaAeu+wvvOLAIUrqCQtA7068XfyQQg8tCUSx3+r6YF26+LmMl7773352+99dbHjp54g69+/c9JRXPuVWvKGMzPII5CnDJOGOL7RQJlVaPTtbMgUGs3LEejVEYcF98P8dwAHBcvGH5OVnc3GXLHeOuEzPIu4r502uAoB8JEKKStDqdOLBPHCa2slOlv2B1ovOojQdbXG6q4+VlfJdLJFbc8N7M+B88NSBPD0mn7fTvnWvj1iELkUlDWmHkrWuHS72GATtaeej6qExnD2SCg7rgE+27ijn17KFer3H7vvSBCEAR9+2alFGNjY4RhCHBYa/27+cmXHc6vaDzxxBN2In/7O7z68qt4nsdf/9+/BuCee+7pCzi8jbFqjPnYUAp4+JovkIceeujr2Yp9yBiDH7h86/DTRGnMm0vnrDjy9AziCq4EOF6IcnwcwJiUuGsXRpJaPrUroFwXx4DX4zpYpGRux/SyHTAz8bwM4bg8PF/nOCfkYO6eIxRcQccxG2vNTTl/oNvJHBHFbJomDp11XOVsvn+m2yW51navBzNQlJNchyIT8VbKQ4umUa/Z3n8jxmlYDr9tjVg24aZ2m+SYypufK89eBiFx7A+nooi20ZwXn4ar2D01xZ5bb6VarbJ4xx0DRKmeZ3mpVOo51Z5eXFx86u3Om9tvvx0RYXl5mXLJKut8/+T3EbHkqrcLRLzvvvta2x3Ir3UE6U2wvwIol8s3/cSjH3qs3e2QPmPPDqeXV0iNhnAK3JIt6Lg23dJOMLDNmTgiTRO6qUF3u+AopGVDuRMU7W7upRis1Kk1vM/+5Ks4Zqi+P/Q7bQRtLGFImRhMTMF3KBUDHFcRpanlPWRrLErTrMEXE8eGKE6Ik5ROJ2F9PSJNNbFOcj2WvKjdICbEZJFilNubMUKaCHHX0DhpxbDHjEK5HgUjOHneyyiRtnxgVEJba1a1FZc+kVWJunNzGEdx4+JN+JNTzCzMs3Pv3r5yiIgwMWFtubvd7pfSND2XO0e9+A5Nmb8yxlAICjd96Cc/9Fin0+HQoUMAHDlyhFKpxF133fWPjDELxpgXlFLfuJrz96ovkPvvv//LwJdfeeXlj95914881mg0WLtoD6sXDv5vWu0W7FiAMlCyWZLRijQJBswg024H60/RopMrcYlyKIzPZHAWe15RxZJVFGcQyzRA/TGjM3WrR5ta7SsdgY4oBg6VcojrabpJPIBa6STWeGBjvUvLS6nVWzTbHdrdlI16tKVWMPIoNAB7V0O1hewetUOSCHHLsPHaCgjsNi6e41BMDM7lVngyOEoHzZtJly6Gb0SWmbpz936cIOCOD7yf+b17KZfLVCqVAeDg3NxcL4r8/uLi4uF3er4Ui8UvA18+duzYRz/+cx9/bGVlha989Ss2BXz+eTzP46677voE8Ang88C7e4H0v5dM7Mx1XWZnbZ185/wCnXabduCTqBiVdEhaTQyCyowgLXpWcqLPkjmt5tQBs0NcHLVRohAxGdjPR5zALhPlDG6kvVAtqg+zsLZoGpVRggMSRBJ8BzzX2jgMGx71moetVozrajqdhKirSZOMu34Z54zBiJvrO0Kf7xBF0NroEjcj/K59GI7eNJx+q+WRZLLgHaOJdMqaGBphQCwwNWeprTvm5/GCgGKp1OeO9+AihUKhB+V4LnvJxlWeMquZNpu/Z8+e94AlW0VRxNraGsvLy9dk3l6zBdJjlLmux0/+5IcBw9zcHN1ul28/922WTi+xXutycW0dpxhS2rMLRPDcqp0wHTt7Ik1mFpMdp7WmW7M8k25tdWDv9cIiXlhBOS7FsDJ4Q4EPolBuiKjAVqhNhJgIt2VFyHZIHUd1mS76TFYdPGVoNlLyGKV2YtWkVpajXsvcLghHIYEz0ktjFBBgOLL04S5OgO8WaJ6r872nT6O6KRNLViWmRKHvy5tsUxnu/e9lNBFwLGlxSnfplsts7NxBoVjixx//KQAeuOdufM+jk9m6FYtFyuUyYRj2KA4J8HCxWIyu9nxZXFz8OvDgoUOHFj71qU+dAvit3/ot1tfXefrppzl//vzfrQWSheUkO3+6fbF8EbvrK2tGr7QMiTibLWjWAb9sM6Jek+eUb9cRGGAzDeXqObPW3p+tMJV82VlGlmnNFQhDmrdonPWRAiMZdsPQnM3Pp3MF3Z74hfVbV6jsoD3s3JQrHWhjjM5/f++0tOcV9NcQEbfHFen1j44dO+ZmCyq5KvP2Wn3CY8eOKUAFQbAgIicA3njjDZIkIYoi0jTlzVOnOPrGUTZaDb519GVAWK3vAAPVhdvwwnL/waRA12yiUGHYxuUtdoZSBXG37g8mjdFnLY/gsX1vUHQTbt/jMz3mkpiEeDu/cLPZWJShhcpl3NVwRAn8MiIO5882WVluY45tkPzlKTwjLA54zm591RjDxcyZ43tYdPDFqSliz2dm/+1MLu5janaWW+68K8sybSo4MzONUg6zs7M9BZHPnzhx4l8Xi8U+SU5Ekqyse03GoUOHELGUx+WzSyeAhaf+9E959bUjPP744/rJJ5/UxpjDi4uLP/aujiCLi4sa0GfOnEmGd8k+v1hkc8fuh4A8U8QMHNw3katDHPLLQBKY4Xwnt+3nG+4DCBizVbZ51OnbDBpoDK0hYYvl86h76i34HAVFeurvV9Arz7NrjGyGRREbQba0RUUGOOT3339/wg9wPProo/0I8sf/47/DIJ9fAaq3gN7VCyQ3LojIg8YYbrvttj8Tkdlz587RaDTwfZ+ZmRm6UZc7br0VYwzPvfAq2hhePfU9ap0E3AI4PioI8camERHcslXR6HRMvzs8iGrcmvWn7ebIDqrSEZOebWTeuFCiVIAg6NLNzhqSW5Q203P+dnF5m2v8TIP59Atn6NRi5GybYKVD0NFU8LNu+SBXo46mi6FGyioJsetxcczatE2+5x+ACA/+yL0UqmOUJ8YpVioUwpDxiQkcx2FHpqRdq9Ue73a7F3K3c44frvE4gv/www//h/f92I89orXmqaeeYnp6en+r1XoWSETkA2EYJu/aBTI/P99Xamy1WlGvQtLtdntWviRxjJcpE75ResPuFlGTtNnGeEWMV8ATQYy1OVNOLxKZTbe0AWF+s8XwRVIL396aySf4YlX6woJHsQBKdfsmlsMAwkFRtUseDd56wRhwsgyuc7FDa7VL4Xwb/2IXF0XYN94YPMUkWB+VlmhqpETKoeY5oITpqWlQwvjCAuXxccIwJAgCPM/D8zxc1+2XciuVyguTk5On+SEd//TjP/cCwFNPPbUKcPbsWU6cOIHv+2XgAJBcM3+QazQ+h+2A2KpMqfSBarX6kR4jDWO45557MMbgl6dotDpcWFljbb1OO6mxvrQOyiFu2R1QFScQcVC+j3KdfC12U3J8m21cx210a5XASVicsXl72TOErvXYsK7fJiuWDpicbb8GzFu1JMTy31PTt0Y9/ZylTAQn2/j1mKCr8Vzb7NxINRqoZbq7azq2jrbVMklQwJmcoDA3Q7lYZM++RRBYuP02RGB+9y6CsEi1WqVcLpOm6UsbGxtfzDX6MMas8y4YIvJF4Jm9e/d+4KabbvpItVq9YhG6d8UCKRaL/yX/89LSEsaYj1iJ+y5guOWWWzDGUCwW6XQ6vP7663xf11lZb3Hx4nmMckgju/2Wd4aI6+I6LspzN2doKnYSXmJ312mXpLWM52kWxroIQugqAkfophY9bEXA6LP0rB5ueokvki3N7fzbKnHxMlKS6dgIdeFV216YW+rid1MC3+C60NFQ04bUGM5pSy08qTukGOKwiq4UmN61gxtuu51ypcotd92dNfZ2AEK1WsJxXKamJqlWxwCO3nLLLZ/nXTieeOKJgwArVkbqI++kkuIPWwQZKouao2Q4mcw/XBWLxZ8CVLlcxnVdduzYgeu6TG008AplUgMX2zb1aLbWSUVhoqatUGUi04KgxOK0lFscEFfTcRuTdPB1nfFSm9A3TI9bmIsRSHprQiwDsNfp7h9z3toloP+Xkp7QmoOIwqSWZ5W0Emrft4ePpGFDSUMnuKQWsYumqxzqoYcRoR3aoFsdq6CVEC7M41YrTO7cydy+GykUi0xNTWXQkPHesvw6mNVcceQZ3v3jKPBUrqiQ9MrS77oy799mHD9+3DfG1AH/7NmzAyYp9Xqdc+fO0Y26HD5sEQ+HX7tAJ8rt6G6I8UIczyOYGEdEKFR3D7xHWlsibV5gutTmlrlVigWHDx+YsrZgUZu39byHolTBUdYd2y0hyqPV7FKvtWkst3ntoOWvh0drA/982cSsm4S0VCCam0S5HtW9iwDc/N4HcH2fffv2MT4+ztjYGJOTE7iuS7lcQUT6VFYReTAMw8NcH+/eCDIiooDll/QL/0qpcREp97gHWmtKmUz+WKVEIdbWJsAYtHJJlYADQowYQcedTEA6sU23pIOjExQaR1nBtzTriGctshF502UObTYBikCS2BJtlKQIikYzplaPaDVjoqw65meCDOJYXSxHAnzR6FKINzaGcj0qY2O9Mxuu7xOGIYVCAcdxGiKyPiJnh76/wvXxdyaCbBNVfg/4dKPR2ILHWVu+gE41SxfO02i2WFlZ4fyF8zSjDkdXzgLC2gUfjGBqJyHpMFWpMF4uMTPjctsdRVwF83M2jSpJ0Nd9BXA8F68QbH8gzz3NNE6IO120MTSNjXz1iwkmNZw5FdOoaU6dXefYqRU85TBVtGnT3jm74xd37cUNi1RnZ6lMTVEuFdk5O4fjuezeZ4XZduzYkVm8lXvKgp8vFou/dn1a/z2JIH+rqCMjo9CgoayM3iVGW7pdgW3VKFDVUDVri6/5yEu38Z8f4vhfH9cjyKgIMg6Up6enH/M87w/SNGVpacmWSE+dIskMHZNM9rJn05zo1BpprtUxGE6/+X06nTYbK8s0amvEaUo7jhAMpUKcPZyhDkc2MXWc0Lm4/tbpYdb57p1ixnbdhHI9pmfmKBXLOK6H5/kEQcANWbOumME4JqemcH2fsbExypUKvutStryMpRNnzjwA1vDScRxKpVIvgjSKxeL69Wn99ziC3HjjjevAutb6IkCapn3ivXIcHOi5DY2euKnGGMN6uYTjKDqNDdquQ6wNUZTBXoYEsvP4KoNBRwntnvPscPQwMCxQ0sNEFhJl+x7KR3kBQaFAsVgkCAIq2WE6CGwKV6xU8DyPUrlsvfschzAIMKDvu+++09en7vUF8lbjMFbPaMvOvWfPnn/luu6BnmVwmibU67a/0Kvq+L5PmqZENy0SR12SJKWdMescZff8OB40lklTTZompHFKc8NWm5rN5gCP3XEcnMwXw/M9lNgzAsD0zgWU42ZnBo9CtkBs1Skr3Wac62az+eutVuv1Eeli5/q0vZ5iva2xsrLyJ8BHW60m9XqNKIq5eHFlS2Un31zSWmcLYnN0Op2Ba5IkIY5j0jS1C8MY1tfXB67xPM8uDs8jDEOUUkxkXnuzs3MopfA8F6UUhUKBUqmUtwXoU1qNMQ8Wi8XrZdnrEeQqrHqR/2OM6achQRDs2L1798/0JnkvNTPGwJxAFQAAAMJJREFUkCRJ5jmRblkgxWIRMKSp7p9n4tiebezvoFyuZJFD9RdIrwQdZFI5lYq9ZnJyyqZ1nc7BTqdztBcV8qjm3MI9d316Xo8g12RorQ+IyLM9bnU+OrTbbbrdbjb5R7cK7O81SWIX0qjRiwqe5/YlcXzfnid6ogdjY2M9c5iPFYvFp65Pv+sR5IdlNPLaR3lPxVyGn1VVM77HkGnM5r+x1/R9A82m6uLgNYPRYeg9V69Pvevj+rg+ro/r4/r4uzz+PwpKH6gvwSYQAAAAAElFTkSuQmCC');
-      background-repeat: no-repeat;
-      -webkit-background-size: 100px 100px;
-      background-size: 100px 100px;
-      background-position: center center;
-      text-align: center;
-      font-size: 42px;
-      padding: 250px 0 70px;
-      font-weight: normal;
-      text-shadow: 0px 1px 2px #ddd;
-    }
-
-    header {
-      padding: 100px 0;
-    }
-
-    footer {
-      line-height: 1.8;
-      text-align: center;
-      padding: 50px 0;
-      color: #999;
-    }
-
-    .description {
-      text-align: center;
-      font-size: 16px;
-    }
-
-    a {
-      color: #444;
-      text-decoration: none;
-    }
-
-    .backdrop {
-      position: absolute;
-      width: 100%;
-      height: 100%;
-      box-shadow: inset 0px 0px 100px #ddd;
-      z-index: -1;
-      top: 0px;
-      left: 0px;
-    }
-  </style>
-</head>
-
-<body>
-  <header>
-    <h1 class="logo">Welcome to Beego</h1>
-    <div class="description">
-      Beego is a simple & powerful Go web framework which is inspired by tornado and sinatra.
-    </div>
-  </header>
-  <footer>
-    <div class="author">
-      Official website:
-      <a href="http://{{.Website}}">{{.Website}}</a> /
-      Contact me:
-      <a class="email" href="mailto:{{.Email}}">{{.Email}}</a>
-    </div>
-  </footer>
-  <div class="backdrop"></div>
-
-  <script src="/static/js/reload.min.js"></script>
-</body>
-</html>